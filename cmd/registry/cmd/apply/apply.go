@@ -16,6 +16,7 @@ package apply
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 
 	"github.com/apigee/registry/cmd/registry/patch"
@@ -29,6 +30,7 @@ func Command() *cobra.Command {
 	var parent string
 	var recursive bool
 	var jobs int
+	var skipUnchanged bool
 	cmd := &cobra.Command{
 		Use:   "apply",
 		Short: "Apply patches that add content to the API Registry",
@@ -49,7 +51,13 @@ func Command() *cobra.Command {
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
-			err = patch.Apply(ctx, client, fileName, parent, recursive, jobs)
+			if skipUnchanged {
+				var stats patch.ApplyStats
+				stats, err = patch.ApplyChanged(ctx, client, fileName, parent, recursive, jobs)
+				log.FromContext(ctx).Infof("Applied %d files, skipped %d unchanged files", stats.Applied, stats.Skipped)
+			} else {
+				err = patch.Apply(ctx, client, fileName, parent, recursive, jobs)
+			}
 			if errors.Is(err, fs.ErrNotExist) {
 				log.FromContext(ctx).WithError(err).Fatalf("File %q doesn't exist", fileName)
 			} else if err != nil {
@@ -62,5 +70,7 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVarP(&recursive, "recursive", "R", false,
 		"Process the directory used in -f, --file recursively. Useful when you want to manage related manifests organized within the same directory")
 	cmd.Flags().IntVarP(&jobs, "jobs", "j", 10, "Number of apply operations to perform simultaneously")
+	cmd.Flags().BoolVar(&skipUnchanged, "skip-unchanged", false,
+		fmt.Sprintf("Skip files that haven't changed since they were last applied with this flag, as recorded in the %q annotation", patch.AppliedHashAnnotation))
 	return cmd
 }
@@ -17,7 +17,10 @@ package apply
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -27,9 +30,12 @@ import (
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry"
 	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 const sampleDir = "testdata/sample"
@@ -443,3 +449,545 @@ func TestUnbundledExport(t *testing.T) {
 		}
 	})
 }
+
+func TestDeploymentRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	source := names.Project{ProjectID: "deployment-source-test"}
+	target := names.Project{ProjectID: "deployment-target-test"}
+	for _, project := range []names.Project{source, target} {
+		if err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+			Name:  project.String(),
+			Force: true,
+		}); err != nil && status.Code(err) != codes.NotFound {
+			t.Errorf("Setup: failed to delete test project: %s", err)
+		}
+	}
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	deployment := &rpc.ApiDeployment{
+		Name:               "projects/deployment-source-test/locations/global/apis/a/deployments/prod",
+		DisplayName:        "Production",
+		Description:        "The production deployment",
+		ApiSpecRevision:    "projects/deployment-source-test/locations/global/apis/a/versions/v1/specs/openapi",
+		EndpointUri:        "https://a.example.com/v1",
+		ExternalChannelUri: "https://developers.example.com/a",
+		IntendedAudience:   "Public",
+		AccessGuidance:     "Sign up for an API key",
+		Labels:             map[string]string{"tier": "1"},
+		Annotations:        map[string]string{"owner": "a-team"},
+	}
+	if err := seeder.SeedDeployments(ctx, client, deployment); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	if err := seeder.SeedApis(ctx, client, &rpc.Api{Name: "projects/deployment-target-test/locations/global/apis/a"}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	// Export the deployment from the source project and apply it to the target project.
+	message, err := registryClient.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: deployment.Name})
+	if err != nil {
+		t.Fatalf("GetApiDeployment(%q) returned error: %s", deployment.Name, err)
+	}
+	exported, _, err := patch.ExportAPIDeployment(ctx, registryClient, message, false)
+	if err != nil {
+		t.Fatalf("ExportAPIDeployment(%+v) returned error: %s", message, err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "deployment.yaml"), exported, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := patch.Apply(ctx, registryClient, dir, target.String()+"/locations/global", false, 1); err != nil {
+		t.Fatalf("Apply() returned error: %s", err)
+	}
+
+	applied, err := registryClient.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{
+		Name: "projects/deployment-target-test/locations/global/apis/a/deployments/prod",
+	})
+	if err != nil {
+		t.Fatalf("GetApiDeployment() returned error: %s", err)
+	}
+	want := proto.Clone(deployment).(*rpc.ApiDeployment)
+	want.Name = applied.Name
+	want.ApiSpecRevision = "projects/deployment-target-test/locations/global/apis/a/versions/v1/specs/openapi"
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&rpc.ApiDeployment{}, "revision_id", "create_time", "revision_create_time", "revision_update_time"),
+	}
+	if diff := cmp.Diff(want, applied, opts); diff != "" {
+		t.Errorf("Applied deployment differs from the exported deployment (-want +got):\n%s", diff)
+	}
+
+	reexported, _, err := patch.ExportAPIDeployment(ctx, registryClient, applied, false)
+	if err != nil {
+		t.Fatalf("ExportAPIDeployment(%+v) returned error: %s", applied, err)
+	}
+	if diff := cmp.Diff(string(exported), string(reexported)); diff != "" {
+		t.Errorf("Re-exported deployment differs from the exported deployment (-want +got):\n%s", diff)
+	}
+}
+
+func TestArtifactAnchorsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	source := names.Project{ProjectID: "anchors-source-test"}
+	target := names.Project{ProjectID: "anchors-target-test"}
+	for _, project := range []names.Project{source, target} {
+		if err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+			Name:  project.String(),
+			Force: true,
+		}); err != nil && status.Code(err) != codes.NotFound {
+			t.Errorf("Setup: failed to delete test project: %s", err)
+		}
+	}
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	references, err := proto.Marshal(&rpc.ReferenceList{
+		References: []*rpc.ReferenceList_Reference{
+			{Id: "docs", DisplayName: "Documentation", Uri: "https://docs.example.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := []seeder.RegistryResource{
+		&rpc.Project{Name: target.String()},
+	}
+	for _, version := range []string{"v1", "v2", "v3"} {
+		seed = append(seed, &rpc.Artifact{
+			Name:     fmt.Sprintf("projects/anchors-source-test/locations/global/apis/a/versions/%s/artifacts/references", version),
+			MimeType: patch.MimeTypeForKind("ReferenceList"),
+			Contents: references,
+		})
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	api, err := registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: "projects/anchors-source-test/locations/global/apis/a"})
+	if err != nil {
+		t.Fatalf("GetApi() returned error: %s", err)
+	}
+
+	plain, _, err := patch.ExportAPI(ctx, registryClient, api, true)
+	if err != nil {
+		t.Fatalf("ExportAPI(%+v) returned error: %s", api, err)
+	}
+	anchored, err := patch.FormatYAML(plain, patch.YAMLOptions{Indent: 2, ArtifactAnchors: true})
+	if err != nil {
+		t.Fatalf("FormatYAML() returned error: %s", err)
+	}
+
+	// The artifact is written once with an anchor and then referenced by aliases.
+	if got := strings.Count(string(anchored), "&references"); got != 1 {
+		t.Errorf("Anchored export has %d anchors, want 1:\n%s", got, anchored)
+	}
+	if got := strings.Count(string(anchored), "*references"); got != 2 {
+		t.Errorf("Anchored export has %d aliases, want 2:\n%s", got, anchored)
+	}
+	if len(anchored) >= len(plain) {
+		t.Errorf("Anchored export is %d bytes, want fewer than %d", len(anchored), len(plain))
+	}
+
+	// Applying the anchored export reproduces the plain export.
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "api.yaml"), anchored, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := patch.Apply(ctx, registryClient, dir, target.String()+"/locations/global", true, 1); err != nil {
+		t.Fatalf("Apply() returned error: %s", err)
+	}
+	applied, err := registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: "projects/anchors-target-test/locations/global/apis/a"})
+	if err != nil {
+		t.Fatalf("GetApi() returned error: %s", err)
+	}
+	reexported, _, err := patch.ExportAPI(ctx, registryClient, applied, true)
+	if err != nil {
+		t.Fatalf("ExportAPI(%+v) returned error: %s", applied, err)
+	}
+	if diff := cmp.Diff(string(plain), string(reexported)); diff != "" {
+		t.Errorf("Re-exported API differs from the exported API (-want +got):\n%s", diff)
+	}
+}
+
+func TestSpecRevisionTags(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	project := names.Project{ProjectID: "revision-tags-test"}
+	if err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  project.String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Errorf("Setup: failed to delete test project: %s", err)
+	}
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	version := project.Api("a").Version("v1")
+	if err := seeder.SeedVersions(ctx, client, &rpc.ApiVersion{Name: version.String()}); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	dir := t.TempDir()
+	contents := filepath.Join(dir, "openapi.yaml")
+	if err := os.WriteFile(contents, []byte("openapi: 3.0.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	apply := func(tags ...string) {
+		t.Helper()
+		yaml := fmt.Sprintf(`apiVersion: apigeeregistry/v1
+kind: Spec
+metadata:
+  name: openapi
+  parent: apis/a/versions/v1
+data:
+  mimeType: application/x.openapi+gzip;version=3
+  sourceURI: file:///%s
+  revisionTags: [%s]
+`, contents, strings.Join(tags, ", "))
+		file := filepath.Join(dir, "spec.yaml")
+		if err := os.WriteFile(file, []byte(yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := patch.Apply(ctx, registryClient, file, project.String()+"/locations/global", false, 1); err != nil {
+			t.Fatalf("Apply() returned error: %s", err)
+		}
+	}
+
+	// Applying the same contents twice tags the same revision.
+	apply("release-1")
+	apply("release-2")
+	spec := version.Spec("openapi")
+	current, err := registryClient.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.String()})
+	if err != nil {
+		t.Fatalf("GetApiSpec(%q) returned error: %s", spec, err)
+	}
+	for _, tag := range []string{"release-1", "release-2"} {
+		name := spec.Revision(tag).String()
+		tagged, err := registryClient.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: name})
+		if err != nil {
+			t.Fatalf("GetApiSpec(%q) returned error: %s", name, err)
+		}
+		if tagged.GetRevisionId() != current.GetRevisionId() {
+			t.Errorf("GetApiSpec(%q) returned revision %q, want %q", name, tagged.GetRevisionId(), current.GetRevisionId())
+		}
+	}
+	it := registryClient.ListApiSpecRevisions(ctx, &rpc.ListApiSpecRevisionsRequest{Name: spec.Revision("-").String()})
+	count := 0
+	for _, err := it.Next(); err == nil; _, err = it.Next() {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Applying unchanged contents created %d revisions, want 1", count)
+	}
+
+	// Changed contents create a new revision that gets the new tag.
+	if err := os.WriteFile(contents, []byte("openapi: 3.0.1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	apply("release-3")
+	latest, err := registryClient.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.Revision("release-3").String()})
+	if err != nil {
+		t.Fatalf("GetApiSpec(%q) returned error: %s", spec.Revision("release-3"), err)
+	}
+	if latest.GetRevisionId() == current.GetRevisionId() {
+		t.Errorf("Applying changed contents didn't create a new revision")
+	}
+	previous, err := registryClient.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: spec.Revision("release-1").String()})
+	if err != nil {
+		t.Fatalf("GetApiSpec(%q) returned error: %s", spec.Revision("release-1"), err)
+	}
+	if previous.GetRevisionId() != current.GetRevisionId() {
+		t.Errorf("Tag %q moved to revision %q, want %q", "release-1", previous.GetRevisionId(), current.GetRevisionId())
+	}
+}
+
+func TestApplyReader(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	project := names.Project{ProjectID: "apply-reader-test"}
+	if err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  project.String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Errorf("Setup: failed to delete test project: %s", err)
+	}
+	if _, err := adminClient.CreateProject(ctx, &rpc.CreateProjectRequest{
+		ProjectId: project.ProjectID,
+		Project:   &rpc.Project{},
+	}); err != nil {
+		t.Fatalf("Setup: failed to create test project: %s", err)
+	}
+	parent := project.String() + "/locations/global"
+
+	documents := []string{
+		`apiVersion: apigeeregistry/v1
+kind: API
+metadata:
+  name: a
+data:
+  displayName: A
+`,
+		`apiVersion: apigeeregistry/v1
+kind: Version
+metadata:
+  name: v1
+  parent: apis/a
+data:
+  displayName: V1
+`,
+	}
+	for _, document := range documents {
+		if err := patch.ApplyReader(ctx, registryClient, strings.NewReader(document), parent); err != nil {
+			t.Fatalf("ApplyReader() returned error: %s", err)
+		}
+	}
+	version := project.Api("a").Version("v1")
+	got, err := registryClient.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: version.String()})
+	if err != nil {
+		t.Fatalf("GetApiVersion(%q) returned error: %s", version, err)
+	}
+	if got.GetDisplayName() != "V1" {
+		t.Errorf("GetApiVersion(%q) returned display name %q, want %q", version, got.GetDisplayName(), "V1")
+	}
+
+	for _, document := range []string{
+		`apiVersion: apigeeregistry/v1
+kind: Widget
+metadata:
+  name: w
+`,
+		`apiVersion: apigeeregistry/v2
+kind: API
+metadata:
+  name: b
+`,
+		`[`,
+	} {
+		if err := patch.ApplyReader(ctx, registryClient, strings.NewReader(document), parent); err == nil {
+			t.Errorf("ApplyReader(%q) should have failed", document)
+		}
+	}
+}
+
+func TestApplyChanged(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer adminClient.Close()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	project := names.Project{ProjectID: "apply-changed-test"}
+	if err = adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  project.String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Errorf("Setup: failed to delete test project: %s", err)
+	}
+	if _, err := adminClient.CreateProject(ctx, &rpc.CreateProjectRequest{
+		ProjectId: project.ProjectID,
+		Project:   &rpc.Project{},
+	}); err != nil {
+		t.Fatalf("Setup: failed to create test project: %s", err)
+	}
+	parent := project.String() + "/locations/global"
+
+	dir := t.TempDir()
+	apiFile := filepath.Join(dir, "api.yaml")
+	writeFile := func(name, contents string) {
+		if err := os.WriteFile(name, []byte(contents), 0644); err != nil {
+			t.Fatalf("Setup: failed to write %s: %s", name, err)
+		}
+	}
+	writeFile(apiFile, `apiVersion: apigeeregistry/v1
+kind: API
+metadata:
+  name: a
+  labels:
+    owner: alice
+data:
+  displayName: A
+`)
+	writeFile(filepath.Join(dir, "lifecycle.yaml"), `apiVersion: apigeeregistry/v1
+kind: Lifecycle
+metadata:
+  name: lifecycle
+data:
+  stages:
+    - id: design
+      displayName: Design
+`)
+
+	applyChanged := func(desc string, want patch.ApplyStats) {
+		t.Helper()
+		got, err := patch.ApplyChanged(ctx, registryClient, dir, parent, false, 1)
+		if err != nil {
+			t.Fatalf("ApplyChanged() %s returned error: %s", desc, err)
+		}
+		if got != want {
+			t.Errorf("ApplyChanged() %s returned %+v, want %+v", desc, got, want)
+		}
+	}
+	applyChanged("on new files", patch.ApplyStats{Applied: 2})
+	applyChanged("on unchanged files", patch.ApplyStats{Skipped: 2})
+
+	writeFile(apiFile, `apiVersion: apigeeregistry/v1
+kind: API
+metadata:
+  name: a
+  labels:
+    owner: alice
+data:
+  displayName: A2
+`)
+	applyChanged("on a changed file", patch.ApplyStats{Applied: 1, Skipped: 1})
+
+	api, err := registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: parent + "/apis/a"})
+	if err != nil {
+		t.Fatalf("GetApi() returned error: %s", err)
+	}
+	if api.GetDisplayName() != "A2" {
+		t.Errorf("GetApi() returned display name %q, want %q", api.GetDisplayName(), "A2")
+	}
+	if api.GetLabels()["owner"] != "alice" {
+		t.Errorf("GetApi() returned labels %v, want owner label", api.GetLabels())
+	}
+	if api.GetAnnotations()[patch.AppliedHashAnnotation] == "" {
+		t.Errorf("GetApi() returned annotations %v, want %q", api.GetAnnotations(), patch.AppliedHashAnnotation)
+	}
+
+	if err := adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  project.String(),
+		Force: true,
+	}); err != nil {
+		t.Logf("Cleanup: Failed to delete test project: %s", err)
+	}
+}
+
+func TestApplyInvalidArtifactData(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "guidelines: not a list\n")
+	}))
+	defer source.Close()
+
+	tests := []struct {
+		desc string
+		doc  string
+		want string
+	}{
+		{
+			desc: "inline data",
+			doc: `apiVersion: apigeeregistry/v1
+kind: StyleGuide
+metadata:
+  name: styleguide
+data:
+  guidelines: not a list
+`,
+			want: `invalid data for StyleGuide "styleguide": not a valid google.cloud.apigeeregistry.v1.style.StyleGuide`,
+		},
+		{
+			desc: "source",
+			doc: `apiVersion: apigeeregistry/v1
+kind: StyleGuide
+metadata:
+  name: styleguide
+source:
+  uri: ` + source.URL + `/styleguide.yaml
+`,
+			want: "invalid source " + source.URL + `/styleguide.yaml for StyleGuide "styleguide"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := patch.ApplyReader(ctx, registryClient, strings.NewReader(test.doc), "projects/apply-invalid-test/locations/global")
+			if err == nil {
+				t.Fatalf("ApplyReader() succeeded but should have failed")
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("ApplyReader() returned error %q, want it to contain %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestApplyApiResourceName(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %+v", err)
+	}
+	defer registryClient.Close()
+
+	doc := `apiVersion: apigeeregistry/v1
+kind: API
+metadata:
+  name: projects/apply-name-test/locations/global/apis/petstore
+`
+	err = patch.ApplyReader(ctx, registryClient, strings.NewReader(doc), "projects/apply-name-test/locations/global")
+	if err == nil {
+		t.Fatalf("ApplyReader() succeeded but should have failed")
+	}
+	if want := "must be an ID, not a resource name"; !strings.Contains(err.Error(), want) {
+		t.Errorf("ApplyReader() returned error %q, want it to contain %q", err, want)
+	}
+}
@@ -16,8 +16,8 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/log"
@@ -26,11 +26,6 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
-
-	discovery "github.com/google/gnostic/discovery"
-	metrics "github.com/google/gnostic/metrics"
-	oas2 "github.com/google/gnostic/openapiv2"
-	oas3 "github.com/google/gnostic/openapiv3"
 )
 
 func complexityCommand() *cobra.Command {
@@ -114,52 +109,15 @@ func (task *computeComplexityTask) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	var spec *rpc.ApiSpec
-	if err = core.GetSpecRevision(ctx, task.client, specName, true, func(s *rpc.ApiSpec) error {
-		spec = s
-		return nil
-	}); err != nil {
-		return err
-	}
 
 	relation := "complexity"
 	log.Debugf(ctx, "Computing %s/artifacts/%s", task.specName, relation)
-	contents := spec.GetContents()
-	if strings.Contains(spec.GetMimeType(), "+gzip") {
-		if contents, err = core.GUnzippedBytes(contents); err != nil {
-			return err
-		}
-	}
-	var complexity *metrics.Complexity
-	if core.IsOpenAPIv2(spec.GetMimeType()) {
-		document, err := oas2.ParseDocument(contents)
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
-			return nil
-		}
-		complexity = core.SummarizeOpenAPIv2Document(document)
-	} else if core.IsOpenAPIv3(spec.GetMimeType()) {
-		document, err := oas3.ParseDocument(contents)
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
-			return nil
-		}
-		complexity = core.SummarizeOpenAPIv3Document(document)
-	} else if core.IsDiscovery(spec.GetMimeType()) {
-		document, err := discovery.ParseDocument(contents)
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid Discovery: %s", task.specName)
-			return nil
-		}
-		complexity = core.SummarizeDiscoveryDocument(document)
-	} else if core.IsProto(spec.GetMimeType()) && core.IsZipArchive(spec.GetMimeType()) {
-		complexity, err = core.SummarizeZippedProtos(contents)
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Error processing protos: %s", task.specName)
-			return nil
-		}
-	} else {
+	complexity, err := core.ComputeComplexity(ctx, task.client, specName)
+	if errors.Is(err, core.ErrNoComplexityAnalyzer) {
 		return fmt.Errorf("we don't know how to summarize %s", task.specName)
+	} else if err != nil {
+		log.FromContext(ctx).WithError(err).Errorf("Failed to compute complexity: %s", task.specName)
+		return nil
 	}
 
 	if task.dryRun {
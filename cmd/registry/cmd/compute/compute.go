@@ -31,6 +31,7 @@ func Command() *cobra.Command {
 	cmd.AddCommand(referencesCommand())
 	cmd.AddCommand(scoreCommand())
 	cmd.AddCommand(scoreCardCommand())
+	cmd.AddCommand(summaryCommand())
 	cmd.AddCommand(vocabularyCommand())
 
 	cmd.PersistentFlags().String("filter", "", "Filter selected resources")
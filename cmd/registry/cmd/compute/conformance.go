@@ -32,6 +32,7 @@ import (
 var styleguideFilter = fmt.Sprintf("mime_type.contains('%s')", patch.MimeTypeForKind("StyleGuide"))
 
 func conformanceCommand() *cobra.Command {
+	var styleguide string
 	cmd := &cobra.Command{
 		Use:   "conformance",
 		Short: "Compute lint results for API specs",
@@ -84,7 +85,7 @@ func conformanceCommand() *cobra.Command {
 			}
 
 			guides := make([]*rpc.StyleGuide, 0)
-			if err := core.ListArtifacts(ctx, client, name.Project().Artifact("-"), styleguideFilter, true, func(artifact *rpc.Artifact) error {
+			addGuide := func(artifact *rpc.Artifact) error {
 				guide := new(rpc.StyleGuide)
 				if err := proto.Unmarshal(artifact.GetContents(), guide); err != nil {
 					log.FromContext(ctx).WithError(err).Debugf("Unmarshal() to StyleGuide failed on artifact: %s", artifact.GetName())
@@ -92,8 +93,19 @@ func conformanceCommand() *cobra.Command {
 				}
 				guides = append(guides, guide)
 				return nil
-			}); err != nil {
-				log.FromContext(ctx).WithError(err).Fatal("Failed to list styleguide artifacts")
+			}
+			if styleguide == "" {
+				if err := core.ListArtifacts(ctx, client, name.Project().Artifact("-"), styleguideFilter, true, addGuide); err != nil {
+					log.FromContext(ctx).WithError(err).Fatal("Failed to list styleguide artifacts")
+				}
+			} else {
+				guideName, err := names.ParseArtifact(c.FQName(styleguide))
+				if err != nil {
+					log.FromContext(ctx).WithError(err).Fatal("Invalid styleguide artifact name")
+				}
+				if err := core.GetArtifact(ctx, client, guideName, true, addGuide); err != nil {
+					log.FromContext(ctx).WithError(err).Fatal("Failed to get styleguide artifact")
+				}
 			}
 
 			for _, guide := range guides {
@@ -103,6 +115,7 @@ func conformanceCommand() *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&styleguide, "styleguide", "", "Name of the styleguide artifact to use (default: all styleguides in the project)")
 	return cmd
 }
 
@@ -80,6 +80,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "norefsiblings",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "No $ref siblings",
 												Description: "An object exposing a $ref property cannot be further extended with additional properties.",
@@ -120,6 +121,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "norefsiblings",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "No $ref siblings",
 												Description: "An object exposing a $ref property cannot be further extended with additional properties.",
@@ -165,12 +167,14 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "operationtags",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Operation tags",
 												Description: "Operation should have non-empty tags array.",
 											},
 											{
 												RuleId:      "operationtagdefined",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Operation tag defined",
 												Description: "Operation tags should be defined in global tags.",
@@ -183,12 +187,14 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "openapitags",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "OpenAPI tags",
 												Description: "OpenAPI object should have non-empty tags array.",
 											},
 											{
 												RuleId:      "openapitagsalphabetical",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "OpenAPI tags alphabetical",
 												Description: "OpenAPI object should have alphabetical tags. This will be sorted by the name property.",
@@ -228,6 +234,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "operationtags",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Operation tags",
 												Description: "Operation should have non-empty tags array.",
@@ -240,6 +247,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "openapitags",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "OpenAPI tags",
 												Description: "OpenAPI object should have non-empty tags array.",
@@ -263,6 +271,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "norefsiblings",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "No $ref siblings",
 												Description: "An object exposing a $ref property cannot be further extended with additional properties.",
@@ -304,12 +313,14 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "operationdescription",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Operation description",
 												Description: "Operation should have non-empty description.",
 											},
 											{
 												RuleId:      "infodescription",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Info description",
 												Description: "OpenAPI object info description must be present and non-empty string.",
@@ -321,6 +332,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "descriptiontags",
+												Linter:      "openapi-sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Description tags",
 												Description: "Ensures that description fields in the OpenAPI spec contain no tags (such as HTML tags).",
@@ -332,6 +344,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "tagdescription",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "Tag description",
 												Description: "Tags alone are not very descriptive. Give folks a bit more information to work with.",
@@ -355,6 +368,7 @@ func TestConformance(t *testing.T) {
 										RuleReports: []*rpc.RuleReport{
 											{
 												RuleId:      "norefsiblings",
+												Linter:      "sample",
 												Spec:        "projects/conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
 												DisplayName: "No $ref siblings",
 												Description: "An object exposing a $ref property cannot be further extended with additional properties.",
@@ -17,6 +17,7 @@ package compute
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/log"
@@ -83,7 +84,7 @@ func lintCommand() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&linter, "linter", "", "The linter to use (aip|spectral|gnostic)")
+	cmd.Flags().StringVar(&linter, "linter", "", fmt.Sprintf("The linter to use (%s)", strings.Join(core.LinterNames(), "|")))
 	return cmd
 }
 
@@ -114,34 +115,29 @@ func (task *computeLintTask) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	var relation string
-	var lint *rpc.Lint
-	if core.IsOpenAPIv2(spec.GetMimeType()) || core.IsOpenAPIv3(spec.GetMimeType()) {
-		// the default openapi linter is gnostic
-		if task.linter == "" {
+	if task.linter == "" {
+		switch {
+		case core.IsOpenAPIv2(spec.GetMimeType()) || core.IsOpenAPIv3(spec.GetMimeType()):
+			// the default openapi linter is gnostic
 			task.linter = "gnostic"
-		}
-		relation = lintRelation(task.linter)
-		log.Debugf(ctx, "Computing %s/artifacts/%s", spec.Name, relation)
-		lint, err = core.NewLintFromOpenAPI(spec.Name, data, task.linter)
-		if err != nil {
-			return fmt.Errorf("error processing OpenAPI: %s (%s)", spec.Name, err.Error())
-		}
-	} else if core.IsDiscovery(spec.GetMimeType()) {
-		return fmt.Errorf("unsupported Discovery document: %s", spec.Name)
-	} else if core.IsProto(spec.GetMimeType()) && core.IsZipArchive(spec.GetMimeType()) {
-		// the default proto linter is the aip linter
-		if task.linter == "" {
+		case core.IsDiscovery(spec.GetMimeType()):
+			return fmt.Errorf("unsupported Discovery document: %s", spec.Name)
+		case core.IsProto(spec.GetMimeType()) && core.IsZipArchive(spec.GetMimeType()):
+			// the default proto linter is the aip linter
 			task.linter = "aip"
+		default:
+			return fmt.Errorf("we don't know how to lint %s", spec.Name)
 		}
-		relation = lintRelation(task.linter)
-		log.Debugf(ctx, "Computing %s/artifacts/%s", spec.Name, relation)
-		lint, err = core.NewLintFromZippedProtos(spec.Name, data)
-		if err != nil {
-			return fmt.Errorf("error processing protos: %s (%s)", spec.Name, err.Error())
-		}
-	} else {
-		return fmt.Errorf("we don't know how to lint %s", spec.Name)
+	}
+	linter, err := core.GetLinter(task.linter)
+	if err != nil {
+		return err
+	}
+	relation := lintRelation(task.linter)
+	log.Debugf(ctx, "Computing %s/artifacts/%s", spec.Name, relation)
+	lint, err := linter.Lint(spec, data)
+	if err != nil {
+		return fmt.Errorf("error linting %s with %s: %s", spec.Name, task.linter, err)
 	}
 
 	if task.dryRun {
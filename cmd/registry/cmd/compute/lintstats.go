@@ -20,6 +20,7 @@ import (
 	"sort"
 
 	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/gapic"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
@@ -193,8 +194,8 @@ func computeLintStatsProjects(ctx context.Context,
 		if dryRun {
 			core.PrintMessage(project_stats)
 		} else {
-			// Store the aggregate stats on this project
-			_ = storeLintStatsArtifact(ctx, client, project.GetName()+"/locations/global", linter, project_stats)
+			// Store the aggregate stats on this project, in the location of its APIs
+			_ = storeLintStatsArtifact(ctx, client, patterns.ProjectLocation(project.GetName()), linter, project_stats)
 			log.Debug(ctx, project.GetName())
 		}
 		return nil
@@ -15,7 +15,7 @@
 package compute
 
 import (
-	"context"
+	"errors"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/patterns"
@@ -28,7 +28,10 @@ import (
 )
 
 func scoreCommand() *cobra.Command {
-	return &cobra.Command{
+	var definitionID string
+	var severityChangesOnly bool
+	var checkConcurrentUpdates bool
+	cmd := &cobra.Command{
 		Use:   "score",
 		Short: "Compute scores for APIs and API specs",
 		Args:  cobra.ExactArgs(1),
@@ -49,20 +52,33 @@ func scoreCommand() *cobra.Command {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
 
-			// Initialize task queue.
-			// Use the warnings queue to make sure that failure in one score calculation task doesn't abort the whole queue.
 			jobs, err := cmd.Flags().GetInt("jobs")
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get jobs from flags")
 			}
-			taskQueue, wait := core.WorkerPoolWithWarnings(ctx, jobs)
-			defer wait()
 
 			inputPattern, err := patterns.ParseResourcePattern(args[0])
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("invalid pattern supplied in the args")
 			}
 			artifactClient := &scoring.RegistryArtifactClient{RegistryClient: client}
+			opts := scoring.Options{
+				SeverityChangesOnly:    severityChangesOnly,
+				CheckConcurrentUpdates: checkConcurrentUpdates,
+			}
+
+			if definitionID != "" {
+				scores, err := scoring.CalculateScoreForResourceWithOptions(ctx, artifactClient, definitionID, args[0], dryRun, opts)
+				if err != nil {
+					log.FromContext(ctx).WithError(err).Fatalf("Failed to compute score %q", definitionID)
+				}
+				if dryRun {
+					for _, s := range scores {
+						core.PrintMessage(s.Score)
+					}
+				}
+				return
+			}
 
 			scoreDefinitions, err := scoring.FetchScoreDefinitions(ctx, artifactClient, inputPattern.Project())
 			if err != nil {
@@ -88,30 +104,25 @@ func scoreCommand() *cobra.Command {
 					continue
 				}
 
-				for _, r := range resources {
-					taskQueue <- &computeScoreTask{
-						client:      artifactClient,
-						defArtifact: d,
-						resource:    r,
-						dryRun:      dryRun,
+				// A failure to score one resource doesn't stop the others from being scored.
+				scores, err := scoring.CalculateScoresPoolWithOptions(ctx, artifactClient, d, resources, jobs, dryRun, opts)
+				var failed scoring.ScoreErrors
+				if errors.As(err, &failed) {
+					for _, f := range failed {
+						log.FromContext(ctx).WithError(f.Err).Warnf("Failed to compute score for %s", f.Resource)
+					}
+				}
+				if dryRun {
+					for _, s := range scores {
+						core.PrintMessage(s.Score)
 					}
 				}
 			}
 		},
 	}
-}
-
-type computeScoreTask struct {
-	client      *scoring.RegistryArtifactClient
-	defArtifact *rpc.Artifact
-	resource    patterns.ResourceInstance
-	dryRun      bool
-}
-
-func (task *computeScoreTask) String() string {
-	return "compute score " + task.resource.ResourceName().String()
-}
 
-func (task *computeScoreTask) Run(ctx context.Context) error {
-	return scoring.CalculateScore(ctx, task.client, task.defArtifact, task.resource, task.dryRun)
+	cmd.Flags().StringVar(&definitionID, "definition", "", "if set, only the score with this definition ID is computed for the named resource")
+	cmd.Flags().BoolVar(&severityChangesOnly, "severity-changes-only", false, "if set, scores are only saved when their severity changes")
+	cmd.Flags().BoolVar(&checkConcurrentUpdates, "check-concurrent-updates", false, "if set, scores aren't saved over scores that were updated while they were computed")
+	return cmd
 }
@@ -0,0 +1,81 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+func summaryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "summary PROJECT",
+		Short: "Compute a summary of the APIs and scores of a project",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			name := c.FQName(args[0])
+			project, err := names.ParseProjectWithLocation(name)
+			if err != nil {
+				project, err = names.ParseProject(name)
+			}
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Invalid project")
+			}
+
+			dryRun, err := cmd.Flags().GetBool("dry-run")
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get dry-run from flags")
+			}
+
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+
+			summary, err := core.ComputeProjectSummary(ctx, client, project)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to compute summary")
+			}
+
+			if dryRun {
+				core.PrintMessage(summary)
+				return
+			}
+
+			messageData, err := proto.Marshal(summary)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to marshal summary")
+			}
+			err = core.SetArtifact(ctx, client, &rpc.Artifact{
+				Name:     project.Artifact(core.ProjectSummaryArtifactID).String(),
+				MimeType: core.MimeTypeForMessageType("google.cloud.apigeeregistry.v1.apihub.ProjectSummary"),
+				Contents: messageData,
+			})
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to save summary")
+			}
+		},
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func integerScore(definition string, value int32) []byte {
+	b, _ := proto.Marshal(&rpc.Score{
+		Id:             "score",
+		DefinitionName: definition,
+		Value:          &rpc.Score_IntegerValue{IntegerValue: &rpc.IntegerValue{Value: value, MaxValue: 10}},
+	})
+	return b
+}
+
+func booleanScore(definition string, value bool) []byte {
+	b, _ := proto.Marshal(&rpc.Score{
+		Id:             "score",
+		DefinitionName: definition,
+		Value:          &rpc.Score_BooleanValue{BooleanValue: &rpc.BooleanValue{Value: value}},
+	})
+	return b
+}
+
+func TestSummary(t *testing.T) {
+	const (
+		project = "projects/summary-test/locations/global"
+		lint    = project + "/artifacts/score-lint"
+		owned   = project + "/artifacts/score-owned"
+	)
+	seed := []seeder.RegistryResource{
+		&rpc.ApiSpec{Name: project + "/apis/petstore/versions/1.0.0/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: project + "/apis/petstore/versions/1.1.0/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: project + "/apis/petstore/versions/1.1.0/specs/protos.zip"},
+		&rpc.ApiVersion{Name: project + "/apis/bookstore/versions/1.0.0"},
+		&rpc.Artifact{
+			Name:     project + "/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/score-lint",
+			MimeType: scoreType,
+			Contents: integerScore(lint, 2),
+		},
+		&rpc.Artifact{
+			Name:     project + "/apis/petstore/versions/1.1.0/specs/openapi.yaml/artifacts/score-lint",
+			MimeType: scoreType,
+			Contents: integerScore(lint, 6),
+		},
+		&rpc.Artifact{
+			Name:     project + "/apis/petstore/versions/1.1.0/specs/protos.zip/artifacts/score-lint",
+			MimeType: scoreType,
+			Contents: integerScore(lint, 7),
+		},
+		&rpc.Artifact{
+			Name:     project + "/apis/petstore/artifacts/score-owned",
+			MimeType: scoreType,
+			Contents: booleanScore(owned, true),
+		},
+		&rpc.Artifact{
+			Name:     project + "/apis/bookstore/artifacts/score-owned",
+			MimeType: scoreType,
+			Contents: booleanScore(owned, false),
+		},
+		&rpc.Artifact{
+			Name:     project + "/apis/bookstore/versions/1.0.0/artifacts/notes",
+			MimeType: "text/plain",
+			Contents: []byte("not a score"),
+		},
+	}
+
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "summary-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "summary-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	cmd := Command()
+	args := []string{"summary", "projects/summary-test"}
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() with args %v returned error: %s", args, err)
+	}
+
+	contents, err := registryClient.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{
+		Name: project + "/artifacts/registry-summary",
+	})
+	if err != nil {
+		t.Fatalf("Failed to get summary: %s", err)
+	}
+	got := &rpc.ProjectSummary{}
+	if err := proto.Unmarshal(contents.GetData(), got); err != nil {
+		t.Fatalf("Failed to unmarshal summary: %s", err)
+	}
+	want := &rpc.ProjectSummary{
+		Id:           "registry-summary",
+		Kind:         "ProjectSummary",
+		ApiCount:     2,
+		VersionCount: 3,
+		SpecCount:    3,
+		Scores: []*rpc.ProjectSummary_ScoreStatistics{
+			{
+				DefinitionName: lint,
+				Count:          3,
+				AverageValue:   5,
+				MinValue:       2,
+				MaxValue:       7,
+			},
+			{
+				DefinitionName: owned,
+				Count:          2,
+				AverageValue:   0.5,
+				MinValue:       0,
+				MaxValue:       1,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("compute summary stored unexpected summary (-want +got):\n%s", diff)
+	}
+}
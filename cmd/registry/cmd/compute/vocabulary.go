@@ -16,6 +16,7 @@ package compute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/apigee/registry/cmd/registry/core"
@@ -23,14 +24,8 @@ import (
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
-	"github.com/google/gnostic/metrics/vocabulary"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
-
-	discovery "github.com/google/gnostic/discovery"
-	metrics "github.com/google/gnostic/metrics"
-	oas2 "github.com/google/gnostic/openapiv2"
-	oas3 "github.com/google/gnostic/openapiv3"
 )
 
 func vocabularyCommand() *cobra.Command {
@@ -118,37 +113,12 @@ func (task *computeVocabularyTask) Run(ctx context.Context) error {
 	}
 
 	log.Debugf(ctx, "Computing %s/artifacts/vocabulary", task.specName)
-	var vocab *metrics.Vocabulary
-
-	if core.IsOpenAPIv2(contents.GetContentType()) {
-		document, err := oas2.ParseDocument(contents.GetData())
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
-			return nil
-		}
-		vocab = vocabulary.NewVocabularyFromOpenAPIv2(document)
-	} else if core.IsOpenAPIv3(contents.GetContentType()) {
-		document, err := oas3.ParseDocument(contents.GetData())
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid OpenAPI: %s", task.specName)
-			return nil
-		}
-		vocab = vocabulary.NewVocabularyFromOpenAPIv3(document)
-	} else if core.IsDiscovery(contents.GetContentType()) {
-		document, err := discovery.ParseDocument(contents.GetData())
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Invalid Discovery: %s", task.specName)
-			return nil
-		}
-		vocab = vocabulary.NewVocabularyFromDiscovery(document)
-	} else if core.IsProto(contents.GetContentType()) && core.IsZipArchive(contents.GetContentType()) {
-		vocab, err = core.NewVocabularyFromZippedProtos(contents.GetData())
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Errorf("Error processing protos: %s", task.specName)
-			return nil
-		}
-	} else {
+	vocab, err := core.NewVocabularyFromSpec(contents.GetContentType(), contents.GetData())
+	if errors.Is(err, core.ErrNoVocabularyExtractor) {
 		return fmt.Errorf("we don't know how to summarize %s", task.specName)
+	} else if err != nil {
+		log.FromContext(ctx).WithError(err).Errorf("Failed to compute vocabulary: %s", task.specName)
+		return nil
 	}
 
 	if task.dryRun {
@@ -16,6 +16,7 @@ package configurations
 
 import (
 	"bytes"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	"github.com/apigee/registry/pkg/config"
 	"github.com/apigee/registry/pkg/config/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/cobra"
 )
 
 func TestCommand(t *testing.T) {
@@ -241,3 +243,109 @@ func TestCreateConfiguration(t *testing.T) {
 		t.Errorf("unexpected diff: (-want +got):\n%s", diff)
 	}
 }
+
+func TestFormattedOutput(t *testing.T) {
+	t.Cleanup(test.CleanConfigDir(t))
+
+	contents := []byte("registry:\n  address: foo\n  insecure: true\n  token: secret\n")
+	if err := os.WriteFile(filepath.Join(config.Directory, "config1"), contents, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Activate("config1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc string
+		cmd  func() *cobra.Command
+		args []string
+		want string
+	}{
+		{
+			desc: "describe json",
+			cmd:  describeCommand,
+			args: []string{"config1", "--format", "json"},
+			want: `{
+  "name": "config1",
+  "is_active": true,
+  "properties": {
+    "registry.address": "foo",
+    "registry.insecure": true,
+    "registry.location": "",
+    "registry.project": "",
+    "registry.token": "REDACTED",
+    "token-source": ""
+  }
+}
+`,
+		},
+		{
+			desc: "describe yaml with secrets",
+			cmd:  describeCommand,
+			args: []string{"config1", "--format", "yaml", "--show-secrets"},
+			want: `name: config1
+is_active: true
+properties:
+    registry.address: foo
+    registry.insecure: true
+    registry.location: ""
+    registry.project: ""
+    registry.token: secret
+    token-source: ""
+`,
+		},
+		{
+			desc: "describe text with secrets",
+			cmd:  describeCommand,
+			args: []string{"config1", "--show-secrets"},
+			want: `is_active: true
+name: config1
+properties:
+  registry.address: foo
+  registry.insecure: true
+  registry.token: secret
+`,
+		},
+		{
+			desc: "list json",
+			cmd:  listCommand,
+			args: []string{"--format", "json"},
+			want: `[
+  {
+    "name": "config1",
+    "is_active": true,
+    "properties": {
+      "registry.address": "foo",
+      "registry.insecure": true,
+      "registry.location": "",
+      "registry.project": "",
+      "registry.token": "REDACTED",
+      "token-source": ""
+    }
+  }
+]
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cmd := test.cmd()
+			cmd.SetArgs(test.args)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, out.String()); diff != "" {
+				t.Errorf("unexpected diff: (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	cmd := listCommand()
+	cmd.SetArgs([]string{"--format", "xml"})
+	cmd.SetOut(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
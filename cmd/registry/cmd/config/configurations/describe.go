@@ -30,39 +30,55 @@ func describeCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return fmt.Errorf("Failed to get format from flags: %s", err)
+			}
+			if err := validateFormat(format); err != nil {
+				return err
+			}
+			showSecrets, err := cmd.Flags().GetBool("show-secrets")
+			if err != nil {
+				return fmt.Errorf("Failed to get show-secrets from flags: %s", err)
+			}
+
 			name := args[0]
 			s, err := config.Read(name)
 			if err != nil {
 				return fmt.Errorf("Cannot read config %q: %v", name, err)
 			}
-			settingsMap, err := s.FlatMap()
+			activeName, err := config.ActiveName()
 			if err != nil {
-				return fmt.Errorf("Cannot decode config %q: %v", name, err)
+				return fmt.Errorf("Cannot read active config %q: %v", name, err)
 			}
 
-			activeName, err := config.ActiveName()
+			info, err := newConfigurationInfo(name, activeName, s, showSecrets)
 			if err != nil {
-				return fmt.Errorf("Cannot read active config %q: %v", name, err)
+				return fmt.Errorf("Cannot decode config %q: %v", name, err)
+			}
+			if format != "" {
+				return printFormatted(cmd, format, info)
 			}
 
-			sortedNames := make([]string, 0, len(settingsMap))
-			for n := range settingsMap {
-				if n != "registry.token" {
+			sortedNames := make([]string, 0, len(info.Properties))
+			for n := range info.Properties {
+				if n != tokenProperty || showSecrets {
 					sortedNames = append(sortedNames, n)
 				}
 			}
 			sort.Strings(sortedNames)
 
-			cmd.Printf("is_active: %v\n", name == activeName)
-			cmd.Printf("name: %v\n", name)
+			cmd.Printf("is_active: %v\n", info.IsActive)
+			cmd.Printf("name: %v\n", info.Name)
 			cmd.Printf("properties:\n")
 			for _, name := range sortedNames {
-				if settingsMap[name] != "" {
-					cmd.Printf("  %s: %v\n", name, settingsMap[name])
+				if info.Properties[name] != "" {
+					cmd.Printf("  %s: %v\n", name, info.Properties[name])
 				}
 			}
 			return nil
 		},
 	}
+	addFormatFlags(cmd)
 	return cmd
 }
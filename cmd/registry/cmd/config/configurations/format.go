@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configurations
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apigee/registry/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	tokenProperty = "registry.token"
+	redacted      = "REDACTED"
+)
+
+// configurationInfo is the structured form of a named configuration.
+type configurationInfo struct {
+	Name       string                 `json:"name" yaml:"name"`
+	IsActive   bool                   `json:"is_active" yaml:"is_active"`
+	Properties map[string]interface{} `json:"properties" yaml:"properties"`
+}
+
+// newConfigurationInfo describes a configuration. Unless showSecrets is set,
+// the token is redacted.
+func newConfigurationInfo(name, activeName string, c config.Configuration, showSecrets bool) (configurationInfo, error) {
+	properties, err := c.FlatMap()
+	if err != nil {
+		return configurationInfo{}, err
+	}
+	if token, ok := properties[tokenProperty]; !ok || token == "" {
+		delete(properties, tokenProperty)
+	} else if !showSecrets {
+		properties[tokenProperty] = redacted
+	}
+	return configurationInfo{
+		Name:       name,
+		IsActive:   name == activeName,
+		Properties: properties,
+	}, nil
+}
+
+func addFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().String("format", "", "Output format, one of: json, yaml. Defaults to human-readable text")
+	cmd.Flags().Bool("show-secrets", false, "Include secrets such as tokens in the output")
+}
+
+func validateFormat(format string) error {
+	switch format {
+	case "", "json", "yaml":
+		return nil
+	default:
+		return fmt.Errorf("Unsupported format %q, must be one of: json, yaml", format)
+	}
+}
+
+// printFormatted writes v to the command's output in the specified format.
+func printFormatted(cmd *cobra.Command, format string, v interface{}) error {
+	var b []byte
+	var err error
+	switch format {
+	case "json":
+		b, err = json.MarshalIndent(v, "", "  ")
+		b = append(b, '\n')
+	case "yaml":
+		b, err = yaml.Marshal(v)
+	default:
+		err = validateFormat(format)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = cmd.OutOrStdout().Write(b)
+	return err
+}
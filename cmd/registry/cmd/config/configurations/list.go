@@ -33,8 +33,22 @@ func listCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			cmd.SilenceUsage = true
 			cmd.SilenceErrors = true
+			format, err := cmd.Flags().GetString("format")
+			if err != nil {
+				return fmt.Errorf("Failed to get format from flags: %s", err)
+			}
+			if err := validateFormat(format); err != nil {
+				return err
+			}
+			showSecrets, err := cmd.Flags().GetBool("show-secrets")
+			if err != nil {
+				return fmt.Errorf("Failed to get show-secrets from flags: %s", err)
+			}
+
 			configs, err := config.Configurations()
-			if errors.Is(err, fs.ErrNotExist) || len(configs) == 0 {
+			if format != "" && (errors.Is(err, fs.ErrNotExist) || len(configs) == 0) {
+				return printFormatted(cmd, format, []configurationInfo{})
+			} else if errors.Is(err, fs.ErrNotExist) || len(configs) == 0 {
 				cmd.Println("You don't have any configurations. Run 'registry config configurations create' to create a configuration.")
 				return nil
 			} else if err != nil {
@@ -52,6 +66,18 @@ func listCommand() *cobra.Command {
 			}
 			sort.Strings(names)
 
+			if format != "" {
+				infos := make([]configurationInfo, 0, len(names))
+				for _, name := range names {
+					info, err := newConfigurationInfo(name, activeName, configs[name], showSecrets)
+					if err != nil {
+						return fmt.Errorf("Cannot decode config %q: %v", name, err)
+					}
+					infos = append(infos, info)
+				}
+				return printFormatted(cmd, format, infos)
+			}
+
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
 			defer w.Flush()
 			fmt.Fprintln(w, "NAME\tIS_ACTIVE\tADDRESS\tINSECURE")
@@ -62,5 +88,6 @@ func listCommand() *cobra.Command {
 			return nil
 		},
 	}
+	addFormatFlags(cmd)
 	return cmd
 }
@@ -1,12 +1,24 @@
 package export
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apigee/registry/cmd/registry/patch"
 	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/pkg/models"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
 	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
 )
 
 func TestExportYAML(t *testing.T) {
@@ -98,3 +110,398 @@ func TestExportYAML(t *testing.T) {
 		}
 	}
 }
+
+func TestExportProjectStream(t *testing.T) {
+	const scoreType = "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.Score"
+	artifacts := []*rpc.Artifact{
+		{Name: "projects/stream-project/locations/global/artifacts/x", MimeType: scoreType},
+		{Name: "projects/stream-project/locations/global/apis/a/versions/v/specs/s/artifacts/x", MimeType: scoreType},
+		{Name: "projects/stream-project/locations/global/apis/b/deployments/d/artifacts/x", MimeType: scoreType},
+	}
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedArtifacts(ctx, client, artifacts...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		nested bool
+		want   []string
+	}{
+		{
+			nested: false,
+			want:   []string{"API:a", "API:b"},
+		},
+		{
+			nested: true,
+			want:   []string{"API:a", "API:b", "Score:x"},
+		},
+	}
+	for _, test := range tests {
+		r, err := patch.ExportProjectStream(ctx, registryClient, names.Project{ProjectID: "stream-project"}, test.nested)
+		if err != nil {
+			t.Fatalf("ExportProjectStream() returned error: %s", err)
+		}
+		defer r.Close()
+		got := make([]string, 0)
+		dec := yaml.NewDecoder(r)
+		for {
+			var header models.Header
+			if err := dec.Decode(&header); errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				t.Fatalf("Failed to decode stream: %s", err)
+			}
+			got = append(got, header.Kind+":"+header.Metadata.Name)
+		}
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("ExportProjectStream(nested=%t) returned unexpected documents (-want +got):\n%s", test.nested, diff)
+		}
+	}
+}
+
+func TestExportProjectStreamStops(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedApis(ctx, client,
+		&rpc.Api{Name: "projects/stream-stop-project/locations/global/apis/a"},
+		&rpc.Api{Name: "projects/stream-stop-project/locations/global/apis/b"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	project := names.Project{ProjectID: "stream-stop-project"}
+
+	// Closing the stream before reading it stops the export.
+	r, err := patch.ExportProjectStream(ctx, registryClient, project, true)
+	if err != nil {
+		t.Fatalf("ExportProjectStream() returned error: %s", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() returned error: %s", err)
+	}
+	if _, err := io.ReadAll(r); err == nil {
+		t.Errorf("Reading a closed stream succeeded but should have failed")
+	}
+
+	// Canceling the context stops the export and is reported by reads.
+	canceled, cancel := context.WithCancel(ctx)
+	r, err = patch.ExportProjectStream(canceled, registryClient, project, true)
+	if err != nil {
+		t.Fatalf("ExportProjectStream() returned error: %s", err)
+	}
+	defer r.Close()
+	cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.ReadAll(r)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) && status.Code(err) != codes.Canceled {
+			t.Errorf("Reading a canceled stream returned %v, want a cancellation error", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Reading a canceled stream did not return")
+	}
+}
+
+func TestExportProjectBundle(t *testing.T) {
+	const scoreType = "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.Score"
+	artifacts := []*rpc.Artifact{
+		{Name: "projects/bundle-project/locations/global/artifacts/x", MimeType: scoreType},
+		{Name: "projects/bundle-project/locations/global/artifacts/generic", MimeType: "text/plain", Contents: []byte("hello")},
+		{Name: "projects/bundle-project/locations/global/apis/a/versions/v/specs/s/artifacts/x", MimeType: scoreType},
+		{Name: "projects/bundle-project/locations/global/apis/b/deployments/d/artifacts/x", MimeType: scoreType},
+	}
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedArtifacts(ctx, client, artifacts...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	want := exportProject(t, registryClient, "bundle-project")
+
+	tests := []struct {
+		desc    string
+		format  patch.BundleFormat
+		project string
+	}{
+		{
+			desc:    "tar",
+			format:  patch.TarBundle,
+			project: "bundle-tar",
+		},
+		{
+			desc:    "zip",
+			format:  patch.ZipBundle,
+			project: "bundle-zip",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := patch.ExportProjectBundle(ctx, registryClient, names.Project{ProjectID: "bundle-project"}, &b, test.format); err != nil {
+				t.Fatalf("ExportProjectBundle() returned error: %s", err)
+			}
+
+			if err := seeder.SeedProjects(ctx, client, &rpc.Project{Name: "projects/" + test.project}); err != nil {
+				t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+			}
+			if err := patch.ApplyBundle(ctx, registryClient, &b, "projects/"+test.project+"/locations/global"); err != nil {
+				t.Fatalf("ApplyBundle() returned error: %s", err)
+			}
+			if diff := cmp.Diff(want, exportProject(t, registryClient, test.project)); diff != "" {
+				t.Errorf("ApplyBundle() applied unexpected resources (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := patch.ApplyBundle(ctx, registryClient, strings.NewReader("not a bundle"), "projects/bundle-tar/locations/global"); err == nil {
+		t.Errorf("ApplyBundle() succeeded with an invalid bundle")
+	}
+}
+
+// exportProject returns the YAML of the APIs and artifacts of a project.
+func exportProject(t *testing.T, client connection.RegistryClient, projectID string) string {
+	t.Helper()
+	r, err := patch.ExportProjectStream(context.Background(), client, names.Project{ProjectID: projectID}, true)
+	if err != nil {
+		t.Fatalf("ExportProjectStream() returned error: %s", err)
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %s", err)
+	}
+	return string(b)
+}
+
+func TestExportProjectArtifacts(t *testing.T) {
+	const scoreType = "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.Score"
+	artifacts := []*rpc.Artifact{
+		{Name: "projects/artifacts-project/locations/global/artifacts/x", MimeType: scoreType},
+		{Name: "projects/artifacts-project/locations/global/artifacts/y", MimeType: scoreType},
+		{Name: "projects/artifacts-project/locations/global/artifacts/generic", MimeType: "text/plain", Contents: []byte("hello")},
+		{Name: "projects/artifacts-project/locations/global/apis/a/artifacts/z", MimeType: scoreType},
+	}
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedArtifacts(ctx, client, artifacts...); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	b, err := patch.ExportProjectArtifacts(ctx, registryClient, names.Project{ProjectID: "artifacts-project"})
+	if err != nil {
+		t.Fatalf("ExportProjectArtifacts() returned error: %s", err)
+	}
+	got := make([]string, 0)
+	dec := yaml.NewDecoder(strings.NewReader(string(b)))
+	for {
+		var header models.Header
+		if err := dec.Decode(&header); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("Failed to decode artifacts: %s", err)
+		}
+		if header.ApiVersion == "" {
+			t.Errorf("ExportProjectArtifacts() returned %s without an apiVersion", header.Metadata.Name)
+		}
+		got = append(got, header.Kind+":"+header.Metadata.Name)
+	}
+	want := []string{"Score:x", "Score:y"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExportProjectArtifacts() returned unexpected documents (-want +got):\n%s", diff)
+	}
+
+	if _, err := patch.ExportProjectArtifacts(ctx, registryClient, names.Project{ProjectID: "-"}); err == nil {
+		t.Errorf("ExportProjectArtifacts() with invalid project succeeded but should have failed")
+	}
+}
+
+func TestExportYAMLOptions(t *testing.T) {
+	description := strings.TrimSpace(strings.Repeat("A very long description. ", 10))
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	api := &rpc.Api{
+		Name:         "projects/options-project/locations/global/apis/a",
+		DisplayName:  "A",
+		Description:  description,
+		Availability: "GENERAL",
+		Labels:       map[string]string{"team": "x", "tier": "1"},
+	}
+	if err := seeder.SeedApis(ctx, client, api); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	api, err = registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: api.Name})
+	if err != nil {
+		t.Fatalf("Failed to get API: %s", err)
+	}
+
+	exported, _, err := patch.ExportAPI(ctx, registryClient, api, false)
+	if err != nil {
+		t.Fatalf("ExportAPI() returned error: %s", err)
+	}
+	b, err := patch.FormatYAML(exported, patch.YAMLOptions{Indent: 4, FlowMaxEntries: 2})
+	if err != nil {
+		t.Fatalf("FormatYAML() returned error: %s", err)
+	}
+	got := string(b)
+	for _, want := range []string{
+		"\n    labels: {team: x, tier: \"1\"}\n",
+		"\n    description: " + description + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatYAML() output does not contain %q:\n%s", want, got)
+		}
+	}
+
+	// Formatting with the default options reproduces the export.
+	b, err = patch.FormatYAML(exported, patch.DefaultYAMLOptions)
+	if err != nil {
+		t.Fatalf("FormatYAML() returned error: %s", err)
+	}
+	if diff := cmp.Diff(string(exported), string(b)); diff != "" {
+		t.Errorf("FormatYAML() with default options changed the export (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportAPIModes(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	spec := &rpc.ApiSpec{
+		Name:     "projects/mode-project/locations/global/apis/a/versions/v/specs/s",
+		MimeType: "text/plain",
+		Contents: []byte("contents"),
+	}
+	if err := seeder.SeedSpecs(ctx, client, spec); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+	api, err := registryClient.GetApi(ctx, &rpc.GetApiRequest{Name: "projects/mode-project/locations/global/apis/a"})
+	if err != nil {
+		t.Fatalf("Failed to get API: %s", err)
+	}
+
+	export := func(mode patch.ExportMode) *models.Api {
+		t.Helper()
+		b, _, err := patch.ExportAPIWithMode(ctx, registryClient, api, true, mode)
+		if err != nil {
+			t.Fatalf("ExportAPIWithMode(%d) returned error: %s", mode, err)
+		}
+		got := &models.Api{}
+		if err := yaml.Unmarshal(b, got); err != nil {
+			t.Fatalf("Failed to unmarshal export: %s", err)
+		}
+		if len(got.Data.ApiVersions) != 1 || len(got.Data.ApiVersions[0].Data.ApiSpecs) != 1 {
+			t.Fatalf("ExportAPIWithMode(%d) returned unexpected children:\n%s", mode, b)
+		}
+		return got
+	}
+
+	t.Run("apply ready", func(t *testing.T) {
+		got := export(patch.ApplyReady)
+		version := got.Data.ApiVersions[0]
+		spec := version.Data.ApiSpecs[0]
+		for _, metadata := range []models.Metadata{got.Metadata, version.Metadata, spec.Metadata} {
+			want := models.Metadata{Name: metadata.Name}
+			if diff := cmp.Diff(want, metadata); diff != "" {
+				t.Errorf("ApplyReady export includes server fields (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("full fidelity", func(t *testing.T) {
+		got := export(patch.FullFidelity)
+		version := got.Data.ApiVersions[0]
+		spec := version.Data.ApiSpecs[0]
+		if got.Metadata.CreateTime == "" || got.Metadata.UpdateTime == "" {
+			t.Errorf("FullFidelity export is missing API timestamps: %+v", got.Metadata)
+		}
+		if version.Metadata.CreateTime == "" || version.Metadata.UpdateTime == "" {
+			t.Errorf("FullFidelity export is missing version timestamps: %+v", version.Metadata)
+		}
+		if spec.Metadata.RevisionID == "" || spec.Metadata.RevisionCreateTime == "" || spec.Metadata.Hash == "" {
+			t.Errorf("FullFidelity export is missing spec revision fields: %+v", spec.Metadata)
+		}
+		if spec.Metadata.SizeBytes != int32(len("contents")) {
+			t.Errorf("FullFidelity export has spec sizeBytes %d, want %d", spec.Metadata.SizeBytes, len("contents"))
+		}
+	})
+}
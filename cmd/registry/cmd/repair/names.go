@@ -0,0 +1,107 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repair
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/spf13/cobra"
+)
+
+func artifactNamesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "artifact-names PROJECT",
+		Short: "Report artifacts with malformed names and the names they were meant to have",
+		Long: `Report artifacts with malformed names and the names they were meant to have.
+
+Malformed names were stored when full or relative resource names were used
+where IDs were expected. Each malformed name is printed with its repaired name
+or with the reason that it can't be repaired. This command only reports names:
+the registry rejects malformed names in requests, so artifacts with malformed
+names must be renamed or deleted in the registry's storage.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			project, err := names.ParseProject(c.FQName(args[0]))
+			if err != nil {
+				return fmt.Errorf("invalid project %q: %s", args[0], err)
+			}
+
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+			}
+			count, err := reportMalformedArtifactNames(ctx, client, project, cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			log.Debugf(ctx, "Found %d malformed artifact names", count)
+			return nil
+		},
+	}
+	return cmd
+}
+
+// artifactParents are the parents of all of the artifacts in a project.
+var artifactParents = []string{
+	"",
+	"apis/-/",
+	"apis/-/versions/-/",
+	"apis/-/versions/-/specs/-/",
+	"apis/-/deployments/-/",
+}
+
+// reportMalformedArtifactNames writes a line to w for each artifact of project
+// with a malformed name and returns the number of such artifacts.
+func reportMalformedArtifactNames(ctx context.Context, client connection.RegistryClient, project names.Project, w io.Writer) (int, error) {
+	count := 0
+	for _, parent := range artifactParents {
+		pattern, err := names.ParseArtifact(fmt.Sprintf("%s/%sartifacts/-", patterns.ProjectLocation(project.String()), parent))
+		if err != nil {
+			return count, err
+		}
+		err = core.ListArtifacts(ctx, client, pattern, "", false, func(artifact *rpc.Artifact) error {
+			fixed, changed, err := patch.RepairArtifactName(artifact.GetName())
+			if err != nil {
+				count++
+				_, err = fmt.Fprintf(w, "%s: %s\n", artifact.GetName(), err)
+				return err
+			}
+			if !changed {
+				return nil
+			}
+			count++
+			_, err = fmt.Fprintf(w, "%s -> %s\n", artifact.GetName(), fixed)
+			return err
+		})
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repair
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/pkg/connection/grpctest"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry"
+	"github.com/apigee/registry/server/registry/test/seeder"
+)
+
+func TestMain(m *testing.M) {
+	grpctest.TestMain(m, registry.Config{})
+}
+
+func TestRepairArtifactName(t *testing.T) {
+	tests := []struct {
+		desc        string
+		stored      string
+		want        string
+		wantChanged bool
+		wantErr     bool
+	}{
+		{
+			desc:   "valid",
+			stored: "projects/p/locations/global/apis/a/versions/v/artifacts/x",
+			want:   "projects/p/locations/global/apis/a/versions/v/artifacts/x",
+		},
+		{
+			desc:        "embedded full name",
+			stored:      "projects/p/locations/global/apis/projects/p/locations/global/apis/a/artifacts/x",
+			want:        "projects/p/locations/global/apis/a/artifacts/x",
+			wantChanged: true,
+		},
+		{
+			desc:        "embedded relative name",
+			stored:      "projects/p/locations/global/apis/a/versions/versions/v/specs/s/artifacts/x",
+			want:        "projects/p/locations/global/apis/a/versions/v/specs/s/artifacts/x",
+			wantChanged: true,
+		},
+		{
+			desc:    "name from another project",
+			stored:  "projects/p/locations/global/apis/projects/q/locations/global/apis/a/artifacts/x",
+			wantErr: true,
+		},
+		{
+			desc:    "unrepairable",
+			stored:  "projects/p/locations/global/apis/a/b/artifacts/x",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, changed, err := patch.RepairArtifactName(test.stored)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("RepairArtifactName(%q) returned error %v, want error %t", test.stored, err, test.wantErr)
+			}
+			if got != test.want || changed != test.wantChanged {
+				t.Errorf("RepairArtifactName(%q) returned (%q, %t), want (%q, %t)", test.stored, got, changed, test.want, test.wantChanged)
+			}
+		})
+	}
+}
+
+func TestArtifactNames(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	// The registry rejects malformed names, so only valid names can be seeded.
+	if err := seeder.SeedArtifacts(ctx, client,
+		&rpc.Artifact{Name: "projects/repair-test/locations/global/artifacts/x"},
+		&rpc.Artifact{Name: "projects/repair-test/locations/global/apis/a/versions/v/specs/s/artifacts/x"},
+		&rpc.Artifact{Name: "projects/repair-test/locations/global/apis/a/deployments/d/artifacts/x"},
+	); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	cmd := Command()
+	cmd.SetArgs([]string{"artifact-names", "projects/repair-test"})
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() returned error: %s", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Execute() reported malformed names:\n%s", out)
+	}
+}
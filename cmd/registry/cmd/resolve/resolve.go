@@ -17,6 +17,7 @@ package resolve
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/controller"
 	"github.com/apigee/registry/cmd/registry/core"
@@ -56,6 +57,13 @@ func Command() *cobra.Command {
 	var dryRun bool
 	var jobs int
 	var maxActions int
+	var prune bool
+	var expire bool
+	var selectors []string
+	var skipKey string
+	var checkExistence bool
+	var healthTimeout time.Duration
+	var skipDegraded bool
 	cmd := &cobra.Command{
 		Use:   "resolve MANIFEST_RESOURCE",
 		Short: "resolve the dependencies and update the registry state (experimental)",
@@ -73,6 +81,22 @@ func Command() *cobra.Command {
 				log.FromContext(ctx).WithError(err).Fatal("Invalid manifest resource name")
 			}
 
+			if healthTimeout > 0 {
+				adminClient, err := connection.NewAdminClientWithSettings(ctx, c)
+				if err != nil {
+					log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
+				}
+				health := controller.CheckHealth(ctx, adminClient, controller.HealthOptions{Timeout: healthTimeout})
+				adminClient.Close()
+				switch {
+				case health.Readiness == controller.Down, health.Readiness == controller.Degraded && skipDegraded:
+					log.FromContext(ctx).WithError(health.Err).Warnf("Skipping resolve, registry is %s: %s", health.Readiness, health.Message)
+					return
+				case health.Readiness == controller.Degraded:
+					log.FromContext(ctx).WithError(health.Err).Warnf("Registry is %s: %s", health.Readiness, health.Message)
+				}
+			}
+
 			registryClient, err := connection.NewRegistryClientWithSettings(ctx, c)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
@@ -82,11 +106,22 @@ func Command() *cobra.Command {
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to fetch manifest")
 			}
+			manifest, err = controller.SelectGeneratedResources(manifest, selectors)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to select generated resources")
+			}
 
 			client := &controller.RegistryLister{RegistryClient: registryClient}
+			opts := controller.Options{SkipKey: skipKey, CheckExistence: checkExistence}
 
 			log.Debug(ctx, "Generating the list of actions...")
-			actions := controller.ProcessManifest(ctx, client, name.ProjectID(), manifest, maxActions)
+			actions := controller.ProcessManifestWithOptions(ctx, client, name.ProjectID(), manifest, maxActions, opts)
+			if prune && len(actions) < maxActions {
+				actions = append(actions, controller.PruneOrphans(ctx, client, name.ProjectID(), manifest, maxActions-len(actions))...)
+			}
+			if expire && len(actions) < maxActions {
+				actions = append(actions, controller.ExpireArtifacts(ctx, client, name.ProjectID(), manifest, maxActions-len(actions))...)
+			}
 
 			// The monitoring metrics/dashboards are built on top of the format of the log messages here.
 			// Check the metric filters before making any changes to the format.
@@ -101,7 +136,11 @@ func Command() *cobra.Command {
 			// If dry_run is set to true, print the generated actions and exit
 			if dryRun {
 				for _, a := range actions {
-					log.Debugf(ctx, "Action: %q", a.Command)
+					if a.Destructive {
+						log.Debugf(ctx, "Action (deletes %s): %q", a.GeneratedResource, a.Command)
+					} else {
+						log.Debugf(ctx, "Action: %q", a.Command)
+					}
 				}
 				return
 			}
@@ -112,6 +151,7 @@ func Command() *cobra.Command {
 			// Submit tasks to taskQueue
 			for i := 0; i < len(actions) && i < maxActions; i++ {
 				taskQueue <- &controller.ExecCommandTask{
+					Client: registryClient,
 					Action: actions[i],
 					TaskID: fmt.Sprintf("%.8s", uuid.New()),
 				}
@@ -122,5 +162,12 @@ func Command() *cobra.Command {
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "if set, actions will only be printed and not executed")
 	cmd.Flags().IntVarP(&jobs, "jobs", "j", 10, "Number of actions to execute simultaneously")
 	cmd.Flags().IntVarP(&maxActions, "max-actions", "a", 100, "Maximum number of actions to execute")
+	cmd.Flags().BoolVar(&prune, "prune", false, "if set, generated artifacts whose dependencies no longer exist will be deleted")
+	cmd.Flags().BoolVar(&expire, "expire", false, "if set, generated artifacts that are older than their manifest expiry will be deleted")
+	cmd.Flags().StringVar(&skipKey, "skip-key", "", "if set, resources with a label or annotation with this key (and a value other than \"false\") will not trigger or receive generated resources")
+	cmd.Flags().BoolVar(&checkExistence, "check-existence", false, "if set, actions for resources that were deleted while the manifest was processed will be dropped (adds a list call per action)")
+	cmd.Flags().DurationVar(&healthTimeout, "health-timeout", 0, "if set, resolve is skipped when the registry status can't be confirmed within this duration")
+	cmd.Flags().BoolVar(&skipDegraded, "skip-degraded", false, "if set with --health-timeout, resolve is also skipped when the registry is degraded")
+	cmd.Flags().StringSliceVar(&selectors, "select", nil, "if set, only the generated resources with these artifact IDs or patterns will be resolved")
 	return cmd
 }
@@ -28,9 +28,11 @@ import (
 	"github.com/apigee/registry/cmd/registry/cmd/index"
 	"github.com/apigee/registry/cmd/registry/cmd/label"
 	"github.com/apigee/registry/cmd/registry/cmd/list"
+	"github.com/apigee/registry/cmd/registry/cmd/repair"
 	"github.com/apigee/registry/cmd/registry/cmd/resolve"
 	"github.com/apigee/registry/cmd/registry/cmd/rpc"
 	"github.com/apigee/registry/cmd/registry/cmd/upload"
+	"github.com/apigee/registry/cmd/registry/cmd/validate"
 	"github.com/apigee/registry/cmd/registry/cmd/vocabulary"
 	pkgconf "github.com/apigee/registry/pkg/config"
 	"github.com/spf13/cobra"
@@ -62,7 +64,9 @@ func Command() *cobra.Command {
 	cmd.AddCommand(index.Command())
 	cmd.AddCommand(label.Command())
 	cmd.AddCommand(list.Command())
+	cmd.AddCommand(repair.Command())
 	cmd.AddCommand(upload.Command())
+	cmd.AddCommand(validate.Command())
 	cmd.AddCommand(vocabulary.Command())
 	cmd.AddCommand(rpc.Command())
 	return cmd
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/spf13/cobra"
@@ -57,7 +58,11 @@ func getParent(cmd *cobra.Command) (string, error) {
 		return parent, nil
 	} else if projectID != "" {
 		log.FromContext(ctx).Warn("--project-id is deprecated, please use --parent or configure registry.project")
-		return "projects/" + projectID + "/locations/global", nil
+		c, err := connection.ActiveConfig()
+		if err != nil {
+			return "", fmt.Errorf("unable to identify parent (%s)", err)
+		}
+		return patterns.ProjectLocationWithID("projects/"+projectID, c.Location), nil
 	}
 	c, err := connection.ActiveConfig()
 	if err != nil {
@@ -75,6 +75,30 @@ func TestParentFromFlags(t *testing.T) {
 	}
 }
 
+func TestParentFromProjectIDAndConfiguredLocation(t *testing.T) {
+	config, err := connection.ActiveConfig()
+	if err != nil {
+		t.Fatalf("Setup: Failed to get registry configuration: %s", err)
+	}
+	t.Cleanup(func() { connection.SetConfig(config) })
+	configured := config
+	configured.Location = "us-central1"
+	connection.SetConfig(configured)
+
+	cmd := Command()
+	cmd.SetContext(context.Background())
+	if err := cmd.ParseFlags([]string{"--project-id", "sample"}); err != nil {
+		t.Fatalf("Failed to parse flags")
+	}
+	parent, err := getParent(cmd)
+	if err != nil {
+		t.Fatalf("Get parent unexpectedly failed with error: %s", err)
+	}
+	if want := "projects/sample/locations/us-central1"; parent != want {
+		t.Errorf("Get parent: wanted %s, got %s", want, parent)
+	}
+}
+
 func TestParentFromConfiguration(t *testing.T) {
 	tests := []struct {
 		desc       string
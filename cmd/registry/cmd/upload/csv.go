@@ -23,6 +23,7 @@ import (
 	"os"
 
 	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
@@ -49,12 +50,16 @@ func csvCommand() *cobra.Command {
 				log.Fatalf(ctx, "Invalid delimiter %q: must be exactly one character", delimiter)
 			}
 
-			client, err := connection.NewRegistryClient(ctx)
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
 
-			adminClient, err := connection.NewAdminClient(ctx)
+			adminClient, err := connection.NewAdminClientWithSettings(ctx, c)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
@@ -89,7 +94,7 @@ func csvCommand() *cobra.Command {
 
 				taskQueue <- &uploadSpecTask{
 					client:    client,
-					projectID: projectID,
+					parent:    patterns.ProjectLocationWithID("projects/"+projectID, c.Location),
 					apiID:     row.ApiID,
 					versionID: row.VersionID,
 					specID:    row.SpecID,
@@ -179,8 +184,9 @@ func (r *uploadCSVReader) buildColumnIndex(header []string) error {
 }
 
 type uploadSpecTask struct {
-	client    connection.RegistryClient
-	projectID string
+	client connection.RegistryClient
+	// parent is the location that the spec is uploaded to, e.g. "projects/demo/locations/global".
+	parent    string
 	apiID     string
 	versionID string
 	specID    string
@@ -189,7 +195,7 @@ type uploadSpecTask struct {
 
 func (t uploadSpecTask) Run(ctx context.Context) error {
 	api, err := t.client.CreateApi(ctx, &rpc.CreateApiRequest{
-		Parent: fmt.Sprintf("projects/%s/locations/global", t.projectID),
+		Parent: t.parent,
 		ApiId:  t.apiID,
 		Api:    &rpc.Api{},
 	})
@@ -199,7 +205,7 @@ func (t uploadSpecTask) Run(ctx context.Context) error {
 		log.Debugf(ctx, "Created API: %s", api.GetName())
 	case codes.AlreadyExists:
 		api = &rpc.Api{
-			Name: fmt.Sprintf("projects/%s/locations/global/apis/%s", t.projectID, t.apiID),
+			Name: fmt.Sprintf("%s/apis/%s", t.parent, t.apiID),
 		}
 	default:
 		return fmt.Errorf("failed to ensure API exists: %s", err)
@@ -216,7 +222,7 @@ func (t uploadSpecTask) Run(ctx context.Context) error {
 		log.Debugf(ctx, "Created API version: %s", version.GetName())
 	case codes.AlreadyExists:
 		version = &rpc.ApiVersion{
-			Name: fmt.Sprintf("projects/%s/locations/global/apis/%s/versions/%s", t.projectID, t.apiID, t.versionID),
+			Name: fmt.Sprintf("%s/apis/%s/versions/%s", t.parent, t.apiID, t.versionID),
 		}
 	default:
 		return fmt.Errorf("failed to ensure API version exists: %s", err)
@@ -20,6 +20,7 @@ import (
 
 	"github.com/apigee/registry/cmd/registry/controller"
 	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
@@ -65,7 +66,7 @@ func manifestCommand() *cobra.Command {
 			}
 
 			// validate the manifest
-			errs := controller.ValidateManifest(fmt.Sprintf("projects/%s/locations/global", projectID), manifest)
+			errs := controller.ValidateManifest(patterns.ProjectLocation("projects/"+projectID), manifest)
 			if len(errs) > 0 {
 				for _, err := range errs {
 					log.FromContext(ctx).WithError(err).Errorf("Invalid manifest entry")
@@ -74,13 +75,17 @@ func manifestCommand() *cobra.Command {
 			}
 
 			manifestData, _ := proto.Marshal(manifest)
-			client, err := connection.NewRegistryClient(ctx)
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
 
 			artifact := &rpc.Artifact{
-				Name:     "projects/" + projectID + "/locations/global/artifacts/" + manifest.GetId(),
+				Name:     patterns.ProjectLocationWithID("projects/"+projectID, c.Location) + "/artifacts/" + manifest.GetId(),
 				MimeType: core.MimeTypeForMessageType("google.cloud.apigeeregistry.v1.controller.Manifest"),
 				Contents: manifestData,
 			}
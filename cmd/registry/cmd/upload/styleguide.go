@@ -20,6 +20,7 @@ import (
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
@@ -72,16 +73,17 @@ func styleGuideCommand() *cobra.Command {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to encode style guide")
 			}
 
-			client, err := connection.NewRegistryClient(ctx)
+			c, err := connection.ActiveConfig()
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Fatal("Failed to get config")
+			}
+			client, err := connection.NewRegistryClientWithSettings(ctx, c)
 			if err != nil {
 				log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
 			}
 
 			artifact := &rpc.Artifact{
-				Name: "projects/" +
-					projectID +
-					"/locations/global/artifacts/" +
-					styleGuide.GetId(),
+				Name:     patterns.ProjectLocationWithID("projects/"+projectID, c.Location) + "/artifacts/" + styleGuide.GetId(),
 				MimeType: patch.MimeTypeForKind("StyleGuide"),
 				Contents: styleGuideMarshalled,
 			}
@@ -0,0 +1,58 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/apigee/registry/cmd/registry/controller"
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/spf13/cobra"
+)
+
+func manifestCommand() *cobra.Command {
+	var projectID string
+	cmd := &cobra.Command{
+		Use:   "manifest FILE_PATH",
+		Short: "Check a dependency manifest for errors",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			yamlBytes, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			manifest, errs := controller.ValidateManifestYAML(patterns.ProjectLocation("projects/"+projectID), yamlBytes)
+			for _, err := range errs {
+				cmd.Printf("%s: %s\n", args[0], err)
+			}
+			if count := len(errs); count > 0 {
+				return fmt.Errorf("manifest %q contains %d error(s)", args[0], count)
+			}
+			// Warnings point out entries that can never be generated but don't make the manifest invalid.
+			for _, warning := range controller.AnalyzeManifest(manifest) {
+				cmd.Printf("%s: warning: %s\n", args[0], warning)
+			}
+			cmd.Printf("%s: ok\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projectID, "project-id", "-", "Project ID to use when checking resource patterns")
+	return cmd
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateManifest(t *testing.T) {
+	dir := t.TempDir()
+	good := filepath.Join(dir, "good.yaml")
+	if err := os.WriteFile(good, []byte(`id: good
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte(`id: bad
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    action: "registry compute complexity $resource.spec"
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := Command()
+	cmd.SetArgs([]string{"manifest", good})
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() returned error: %s", err)
+	}
+	if diff := cmp.Diff(good+": ok\n", out.String()); diff != "" {
+		t.Errorf("unexpected diff: (-want +got):\n%s", diff)
+	}
+
+	cmd = Command()
+	cmd.SetArgs([]string{"manifest", bad})
+	out = new(bytes.Buffer)
+	cmd.SetOut(out)
+	if err := cmd.Execute(); err == nil {
+		t.Errorf("Execute() succeeded for invalid manifest")
+	}
+	if out.Len() == 0 {
+		t.Errorf("Execute() printed no errors for invalid manifest")
+	}
+
+	unreachable := filepath.Join(dir, "unreachable.yaml")
+	if err := os.WriteFile(unreachable, []byte(`id: unreachable
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/score
+    dependencies:
+      - pattern: $resource.spec/artifacts/score
+    action: "registry compute score $resource.spec"
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd = Command()
+	cmd.SetArgs([]string{"manifest", unreachable})
+	out = new(bytes.Buffer)
+	cmd.SetOut(out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() returned error for manifest with warnings: %s", err)
+	}
+	if !strings.Contains(out.String(), unreachable+": warning: ") || !strings.HasSuffix(out.String(), unreachable+": ok\n") {
+		t.Errorf("Execute() printed unexpected output for manifest with warnings: %q", out.String())
+	}
+
+	cmd = Command()
+	cmd.SetArgs([]string{"manifest", filepath.Join(dir, "missing.yaml")})
+	cmd.SetOut(new(bytes.Buffer))
+	if err := cmd.Execute(); err == nil {
+		t.Errorf("Execute() succeeded for missing file")
+	}
+}
@@ -41,7 +41,7 @@ func initializeConformanceReport(specName, styleguideId, project string) *rpc.Co
 	conformanceReport := &rpc.ConformanceReport{
 		Id:         conformanceReportId(styleguideId),
 		Kind:       "ConformanceReport",
-		Styleguide: fmt.Sprintf("projects/%s/locations/global/artifacts/%s", project, styleguideId),
+		Styleguide: names.Project{ProjectID: project}.Artifact(styleguideId).String(),
 	}
 
 	// Initialize guideline report groups.
@@ -88,17 +88,71 @@ func (task *ComputeConformanceTask) String() string {
 	return fmt.Sprintf("compute %s/artifacts/%s", task.Spec.GetName(), conformanceReportId(task.StyleguideId))
 }
 
+// ComputeConformance runs the linters configured by a style guide against a spec
+// and returns the resulting conformance report without storing it.
+func ComputeConformance(
+	ctx context.Context,
+	client connection.RegistryClient,
+	specName string,
+	styleguide *rpc.StyleGuide) (*rpc.ConformanceReport, error) {
+	name, err := names.ParseSpecRevision(specName)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: name.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	supported := false
+	for _, mimeType := range styleguide.GetMimeTypes() {
+		if mimeType == spec.GetMimeType() {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return nil, fmt.Errorf("style guide %q does not support specs of type %q", styleguide.GetId(), spec.GetMimeType())
+	}
+
+	linterMetadata, err := GenerateLinterMetadata(styleguide)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &ComputeConformanceTask{
+		Client:          client,
+		Spec:            spec,
+		LintersMetadata: linterMetadata,
+		StyleguideId:    styleguide.GetId(),
+	}
+	return task.computeReport(ctx)
+}
+
 func (task *ComputeConformanceTask) Run(ctx context.Context) error {
 	log.Debugf(ctx, "Computing conformance report %s/artifacts/%s", task.Spec.GetName(), conformanceReportId(task.StyleguideId))
 
-	data, err := core.GetBytesForSpec(ctx, task.Client, task.Spec)
+	conformanceReport, err := task.computeReport(ctx)
 	if err != nil {
 		return err
 	}
+
+	if task.DryRun {
+		core.PrintMessage(conformanceReport)
+		return nil
+	}
+	return task.storeConformanceReport(ctx, conformanceReport)
+}
+
+func (task *ComputeConformanceTask) computeReport(ctx context.Context) (*rpc.ConformanceReport, error) {
+	data, err := core.GetBytesForSpec(ctx, task.Client, task.Spec)
+	if err != nil {
+		return nil, err
+	}
 	// Put the spec in a temporary directory.
 	root, err := os.MkdirTemp("", "registry-spec-")
 	if err != nil {
-		return err
+		return nil, err
 	}
 	name := filepath.Base(task.Spec.GetName())
 	defer os.RemoveAll(root)
@@ -110,20 +164,20 @@ func (task *ComputeConformanceTask) Run(ctx context.Context) error {
 		err = os.WriteFile(filepath.Join(root, name), data, 0644)
 	}
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get project
 	spec, err := names.ParseSpecRevision(task.Spec.GetName())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Run the linters and compute conformance report
 	conformanceReport := initializeConformanceReport(task.Spec.GetName(), task.StyleguideId, spec.ProjectID)
 	guidelineReportsMap := make(map[string]int)
 	for _, metadata := range task.LintersMetadata {
-		linterResponse, err := task.invokeLinter(ctx, root, metadata)
+		linterResponse, err := task.runLinter(ctx, root, data, metadata)
 		// If a linter returned an error, we shouldn't stop linting completely across all linters and
 		// discard the conformance report for this spec. We should log but still continue, because there
 		// may still be useful information from other linters that we may be discarding.
@@ -135,11 +189,31 @@ func (task *ComputeConformanceTask) Run(ctx context.Context) error {
 		task.computeConformanceReport(ctx, conformanceReport, guidelineReportsMap, linterResponse, metadata)
 	}
 
-	if task.DryRun {
-		core.PrintMessage(conformanceReport)
-		return nil
+	return conformanceReport, nil
+}
+
+// runLinter runs the binary of a linter if it can be found and otherwise
+// runs the linter in-process if it is registered with core.RegisterLinter.
+func (task *ComputeConformanceTask) runLinter(
+	ctx context.Context,
+	specDirectory string,
+	data []byte,
+	metadata *linterMetadata) (*rpc.LinterResponse, error) {
+	if linterAvailable(metadata.name) {
+		return task.invokeLinter(ctx, specDirectory, metadata)
 	}
-	return task.storeConformanceReport(ctx, conformanceReport)
+	linter, err := core.GetLinter(metadata.name)
+	if err != nil {
+		return nil, fmt.Errorf("%s was not found and %s", getLinterBinaryName(metadata.name), err)
+	}
+
+	linterStartTime := time.Now()
+	lint, err := linter.Lint(task.Spec, data)
+	if err != nil {
+		return nil, fmt.Errorf("Linter %s returned error: %s", metadata.name, err)
+	}
+	log.Debugf(ctx, "Linter %s ran in time %s", metadata.name, time.Since(linterStartTime))
+	return &rpc.LinterResponse{Lint: lint}, nil
 }
 
 func (task *ComputeConformanceTask) invokeLinter(
@@ -234,6 +308,7 @@ func (task *ComputeConformanceTask) computeConformanceReport(
 				DisplayName: guidelineRule.GetDisplayName(),
 				Description: guidelineRule.GetDescription(),
 				DocUri:      guidelineRule.GetDocUri(),
+				Linter:      linterMetadata.name,
 			}
 			// Add the rule report to the appropriate guideline report.
 			guidelineGroup := conformanceReport.GuidelineReportGroups[guideline.GetState()]
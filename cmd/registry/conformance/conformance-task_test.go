@@ -176,6 +176,7 @@ func TestComputeConformanceReport(t *testing.T) {
 												RuleId:     "norefsiblings",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix no-$ref-siblings",
 												Location: &rpc.LintLocation{
 													StartPosition: &rpc.LintPosition{LineNumber: 11, ColumnNumber: 25},
@@ -258,6 +259,7 @@ func TestComputeConformanceReport(t *testing.T) {
 												RuleId:     "operationdescription",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix operation-description",
 											},
 										},
@@ -380,6 +382,7 @@ func TestComputeConformanceReport(t *testing.T) {
 												RuleId:     "norefsiblings",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix no-$ref-siblings",
 											},
 										},
@@ -405,6 +408,7 @@ func TestComputeConformanceReport(t *testing.T) {
 												RuleId:     "operationdescription",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix operation-description",
 											},
 										},
@@ -416,12 +420,14 @@ func TestComputeConformanceReport(t *testing.T) {
 												RuleId:     "tagdescription",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix tag-description",
 											},
 											{
 												RuleId:     "infodescription",
 												Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 												File:       "test-result-file",
+												Linter:     "sample-linter",
 												Suggestion: "fix info-description",
 											},
 										},
@@ -572,6 +578,7 @@ func TestPreExistingConformanceReport(t *testing.T) {
 										RuleId:     "operationdescription",
 										Spec:       fmt.Sprintf("%s@%s", specName, revisionId),
 										File:       "test-result-file",
+										Linter:     "sample-linter",
 										Suggestion: "fix operation-description",
 									},
 								},
@@ -15,10 +15,16 @@
 package conformance
 
 import (
+	"context"
 	"testing"
 
+	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/pkg/connection/grpctest"
+	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 // TestMain will set up a local RegistryServer and grpc.Server for all
@@ -27,3 +33,65 @@ import (
 func TestMain(m *testing.M) {
 	grpctest.TestMain(m, registry.Config{})
 }
+
+func TestComputeConformance(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject := &rpc.DeleteProjectRequest{Name: "projects/compute-conformance-test", Force: true}
+	_ = adminClient.DeleteProject(ctx, deleteProject)
+	t.Cleanup(func() { _ = adminClient.DeleteProject(ctx, deleteProject) })
+
+	spec := &rpc.ApiSpec{
+		Name:     "projects/compute-conformance-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		MimeType: "application/x.openapi;version=3",
+		Contents: []byte("openapi: 3.0.0"),
+	}
+	client := seeder.Client{RegistryClient: registryClient, AdminClient: adminClient}
+	if err := seeder.SeedSpecs(ctx, client, spec); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	styleguide := &rpc.StyleGuide{
+		Id:        "styleguide",
+		MimeTypes: []string{"application/x.openapi;version=3"},
+		Guidelines: []*rpc.Guideline{
+			{
+				Id: "descriptions",
+				Rules: []*rpc.Rule{
+					{
+						Id:             "operationdescription",
+						Linter:         "nonexistent",
+						LinterRulename: "operation-description",
+						Severity:       rpc.Rule_ERROR,
+					},
+				},
+				State: rpc.Guideline_ACTIVE,
+			},
+		},
+	}
+
+	// Linter failures are logged and the report is still returned.
+	got, err := ComputeConformance(ctx, registryClient, spec.GetName(), styleguide)
+	if err != nil {
+		t.Fatalf("ComputeConformance() returned error: %s", err)
+	}
+	want := initializeConformanceReport(spec.GetName(), "styleguide", "compute-conformance-test")
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ComputeConformance() returned unexpected report (-want +got):\n%s", diff)
+	}
+
+	styleguide.MimeTypes = []string{"application/x.protobuf"}
+	if _, err := ComputeConformance(ctx, registryClient, spec.GetName(), styleguide); err == nil {
+		t.Errorf("ComputeConformance() with unsupported mime type succeeded but should have failed")
+	}
+}
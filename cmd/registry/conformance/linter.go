@@ -16,7 +16,9 @@ package conformance
 
 import (
 	"fmt"
+	"os/exec"
 
+	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/rpc"
 )
 
@@ -35,6 +37,51 @@ func getLinterBinaryName(linterName string) string {
 	return "registry-lint-" + linterName
 }
 
+// linterAvailable reports whether the binary for a linter can be found.
+var linterAvailable = func(linterName string) bool {
+	_, err := exec.LookPath(getLinterBinaryName(linterName))
+	return err == nil
+}
+
+// linterRegistered reports whether a linter is registered to run in-process.
+func linterRegistered(linterName string) bool {
+	_, err := core.GetLinter(linterName)
+	return err == nil
+}
+
+// selectLinter returns the first available linter that can enforce a rule
+// and the name of the rule on that linter.
+// Linters are available if their binary can be found or if they are registered
+// to run in-process.
+// Linters name their rules differently, so alternate linters must give the
+// name of the rule on that linter.
+// If none are available, the preferred linter is returned so that the
+// failure to run it is reported.
+func selectLinter(rule *rpc.Rule) (string, string, error) {
+	candidates := make([]*rpc.AlternateLinter, 0, 1+len(rule.GetAlternateLinters()))
+	if len(rule.GetLinter()) > 0 {
+		candidates = append(candidates, &rpc.AlternateLinter{
+			Linter:         rule.GetLinter(),
+			LinterRulename: rule.GetLinterRulename(),
+		})
+	}
+	for _, alternate := range rule.GetAlternateLinters() {
+		if len(alternate.GetLinter()) == 0 || len(alternate.GetLinterRulename()) == 0 {
+			return "", "", fmt.Errorf("rule %q has an alternate linter without a linter or linter_rulename", rule.GetId())
+		}
+		candidates = append(candidates, alternate)
+	}
+	if len(candidates) == 0 {
+		return "", "", fmt.Errorf("rule %q does not list any linters", rule.GetId())
+	}
+	for _, c := range candidates {
+		if linterAvailable(c.GetLinter()) || linterRegistered(c.GetLinter()) {
+			return c.GetLinter(), c.GetLinterRulename(), nil
+		}
+	}
+	return candidates[0].GetLinter(), candidates[0].GetLinterRulename(), nil
+}
+
 func GenerateLinterMetadata(styleguide *rpc.StyleGuide) (map[string]*linterMetadata, error) {
 	linterNameToMetadata := make(map[string]*linterMetadata)
 
@@ -42,10 +89,11 @@ func GenerateLinterMetadata(styleguide *rpc.StyleGuide) (map[string]*linterMetad
 	for _, guideline := range styleguide.GetGuidelines() {
 		// Iterate through all the rules of the style guide.
 		for _, rule := range guideline.GetRules() {
-			// Get the name of the linter associated with the rule.
-			linterName := rule.GetLinter()
-			if len(linterName) == 0 {
-				continue
+			// Get the name of the linter that will enforce the rule
+			// and the name of the rule on that linter.
+			linterName, linterRuleName, err := selectLinter(rule)
+			if err != nil {
+				return nil, err
 			}
 
 			metadata, ok := linterNameToMetadata[linterName]
@@ -58,7 +106,6 @@ func GenerateLinterMetadata(styleguide *rpc.StyleGuide) (map[string]*linterMetad
 				linterNameToMetadata[linterName] = metadata
 			}
 
-			linterRuleName := rule.GetLinterRulename()
 			if len(linterRuleName) == 0 {
 				continue
 			}
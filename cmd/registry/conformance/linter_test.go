@@ -15,8 +15,12 @@
 package conformance
 
 import (
+	"context"
+	"os/exec"
+	"strings"
 	"testing"
 
+	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/rpc"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -44,6 +48,18 @@ var operationDescriptionRule = &rpc.Rule{
 	Severity:       rpc.Rule_ERROR,
 }
 
+// alternateLinterRule prefers a linter that isn't installed,
+// so its alternate linter, which runs in-process, is used.
+var alternateLinterRule = &rpc.Rule{
+	Id:             "operationtags",
+	Linter:         "not-installed",
+	LinterRulename: "operationtags",
+	Severity:       rpc.Rule_ERROR,
+	AlternateLinters: []*rpc.AlternateLinter{
+		{Linter: "gnostic", LinterRulename: "operation-tags"},
+	},
+}
+
 func TestGenerateLinterMetadata(t *testing.T) {
 	tests := []struct {
 		desc       string
@@ -264,6 +280,36 @@ func TestGenerateLinterMetadata(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "Alternate linter",
+			styleguide: &rpc.StyleGuide{
+				Id:        "openapitest",
+				MimeTypes: []string{"application/x.openapi+gzip;version=3.0.0"},
+				Guidelines: []*rpc.Guideline{
+					{
+						Id:    "operationproperties",
+						Rules: []*rpc.Rule{alternateLinterRule},
+						State: rpc.Guideline_ACTIVE,
+					},
+				},
+			},
+			want: map[string]*linterMetadata{
+				"gnostic": {
+					name:  "gnostic",
+					rules: []string{"operation-tags"},
+					rulesMetadata: map[string]*ruleMetadata{
+						"operation-tags": {
+							guidelineRule: alternateLinterRule,
+							guideline: &rpc.Guideline{
+								Id:    "operationproperties",
+								Rules: []*rpc.Rule{alternateLinterRule},
+								State: rpc.Guideline_ACTIVE,
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -289,3 +335,151 @@ func TestGenerateLinterMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectLinter(t *testing.T) {
+	available := map[string]bool{"spectral": true, "api-linter": true}
+	linterAvailable = func(name string) bool { return available[name] }
+	t.Cleanup(func() {
+		linterAvailable = func(name string) bool {
+			_, err := exec.LookPath(getLinterBinaryName(name))
+			return err == nil
+		}
+	})
+
+	tests := []struct {
+		desc         string
+		rule         *rpc.Rule
+		want         string
+		wantRulename string
+		wantErr      bool
+	}{
+		{
+			desc: "preferred linter available",
+			rule: &rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "operation-tags", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "api-linter", LinterRulename: "core::0131::http-method"},
+			}},
+			want:         "spectral",
+			wantRulename: "operation-tags",
+		},
+		{
+			desc: "alternate linter available",
+			rule: &rpc.Rule{Id: "r", Linter: "sample", LinterRulename: "operationtags", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "missing", LinterRulename: "missing-rule"},
+				{Linter: "api-linter", LinterRulename: "core::0131::http-method"},
+			}},
+			want:         "api-linter",
+			wantRulename: "core::0131::http-method",
+		},
+		{
+			desc: "only alternate linters",
+			rule: &rpc.Rule{Id: "r", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "api-linter", LinterRulename: "core::0131::http-method"},
+			}},
+			want:         "api-linter",
+			wantRulename: "core::0131::http-method",
+		},
+		{
+			desc: "registered linter available",
+			rule: &rpc.Rule{Id: "r", Linter: "sample", LinterRulename: "operationtags", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "gnostic", LinterRulename: "operation-tags"},
+			}},
+			want:         "gnostic",
+			wantRulename: "operation-tags",
+		},
+		{
+			desc: "no linter available",
+			rule: &rpc.Rule{Id: "r", Linter: "sample", LinterRulename: "operationtags", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "missing", LinterRulename: "missing-rule"},
+			}},
+			want:         "sample",
+			wantRulename: "operationtags",
+		},
+		{
+			desc:    "no linters",
+			rule:    &rpc.Rule{Id: "r"},
+			wantErr: true,
+		},
+		{
+			desc: "alternate linter without rulename",
+			rule: &rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "operation-tags", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "api-linter"},
+			}},
+			wantErr: true,
+		},
+		{
+			desc: "alternate rulename without linter",
+			rule: &rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "operation-tags", AlternateLinters: []*rpc.AlternateLinter{
+				{LinterRulename: "core::0131::http-method"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, gotRulename, err := selectLinter(test.rule)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("selectLinter(%v) succeeded but should have failed", test.rule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectLinter(%v) returned error: %s", test.rule, err)
+			}
+			if got != test.want || gotRulename != test.wantRulename {
+				t.Errorf("selectLinter(%v) returned (%q, %q), want (%q, %q)", test.rule, got, gotRulename, test.want, test.wantRulename)
+			}
+		})
+	}
+}
+
+type fakeLinter struct{}
+
+func (fakeLinter) Name() string {
+	return "conformance-test"
+}
+
+func (fakeLinter) Lint(spec *rpc.ApiSpec, contents []byte) (*rpc.Lint, error) {
+	return &rpc.Lint{
+		Name: spec.GetName(),
+		Files: []*rpc.LintFile{{
+			FilePath: "openapi.yaml",
+			Problems: []*rpc.LintProblem{{Message: string(contents), RuleId: "test-rule"}},
+		}},
+	}, nil
+}
+
+func TestRunRegisteredLinter(t *testing.T) {
+	if !linterRegistered(fakeLinter{}.Name()) {
+		core.RegisterLinter(fakeLinter{})
+	}
+	task := &ComputeConformanceTask{Spec: &rpc.ApiSpec{Name: "projects/p/locations/global/apis/a/versions/v/specs/s"}}
+	ctx := context.Background()
+
+	got, err := task.runLinter(ctx, t.TempDir(), []byte("contents"), &linterMetadata{name: fakeLinter{}.Name()})
+	if err != nil {
+		t.Fatalf("runLinter() returned error: %s", err)
+	}
+	want := &rpc.LinterResponse{
+		Lint: &rpc.Lint{
+			Name: task.Spec.GetName(),
+			Files: []*rpc.LintFile{{
+				FilePath: "openapi.yaml",
+				Problems: []*rpc.LintProblem{{Message: "contents", RuleId: "test-rule"}},
+			}},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("runLinter() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	_, err = task.runLinter(ctx, t.TempDir(), nil, &linterMetadata{name: "missing"})
+	if err == nil {
+		t.Fatal("runLinter() succeeded for an unknown linter but should have failed")
+	}
+	for _, s := range []string{"no such linter registered", "gnostic", fakeLinter{}.Name()} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("runLinter() returned error %q, want it to contain %q", err, s)
+		}
+	}
+}
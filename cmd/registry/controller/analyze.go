@@ -0,0 +1,163 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+)
+
+// AnalyzeManifest returns warnings for generated resources that can never be
+// generated because of the structure of the manifest. This happens when a
+// dependency can only be satisfied by the results of other entries (or of
+// the entry itself) that can't be generated either, e.g. when entries depend
+// on each other's results. Entries with "refresh" set don't need their
+// dependencies and are always reachable.
+// The analysis doesn't depend on the contents of a registry, so entries that
+// do nothing because a registry has no matching resources aren't reported.
+// Dependencies with invalid patterns are ignored, see ValidateManifest.
+func AnalyzeManifest(manifest *rpc.Manifest) []error {
+	resources := manifest.GetGeneratedResources()
+
+	// producers[i][j] lists the entries that generate every resource that
+	// matches dependency j of entry i. Dependencies without producers can be
+	// satisfied by resources that are added to the registry.
+	producers := make([][][]int, len(resources))
+	for i, resource := range resources {
+		producers[i] = make([][]int, len(resource.Dependencies))
+		for j, dependency := range resource.Dependencies {
+			pattern, ok := expandDependencyPattern(resource.Pattern, dependency.Pattern)
+			if !ok {
+				continue
+			}
+			for k, other := range resources {
+				if other.Filter == "" && patternContains(other.Pattern, pattern) {
+					producers[i][j] = append(producers[i][j], k)
+				}
+			}
+		}
+	}
+
+	// Find the reachable entries by repeatedly marking entries whose
+	// dependencies are all satisfiable until nothing changes.
+	reachable := make([]bool, len(resources))
+	for changed := true; changed; {
+		changed = false
+		for i, resource := range resources {
+			if reachable[i] {
+				continue
+			}
+			if resource.Refresh != nil || dependenciesSatisfiable(producers[i], reachable) {
+				reachable[i] = true
+				changed = true
+			}
+		}
+	}
+
+	warnings := make([]error, 0)
+	for i, resource := range resources {
+		if reachable[i] {
+			continue
+		}
+		for j, dependency := range resource.Dependencies {
+			if !satisfiable(producers[i][j], reachable) {
+				warnings = append(warnings, fmt.Errorf("generated_resources[%d] (pattern %q) is unreachable: dependency %q can only be satisfied by %s, which can never be generated",
+					i, resource.Pattern, dependency.Pattern, describeEntries(resources, producers[i][j])))
+				break
+			}
+		}
+	}
+	return warnings
+}
+
+// dependenciesSatisfiable reports whether every dependency can be satisfied.
+func dependenciesSatisfiable(producers [][]int, reachable []bool) bool {
+	for _, p := range producers {
+		if !satisfiable(p, reachable) {
+			return false
+		}
+	}
+	return true
+}
+
+// satisfiable reports whether a dependency with these producers can be satisfied.
+func satisfiable(producers []int, reachable []bool) bool {
+	if len(producers) == 0 {
+		return true
+	}
+	for _, k := range producers {
+		if reachable[k] {
+			return true
+		}
+	}
+	return false
+}
+
+// patternContains reports whether every resource that matches inner also matches outer.
+func patternContains(outer, inner string) bool {
+	outerSegments := strings.Split(strings.Trim(outer, "/"), "/")
+	innerSegments := strings.Split(strings.Trim(inner, "/"), "/")
+	if len(outerSegments) != len(innerSegments) {
+		return false
+	}
+	for i := range outerSegments {
+		if outerSegments[i] != "-" && !strings.EqualFold(outerSegments[i], innerSegments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeEntries(resources []*rpc.GeneratedResource, entries []int) string {
+	s := make([]string, len(entries))
+	for i, k := range entries {
+		s[i] = fmt.Sprintf("generated_resources[%d] (pattern %q)", k, resources[k].Pattern)
+	}
+	return strings.Join(s, ", ")
+}
+
+// expandDependencyPattern replaces the $resource reference in a dependency
+// pattern with the corresponding part of the target pattern.
+// Example:
+// target: "apis/-/versions/-/specs/-/artifacts/score"
+// dependency: "$resource.spec/artifacts/lint"
+// Returns "apis/-/versions/-/specs/-/artifacts/lint"
+func expandDependencyPattern(targetPattern, dependencyPattern string) (string, bool) {
+	entity, entityType, err := patterns.GetReferenceEntityType(dependencyPattern)
+	if err != nil {
+		return "", false
+	}
+	if entityType == "default" {
+		return dependencyPattern, true
+	}
+
+	collection := map[string]string{
+		"api":      "apis",
+		"version":  "versions",
+		"spec":     "specs",
+		"artifact": "artifacts",
+	}[entityType]
+	segments := strings.Split(strings.Trim(targetPattern, "/"), "/")
+	for i := 0; i+1 < len(segments); i += 2 {
+		if segments[i] == collection {
+			prefix := strings.Join(segments[:i+2], "/")
+			return strings.Replace(dependencyPattern, entity, prefix, 1), true
+		}
+	}
+	return "", false
+}
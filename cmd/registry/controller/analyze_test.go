@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestAnalyzeManifest(t *testing.T) {
+	entry := func(pattern string, dependencies ...string) *rpc.GeneratedResource {
+		r := &rpc.GeneratedResource{
+			Pattern: pattern,
+			Action:  "registry compute something",
+		}
+		for _, d := range dependencies {
+			r.Dependencies = append(r.Dependencies, &rpc.Dependency{Pattern: d})
+		}
+		return r
+	}
+	refreshed := func(r *rpc.GeneratedResource) *rpc.GeneratedResource {
+		r.Refresh = &durationpb.Duration{Seconds: 60}
+		return r
+	}
+	filtered := func(r *rpc.GeneratedResource) *rpc.GeneratedResource {
+		r.Filter = "mime_type.contains('openapi')"
+		return r
+	}
+
+	tests := []struct {
+		desc      string
+		resources []*rpc.GeneratedResource
+		want      []string // patterns of the unreachable entries
+	}{
+		{
+			desc: "chain of results",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", "$resource.spec"),
+				entry("apis/-/versions/-/specs/-/artifacts/score", "$resource.spec/artifacts/lint"),
+				entry("apis/-/versions/-/artifacts/summary", "$resource.version/specs/-/artifacts/score"),
+			},
+		},
+		{
+			desc: "dependency provided by the registry",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/conformance", "$resource.spec", "artifacts/registry-styleguide"),
+			},
+		},
+		{
+			desc: "self dependency",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/score", "$resource.spec/artifacts/score"),
+			},
+			want: []string{"apis/-/versions/-/specs/-/artifacts/score"},
+		},
+		{
+			desc: "entries that depend on each other",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/a", "$resource.spec/artifacts/b"),
+				entry("apis/-/versions/-/specs/-/artifacts/b", "$resource.spec/artifacts/a"),
+				entry("apis/-/versions/-/specs/-/artifacts/c", "$resource.spec/artifacts/a"),
+				entry("apis/-/versions/-/specs/-/artifacts/d", "$resource.spec"),
+			},
+			want: []string{
+				"apis/-/versions/-/specs/-/artifacts/a",
+				"apis/-/versions/-/specs/-/artifacts/b",
+				"apis/-/versions/-/specs/-/artifacts/c",
+			},
+		},
+		{
+			desc: "refresh makes an entry reachable",
+			resources: []*rpc.GeneratedResource{
+				refreshed(entry("apis/-/versions/-/specs/-/artifacts/a", "$resource.spec/artifacts/b")),
+				entry("apis/-/versions/-/specs/-/artifacts/b", "$resource.spec/artifacts/a"),
+			},
+		},
+		{
+			desc: "filtered producer doesn't generate every dependency",
+			resources: []*rpc.GeneratedResource{
+				filtered(entry("apis/-/versions/-/specs/-/artifacts/a", "$resource.spec/artifacts/b")),
+				entry("apis/-/versions/-/specs/-/artifacts/b", "$resource.spec/artifacts/a"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			warnings := AnalyzeManifest(&rpc.Manifest{Id: "test", GeneratedResources: test.resources})
+			if len(warnings) != len(test.want) {
+				t.Fatalf("AnalyzeManifest() returned %d warnings, want %d: %v", len(warnings), len(test.want), warnings)
+			}
+			for i, w := range warnings {
+				if !strings.Contains(w.Error(), test.want[i]) {
+					t.Errorf("AnalyzeManifest() warning %q does not name %q", w, test.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// compileCondition checks that a generated resource condition is a valid
+// CEL expression that evaluates to a bool.
+func compileCondition(condition string) (*cel.Env, *cel.Ast, error) {
+	env, err := cel.NewEnv(
+		cel.Declarations(
+			decls.NewFunction("has_artifact",
+				decls.NewOverload("has_artifact_string",
+					[]*exprpb.Type{decls.String},
+					decls.Bool),
+			),
+		),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	ast, iss := env.Compile(condition)
+	if iss.Err() != nil {
+		return nil, nil, iss.Err()
+	}
+	if !proto.Equal(ast.ResultType(), decls.Bool) {
+		return nil, nil, fmt.Errorf("condition must evaluate to a bool, got %s", ast.OutputType())
+	}
+	return env, ast, nil
+}
+
+// filterActionsByCondition returns the actions whose generated resources
+// belong to resources that satisfy the condition. Artifacts of each parent
+// resource are listed once.
+func filterActionsByCondition(
+	ctx context.Context,
+	client listingClient,
+	condition string,
+	actions []*Action) ([]*Action, error) {
+	env, ast, err := compileCondition(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	// artifacts holds the artifact IDs of the resource being evaluated.
+	var artifacts map[string]bool
+	hasArtifact := func(arg ref.Val) ref.Val {
+		id, ok := arg.(types.String)
+		if !ok {
+			return types.MaybeNoSuchOverloadErr(arg)
+		}
+		return types.Bool(artifacts[string(id)])
+	}
+	prg, err := env.Program(ast, cel.Functions(
+		&functions.Overload{Operator: "has_artifact", Unary: hasArtifact},
+		&functions.Overload{Operator: "has_artifact_string", Unary: hasArtifact},
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	artifactsByParent := make(map[string]map[string]bool)
+	filtered := make([]*Action, 0, len(actions))
+	for _, a := range actions {
+		resource, err := patterns.ParseResourcePattern(a.GeneratedResource)
+		if err != nil {
+			return nil, err
+		}
+		parent := resource.ParentName().String()
+		var ok bool
+		artifacts, ok = artifactsByParent[parent]
+		if !ok {
+			artifacts, err = listArtifactIDs(ctx, client, parent)
+			if err != nil {
+				return nil, err
+			}
+			artifactsByParent[parent] = artifacts
+		}
+
+		out, _, err := prg.Eval(map[string]interface{}{})
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Debugf("Skipping %s: failed to evaluate condition %q", a.GeneratedResource, condition)
+			continue
+		}
+		if out == types.True {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered, nil
+}
+
+func listArtifactIDs(ctx context.Context, client listingClient, parent string) (map[string]bool, error) {
+	artifact, err := names.ParseArtifact(parent + "/artifacts/-")
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	err = client.ListArtifacts(ctx, artifact, "", false, func(a *rpc.Artifact) error {
+		name, err := names.ParseArtifact(a.GetName())
+		if err != nil {
+			return err
+		}
+		ids[name.ArtifactID()] = true
+		return nil
+	})
+	return ids, err
+}
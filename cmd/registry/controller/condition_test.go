@@ -0,0 +1,123 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestConditionalGeneratedResource(t *testing.T) {
+	tests := []struct {
+		desc      string
+		condition string
+		want      []*Action
+	}{
+		{
+			desc:      "marker present",
+			condition: "has_artifact('approved')",
+			want: []*Action{
+				{
+					Command:           "registry compute complexity projects/condition-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+					GeneratedResource: "projects/condition-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
+				},
+			},
+		},
+		{
+			desc:      "marker absent",
+			condition: "!has_artifact('approved')",
+			want: []*Action{
+				{
+					Command:           "registry compute complexity projects/condition-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml",
+					GeneratedResource: "projects/condition-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml/artifacts/complexity",
+				},
+			},
+		},
+		{
+			desc:      "never true",
+			condition: "has_artifact('approved') && !has_artifact('approved')",
+			want:      []*Action{},
+		},
+	}
+
+	const projectID = "condition-test"
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, projectID)
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, projectID) })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			seed := []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/condition-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.Artifact{
+					Name: "projects/condition-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/approved",
+				},
+				&rpc.ApiSpec{
+					Name: "projects/condition-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml",
+				},
+			}
+			if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "condition-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/versions/-/specs/-/artifacts/complexity",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.spec",
+							},
+						},
+						Condition: test.condition,
+						Action:    "registry compute complexity $resource.spec",
+					},
+				},
+			}
+			lister := &RegistryLister{RegistryClient: registryClient}
+			actions := ProcessManifest(ctx, lister, projectID, manifest, 10)
+
+			addSpecRevisions(t, ctx, registryClient, test.want)
+			if diff := cmp.Diff(test.want, actions, sortActions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
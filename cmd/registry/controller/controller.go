@@ -22,58 +22,191 @@ import (
 
 	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/tracing"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type Action struct {
 	Command           string
 	GeneratedResource string
 	RequiresReceipt   bool
+	// Labels are set on the generated resource after the command is executed.
+	Labels map[string]string
+	// Destructive is true if the command deletes GeneratedResource.
+	Destructive bool
 }
 
+// Options control how ProcessManifestWithOptions and StreamActionsWithOptions
+// process a manifest.
+type Options struct {
+	// SkipKey is the key of a label or annotation that opts resources out of
+	// automation. When it is set, resources that have a label or annotation
+	// with this key are ignored unless its value is "false", so they don't
+	// trigger actions as dependencies and nothing is generated for them.
+	// Skipped resources are ignored after dependency filters are applied: a
+	// resource that matches a filter is still skipped. PruneOrphans and
+	// ExpireArtifacts don't skip resources, so the generated resources of
+	// skipped resources aren't orphaned.
+	SkipKey string
+	// CheckExistence lists the resource that each action generates a resource
+	// for once more before returning the action, and drops the action if that
+	// resource was deleted while the manifest was processed. This adds a list
+	// call for each action.
+	CheckExistence bool
+}
+
+// ProcessManifest returns the actions that are needed to bring the generated
+// resources of manifest up-to-date. At most maxActions actions are returned.
+// If the manifest sets max_actions_per_api, at most that many actions are
+// returned for each API and maxActions still limits the total.
 func ProcessManifest(
 	ctx context.Context,
 	client listingClient,
 	projectID string,
 	manifest *rpc.Manifest,
 	maxActions int) []*Action {
+	return ProcessManifestWithOptions(ctx, client, projectID, manifest, maxActions, Options{})
+}
+
+// ProcessManifestWithOptions is like ProcessManifest, but processes the manifest with opts.
+func ProcessManifestWithOptions(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	manifest *rpc.Manifest,
+	maxActions int,
+	opts Options) []*Action {
+	stream, err := StreamActionsWithOptions(ctx, client, projectID, manifest, maxActions, opts)
+	if err != nil {
+		log.FromContext(ctx).WithError(err).Debug("Failed to process manifest")
+		return nil
+	}
 	var actions []*Action
-	//Check for errors in manifest
-	errs := ValidateManifest(fmt.Sprintf("projects/%s/locations/global", projectID), manifest)
-	if len(errs) > 0 {
-		for _, err := range errs {
-			log.FromContext(ctx).WithError(err).Debugf("Error in manifest")
-		}
+	for a := range stream {
+		actions = append(actions, a)
 	}
+	return actions
+}
 
-	for _, resource := range manifest.GeneratedResources {
-		log.Debugf(ctx, "Processing entry: %v", resource)
+// StreamActions generates the same actions as ProcessManifest, but sends them
+// on the returned channel as soon as the actions of each generated resource
+// are known, so that they can be executed while the rest of the manifest is
+// processed. The channel is closed after maxActions actions have been sent,
+// after all generated resources have been processed, or when ctx is done.
+func StreamActions(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	manifest *rpc.Manifest,
+	maxActions int) (<-chan *Action, error) {
+	return StreamActionsWithOptions(ctx, client, projectID, manifest, maxActions, Options{})
+}
 
-		errs := validateGeneratedResourceEntry(fmt.Sprintf("projects/%s/locations/global", projectID), resource)
-		if len(errs) > 0 {
-			log.FromContext(ctx).Debugf("Skipping resource: %q", resource)
-			continue
+// StreamActionsWithOptions is like StreamActions, but processes the manifest with opts.
+func StreamActionsWithOptions(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	manifest *rpc.Manifest,
+	maxActions int,
+	opts Options) (<-chan *Action, error) {
+	if manifest == nil {
+		return nil, fmt.Errorf("missing manifest")
+	}
+	actions := make(chan *Action)
+	go func() {
+		defer close(actions)
+		ctx, span := tracing.Start(ctx, "ProcessManifest",
+			attribute.String("manifest.id", manifest.GetId()),
+			attribute.String("project.id", projectID))
+		defer span.End()
+		lister := &countingLister{listingClient: client}
+		client = lister
+		if opts.SkipKey != "" {
+			client = &skippingLister{listingClient: lister, key: opts.SkipKey}
 		}
+		logger := log.FromContext(ctx).WithFields(map[string]interface{}{
+			"manifest": manifest.GetId(),
+			"project":  projectID,
+		})
 
-		newActions, err := processManifestResource(ctx, client, projectID, resource)
-		if err != nil {
-			log.FromContext(ctx).WithError(err).Debugf("Skipping resource: %q", resource)
-			continue
+		//Check for errors in manifest
+		errs := ValidateManifest(patterns.ProjectLocation("projects/"+projectID), manifest)
+		if len(errs) > 0 {
+			for _, err := range errs {
+				logger.WithError(err).Debug("Error in manifest")
+			}
 		}
-		actions = append(actions, newActions...)
 
-		if len(actions) >= maxActions {
-			log.FromContext(ctx).Debugf("Reached max actions limit %d", maxActions)
-			break
-		}
-	}
+		// Counts the actions of each API when the manifest limits them.
+		apiCounts := make(map[string]int)
+		count := 0
+		defer func() {
+			span.SetAttributes(
+				attribute.Int("resources.listed", lister.count),
+				attribute.Int("actions.generated", count))
+			logger.WithFields(map[string]interface{}{
+				"actions":         count,
+				"resourcesListed": lister.count,
+			}).Debug("Processed manifest")
+		}()
+		for _, resource := range manifest.GeneratedResources {
+			if count >= maxActions {
+				logger.WithField("maxActions", maxActions).Debug("Reached max actions limit")
+				return
+			}
+			entryLogger := logger.WithField("pattern", resource.Pattern)
+			entryLogger.Debug("Processing entry")
 
-	maxLength := len(actions)
-	if maxLength > maxActions {
-		maxLength = maxActions
-	}
+			errs := validateGeneratedResourceEntry(patterns.ProjectLocation("projects/"+projectID), resource)
+			if len(errs) > 0 {
+				entryLogger.WithField("decision", "skip").Debug("Skipping invalid entry")
+				continue
+			}
 
-	return actions[:maxLength]
+			newActions, err := processManifestResource(ctx, client, projectID, resource)
+			if err != nil {
+				entryLogger.WithError(err).WithField("decision", "skip").Debug("Skipping entry")
+				continue
+			}
+			if opts.CheckExistence {
+				existing := dropActionsOfDeletedResources(ctx, lister, newActions)
+				if len(existing) < len(newActions) {
+					entryLogger.WithField("dropped", len(newActions)-len(existing)).Debug("Dropped actions of deleted resources")
+				}
+				newActions = existing
+			}
+			if perApi := int(manifest.GetMaxActionsPerApi()); perApi > 0 {
+				limited := limitActionsPerApi(newActions, perApi, apiCounts)
+				if len(limited) < len(newActions) {
+					entryLogger.WithFields(map[string]interface{}{
+						"maxActionsPerApi": perApi,
+						"dropped":          len(newActions) - len(limited),
+					}).Debug("Reached max actions per API limit")
+				}
+				newActions = limited
+			}
+			entryLogger.WithFields(map[string]interface{}{
+				"actions":  len(newActions),
+				"decision": "process",
+			}).Debug("Generated actions for entry")
+			for _, a := range newActions {
+				if count >= maxActions {
+					logger.WithField("maxActions", maxActions).Debug("Reached max actions limit")
+					return
+				}
+				select {
+				case actions <- a:
+					count++
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return actions, nil
 }
 
 func processManifestResource(
@@ -81,13 +214,24 @@ func processManifestResource(
 	client listingClient,
 	projectID string,
 	generatedResource *rpc.GeneratedResource) ([]*Action, error) {
-	resourcePattern := fmt.Sprintf("projects/%s/locations/global/%s", projectID, generatedResource.Pattern)
+	ctx, span := tracing.Start(ctx, "processManifestResource",
+		attribute.String("resource.pattern", generatedResource.Pattern))
+	defer span.End()
+
+	resourcePattern := fmt.Sprintf("%s/%s", patterns.ProjectLocation("projects/"+projectID), generatedResource.Pattern)
 	// Generate dependency map
 	dependencyMaps := make([]map[string]time.Time, 0, len(generatedResource.Dependencies))
 	for _, dependency := range generatedResource.Dependencies {
 		dMap, err := generateDependencyMap(ctx, client, resourcePattern, dependency)
 		if err != nil {
-			return nil, fmt.Errorf("error while generating dependency map for %v: %s", dependency, err)
+			err = fmt.Errorf("error while generating dependency map for %v: %s", dependency, err)
+			span.RecordError(err)
+			return nil, err
+		}
+		if len(dMap) == 0 {
+			err = fmt.Errorf("no resources found for pattern: %s, filter: %s", dependency.Pattern, dependency.Filter)
+			span.RecordError(err)
+			return nil, err
 		}
 		dependencyMaps = append(dependencyMaps, dMap)
 	}
@@ -96,6 +240,29 @@ func processManifestResource(
 	actions := generateActions(
 		ctx, client, resourcePattern, generatedResource.Filter, dependencyMaps, generatedResource)
 
+	// Skip actions for resources that don't satisfy the condition
+	if generatedResource.Condition != "" {
+		var err error
+		actions, err = filterActionsByCondition(ctx, client, generatedResource.Condition, actions)
+		if err != nil {
+			err = fmt.Errorf("error while evaluating condition %q: %s", generatedResource.Condition, err)
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	// Execute action templates for the remaining resources
+	if generatedResource.ActionTemplate {
+		var err error
+		actions, err = renderActionTemplates(ctx, client, generatedResource, actions)
+		if err != nil {
+			err = fmt.Errorf("error while executing action template %q: %s", generatedResource.Action, err)
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	span.SetAttributes(attribute.Int("actions.generated", len(actions)))
 	return actions, nil
 }
 
@@ -146,10 +313,6 @@ func generateDependencyMap(
 		}
 	}
 
-	if len(sourceMap) == 0 {
-		return nil, fmt.Errorf("no resources found for pattern: %s, filer: %s", extDependencyName.String(), dependency.Filter)
-	}
-
 	return sourceMap, nil
 }
 
@@ -212,14 +375,16 @@ func generateUpdateActions(
 		}
 
 		if takeAction {
-			cmd, err := generateCommand(generatedResource.Action, targetResource.ResourceName().String())
+			resourceName := actionResourceName(generatedResource, targetResource.ResourceName())
+			cmd, err := generateActionCommand(generatedResource, resourceName)
 			if err != nil {
 				return nil, nil, fmt.Errorf("Cannot generate command: %s", err)
 			}
 			a := &Action{
 				Command:           cmd,
-				GeneratedResource: targetResource.ResourceName().String(),
+				GeneratedResource: resourceName.String(),
 				RequiresReceipt:   generatedResource.Receipt,
+				Labels:            generatedResource.Labels,
 			}
 			actions = append(actions, a)
 		}
@@ -228,6 +393,95 @@ func generateUpdateActions(
 	return actions, visited, nil
 }
 
+// dropActionsOfDeletedResources returns the actions whose generated resources
+// belong to APIs, versions and specs that still exist. Other actions are kept,
+// and so are actions whose resource can't be checked, so that a failed check
+// doesn't prevent any work.
+func dropActionsOfDeletedResources(ctx context.Context, client listingClient, actions []*Action) []*Action {
+	result := make([]*Action, 0, len(actions))
+	for _, a := range actions {
+		subject, err := actionSubject(a)
+		if err != nil {
+			result = append(result, a)
+			continue
+		}
+		resources, err := listResources(ctx, client, subject, "")
+		if err != nil {
+			log.FromContext(ctx).WithError(err).WithField("resource", subject).Debug("Failed to check that resource exists")
+			result = append(result, a)
+			continue
+		}
+		if len(resources) > 0 {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// actionSubject returns the name of the API, version or spec that the
+// generated resource of action belongs to.
+func actionSubject(action *Action) (string, error) {
+	artifact, err := names.ParseArtifact(action.GeneratedResource)
+	if err != nil {
+		return "", err
+	}
+	subject, err := artifact.Subject()
+	if err != nil {
+		return "", err
+	}
+	n, err := names.Parse(subject)
+	if err != nil {
+		return "", err
+	}
+	switch n.(type) {
+	case names.Api, names.Version, names.Spec:
+		return subject, nil
+	default:
+		return "", fmt.Errorf("unsupported resource %q", subject)
+	}
+}
+
+// limitActionsPerApi returns actions without the actions of APIs that have
+// already reached perApi actions, ordered so that APIs take turns:
+// the first action of each API, then the second action of each API, etc.
+// APIs take turns in the order of their first actions. apiCounts holds the
+// number of actions of each API so far, it is updated with the returned actions.
+// Actions for resources that don't belong to an API are returned first and
+// aren't limited.
+func limitActionsPerApi(actions []*Action, perApi int, apiCounts map[string]int) []*Action {
+	result := make([]*Action, 0, len(actions))
+	apis := make([]string, 0)
+	byApi := make(map[string][]*Action)
+	for _, a := range actions {
+		api := ""
+		if name, err := patterns.ParseResourcePattern(a.GeneratedResource); err == nil {
+			api = name.Api()
+		}
+		if api == "" {
+			result = append(result, a)
+			continue
+		}
+		if _, ok := byApi[api]; !ok {
+			apis = append(apis, api)
+		}
+		byApi[api] = append(byApi[api], a)
+	}
+
+	for turn := 0; ; turn++ {
+		added := false
+		for _, api := range apis {
+			if turn < len(byApi[api]) && apiCounts[api] < perApi {
+				result = append(result, byApi[api][turn])
+				apiCounts[api]++
+				added = true
+			}
+		}
+		if !added {
+			return result
+		}
+	}
+}
+
 // Constructs a CEL filter to exclude resources with visited parents.
 // Makes use of `e.all(x,p)` macro as defined here: https://github.com/google/cel-spec/blob/master/doc/langdef.md#macros
 // The filter excludes resources whose `name` property is equal to any of the visited parent names.
@@ -319,14 +573,16 @@ func generateCreateActions(
 			continue
 		}
 
-		cmd, err := generateCommand(generatedResource.Action, targetResourceName.String())
+		resourceName := actionResourceName(generatedResource, targetResourceName)
+		cmd, err := generateActionCommand(generatedResource, resourceName)
 		if err != nil {
 			return nil, fmt.Errorf("cannot generate command: %s", err)
 		}
 		a := &Action{
 			Command:           cmd,
-			GeneratedResource: targetResourceName.String(),
+			GeneratedResource: resourceName.String(),
 			RequiresReceipt:   generatedResource.Receipt,
+			Labels:            generatedResource.Labels,
 		}
 		actions = append(actions, a)
 	}
@@ -19,9 +19,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/pkg/connection/grpctest"
 	"github.com/apigee/registry/rpc"
@@ -31,6 +33,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // TestMain will set up a local RegistryServer and grpc.Server for all
@@ -417,6 +420,121 @@ func TestDerivedArtifacts(t *testing.T) {
 	}
 }
 
+// Tests for generated resources that depend on artifacts of their ancestors
+func TestAncestorArtifacts(t *testing.T) {
+	tests := []struct {
+		desc string
+		seed []seeder.RegistryResource
+		want []*Action
+	}{
+		{
+			desc: "create artifacts",
+			seed: []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name: "projects/controller-test/locations/global/artifacts/style-guide",
+				},
+				&rpc.Artifact{
+					Name: "projects/controller-test/locations/global/apis/petstore/artifacts/lint-config",
+				},
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml",
+				},
+			},
+			want: []*Action{
+				{
+					Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml projects/controller-test/locations/global/apis/petstore/artifacts/lint-config",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint",
+				},
+				{
+					Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml projects/controller-test/locations/global/apis/petstore/artifacts/lint-config",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml/artifacts/lint",
+				},
+				{
+					Command:           "registry compute conformance projects/controller-test/locations/global/apis/petstore/versions/1.0.0 projects/controller-test/locations/global/artifacts/style-guide",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/artifacts/conformance",
+				},
+				{
+					Command:           "registry compute conformance projects/controller-test/locations/global/apis/petstore/versions/1.1.0 projects/controller-test/locations/global/artifacts/style-guide",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/artifacts/conformance",
+				},
+			},
+		},
+		{
+			desc: "missing ancestor artifacts",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			want: nil,
+		},
+	}
+
+	const projectID = "controller-test"
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "controller-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			lister := &RegistryLister{RegistryClient: registryClient}
+
+			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "controller-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/versions/-/artifacts/conformance",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.project/artifacts/style-guide",
+							},
+						},
+						Action: "registry compute conformance $resource.version $resource.project/artifacts/style-guide",
+					},
+					{
+						Pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.api/artifacts/lint-config",
+							},
+						},
+						Action: "registry compute lint $resource.spec $resource.api/artifacts/lint-config",
+					},
+				},
+			}
+			actions := ProcessManifest(ctx, lister, projectID, manifest, 10)
+			addSpecRevisions(t, ctx, registryClient, test.want)
+
+			if diff := cmp.Diff(test.want, actions, sortActions); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
+
 // Tests for receipt artifacts as generated resource
 func TestReceiptArtifacts(t *testing.T) {
 	tests := []struct {
@@ -790,31 +908,280 @@ func TestMultipleEntitiesArtifacts(t *testing.T) {
 	}
 }
 
+func TestNamedDependencyArtifacts(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "controller-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name:     "projects/controller-test/locations/global/artifacts/custom-styleguide",
+			MimeType: core.MimeTypeForMessageType("google.cloud.apigeeregistry.v1.style.StyleGuide"),
+			Contents: protoMarshal(styleguide),
+		},
+		&rpc.ApiSpec{
+			Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		},
+		&rpc.ApiSpec{
+			Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml",
+		},
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	manifest := &rpc.Manifest{
+		Id: "controller-test",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance-custom-styleguide",
+				Receipt: true,
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+					{
+						Pattern: "artifacts/custom-styleguide",
+						Name:    "styleguide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+		},
+	}
+	want := []*Action{
+		{
+			Command:           "registry compute conformance projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml --styleguide projects/controller-test/locations/global/artifacts/custom-styleguide",
+			GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/conformance-custom-styleguide",
+			RequiresReceipt:   true,
+		},
+		{
+			Command:           "registry compute conformance projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml --styleguide projects/controller-test/locations/global/artifacts/custom-styleguide",
+			GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml/artifacts/conformance-custom-styleguide",
+			RequiresReceipt:   true,
+		},
+	}
+
+	lister := &RegistryLister{RegistryClient: registryClient}
+	actions := ProcessManifest(ctx, lister, "controller-test", manifest, 10)
+	addSpecRevisions(t, ctx, registryClient, want)
+
+	if diff := cmp.Diff(want, actions, sortActions); diff != "" {
+		t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+	}
+}
+
+func TestPinRevision(t *testing.T) {
+	tests := []struct {
+		desc   string
+		pin    *bool
+		pinned bool
+	}{
+		{
+			desc:   "default",
+			pin:    nil,
+			pinned: true,
+		},
+		{
+			desc:   "pinned",
+			pin:    proto.Bool(true),
+			pinned: true,
+		},
+		{
+			desc:   "unpinned",
+			pin:    proto.Bool(false),
+			pinned: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "controller-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			// The artifact of the first spec is updated and the artifact of the second spec is created.
+			seed := []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-gnostic",
+				},
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml",
+				},
+			}
+			if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "controller-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern:     "apis/-/versions/-/specs/-/artifacts/lint-gnostic",
+						Refresh:     durationpb.New(time.Nanosecond),
+						Action:      "registry compute lint $resource.spec --linter gnostic",
+						PinRevision: test.pin,
+					},
+				},
+			}
+			want := []*Action{
+				{
+					Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml --linter gnostic",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-gnostic",
+				},
+				{
+					Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml --linter gnostic",
+					GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml/artifacts/lint-gnostic",
+				},
+			}
+			if test.pinned {
+				addSpecRevisions(t, ctx, registryClient, want)
+			}
+
+			lister := &RegistryLister{RegistryClient: registryClient}
+			actions := ProcessManifest(ctx, lister, "controller-test", manifest, 10)
+			if diff := cmp.Diff(want, actions, sortActions); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
+
+func TestActionTemplate(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "controller-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	seed := []seeder.RegistryResource{
+		&rpc.ApiSpec{
+			Name:   "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			Labels: map[string]string{"linter": "gnostic"},
+		},
+		&rpc.ApiSpec{
+			Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml",
+		},
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	manifest := &rpc.Manifest{
+		Id: "controller-test",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Action:         `registry compute lint {{.Spec}} --linter {{or (index .Labels "linter") "spectral"}}`,
+				ActionTemplate: true,
+				Labels:         map[string]string{"manifest": "controller-test"},
+			},
+		},
+	}
+	want := []*Action{
+		{
+			Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml --linter gnostic",
+			GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint",
+			Labels:            map[string]string{"manifest": "controller-test"},
+		},
+		{
+			Command:           "registry compute lint projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml --linter spectral",
+			GeneratedResource: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml/artifacts/lint",
+			Labels:            map[string]string{"manifest": "controller-test"},
+		},
+	}
+
+	lister := &RegistryLister{RegistryClient: registryClient}
+	actions := ProcessManifest(ctx, lister, "controller-test", manifest, 10)
+	addSpecRevisions(t, ctx, registryClient, want)
+
+	if diff := cmp.Diff(want, actions, sortActions); diff != "" {
+		t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+	}
+}
+
 func addSpecRevisions(t *testing.T, ctx context.Context, registryClient *gapic.RegistryClient, actions []*Action) {
+	// Many actions can refer to the same spec, so each spec's revision is only fetched once.
+	revisions := make(map[string]string)
 	for _, action := range actions {
 		gr := action.GeneratedResource
 		a, err := names.ParseArtifact(gr)
 		if err != nil {
 			t.Fatal("Failed to parse GeneratedResource", err)
 		}
-		if a.SpecID() == "" {
-			return
+		subject, err := a.Subject()
+		if err != nil {
+			t.Fatal("Failed to get artifact subject", err)
 		}
-		sr := names.Spec{
-			ProjectID: a.ProjectID(),
-			ApiID:     a.ApiID(),
-			VersionID: a.VersionID(),
-			SpecID:    a.SpecID(),
+		sr, err := names.ParseSpec(subject)
+		if err != nil {
+			return
 		}
-		if err := core.GetSpec(ctx, registryClient, sr, false, func(s *rpc.ApiSpec) error {
-			action.Command = strings.ReplaceAll(action.Command,
-				fmt.Sprintf("/%s", a.SpecID()), fmt.Sprintf("/%s@%s", a.SpecID(), s.GetRevisionId()))
-			action.GeneratedResource = strings.ReplaceAll(action.GeneratedResource,
-				fmt.Sprintf("/%s", a.SpecID()), fmt.Sprintf("/%s@%s", a.SpecID(), s.GetRevisionId()))
-			return nil
-		}); err != nil {
-			t.Fatal("Failed GetSpecRevision", err)
+		revisionID, ok := revisions[sr.String()]
+		if !ok {
+			if err := core.GetSpec(ctx, registryClient, sr, false, func(s *rpc.ApiSpec) error {
+				revisionID = s.GetRevisionId()
+				return nil
+			}); err != nil {
+				t.Fatal("Failed GetSpecRevision", err)
+			}
+			revisions[sr.String()] = revisionID
 		}
+		action.Command = strings.ReplaceAll(action.Command,
+			fmt.Sprintf("/%s", a.SpecID()), fmt.Sprintf("/%s@%s", a.SpecID(), revisionID))
+		action.GeneratedResource = strings.ReplaceAll(action.GeneratedResource,
+			fmt.Sprintf("/%s", a.SpecID()), fmt.Sprintf("/%s@%s", a.SpecID(), revisionID))
 	}
 }
 
@@ -906,3 +1273,470 @@ func TestMaxActions(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxActionsPerApi(t *testing.T) {
+	tests := []struct {
+		desc       string
+		perApi     int32
+		maxActions int
+		want       map[string]int
+	}{
+		{
+			desc:       "no limit",
+			perApi:     0,
+			maxActions: 100,
+			want:       map[string]int{"big": 8, "medium": 4, "small": 2},
+		},
+		{
+			desc:       "limited per api",
+			perApi:     2,
+			maxActions: 100,
+			want:       map[string]int{"big": 2, "medium": 2, "small": 2},
+		},
+		{
+			desc:       "limited per api and in total",
+			perApi:     3,
+			maxActions: 4,
+			want:       map[string]int{"big": 2, "medium": 1, "small": 1},
+		},
+	}
+
+	// The "big" API has four specs, "medium" has two and "small" has one.
+	seed := make([]seeder.RegistryResource, 0)
+	for api, versions := range map[string]int{"big": 4, "medium": 2, "small": 1} {
+		for v := 1; v <= versions; v++ {
+			seed = append(seed, &rpc.ApiSpec{
+				Name: fmt.Sprintf("projects/controller-test/locations/global/apis/%s/versions/%d.0.0/specs/openapi.yaml", api, v),
+			})
+		}
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "controller-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			// Each entry generates one action for each spec, the limit covers both entries.
+			manifest := &rpc.Manifest{
+				Id:               "controller-test",
+				MaxActionsPerApi: test.perApi,
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern:      "apis/-/versions/-/specs/-/artifacts/vocabulary",
+						Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+						Action:       "registry compute vocabulary $resource.spec",
+					},
+					{
+						Pattern:      "apis/-/versions/-/specs/-/artifacts/complexity",
+						Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+						Action:       "registry compute complexity $resource.spec",
+					},
+				},
+			}
+			lister := &RegistryLister{RegistryClient: registryClient}
+			actions := ProcessManifest(ctx, lister, "controller-test", manifest, test.maxActions)
+
+			got := make(map[string]int)
+			for _, a := range actions {
+				name, err := names.ParseArtifact(a.GeneratedResource)
+				if err != nil {
+					t.Fatalf("Failed to parse GeneratedResource %q: %s", a.GeneratedResource, err)
+				}
+				got[name.ApiID()]++
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected actions per API (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
+
+func BenchmarkProcessManifest(b *testing.B) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		b.Fatalf("Failed to create client: %+v", err)
+	}
+	b.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		b.Fatalf("Failed to create client: %+v", err)
+	}
+	b.Cleanup(func() { adminClient.Close() })
+
+	const projectID = "controller-benchmark"
+	req := &rpc.DeleteProjectRequest{Name: "projects/" + projectID, Force: true}
+	_ = adminClient.DeleteProject(ctx, req)
+	b.Cleanup(func() { _ = adminClient.DeleteProject(ctx, req) })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	spec := seeder.SyntheticSpec{
+		ProjectID:        projectID,
+		APIs:             10,
+		VersionsPerAPI:   3,
+		SpecsPerVersion:  2,
+		ArtifactsPerSpec: 1,
+	}
+	if err := seeder.SeedSynthetic(ctx, client, spec); err != nil {
+		b.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	manifest := &rpc.Manifest{
+		Id: "controller-benchmark",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint-gnostic",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Action: "registry compute lint $resource.spec --linter gnostic",
+			},
+		},
+	}
+	lister := &RegistryLister{RegistryClient: registryClient}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProcessManifest(ctx, lister, projectID, manifest, 1000)
+	}
+}
+
+func TestStreamActions(t *testing.T) {
+	ctx := context.Background()
+	client := new(fakeLister)
+	seed := []seeder.RegistryResource{
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml"},
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	manifest := &rpc.Manifest{
+		Id: "controller-test",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/lint-gnostic",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute lint $resource.spec --linter gnostic",
+			},
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/complexity",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute complexity $resource.spec",
+			},
+		},
+	}
+
+	for _, maxActions := range []int{0, 2, 4, 6, 10} {
+		t.Run(fmt.Sprintf("max %d", maxActions), func(t *testing.T) {
+			stream, err := StreamActions(ctx, client, "controller-test", manifest, maxActions)
+			if err != nil {
+				t.Fatalf("StreamActions() returned error: %s", err)
+			}
+			var got []*Action
+			for a := range stream {
+				got = append(got, a)
+			}
+			want := ProcessManifest(ctx, client, "controller-test", manifest, maxActions)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("StreamActions() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			wantLen := maxActions
+			if wantLen > 6 {
+				wantLen = 6
+			}
+			if len(got) != wantLen {
+				t.Errorf("StreamActions() returned %d actions, want %d", len(got), wantLen)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		stream, err := StreamActions(ctx, client, "controller-test", manifest, 10)
+		if err != nil {
+			t.Fatalf("StreamActions() returned error: %s", err)
+		}
+		<-stream
+		cancel()
+		// The channel is closed once the context is canceled.
+		for range stream {
+		}
+	})
+
+	t.Run("missing manifest", func(t *testing.T) {
+		if _, err := StreamActions(ctx, client, "controller-test", nil, 10); err == nil {
+			t.Errorf("StreamActions() with a nil manifest returned no error")
+		}
+	})
+}
+
+func TestProcessManifestLogFields(t *testing.T) {
+	logger, rec := log.NewWithRecorder(log.DebugLevel)
+	ctx := log.NewContext(context.Background(), logger)
+	client := new(fakeLister)
+	if err := seeder.SeedRegistry(ctx, client,
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	manifest := &rpc.Manifest{
+		Id: "controller-test",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/complexity",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute complexity $resource.spec",
+			},
+		},
+	}
+	ProcessManifest(ctx, client, "controller-test", manifest, 10)
+
+	want := map[string]interface{}{
+		"manifest": "controller-test",
+		"project":  "controller-test",
+		"pattern":  "apis/-/versions/-/specs/-/artifacts/complexity",
+		"actions":  1,
+		"decision": "process",
+	}
+	for _, e := range rec.LogEntries {
+		if e.Message != "Generated actions for entry" {
+			continue
+		}
+		if diff := cmp.Diff(want, map[string]interface{}(e.Fields)); diff != "" {
+			t.Errorf("ProcessManifest() logged unexpected fields (-want +got):\n%s", diff)
+		}
+		return
+	}
+	t.Errorf("ProcessManifest() didn't log the actions generated for the entry")
+}
+
+func TestSkipKey(t *testing.T) {
+	tests := []struct {
+		desc    string
+		skipKey string
+		want    []string
+	}{
+		{
+			desc: "no skip key",
+			want: []string{"1.0.0", "1.0.1", "1.1.0", "2.0.0"},
+		},
+		{
+			desc:    "skip key",
+			skipKey: "controller.skip",
+			want:    []string{"1.0.0", "2.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "controller-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			seed := []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.ApiSpec{
+					Name:        "projects/controller-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml",
+					Annotations: map[string]string{"controller.skip": "true"},
+				},
+				&rpc.ApiSpec{
+					Name:   "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml",
+					Labels: map[string]string{"controller.skip": ""},
+				},
+				&rpc.ApiSpec{
+					Name:        "projects/controller-test/locations/global/apis/petstore/versions/2.0.0/specs/openapi.yaml",
+					Annotations: map[string]string{"controller.skip": "false"},
+				},
+			}
+			if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "controller-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/versions/-/specs/-/artifacts/lint-gnostic",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.spec",
+								Filter:  "mime_type == ''",
+							},
+						},
+						Action: "registry compute lint $resource.spec --linter gnostic",
+					},
+				},
+			}
+			want := make([]*Action, 0, len(test.want))
+			for _, v := range test.want {
+				spec := fmt.Sprintf("projects/controller-test/locations/global/apis/petstore/versions/%s/specs/openapi.yaml", v)
+				want = append(want, &Action{
+					Command:           fmt.Sprintf("registry compute lint %s --linter gnostic", spec),
+					GeneratedResource: spec + "/artifacts/lint-gnostic",
+				})
+			}
+			addSpecRevisions(t, ctx, registryClient, want)
+
+			lister := &RegistryLister{RegistryClient: registryClient}
+			actions := ProcessManifestWithOptions(ctx, lister, "controller-test", manifest, 10, Options{SkipKey: test.skipKey})
+			if diff := cmp.Diff(want, actions, sortActions); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
+
+// deletingLister deletes a spec after specs have been listed twice: once for
+// the dependencies of a manifest entry and once for the parents of its
+// generated resources, so the spec vanishes before actions are returned.
+type deletingLister struct {
+	*RegistryLister
+	spec  string
+	lists int
+}
+
+func (l *deletingLister) ListSpecs(ctx context.Context, spec names.Spec, filter string, handler core.SpecHandler) error {
+	if err := l.RegistryLister.ListSpecs(ctx, spec, filter, handler); err != nil {
+		return err
+	}
+	l.lists++
+	if l.lists == 2 {
+		return l.RegistryClient.DeleteApiSpec(ctx, &rpc.DeleteApiSpecRequest{Name: l.spec, Force: true})
+	}
+	return nil
+}
+
+func TestCheckExistence(t *testing.T) {
+	tests := []struct {
+		desc           string
+		checkExistence bool
+		want           []string
+	}{
+		{
+			desc: "no existence check",
+			want: []string{"1.0.0", "1.0.1"},
+		},
+		{
+			desc:           "existence check",
+			checkExistence: true,
+			want:           []string{"1.0.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "controller-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			seed := []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.ApiSpec{
+					Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml",
+				},
+			}
+			if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "controller-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/versions/-/specs/-/artifacts/lint-gnostic",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.spec",
+							},
+						},
+						Action: "registry compute lint $resource.spec --linter gnostic",
+					},
+				},
+			}
+			// The revision IDs are fetched before the spec is deleted.
+			want := make([]*Action, 0, len(test.want))
+			for _, v := range test.want {
+				spec := fmt.Sprintf("projects/controller-test/locations/global/apis/petstore/versions/%s/specs/openapi.yaml", v)
+				want = append(want, &Action{
+					Command:           fmt.Sprintf("registry compute lint %s --linter gnostic", spec),
+					GeneratedResource: spec + "/artifacts/lint-gnostic",
+				})
+			}
+			addSpecRevisions(t, ctx, registryClient, want)
+
+			lister := &deletingLister{
+				RegistryLister: &RegistryLister{RegistryClient: registryClient},
+				spec:           "projects/controller-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml",
+			}
+			actions := ProcessManifestWithOptions(ctx, lister, "controller-test", manifest, 10, Options{CheckExistence: test.checkExistence})
+			if diff := cmp.Diff(want, actions, sortActions); diff != "" {
+				t.Errorf("ProcessManifest(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
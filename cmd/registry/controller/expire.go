@@ -0,0 +1,76 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+)
+
+// ExpireArtifacts returns actions that delete generated artifacts that
+// haven't been updated for longer than the expiry of their manifest entry.
+// Entries without an expiry are skipped. At most maxActions actions are returned.
+func ExpireArtifacts(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	manifest *rpc.Manifest,
+	maxActions int) []*Action {
+	now := time.Now()
+	var actions []*Action
+	for _, resource := range manifest.GeneratedResources {
+		if resource.Expiry == nil {
+			continue
+		}
+		errs := validateGeneratedResourceEntry(patterns.ProjectLocation("projects/"+projectID), resource)
+		if len(errs) > 0 {
+			log.FromContext(ctx).Debugf("Skipping resource: %q", resource)
+			continue
+		}
+
+		resourcePattern := fmt.Sprintf("%s/%s", patterns.ProjectLocation("projects/"+projectID), resource.Pattern)
+		resourceList, err := listResources(ctx, client, resourcePattern, resource.Filter)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Debugf("Skipping resource: %q", resource)
+			continue
+		}
+		expiry := resource.Expiry.AsDuration()
+		for _, targetResource := range resourceList {
+			if now.Sub(targetResource.UpdateTimestamp()) <= expiry {
+				continue
+			}
+			actions = append(actions, &Action{
+				Command:           fmt.Sprintf("registry delete %s", targetResource.ResourceName().String()),
+				GeneratedResource: targetResource.ResourceName().String(),
+				Destructive:       true,
+			})
+		}
+
+		if len(actions) >= maxActions {
+			log.FromContext(ctx).Debugf("Reached max actions limit %d", maxActions)
+			break
+		}
+	}
+
+	if len(actions) > maxActions {
+		actions = actions[:maxActions]
+	}
+	return actions
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestExpireArtifacts(t *testing.T) {
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name: "projects/expire-test/locations/global/apis/petstore/artifacts/search-index",
+		},
+		&rpc.Artifact{
+			Name: "projects/expire-test/locations/global/apis/bookstore/artifacts/search-index",
+		},
+		&rpc.Artifact{
+			Name: "projects/expire-test/locations/global/apis/petstore/artifacts/complexity",
+		},
+	}
+	tests := []struct {
+		desc       string
+		expiry     *durationpb.Duration
+		maxActions int
+		want       []*Action
+	}{
+		{
+			desc:       "no expiry",
+			maxActions: 10,
+			want:       []*Action{},
+		},
+		{
+			desc:       "not expired",
+			expiry:     durationpb.New(time.Hour),
+			maxActions: 10,
+			want:       []*Action{},
+		},
+		{
+			desc:       "expired",
+			expiry:     durationpb.New(time.Millisecond),
+			maxActions: 10,
+			want: []*Action{
+				{
+					Command:           "registry delete projects/expire-test/locations/global/apis/bookstore/artifacts/search-index",
+					GeneratedResource: "projects/expire-test/locations/global/apis/bookstore/artifacts/search-index",
+					Destructive:       true,
+				},
+				{
+					Command:           "registry delete projects/expire-test/locations/global/apis/petstore/artifacts/search-index",
+					GeneratedResource: "projects/expire-test/locations/global/apis/petstore/artifacts/search-index",
+					Destructive:       true,
+				},
+			},
+		},
+		{
+			desc:       "max actions",
+			expiry:     durationpb.New(time.Millisecond),
+			maxActions: 1,
+		},
+	}
+
+	const projectID = "expire-test"
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, projectID)
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, projectID) })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	// Let the seeded artifacts age past the shortest expiry.
+	time.Sleep(10 * time.Millisecond)
+
+	lister := &RegistryLister{RegistryClient: registryClient}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			manifest := &rpc.Manifest{
+				Id: "expire-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/artifacts/search-index",
+						Refresh: durationpb.New(time.Hour),
+						Expiry:  test.expiry,
+						Action:  "registry compute search-index $resource.api",
+					},
+				},
+			}
+			actions := ExpireArtifacts(ctx, lister, projectID, manifest, test.maxActions)
+
+			if test.want == nil {
+				if len(actions) != test.maxActions {
+					t.Errorf("ExpireArtifacts(%+v) returned %d actions, want %d", manifest, len(actions), test.maxActions)
+				}
+				return
+			}
+			if diff := cmp.Diff(test.want, actions, sortActions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ExpireArtifacts(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Readiness describes whether a registry can be used for a controller pass.
+type Readiness int
+
+const (
+	// Ready registries can be used normally.
+	Ready Readiness = iota
+	// Degraded registries respond, but slowly or without a complete status.
+	// Controller passes can run against them.
+	Degraded
+	// Down registries are unreachable or migrating their storage.
+	// Controller passes should be skipped or delayed.
+	Down
+)
+
+func (r Readiness) String() string {
+	switch r {
+	case Ready:
+		return "ready"
+	case Degraded:
+		return "degraded"
+	case Down:
+		return "down"
+	default:
+		return fmt.Sprintf("Readiness(%d)", int(r))
+	}
+}
+
+// runningStatus is the status message of a registry that is serving normally.
+const runningStatus = "running"
+
+// HealthOptions configures CheckHealth.
+type HealthOptions struct {
+	// Timeout limits the time spent checking health. Zero means no limit.
+	Timeout time.Duration
+	// DegradedLatency is the response time above which a registry is
+	// considered degraded. Zero disables the latency check.
+	DegradedLatency time.Duration
+	// Migration is the name of a MigrateDatabase operation to check.
+	// If set, registries are down until the operation is done.
+	Migration string
+}
+
+// Health is the result of CheckHealth.
+type Health struct {
+	Readiness Readiness
+	// Message explains the readiness.
+	Message string
+	// Latency is the response time of the status request.
+	Latency time.Duration
+	// Err is the error that caused a registry to be down or degraded, if any.
+	Err error
+}
+
+// CheckHealth gets the status of a registry and reports whether it can be
+// used for a controller pass.
+func CheckHealth(ctx context.Context, client connection.AdminClient, opts HealthOptions) Health {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	s, err := client.GetStatus(ctx, &emptypb.Empty{})
+	health := statusHealth(s, err, time.Since(start), opts)
+	if health.Readiness == Down || opts.Migration == "" {
+		return health
+	}
+	return migrationHealth(ctx, client, health, opts.Migration)
+}
+
+// statusHealth classifies the response to a status request.
+func statusHealth(s *rpc.Status, err error, latency time.Duration, opts HealthOptions) Health {
+	health := Health{Readiness: Ready, Latency: latency}
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// The registry is reachable but doesn't report its status.
+		health.Readiness = Degraded
+		health.Message = "status is not available"
+		health.Err = err
+	case err != nil:
+		health.Readiness = Down
+		health.Message = fmt.Sprintf("failed to get status: %s", status.Code(err))
+		health.Err = err
+	case s.GetMessage() != runningStatus:
+		health.Readiness = Degraded
+		health.Message = fmt.Sprintf("status is %q", s.GetMessage())
+	case opts.DegradedLatency > 0 && latency > opts.DegradedLatency:
+		health.Readiness = Degraded
+		health.Message = fmt.Sprintf("status took %s", latency.Round(time.Millisecond))
+	default:
+		health.Message = s.GetMessage()
+	}
+	return health
+}
+
+// migrationHealth checks the named MigrateDatabase operation and updates health accordingly.
+func migrationHealth(ctx context.Context, client connection.AdminClient, health Health, name string) Health {
+	op := client.MigrateDatabaseOperation(name)
+	if _, err := op.Poll(ctx); err != nil && !op.Done() {
+		// The registry might not serve operations, in which case migrations
+		// complete before MigrateDatabase returns and can't be in progress.
+		if health.Readiness == Ready {
+			health.Readiness = Degraded
+			health.Message = fmt.Sprintf("failed to check migration %q", name)
+			health.Err = err
+		}
+		return health
+	}
+	if !op.Done() {
+		return Health{
+			Readiness: Down,
+			Message:   fmt.Sprintf("migration %q is in progress", name),
+			Latency:   health.Latency,
+			Err:       errors.New("migration in progress"),
+		}
+	}
+	return health
+}
@@ -0,0 +1,100 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusHealth(t *testing.T) {
+	tests := []struct {
+		desc    string
+		status  *rpc.Status
+		err     error
+		latency time.Duration
+		want    Readiness
+	}{
+		{
+			desc:   "running",
+			status: &rpc.Status{Message: "running"},
+			want:   Ready,
+		},
+		{
+			desc:   "not running",
+			status: &rpc.Status{Message: "starting"},
+			want:   Degraded,
+		},
+		{
+			desc:    "slow",
+			status:  &rpc.Status{Message: "running"},
+			latency: 2 * time.Second,
+			want:    Degraded,
+		},
+		{
+			desc: "unimplemented",
+			err:  status.Error(codes.Unimplemented, "unimplemented"),
+			want: Degraded,
+		},
+		{
+			desc: "unavailable",
+			err:  status.Error(codes.Unavailable, "unavailable"),
+			want: Down,
+		},
+		{
+			desc: "timeout",
+			err:  context.DeadlineExceeded,
+			want: Down,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := statusHealth(test.status, test.err, test.latency, HealthOptions{DegradedLatency: time.Second})
+			if got.Readiness != test.want {
+				t.Errorf("statusHealth() returned %s (%s), want %s", got.Readiness, got.Message, test.want)
+			}
+			if (got.Err != nil) != (test.err != nil) {
+				t.Errorf("statusHealth() returned error %v, want %v", got.Err, test.err)
+			}
+		})
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	ctx := context.Background()
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %s", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	health := CheckHealth(ctx, adminClient, HealthOptions{Timeout: 10 * time.Second})
+	if health.Readiness != Ready {
+		t.Errorf("CheckHealth() returned %s (%s), want %s", health.Readiness, health.Message, Ready)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	health = CheckHealth(canceled, adminClient, HealthOptions{Timeout: 10 * time.Second})
+	if health.Readiness != Down {
+		t.Errorf("CheckHealth() with canceled context returned %s (%s), want %s", health.Readiness, health.Message, Down)
+	}
+}
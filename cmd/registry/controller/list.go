@@ -10,31 +10,134 @@ import (
 	"github.com/apigee/registry/server/registry/names"
 )
 
-type listingClient interface {
-	ListAPIs(context.Context, names.Api, string, core.ApiHandler) error
-	ListVersions(context.Context, names.Version, string, core.VersionHandler) error
-	ListSpecs(context.Context, names.Spec, string, core.SpecHandler) error
-	ListArtifacts(context.Context, names.Artifact, string, bool, core.ArtifactHandler) error
-}
+// listingClient is satisfied by RegistryLister and by fakes in tests.
+type listingClient = core.Lister
+
+const (
+	// DefaultPageSize is the page size used by a RegistryLister when none is specified.
+	DefaultPageSize = 200
+	// MaxPageSize is the largest page size that the registry server will return.
+	MaxPageSize = 1000
+)
 
 type RegistryLister struct {
 	RegistryClient connection.RegistryClient
+	// PageSize is the number of resources requested in each list call.
+	// Values larger than MaxPageSize are capped, and zero means DefaultPageSize.
+	PageSize int32
+}
+
+func (r *RegistryLister) pageSize() int32 {
+	switch {
+	case r.PageSize <= 0:
+		return DefaultPageSize
+	case r.PageSize > MaxPageSize:
+		return MaxPageSize
+	default:
+		return r.PageSize
+	}
 }
 
 func (r *RegistryLister) ListAPIs(ctx context.Context, api names.Api, filter string, handler core.ApiHandler) error {
-	return core.ListAPIs(ctx, r.RegistryClient, api, filter, handler)
+	return core.ListAPIsWithPageSize(ctx, r.RegistryClient, api, filter, r.pageSize(), handler)
 }
 
 func (r *RegistryLister) ListVersions(ctx context.Context, version names.Version, filter string, handler core.VersionHandler) error {
-	return core.ListVersions(ctx, r.RegistryClient, version, filter, handler)
+	return core.ListVersionsWithPageSize(ctx, r.RegistryClient, version, filter, r.pageSize(), handler)
 }
 
 func (r *RegistryLister) ListSpecs(ctx context.Context, spec names.Spec, filter string, handler core.SpecHandler) error {
-	return core.ListSpecs(ctx, r.RegistryClient, spec, filter, handler)
+	return core.ListSpecsWithPageSize(ctx, r.RegistryClient, spec, filter, r.pageSize(), handler)
 }
 
 func (r *RegistryLister) ListArtifacts(ctx context.Context, artifact names.Artifact, filter string, contents bool, handler core.ArtifactHandler) error {
-	return core.ListArtifacts(ctx, r.RegistryClient, artifact, filter, contents, handler)
+	return core.ListArtifactsWithPageSize(ctx, r.RegistryClient, artifact, filter, contents, r.pageSize(), handler)
+}
+
+// countingLister counts the resources returned by the client that it wraps.
+type countingLister struct {
+	listingClient
+	count int
+}
+
+func (c *countingLister) ListAPIs(ctx context.Context, api names.Api, filter string, handler core.ApiHandler) error {
+	return c.listingClient.ListAPIs(ctx, api, filter, func(api *rpc.Api) error {
+		c.count++
+		return handler(api)
+	})
+}
+
+func (c *countingLister) ListVersions(ctx context.Context, version names.Version, filter string, handler core.VersionHandler) error {
+	return c.listingClient.ListVersions(ctx, version, filter, func(version *rpc.ApiVersion) error {
+		c.count++
+		return handler(version)
+	})
+}
+
+func (c *countingLister) ListSpecs(ctx context.Context, spec names.Spec, filter string, handler core.SpecHandler) error {
+	return c.listingClient.ListSpecs(ctx, spec, filter, func(spec *rpc.ApiSpec) error {
+		c.count++
+		return handler(spec)
+	})
+}
+
+func (c *countingLister) ListArtifacts(ctx context.Context, artifact names.Artifact, filter string, contents bool, handler core.ArtifactHandler) error {
+	return c.listingClient.ListArtifacts(ctx, artifact, filter, contents, func(artifact *rpc.Artifact) error {
+		c.count++
+		return handler(artifact)
+	})
+}
+
+// skippingLister hides the resources that have a label or annotation with key,
+// unless its value is "false".
+type skippingLister struct {
+	listingClient
+	key string
+}
+
+func (s *skippingLister) skip(labels, annotations map[string]string) bool {
+	for _, m := range []map[string]string{labels, annotations} {
+		if v, ok := m[s.key]; ok && v != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *skippingLister) ListAPIs(ctx context.Context, api names.Api, filter string, handler core.ApiHandler) error {
+	return s.listingClient.ListAPIs(ctx, api, filter, func(api *rpc.Api) error {
+		if s.skip(api.GetLabels(), api.GetAnnotations()) {
+			return nil
+		}
+		return handler(api)
+	})
+}
+
+func (s *skippingLister) ListVersions(ctx context.Context, version names.Version, filter string, handler core.VersionHandler) error {
+	return s.listingClient.ListVersions(ctx, version, filter, func(version *rpc.ApiVersion) error {
+		if s.skip(version.GetLabels(), version.GetAnnotations()) {
+			return nil
+		}
+		return handler(version)
+	})
+}
+
+func (s *skippingLister) ListSpecs(ctx context.Context, spec names.Spec, filter string, handler core.SpecHandler) error {
+	return s.listingClient.ListSpecs(ctx, spec, filter, func(spec *rpc.ApiSpec) error {
+		if s.skip(spec.GetLabels(), spec.GetAnnotations()) {
+			return nil
+		}
+		return handler(spec)
+	})
+}
+
+func (s *skippingLister) ListArtifacts(ctx context.Context, artifact names.Artifact, filter string, contents bool, handler core.ArtifactHandler) error {
+	return s.listingClient.ListArtifacts(ctx, artifact, filter, contents, func(artifact *rpc.Artifact) error {
+		if s.skip(artifact.GetLabels(), artifact.GetAnnotations()) {
+			return nil
+		}
+		return handler(artifact)
+	})
 }
 
 func listResources(ctx context.Context, client listingClient, pattern, filter string) ([]patterns.ResourceInstance, error) {
@@ -0,0 +1,105 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+)
+
+func TestRegistryListerPageSize(t *testing.T) {
+	tests := []struct {
+		desc     string
+		pageSize int32
+		want     int32
+	}{
+		{
+			desc:     "unset",
+			pageSize: 0,
+			want:     DefaultPageSize,
+		},
+		{
+			desc:     "negative",
+			pageSize: -1,
+			want:     DefaultPageSize,
+		},
+		{
+			desc:     "small",
+			pageSize: 10,
+			want:     10,
+		},
+		{
+			desc:     "max",
+			pageSize: MaxPageSize,
+			want:     MaxPageSize,
+		},
+		{
+			desc:     "too large",
+			pageSize: MaxPageSize + 1,
+			want:     MaxPageSize,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			lister := &RegistryLister{PageSize: test.pageSize}
+			if got := lister.pageSize(); got != test.want {
+				t.Errorf("pageSize() returned %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegistryListerMultiplePages(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "controller-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedRegistry(ctx, client,
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.0.1/specs/openapi.yaml"},
+		&rpc.ApiSpec{Name: "projects/controller-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml"},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	lister := &RegistryLister{RegistryClient: registryClient, PageSize: 1}
+	specs, err := listResources(ctx, lister, "projects/controller-test/locations/global/apis/-/versions/-/specs/-", "")
+	if err != nil {
+		t.Fatalf("listResources() returned error: %s", err)
+	}
+	if len(specs) != 3 {
+		t.Errorf("listResources() returned %d specs, want 3", len(specs))
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// LoadManifests reads a manifest that is split across several YAML files.
+// Each file holds a manifest in one of the forms accepted by
+// ValidateManifestYAML, and the generated resources of all files are
+// concatenated in the order of paths. Manifest-level fields, such as id and
+// max_actions_per_api, may be set in any of the files, and files that set
+// the same field must agree on its value. The combined manifest is checked
+// with ValidateManifest, and problems with generated resource entries are
+// reported with the file, line, and column where the entry begins.
+func LoadManifests(paths ...string) (*rpc.Manifest, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no manifest files specified")
+	}
+
+	combined := &rpc.Manifest{}
+	// positions holds the location of each generated resource for error messages.
+	positions := make([]string, 0)
+	// setBy holds the path of the file that set each manifest-level field.
+	setBy := make(map[protoreflect.Name]string)
+	for _, path := range paths {
+		yamlBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		manifest, entries, err := parseManifestYAML(yamlBytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		if err := mergeManifestFields(combined, manifest, setBy, path); err != nil {
+			return nil, err
+		}
+		for i, resource := range manifest.GeneratedResources {
+			position := path
+			if entries != nil && i < len(entries.Content) {
+				position = fmt.Sprintf("%s:%d:%d", path, entries.Content[i].Line, entries.Content[i].Column)
+			}
+			positions = append(positions, position)
+			combined.GeneratedResources = append(combined.GeneratedResources, resource)
+		}
+	}
+
+	errs := make([]string, 0)
+	for _, err := range ValidateManifest(patterns.ProjectLocation("projects/-"), combined) {
+		var entryErr *entryError
+		var overlapErr *overlapError
+		switch {
+		case errors.As(err, &entryErr):
+			errs = append(errs, fmt.Sprintf("%s: invalid entry %q: %s", positions[entryErr.index], entryErr.resource.Pattern, entryErr.err))
+		case errors.As(err, &overlapErr):
+			// Entries with the same pattern and filter generate the same resources.
+			a, b := overlapErr.a, overlapErr.b
+			if strings.EqualFold(strings.Trim(a.Pattern, "/"), strings.Trim(b.Pattern, "/")) && a.Filter == b.Filter {
+				errs = append(errs, fmt.Sprintf("%s: duplicate entry %q, first defined at %s", positions[overlapErr.second], b.Pattern, positions[overlapErr.first]))
+			} else {
+				errs = append(errs, fmt.Sprintf("%s: entry %q can generate the same resource as entry %q at %s", positions[overlapErr.second], b.Pattern, a.Pattern, positions[overlapErr.first]))
+			}
+		default:
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid manifest:\n%s", strings.Join(errs, "\n"))
+	}
+	return combined, nil
+}
+
+// mergeManifestFields copies the manifest-level fields that are set in manifest
+// into combined. setBy records the path of the file that set each field, and an
+// error is returned if a field was already set to a different value.
+// Manifest-level fields are scalars, so their values can be compared directly.
+func mergeManifestFields(combined, manifest *rpc.Manifest, setBy map[protoreflect.Name]string, path string) error {
+	var err error
+	dst := combined.ProtoReflect()
+	manifest.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.IsList() || fd.IsMap() || fd.Message() != nil {
+			return true
+		}
+		if dst.Has(fd) && dst.Get(fd).Interface() != v.Interface() {
+			err = fmt.Errorf("%s: manifest %s %s does not match %s %s of %s",
+				path, fd.Name(), formatFieldValue(v), fd.Name(), formatFieldValue(dst.Get(fd)), setBy[fd.Name()])
+			return false
+		}
+		dst.Set(fd, v)
+		setBy[fd.Name()] = path
+		return true
+	})
+	return err
+}
+
+func formatFieldValue(v protoreflect.Value) string {
+	if s, ok := v.Interface().(string); ok {
+		return strconv.Quote(s)
+	}
+	return v.String()
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -40,6 +41,7 @@ func (w logWriter) Write(p []byte) (n int, err error) {
 }
 
 type ExecCommandTask struct {
+	Client connection.RegistryClient
 	Action *Action
 	TaskID string
 }
@@ -90,26 +92,47 @@ func (task *ExecCommandTask) Run(ctx context.Context) error {
 	}
 
 	if task.Action.RequiresReceipt {
-		if err := touchArtifact(ctx, task.Action.GeneratedResource, task.Action.Command); err != nil {
+		if err := touchArtifact(ctx, task.Client, task.Action.GeneratedResource, task.Action.Command, task.Action.Labels); err != nil {
 			logger.WithError(err).Debug("Failed Execution: failed uploading receipt")
 			return errors.New("failed uploading receipt")
 		}
+	} else if len(task.Action.Labels) > 0 {
+		if err := labelArtifact(ctx, task.Client, task.Action.GeneratedResource, task.Action.Labels); err != nil {
+			logger.WithError(err).Debug("Failed Execution: failed labeling generated resource")
+			return errors.New("failed labeling generated resource")
+		}
 	}
 
 	logger.Debug("Successful Execution:")
 	return nil
 }
 
-func touchArtifact(ctx context.Context, artifactName, action string) error {
-	client, err := connection.NewRegistryClient(ctx)
-	if err != nil {
-		log.FromContext(ctx).WithError(err).Fatal("Failed to get client")
-	}
-
+func touchArtifact(ctx context.Context, client connection.RegistryClient, artifactName, action string, labels map[string]string) error {
 	messageData, _ := proto.Marshal(&rpc.Receipt{Action: action})
 	return core.SetArtifact(ctx, client, &rpc.Artifact{
 		Name:     artifactName,
 		MimeType: core.MimeTypeForMessageType("google.cloud.apigeeregistry.v1.controller.Receipt"),
 		Contents: messageData,
+		Labels:   labels,
+	})
+}
+
+// labelArtifact adds labels to an artifact that was generated by a command.
+// Existing labels with the same keys are overwritten. Only metadata is
+// replaced, the contents of the artifact are left unchanged.
+func labelArtifact(ctx context.Context, client connection.RegistryClient, artifactName string, labels map[string]string) error {
+	name, err := names.ParseArtifact(artifactName)
+	if err != nil {
+		return err
+	}
+	return core.GetArtifact(ctx, client, name, false, func(artifact *rpc.Artifact) error {
+		if artifact.Labels == nil {
+			artifact.Labels = make(map[string]string, len(labels))
+		}
+		for k, v := range labels {
+			artifact.Labels[k] = v
+		}
+		_, err := client.ReplaceArtifact(ctx, &rpc.ReplaceArtifactRequest{Artifact: artifact})
+		return err
 	})
 }
@@ -17,6 +17,13 @@ package controller
 import (
 	"context"
 	"testing"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
 )
 
 // Test the error scenario
@@ -33,3 +40,87 @@ func TestErrorCases(t *testing.T) {
 		t.Errorf("Expected GetCommand() to return error.")
 	}
 }
+
+func TestExecCommandTaskLabels(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "controller-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "controller-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	// The artifact that a command generated.
+	generated := &rpc.Artifact{
+		Name:     "projects/controller-test/locations/global/apis/petstore/artifacts/lint",
+		MimeType: "text/plain",
+		Contents: []byte("lint results"),
+		Labels:   map[string]string{"linter": "spectral"},
+	}
+	if err := seeder.SeedArtifacts(ctx, client, generated); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		desc         string
+		action       *Action
+		wantLabels   map[string]string
+		wantContents bool
+	}{
+		{
+			desc: "generated artifact",
+			action: &Action{
+				Command:           "true",
+				GeneratedResource: generated.Name,
+				Labels:            map[string]string{"manifest": "controller-test", "linter": "gnostic"},
+			},
+			wantLabels:   map[string]string{"manifest": "controller-test", "linter": "gnostic"},
+			wantContents: true,
+		},
+		{
+			desc: "receipt",
+			action: &Action{
+				Command:           "true",
+				GeneratedResource: "projects/controller-test/locations/global/apis/petstore/artifacts/receipt",
+				RequiresReceipt:   true,
+				Labels:            map[string]string{"manifest": "controller-test"},
+			},
+			wantLabels: map[string]string{"manifest": "controller-test"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			task := &ExecCommandTask{Client: registryClient, Action: test.action, TaskID: "task0"}
+			if err := task.Run(ctx); err != nil {
+				t.Fatalf("Run() returned error: %s", err)
+			}
+			name, err := names.ParseArtifact(test.action.GeneratedResource)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := core.GetArtifact(ctx, registryClient, name, true, func(artifact *rpc.Artifact) error {
+				if diff := cmp.Diff(test.wantLabels, artifact.GetLabels()); diff != "" {
+					t.Errorf("Run() set unexpected labels (-want +got):\n%s", diff)
+				}
+				if test.wantContents && string(artifact.GetContents()) != string(generated.Contents) {
+					t.Errorf("Run() changed contents to %q", artifact.GetContents())
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("Failed to get %s: %s", name, err)
+			}
+		})
+	}
+}
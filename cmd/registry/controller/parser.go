@@ -21,19 +21,86 @@ import (
 
 	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
 )
 
 func ValidateManifest(parent string, manifest *rpc.Manifest) []error {
 	totalErrors := make([]error, 0)
-	for _, resource := range manifest.GeneratedResources {
+	for i, resource := range manifest.GeneratedResources {
 		errs := validateGeneratedResourceEntry(parent, resource)
 		for _, err := range errs {
-			totalErrors = append(totalErrors, fmt.Errorf("invalid entry: %v, %s", resource, err))
+			totalErrors = append(totalErrors, &entryError{index: i, resource: resource, err: err})
 		}
 	}
+	totalErrors = append(totalErrors, validateNonOverlapping(manifest.GeneratedResources)...)
+	if manifest.GetMaxActionsPerApi() < 0 {
+		totalErrors = append(totalErrors, fmt.Errorf("invalid max_actions_per_api: %d, must be >=0", manifest.GetMaxActionsPerApi()))
+	}
 	return totalErrors
 }
 
+// entryError is a problem with the generated resource at index in a manifest.
+// Callers that know where the entry was defined can report err with that position.
+type entryError struct {
+	index    int
+	resource *rpc.GeneratedResource
+	err      error
+}
+
+func (e *entryError) Error() string {
+	return fmt.Sprintf("invalid entry: %v, %s", e.resource, e.err)
+}
+
+func (e *entryError) Unwrap() error {
+	return e.err
+}
+
+// validateNonOverlapping returns an error for each pair of generated resources
+// whose patterns can match the same resource, which would make both entries
+// write to it. Patterns overlap if they have the same shape and each pair of
+// segments is equal or contains a "-". Entries with different non-empty
+// filters might select disjoint resources and are not reported.
+func validateNonOverlapping(resources []*rpc.GeneratedResource) []error {
+	errs := make([]error, 0)
+	for i, a := range resources {
+		for j := i + 1; j < len(resources); j++ {
+			b := resources[j]
+			if a.Filter != "" && b.Filter != "" && a.Filter != b.Filter {
+				continue
+			}
+			if patternsOverlap(a.Pattern, b.Pattern) {
+				errs = append(errs, &overlapError{first: i, second: j, a: a, b: b})
+			}
+		}
+	}
+	return errs
+}
+
+// overlapError reports two generated resources, at indexes first and second
+// in a manifest, that can generate the same resource.
+type overlapError struct {
+	first, second int
+	a, b          *rpc.GeneratedResource
+}
+
+func (e *overlapError) Error() string {
+	return fmt.Sprintf("overlapping entries: generated_resources[%d] (pattern %q) and generated_resources[%d] (pattern %q) can generate the same resource", e.first, e.a.Pattern, e.second, e.b.Pattern)
+}
+
+func patternsOverlap(a, b string) bool {
+	as := strings.Split(strings.Trim(a, "/"), "/")
+	bs := strings.Split(strings.Trim(b, "/"), "/")
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i] != "-" && bs[i] != "-" && !strings.EqualFold(as[i], bs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func validateGeneratedResourceEntry(parent string, generatedResource *rpc.GeneratedResource) []error {
 	parsedTargetResource, err := patterns.ParseResourcePattern(
 		fmt.Sprintf("%s/%s", parent, generatedResource.Pattern))
@@ -55,6 +122,8 @@ func validateGeneratedResourceEntry(parent string, generatedResource *rpc.Genera
 
 	validateEntityReference := func(resourceName patterns.ResourceName, entityType string) bool {
 		switch entityType {
+		case "project":
+			return resourceName.Project() != ""
 		case "api":
 			return resourceName.Api() != ""
 		case "version":
@@ -71,6 +140,7 @@ func validateGeneratedResourceEntry(parent string, generatedResource *rpc.Genera
 	}
 
 	errs := make([]error, 0)
+	dependencyNames := make(map[string]bool)
 	for _, dependency := range generatedResource.Dependencies {
 		// Validate that all the dependencies have valid $resource references.
 		_, entityType, err := patterns.GetReferenceEntityType(dependency.Pattern)
@@ -81,6 +151,21 @@ func validateGeneratedResourceEntry(parent string, generatedResource *rpc.Genera
 		if !validateEntityReference(parsedTargetResource, entityType) {
 			errs = append(errs, fmt.Errorf("invalid reference in dependency pattern: %s", dependency.Pattern))
 		}
+
+		// Validate that named dependencies are unique and match a single resource.
+		if dependency.Name == "" {
+			continue
+		}
+		if !dependencyNameRegexp.MatchString(dependency.Name) {
+			errs = append(errs, fmt.Errorf("invalid dependency name %q, it should start with a letter and contain only letters, digits and underscores", dependency.Name))
+		}
+		if dependencyNames[dependency.Name] {
+			errs = append(errs, fmt.Errorf("duplicate dependency name %q", dependency.Name))
+		}
+		dependencyNames[dependency.Name] = true
+		if strings.HasPrefix(dependency.Pattern, "-") || strings.Contains(dependency.Pattern, "/-") {
+			errs = append(errs, fmt.Errorf("invalid pattern for named dependency %q: %s, it should match a single resource", dependency.Name, dependency.Pattern))
+		}
 	}
 
 	// Check that either "dependencies" or "refresh" is set and "refresh > 0"
@@ -93,66 +178,152 @@ func validateGeneratedResourceEntry(parent string, generatedResource *rpc.Genera
 		errs = append(errs, fmt.Errorf("'refresh' must be >0 for generated resource: %v", generatedResource))
 	}
 
+	// Check that "expiry" > 0
+	if generatedResource.Expiry != nil && generatedResource.Expiry.AsDuration() <= 0 {
+		errs = append(errs, fmt.Errorf("'expiry' must be >0 for generated resource: %v", generatedResource))
+	}
+	if generatedResource.Expiry != nil && parsedTargetResource.Artifact() == "" {
+		errs = append(errs, fmt.Errorf("'expiry' is only supported for generated artifacts: %v", generatedResource))
+	}
+
+	// Check that "condition" is a valid boolean expression
+	if generatedResource.Condition != "" {
+		if _, _, err := compileCondition(generatedResource.Condition); err != nil {
+			errs = append(errs, fmt.Errorf("invalid condition %q: %s", generatedResource.Condition, err))
+		}
+	}
+
+	// Action templates are checked by executing them for the target pattern
+	if generatedResource.ActionTemplate {
+		if err := validateActionTemplate(generatedResource, parsedTargetResource); err != nil {
+			errs = append(errs, err)
+		}
+		return errs
+	}
+
 	//Validate that all the action References are valid
-	references, err := getReferencesFromAction(generatedResource.Action)
-	if err != nil {
-		errs = append(errs, err)
+	if _, err := patterns.Substitute(generatedResource.Action, patterns.NewResourceContext(parsedTargetResource)); err != nil {
+		errs = append(errs, fmt.Errorf("invalid reference in action: %s, %s", generatedResource.Action, err))
 	}
-	for _, r := range references {
-		if !validateEntityReference(parsedTargetResource, r.entityType) {
-			errs = append(errs, fmt.Errorf("invalid reference in action: %s", generatedResource.Action))
+
+	// Validate that all the $dependency references in the action are named dependencies
+	for _, name := range getDependencyReferencesFromAction(generatedResource.Action) {
+		if !dependencyNames[name] {
+			errs = append(errs, fmt.Errorf("invalid reference in action: %s, no dependency is named %q", generatedResource.Action, name))
 		}
 	}
 
 	return errs
 }
 
-type reference struct {
-	entity     string
-	entityType string
-}
+// dependencyKW is the keyword used to reference named dependencies in actions.
+const dependencyKW = "$dependency"
 
-func getReferencesFromAction(action string) ([]*reference, error) {
-	references := make([]*reference, 0)
+var (
+	dependencyNameRegexp      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	dependencyReferenceRegexp = regexp.MustCompile(fmt.Sprintf(`\%s\.([A-Za-z0-9_]*)`, dependencyKW))
+)
 
-	// Check if there is a reference to $resource in the action
-	isMatch, err := regexp.MatchString(fmt.Sprintf(`\%s`, patterns.ResourceKW), action)
-	if err != nil {
-		return nil, err
+// getDependencyReferencesFromAction returns the names of the dependencies
+// referenced in action.
+// action = "registry compute conformance $resource.spec --styleguide $dependency.styleguide"
+// returns ["styleguide"]
+func getDependencyReferencesFromAction(action string) []string {
+	names := make([]string, 0)
+	for _, m := range dependencyReferenceRegexp.FindAllStringSubmatch(action, -1) {
+		names = append(names, m[1])
 	}
-	// No $resource references in the action string
-	if !isMatch {
-		return references, nil
+	return names
+}
+
+// substituteDependencies replaces the $dependency references in action with
+// the full resource names of the named dependencies of resourceName.
+func substituteDependencies(action string, dependencies []*rpc.Dependency, resourceName patterns.ResourceName) (string, error) {
+	if len(getDependencyReferencesFromAction(action)) == 0 {
+		return action, nil
 	}
 
-	// Extract the $resource patterns from action
-	// action = "compute lintstats $resource.spec"
-	// This expression will match $resource.spec
-	re := regexp.MustCompile(fmt.Sprintf(`\%s\.(api|version|spec|artifact)($|/| )`, patterns.ResourceKW))
-	match := re.FindAllString(action, -1)
-	if len(match) == 0 {
-		return nil, fmt.Errorf("invalid action: %s missing or incorrect entity in the reference", action)
+	values, err := namedDependencies(dependencies, resourceName)
+	if err != nil {
+		return "", fmt.Errorf("error generating command, %s", err)
 	}
 
-	// Construct a list of entity: entityType for all the references
-	for _, m := range match {
-		// entity = $resource.api, extract the entityType as "api"
-		entity := strings.TrimRight(m, " /")
-		entityType := entity[len(patterns.ResourceKW)+1:]
-		references = append(references, &reference{entity: entity, entityType: entityType})
+	var missing string
+	action = dependencyReferenceRegexp.ReplaceAllStringFunc(action, func(m string) string {
+		name := dependencyReferenceRegexp.FindStringSubmatch(m)[1]
+		value, ok := values[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return value
+	})
+	if missing != "" {
+		return "", fmt.Errorf("error generating command, no dependency is named %q", missing)
 	}
+	return action, nil
+}
 
-	return references, nil
+// namedDependencies returns the full resource names of the named dependencies
+// of resourceName, keyed by dependency name.
+func namedDependencies(dependencies []*rpc.Dependency, resourceName patterns.ResourceName) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, d := range dependencies {
+		if d.Name == "" {
+			continue
+		}
+		dependencyName, err := patterns.SubstituteReferenceEntity(d.Pattern, resourceName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for dependency %q: %s", d.Name, err)
+		}
+		values[d.Name] = dependencyName.String()
+	}
+	return values, nil
 }
 
-func generateCommand(action string, resourceName string) (string, error) {
-	references, err := getReferencesFromAction(action)
+// generateActionCommand returns the command for the action of generatedResource
+// with all $resource and $dependency references replaced.
+// Action templates are returned unchanged, they are executed by renderActionTemplates.
+func generateActionCommand(generatedResource *rpc.GeneratedResource, resourceName patterns.ResourceName) (string, error) {
+	if generatedResource.ActionTemplate {
+		return generatedResource.Action, nil
+	}
+	cmd, err := generateCommand(generatedResource.Action, resourceName.String())
 	if err != nil {
 		return "", err
 	}
+	return substituteDependencies(cmd, generatedResource.Dependencies, resourceName)
+}
 
+// actionResourceName returns the name that the action and generated resource of
+// generatedResource use for resourceName. Unless the manifest disables pinning,
+// this is resourceName itself, which refers to specs by revision.
+func actionResourceName(generatedResource *rpc.GeneratedResource, resourceName patterns.ResourceName) patterns.ResourceName {
+	if generatedResource.PinRevision == nil || generatedResource.GetPinRevision() {
+		return resourceName
+	}
+	switch name := resourceName.(type) {
+	case patterns.SpecName:
+		name.RevisionID = ""
+		return name
+	case patterns.ArtifactName:
+		if name.Name.SpecID() == "" || name.Name.RevisionID() == "" {
+			return name
+		}
+		spec := names.Spec{
+			ProjectID: name.Name.ProjectID(),
+			ApiID:     name.Name.ApiID(),
+			VersionID: name.Name.VersionID(),
+			SpecID:    name.Name.SpecID(),
+		}
+		return patterns.ArtifactName{Name: spec.Artifact(name.Name.ArtifactID())}
+	default:
+		return resourceName
+	}
+}
+
+func generateCommand(action string, resourceName string) (string, error) {
 	// no $resource reference, return the original action
-	if len(references) == 0 {
+	if !strings.Contains(action, patterns.ResourceKW) {
 		return action, nil
 	}
 
@@ -161,24 +332,9 @@ func generateCommand(action string, resourceName string) (string, error) {
 		return "", fmt.Errorf("error generating command, invalid resourceName: %s", resourceName)
 	}
 
-	for _, r := range references {
-		entityVal := ""
-		switch r.entityType {
-		case "api":
-			entityVal = resource.Api()
-		case "version":
-			entityVal = resource.Version()
-		case "spec":
-			entityVal = resource.Spec()
-		case "artifact":
-			entityVal = resource.Artifact()
-		}
-
-		if len(entityVal) == 0 {
-			return "", fmt.Errorf("error generating command, cannot derive args for action. Invalid action: %s", action)
-		}
-		action = strings.ReplaceAll(action, r.entity, entityVal)
+	cmd, err := patterns.Substitute(action, patterns.NewResourceContext(resource))
+	if err != nil {
+		return "", fmt.Errorf("error generating command, cannot derive args for action. Invalid action: %s, %s", action, err)
 	}
-
-	return action, nil
+	return cmd, nil
 }
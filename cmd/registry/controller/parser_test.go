@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/rpc"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
@@ -109,6 +110,57 @@ func TestGenerateCommandError(t *testing.T) {
 	}
 }
 
+func TestGenerateActionCommand(t *testing.T) {
+	tests := []struct {
+		desc              string
+		generatedResource *rpc.GeneratedResource
+		resourceName      string
+		want              string
+	}{
+		{
+			desc: "project dependency",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{Pattern: "$resource.spec"},
+					{Pattern: "artifacts/custom-styleguide", Name: "styleguide"},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+			resourceName: "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/conformance",
+			want:         "registry compute conformance projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml --styleguide projects/demo/locations/global/artifacts/custom-styleguide",
+		},
+		{
+			desc: "referenced dependency",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/artifacts/summary",
+				Dependencies: []*rpc.Dependency{
+					{Pattern: "$resource.api/artifacts/summary-config", Name: "config"},
+				},
+				Action: "registry generate summary $resource.version --config=$dependency.config",
+			},
+			resourceName: "projects/demo/locations/global/apis/petstore/versions/1.0.0/artifacts/summary",
+			want:         "registry generate summary projects/demo/locations/global/apis/petstore/versions/1.0.0 --config=projects/demo/locations/global/apis/petstore/artifacts/summary-config",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			resourceName, err := patterns.ParseResourcePattern(test.resourceName)
+			if err != nil {
+				t.Fatalf("ParseResourcePattern(%q) returned error: %s", test.resourceName, err)
+			}
+			got, err := generateActionCommand(test.generatedResource, resourceName)
+			if err != nil {
+				t.Errorf("generateActionCommand returned unexpected error: %s", err)
+			}
+			if got != test.want {
+				t.Errorf("generateActionCommand returned unexpected value want: %q got:%q", test.want, got)
+			}
+		})
+	}
+}
+
 func TestValidateGeneratedResourceEntry(t *testing.T) {
 	tests := []struct {
 		desc              string
@@ -196,6 +248,65 @@ func TestValidateGeneratedResourceEntry(t *testing.T) {
 				Action: "registry generate summary $resource.version",
 			},
 		},
+		{
+			desc: "condition",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Condition: "has_artifact('approved') && !has_artifact('skip-lint')",
+				Action:    "registry compute lint $resource.spec --linter spectral",
+			},
+		},
+		{
+			desc: "expiry",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/artifacts/search-index",
+				Refresh: &durationpb.Duration{
+					Seconds: 3600,
+				},
+				Expiry: &durationpb.Duration{
+					Seconds: 86400,
+				},
+				Action: "registry compute index $resource.api",
+			},
+		},
+		{
+			desc: "named dependency",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+					{
+						Pattern: "artifacts/custom-styleguide",
+						Name:    "styleguide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+		},
+		{
+			desc: "action template",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+					{
+						Pattern: "artifacts/custom-styleguide",
+						Name:    "styleguide",
+					},
+				},
+				Action:         `registry compute lint {{.Spec}} --linter {{or (index .Labels "linter") "spectral"}} --styleguide {{.Dependencies.styleguide}}`,
+				ActionTemplate: true,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -289,6 +400,140 @@ func TestValidateGeneratedResourceEntryError(t *testing.T) {
 				Action:  "registry generate summary $resource.version",
 			},
 		},
+		{
+			desc: "malformed condition",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Condition: "has_artifact('approved'",
+				Action:    "registry compute lint $resource.spec --linter spectral",
+			},
+		},
+		{
+			desc: "non-boolean condition",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Condition: "'approved'",
+				Action:    "registry compute lint $resource.spec --linter spectral",
+			},
+		},
+		{
+			desc: "unknown function in condition",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Condition: "has_label('approved')",
+				Action:    "registry compute lint $resource.spec --linter spectral",
+			},
+		},
+		{
+			desc: "zero expiry",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/artifacts/search-index",
+				Refresh: &durationpb.Duration{
+					Seconds: 3600,
+				},
+				Expiry: &durationpb.Duration{},
+				Action: "registry compute index $resource.api",
+			},
+		},
+		{
+			desc: "reference to unnamed dependency in action",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+					{
+						Pattern: "artifacts/custom-styleguide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+		},
+		{
+			desc: "duplicate dependency names",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "artifacts/custom-styleguide",
+						Name:    "styleguide",
+					},
+					{
+						Pattern: "artifacts/other-styleguide",
+						Name:    "styleguide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+		},
+		{
+			desc: "invalid dependency name",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "artifacts/custom-styleguide",
+						Name:    "style-guide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec",
+			},
+		},
+		{
+			desc: "named dependency matching multiple resources",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/conformance",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "artifacts/-",
+						Name:    "styleguide",
+					},
+				},
+				Action: "registry compute conformance $resource.spec --styleguide $dependency.styleguide",
+			},
+		},
+		{
+			desc: "unparseable action template",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Action:         "registry compute lint {{.Spec",
+				ActionTemplate: true,
+			},
+		},
+		{
+			desc: "unknown field in action template",
+			generatedResource: &rpc.GeneratedResource{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+				Dependencies: []*rpc.Dependency{
+					{
+						Pattern: "$resource.spec",
+					},
+				},
+				Action:         "registry compute lint {{.Linter}}",
+				ActionTemplate: true,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -299,3 +544,109 @@ func TestValidateGeneratedResourceEntryError(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateManifestOverlappingEntries(t *testing.T) {
+	entry := func(pattern, filter string) *rpc.GeneratedResource {
+		return &rpc.GeneratedResource{
+			Pattern: pattern,
+			Filter:  filter,
+			Dependencies: []*rpc.Dependency{
+				{Pattern: "$resource.api"},
+			},
+			Action: "registry compute lint $resource.api",
+		}
+	}
+	tests := []struct {
+		desc      string
+		resources []*rpc.GeneratedResource
+		wantErrs  int
+	}{
+		{
+			desc: "different artifacts",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint-spectral", ""),
+				entry("apis/-/versions/-/specs/-/artifacts/lint-gnostic", ""),
+			},
+		},
+		{
+			desc: "different collections",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+				entry("apis/-/versions/-/artifacts/lint", ""),
+			},
+		},
+		{
+			desc: "different filters",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", "mime_type.contains('openapi')"),
+				entry("apis/-/versions/-/specs/-/artifacts/lint", "mime_type.contains('protobuf')"),
+			},
+		},
+		{
+			desc: "different specific resources",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/petstore/versions/-/specs/-/artifacts/lint", ""),
+				entry("apis/registry/versions/-/specs/-/artifacts/lint", ""),
+			},
+		},
+		{
+			desc: "same pattern",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+			},
+			wantErrs: 1,
+		},
+		{
+			desc: "wildcard overlaps specific resource",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/petstore/versions/-/specs/-/artifacts/lint", ""),
+				entry("apis/-/versions/1.0.0/specs/-/artifacts/lint", ""),
+			},
+			wantErrs: 1,
+		},
+		{
+			desc: "filter on one entry",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", "mime_type.contains('openapi')"),
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+			},
+			wantErrs: 1,
+		},
+		{
+			desc: "every pair is reported",
+			resources: []*rpc.GeneratedResource{
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+				entry("apis/-/versions/-/specs/openapi/artifacts/lint", ""),
+				entry("apis/-/versions/-/specs/-/artifacts/lint", ""),
+			},
+			wantErrs: 3,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			manifest := &rpc.Manifest{Id: "test", GeneratedResources: test.resources}
+			errs := ValidateManifest("projects/demo/locations/global", manifest)
+			if len(errs) != test.wantErrs {
+				t.Errorf("ValidateManifest() returned %d errors, want %d: %v", len(errs), test.wantErrs, errs)
+			}
+		})
+	}
+}
+
+func TestValidateManifestMaxActionsPerApi(t *testing.T) {
+	for _, test := range []struct {
+		perApi   int32
+		wantErrs int
+	}{
+		{perApi: 0, wantErrs: 0},
+		{perApi: 5, wantErrs: 0},
+		{perApi: -1, wantErrs: 1},
+	} {
+		manifest := &rpc.Manifest{Id: "test", MaxActionsPerApi: test.perApi}
+		if errs := ValidateManifest("projects/demo/locations/global", manifest); len(errs) != test.wantErrs {
+			t.Errorf("ValidateManifest() with max_actions_per_api %d returned %d errors, want %d: %v", test.perApi, len(errs), test.wantErrs, errs)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// Pipeline is a neutral representation of a set of actions that can be
+// marshaled to YAML and adapted to the pipelines of CI systems, such as
+// Tekton pipelines or Argo workflows.
+type Pipeline struct {
+	Tasks []*PipelineTask `yaml:"tasks"`
+}
+
+// PipelineTask is a step of a Pipeline that executes one action.
+type PipelineTask struct {
+	// Name identifies the task in the pipeline. Names are lowercase
+	// alphanumeric strings and '-', which CI systems accept as task names.
+	Name string `yaml:"name"`
+	// Command is the command of the action.
+	Command string `yaml:"command"`
+	// GeneratedResource is the resource that the command generates.
+	GeneratedResource string `yaml:"generatedResource"`
+	// DependsOn lists the names of the tasks that must succeed before this task runs.
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+	// RequiresReceipt is true if the command doesn't store GeneratedResource.
+	// Runners should create a receipt artifact after the command succeeds,
+	// and can use it to verify that the task produced its output.
+	RequiresReceipt bool `yaml:"requiresReceipt,omitempty"`
+	// Labels should be set on GeneratedResource after the command succeeds.
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+// NewPipeline returns a pipeline with a task for each action, in order.
+// Tasks depend on the tasks that generate their dependencies, which are
+// found with the dependencies of the entries of manifest that generate them.
+// For example, if an entry that generates scores depends on
+// "$resource.spec/artifacts/lint-spectral", the task that computes the score
+// of a spec depends on the task that lints the same spec.
+func NewPipeline(manifest *rpc.Manifest, actions []*Action) *Pipeline {
+	tasks := make([]*PipelineTask, len(actions))
+	for i, a := range actions {
+		tasks[i] = &PipelineTask{
+			Name:              fmt.Sprintf("action-%d", i+1),
+			Command:           a.Command,
+			GeneratedResource: a.GeneratedResource,
+			RequiresReceipt:   a.RequiresReceipt,
+			Labels:            a.Labels,
+		}
+	}
+
+	for i, a := range actions {
+		dependencies := actionDependencies(manifest, a)
+		for j, other := range actions {
+			if i == j {
+				continue
+			}
+			for _, d := range dependencies {
+				if ok, _, err := names.Match(d, other.GeneratedResource); err == nil && ok {
+					tasks[i].DependsOn = append(tasks[i].DependsOn, tasks[j].Name)
+					break
+				}
+			}
+		}
+	}
+	return &Pipeline{Tasks: tasks}
+}
+
+// actionDependencies returns the patterns of the dependencies of the resource
+// that action generates, using the entries of manifest that can generate it.
+func actionDependencies(manifest *rpc.Manifest, action *Action) []string {
+	generated := withoutRevisions(action.GeneratedResource)
+	resourceName, err := patterns.ParseResourcePattern(generated)
+	if err != nil {
+		return nil
+	}
+	dependencies := make([]string, 0)
+	for _, resource := range manifest.GetGeneratedResources() {
+		// Entry patterns don't include the project and location.
+		if ok, _, err := names.Match(resource.Pattern, generated); err != nil || !ok {
+			continue
+		}
+		for _, d := range resource.Dependencies {
+			name, err := patterns.SubstituteReferenceEntity(d.Pattern, resourceName)
+			if err != nil {
+				continue
+			}
+			dependencies = append(dependencies, withoutRevisions(name.String()))
+		}
+	}
+	return dependencies
+}
+
+// withoutRevisions removes revision IDs from the segments of a resource name.
+func withoutRevisions(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = strings.Split(s, "@")[0]
+	}
+	return strings.Join(segments, "/")
+}
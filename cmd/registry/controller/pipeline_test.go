@@ -0,0 +1,116 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewPipeline(t *testing.T) {
+	const (
+		spec1 = "projects/pipeline-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+		spec2 = "projects/pipeline-test/locations/global/apis/petstore/versions/1.1.0/specs/openapi.yaml"
+	)
+	manifest := &rpc.Manifest{
+		Id: "pipeline-test",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute lint $resource.spec --linter spectral",
+			},
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/score-lint",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec/artifacts/lint-spectral"}},
+				Action:       "registry compute score $resource.spec",
+			},
+			{
+				Pattern:      "apis/-/artifacts/summary",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.api/versions/-/specs/-/artifacts/score-lint"}},
+				Action:       "publish-summary $resource.api",
+				Receipt:      true,
+			},
+		},
+	}
+	actions := []*Action{
+		{
+			Command:           "registry compute lint " + spec1 + "@aaaa --linter spectral",
+			GeneratedResource: spec1 + "@aaaa/artifacts/lint-spectral",
+		},
+		{
+			Command:           "registry compute lint " + spec2 + "@bbbb --linter spectral",
+			GeneratedResource: spec2 + "@bbbb/artifacts/lint-spectral",
+		},
+		{
+			Command:           "registry compute score " + spec1,
+			GeneratedResource: spec1 + "/artifacts/score-lint",
+			Labels:            map[string]string{"rule": "score"},
+		},
+		{
+			Command:           "publish-summary projects/pipeline-test/locations/global/apis/petstore",
+			GeneratedResource: "projects/pipeline-test/locations/global/apis/petstore/artifacts/summary",
+			RequiresReceipt:   true,
+		},
+	}
+
+	got := NewPipeline(manifest, actions)
+	want := &Pipeline{
+		Tasks: []*PipelineTask{
+			{
+				Name:              "action-1",
+				Command:           actions[0].Command,
+				GeneratedResource: actions[0].GeneratedResource,
+			},
+			{
+				Name:              "action-2",
+				Command:           actions[1].Command,
+				GeneratedResource: actions[1].GeneratedResource,
+			},
+			{
+				Name:              "action-3",
+				Command:           actions[2].Command,
+				GeneratedResource: actions[2].GeneratedResource,
+				DependsOn:         []string{"action-1"},
+				Labels:            map[string]string{"rule": "score"},
+			},
+			{
+				Name:              "action-4",
+				Command:           actions[3].Command,
+				GeneratedResource: actions[3].GeneratedResource,
+				DependsOn:         []string{"action-3"},
+				RequiresReceipt:   true,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPipeline() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	b, err := yaml.Marshal(got)
+	if err != nil {
+		t.Fatalf("Failed to marshal pipeline: %s", err)
+	}
+	roundTrip := &Pipeline{}
+	if err := yaml.Unmarshal(b, roundTrip); err != nil {
+		t.Fatalf("Failed to unmarshal pipeline: %s", err)
+	}
+	if diff := cmp.Diff(got, roundTrip); diff != "" {
+		t.Errorf("Pipeline YAML didn't round trip (-want +got):\n%s\n%s", diff, b)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+)
+
+// PruneOrphans returns actions that delete generated resources whose
+// dependencies no longer exist, e.g. lint results of a deleted spec.
+// Nothing is deleted until the actions are executed, and they are marked
+// as destructive so that they can be reviewed first.
+// At most maxActions actions are returned.
+func PruneOrphans(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	manifest *rpc.Manifest,
+	maxActions int) []*Action {
+	var actions []*Action
+	for _, resource := range manifest.GeneratedResources {
+		errs := validateGeneratedResourceEntry(patterns.ProjectLocation("projects/"+projectID), resource)
+		if len(errs) > 0 {
+			log.FromContext(ctx).Debugf("Skipping resource: %q", resource)
+			continue
+		}
+
+		newActions, err := pruneManifestResource(ctx, client, projectID, resource)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Debugf("Skipping resource: %q", resource)
+			continue
+		}
+		actions = append(actions, newActions...)
+
+		if len(actions) >= maxActions {
+			log.FromContext(ctx).Debugf("Reached max actions limit %d", maxActions)
+			break
+		}
+	}
+
+	if len(actions) > maxActions {
+		actions = actions[:maxActions]
+	}
+	return actions
+}
+
+func pruneManifestResource(
+	ctx context.Context,
+	client listingClient,
+	projectID string,
+	generatedResource *rpc.GeneratedResource) ([]*Action, error) {
+	// Resources that are only refreshed periodically can't become orphans.
+	if len(generatedResource.Dependencies) == 0 {
+		return nil, nil
+	}
+
+	resourcePattern := fmt.Sprintf("%s/%s", patterns.ProjectLocation("projects/"+projectID), generatedResource.Pattern)
+	dependencyMaps := make([]map[string]time.Time, 0, len(generatedResource.Dependencies))
+	for _, dependency := range generatedResource.Dependencies {
+		// Dependency filters select the resources that trigger updates. A resource
+		// whose dependency exists but doesn't match the filter isn't an orphan.
+		unfiltered := &rpc.Dependency{Pattern: dependency.Pattern}
+		dMap, err := generateDependencyMap(ctx, client, resourcePattern, unfiltered)
+		if err != nil {
+			return nil, fmt.Errorf("error while generating dependency map for %v: %s", dependency, err)
+		}
+		dependencyMaps = append(dependencyMaps, dMap)
+	}
+
+	resourceList, err := listResources(ctx, client, resourcePattern, generatedResource.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]*Action, 0)
+	for _, targetResource := range resourceList {
+		orphaned, err := isOrphan(targetResource.ResourceName(), dependencyMaps, generatedResource)
+		if err != nil {
+			log.Errorf(ctx, "%s", err)
+			continue
+		}
+		if orphaned {
+			actions = append(actions, &Action{
+				Command:           fmt.Sprintf("registry delete %s", targetResource.ResourceName().String()),
+				GeneratedResource: targetResource.ResourceName().String(),
+				Destructive:       true,
+			})
+		}
+	}
+	return actions, nil
+}
+
+// A generated resource is an orphan if any of its dependencies is missing.
+func isOrphan(
+	targetResourceName patterns.ResourceName,
+	dependencyMaps []map[string]time.Time,
+	generatedResource *rpc.GeneratedResource) (bool, error) {
+	for i, dependency := range generatedResource.Dependencies {
+		entityKey, err := patterns.GetReferenceEntityValue(dependency.Pattern, targetResourceName)
+		if err != nil {
+			return false, fmt.Errorf("cannot match resource with dependency. Error: %s", err.Error())
+		}
+		if _, ok := dependencyMaps[i][entityKey]; !ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
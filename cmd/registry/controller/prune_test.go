@@ -0,0 +1,171 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPruneOrphans(t *testing.T) {
+	tests := []struct {
+		desc       string
+		seed       []seeder.RegistryResource
+		filter     string
+		maxActions int
+		want       []*Action
+	}{
+		{
+			desc: "no orphans",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/artifacts/vocabulary",
+				},
+			},
+			maxActions: 10,
+			want:       []*Action{},
+		},
+		{
+			desc: "orphaned artifacts",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/artifacts/vocabulary",
+				},
+				&rpc.ApiVersion{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.1",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.1/artifacts/vocabulary",
+				},
+				&rpc.ApiVersion{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.1.0",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.1.0/artifacts/vocabulary",
+				},
+			},
+			maxActions: 10,
+			want: []*Action{
+				{
+					Command:           "registry delete projects/prune-test/locations/global/apis/petstore/versions/1.0.1/artifacts/vocabulary",
+					GeneratedResource: "projects/prune-test/locations/global/apis/petstore/versions/1.0.1/artifacts/vocabulary",
+					Destructive:       true,
+				},
+				{
+					Command:           "registry delete projects/prune-test/locations/global/apis/petstore/versions/1.1.0/artifacts/vocabulary",
+					GeneratedResource: "projects/prune-test/locations/global/apis/petstore/versions/1.1.0/artifacts/vocabulary",
+					Destructive:       true,
+				},
+			},
+		},
+		{
+			desc: "filtered dependency",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name:     "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+					MimeType: "application/x.openapi+gzip;version=3.0.0",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.0/artifacts/vocabulary",
+				},
+			},
+			filter:     "mime_type.contains('protobuf')",
+			maxActions: 10,
+			want:       []*Action{},
+		},
+		{
+			desc: "max actions",
+			seed: []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.0.1/artifacts/vocabulary",
+				},
+				&rpc.Artifact{
+					Name: "projects/prune-test/locations/global/apis/petstore/versions/1.1.0/artifacts/vocabulary",
+				},
+			},
+			maxActions: 1,
+		},
+	}
+
+	const projectID = "prune-test"
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, projectID)
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, projectID) })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+			lister := &RegistryLister{RegistryClient: registryClient}
+
+			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			manifest := &rpc.Manifest{
+				Id: "prune-test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern: "apis/-/versions/-/artifacts/vocabulary",
+						Dependencies: []*rpc.Dependency{
+							{
+								Pattern: "$resource.version/specs/-",
+								Filter:  test.filter,
+							},
+						},
+						Action: "registry compute vocabulary $resource.version",
+					},
+				},
+			}
+			actions := PruneOrphans(ctx, lister, projectID, manifest, test.maxActions)
+
+			if test.want == nil {
+				if len(actions) != test.maxActions {
+					t.Errorf("PruneOrphans(%+v) returned %d actions, want %d", manifest, len(actions), test.maxActions)
+				}
+				return
+			}
+			if diff := cmp.Diff(test.want, actions, sortActions, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("PruneOrphans(%+v) returned unexpected diff (-want +got):\n%s", manifest, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// SelectGeneratedResources returns a copy of manifest that contains only the
+// generated resources that match one of selectors, so that a few entries of a
+// large manifest can be processed on their own.
+// A selector matches a generated resource if it is equal to the pattern of the
+// resource or to the artifact ID at the end of the pattern, e.g. "complexity"
+// matches "apis/-/versions/-/specs/-/artifacts/complexity".
+// It is an error for a selector to match no generated resources.
+func SelectGeneratedResources(manifest *rpc.Manifest, selectors []string) (*rpc.Manifest, error) {
+	selected := proto.Clone(manifest).(*rpc.Manifest)
+	if len(selectors) == 0 {
+		return selected, nil
+	}
+	matched := make(map[string]bool, len(selectors))
+	selected.GeneratedResources = nil
+	for _, resource := range manifest.GeneratedResources {
+		match := false
+		for _, s := range selectors {
+			if selectorMatches(s, resource) {
+				matched[s] = true
+				match = true
+			}
+		}
+		if match {
+			selected.GeneratedResources = append(selected.GeneratedResources, proto.Clone(resource).(*rpc.GeneratedResource))
+		}
+	}
+	for _, s := range selectors {
+		if !matched[s] {
+			return nil, fmt.Errorf("selector %q does not match any generated resource in manifest %q", s, manifest.GetId())
+		}
+	}
+	return selected, nil
+}
+
+func selectorMatches(selector string, resource *rpc.GeneratedResource) bool {
+	pattern := resource.GetPattern()
+	if selector == pattern {
+		return true
+	}
+	i := strings.LastIndex(pattern, "/artifacts/")
+	return i >= 0 && pattern[i+len("/artifacts/"):] == selector
+}
@@ -0,0 +1,107 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestSelectGeneratedResources(t *testing.T) {
+	complexity := &rpc.GeneratedResource{
+		Pattern: "apis/-/versions/-/specs/-/artifacts/complexity",
+		Action:  "registry compute complexity $resource.spec",
+	}
+	lint := &rpc.GeneratedResource{
+		Pattern: "apis/-/versions/-/specs/-/artifacts/lint-spectral",
+		Action:  "registry compute lint $resource.spec --linter spectral",
+	}
+	versionComplexity := &rpc.GeneratedResource{
+		Pattern: "apis/-/versions/-/artifacts/complexity",
+		Action:  "registry compute complexity $resource.version",
+	}
+	manifest := &rpc.Manifest{
+		Id:                 "test-manifest",
+		GeneratedResources: []*rpc.GeneratedResource{complexity, lint, versionComplexity},
+	}
+
+	tests := []struct {
+		desc      string
+		selectors []string
+		want      []*rpc.GeneratedResource
+	}{
+		{
+			desc: "no selectors",
+			want: []*rpc.GeneratedResource{complexity, lint, versionComplexity},
+		},
+		{
+			desc:      "artifact id",
+			selectors: []string{"lint-spectral"},
+			want:      []*rpc.GeneratedResource{lint},
+		},
+		{
+			desc:      "artifact id of several resources",
+			selectors: []string{"complexity"},
+			want:      []*rpc.GeneratedResource{complexity, versionComplexity},
+		},
+		{
+			desc:      "pattern",
+			selectors: []string{"apis/-/versions/-/artifacts/complexity"},
+			want:      []*rpc.GeneratedResource{versionComplexity},
+		},
+		{
+			desc:      "several selectors",
+			selectors: []string{"lint-spectral", "apis/-/versions/-/artifacts/complexity"},
+			want:      []*rpc.GeneratedResource{lint, versionComplexity},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := SelectGeneratedResources(manifest, test.selectors)
+			if err != nil {
+				t.Fatalf("SelectGeneratedResources(%v) returned error: %s", test.selectors, err)
+			}
+			want := &rpc.Manifest{Id: "test-manifest", GeneratedResources: test.want}
+			if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("SelectGeneratedResources(%v) returned unexpected diff (-want +got):\n%s", test.selectors, diff)
+			}
+		})
+	}
+
+	if len(manifest.GeneratedResources) != 3 {
+		t.Errorf("SelectGeneratedResources() modified the manifest: %v", manifest)
+	}
+}
+
+func TestSelectGeneratedResourcesError(t *testing.T) {
+	manifest := &rpc.Manifest{
+		Id: "test-manifest",
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern: "apis/-/versions/-/specs/-/artifacts/complexity",
+				Action:  "registry compute complexity $resource.spec",
+			},
+		},
+	}
+	for _, selectors := range [][]string{{"lint"}, {"complexity", "specs/-/artifacts/complexity"}} {
+		if _, err := SelectGeneratedResources(manifest, selectors); err == nil {
+			t.Errorf("SelectGeneratedResources(%v) returned no error", selectors)
+		}
+	}
+}
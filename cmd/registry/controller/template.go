@@ -0,0 +1,182 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+)
+
+// actionTemplateData holds the values that are available to action templates.
+type actionTemplateData struct {
+	// Resource is the name of the generated resource.
+	Resource string
+	// Project, Api, Version, Spec and Artifact are the names that $resource references are replaced with.
+	Project  string
+	Api      string
+	Version  string
+	Spec     string
+	Artifact string
+	// Labels and Annotations are the metadata of the resource that the generated resource belongs to.
+	Labels      map[string]string
+	Annotations map[string]string
+	// Dependencies holds the names of named dependencies, keyed by dependency name.
+	Dependencies map[string]string
+}
+
+func newActionTemplateData(generatedResource *rpc.GeneratedResource, resourceName patterns.ResourceName) (*actionTemplateData, error) {
+	dependencies, err := namedDependencies(generatedResource.Dependencies, resourceName)
+	if err != nil {
+		return nil, err
+	}
+	resource := patterns.NewResourceContext(resourceName)
+	return &actionTemplateData{
+		Resource:     resourceName.String(),
+		Project:      resource.Project,
+		Api:          resource.Api,
+		Version:      resource.Version,
+		Spec:         resource.Spec,
+		Artifact:     resource.Artifact,
+		Labels:       map[string]string{},
+		Annotations:  map[string]string{},
+		Dependencies: dependencies,
+	}, nil
+}
+
+func parseActionTemplate(action string) (*template.Template, error) {
+	return template.New("action").Option("missingkey=zero").Parse(action)
+}
+
+func executeActionTemplate(tmpl *template.Template, data *actionTemplateData) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// validateActionTemplate checks that the action of generatedResource can be
+// parsed and executed for the target resource pattern.
+func validateActionTemplate(generatedResource *rpc.GeneratedResource, targetResource patterns.ResourceName) error {
+	tmpl, err := parseActionTemplate(generatedResource.Action)
+	if err != nil {
+		return fmt.Errorf("invalid action template: %s", err)
+	}
+	data, err := newActionTemplateData(generatedResource, targetResource)
+	if err != nil {
+		return fmt.Errorf("invalid action template: %s", err)
+	}
+	if _, err := executeActionTemplate(tmpl, data); err != nil {
+		return fmt.Errorf("invalid action template: %s", err)
+	}
+	return nil
+}
+
+// renderActionTemplates replaces the commands of actions with the action
+// template of generatedResource executed for each generated resource.
+// Metadata of each parent resource is fetched once. Actions whose templates
+// fail to execute are skipped.
+func renderActionTemplates(
+	ctx context.Context,
+	client listingClient,
+	generatedResource *rpc.GeneratedResource,
+	actions []*Action) ([]*Action, error) {
+	tmpl, err := parseActionTemplate(generatedResource.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[string]patterns.ResourceInstance)
+	rendered := make([]*Action, 0, len(actions))
+	for _, a := range actions {
+		resource, err := patterns.ParseResourcePattern(a.GeneratedResource)
+		if err != nil {
+			return nil, err
+		}
+		data, err := newActionTemplateData(generatedResource, resource)
+		if err != nil {
+			return nil, err
+		}
+
+		parentName := resource.ParentName()
+		parent, ok := parents[parentName.String()]
+		if !ok {
+			parent, err = getResource(ctx, client, parentName)
+			if err != nil {
+				return nil, err
+			}
+			parents[parentName.String()] = parent
+		}
+		data.Labels, data.Annotations = resourceMetadata(parent)
+
+		cmd, err := executeActionTemplate(tmpl, data)
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Debugf("Skipping %s: failed to execute action template", a.GeneratedResource)
+			continue
+		}
+		rendered = append(rendered, &Action{
+			Command:           cmd,
+			GeneratedResource: a.GeneratedResource,
+			RequiresReceipt:   a.RequiresReceipt,
+			Labels:            a.Labels,
+		})
+	}
+	return rendered, nil
+}
+
+// getResource returns the resource with the specified name, or nil if it isn't found.
+// Projects are not returned since they are not available through the listing client.
+func getResource(ctx context.Context, client listingClient, name patterns.ResourceName) (patterns.ResourceInstance, error) {
+	pattern := name.String()
+	if spec, ok := name.(patterns.SpecName); ok {
+		// Metadata is read from the current revision.
+		pattern = spec.Name.String()
+	}
+	resources, err := listResources(ctx, client, pattern, "")
+	if err != nil || len(resources) == 0 {
+		return nil, err
+	}
+	return resources[0], nil
+}
+
+// resourceMetadata returns the labels and annotations of a resource.
+// Resources without metadata have empty maps so that templates can index them.
+func resourceMetadata(resource patterns.ResourceInstance) (map[string]string, map[string]string) {
+	switch r := resource.(type) {
+	case patterns.ApiResource:
+		return nonNilMap(r.Api.GetLabels()), nonNilMap(r.Api.GetAnnotations())
+	case patterns.VersionResource:
+		return nonNilMap(r.Version.GetLabels()), nonNilMap(r.Version.GetAnnotations())
+	case patterns.SpecResource:
+		return nonNilMap(r.Spec.GetLabels()), nonNilMap(r.Spec.GetAnnotations())
+	case patterns.ArtifactResource:
+		return nonNilMap(r.Artifact.GetLabels()), nonNilMap(r.Artifact.GetAnnotations())
+	default:
+		return map[string]string{}, map[string]string{}
+	}
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/ghodss/yaml"
+	"google.golang.org/protobuf/encoding/protojson"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// ValidateManifestYAML parses a manifest from YAML and validates it without
+// connecting to a registry. The YAML may hold a Manifest message directly or
+// be in the "kind: Manifest" form used by "registry apply".
+// The manifest is checked with ValidateManifest and all problems found are
+// returned. Problems with generated resource entries are prefixed with the
+// line and column where the entry begins.
+func ValidateManifestYAML(parent string, yamlBytes []byte) (*rpc.Manifest, []error) {
+	manifest, entries, err := parseManifestYAML(yamlBytes)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	errs := ValidateManifest(parent, manifest)
+	for i, err := range errs {
+		var entryErr *entryError
+		if !errors.As(err, &entryErr) {
+			continue
+		}
+		prefix := ""
+		if entries != nil && entryErr.index < len(entries.Content) {
+			node := entries.Content[entryErr.index]
+			prefix = fmt.Sprintf("line %d, column %d: ", node.Line, node.Column)
+		}
+		errs[i] = fmt.Errorf("%sinvalid entry %q: %s", prefix, entryErr.resource.Pattern, entryErr.err)
+	}
+	return manifest, errs
+}
+
+// parseManifestYAML parses a manifest in either of the forms accepted by
+// ValidateManifestYAML. It also returns the YAML node that holds the list of
+// generated resources, which is nil if the manifest has none.
+func parseManifestYAML(yamlBytes []byte) (*rpc.Manifest, *yamlv3.Node, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(yamlBytes, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil, fmt.Errorf("manifest is empty")
+	}
+
+	root := doc.Content[0]
+	id := ""
+	if kind := mappingValue(root, "kind"); kind != nil && mappingValue(root, "apiVersion") != nil {
+		if kind.Value != "Manifest" {
+			return nil, nil, fmt.Errorf("line %d, column %d: unexpected kind %q, want \"Manifest\"", kind.Line, kind.Column, kind.Value)
+		}
+		if name := mappingValue(mappingValue(root, "metadata"), "name"); name != nil {
+			id = name.Value
+		}
+		root = mappingValue(root, "data")
+		if root == nil {
+			return nil, nil, fmt.Errorf("manifest has no data")
+		}
+	}
+
+	b, err := yamlv3.Marshal(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	jsonBytes, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest := &rpc.Manifest{}
+	if err := protojson.Unmarshal(jsonBytes, manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest: %s", err)
+	}
+	if manifest.Id == "" {
+		manifest.Id = id
+	}
+
+	entries := mappingValue(root, "generated_resources")
+	if entries == nil {
+		entries = mappingValue(root, "generatedResources")
+	}
+	return manifest, entries, nil
+}
+
+// mappingValue returns the value for key in a YAML mapping node, or nil if not found.
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,337 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestValidateManifestYAML(t *testing.T) {
+	tests := []struct {
+		desc string
+		yaml string
+		want *rpc.Manifest
+	}{
+		{
+			desc: "manifest message",
+			yaml: `id: test
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+`,
+			want: &rpc.Manifest{
+				Id: "test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern:      "apis/-/versions/-/specs/-/artifacts/complexity",
+						Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+						Action:       "registry compute complexity $resource.spec",
+					},
+				},
+			},
+		},
+		{
+			desc: "apply format",
+			yaml: `apiVersion: apigeeregistry/v1
+kind: Manifest
+metadata:
+  name: test
+data:
+  generatedResources:
+    - pattern: apis/-/artifacts/vocabulary
+      dependencies:
+        - pattern: $resource.api/versions/-/specs/-
+      action: "registry compute vocabulary $resource.api"
+`,
+			want: &rpc.Manifest{
+				Id: "test",
+				GeneratedResources: []*rpc.GeneratedResource{
+					{
+						Pattern:      "apis/-/artifacts/vocabulary",
+						Dependencies: []*rpc.Dependency{{Pattern: "$resource.api/versions/-/specs/-"}},
+						Action:       "registry compute vocabulary $resource.api",
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, errs := ValidateManifestYAML("projects/-/locations/global", []byte(test.yaml))
+			if len(errs) > 0 {
+				t.Fatalf("ValidateManifestYAML() returned errors: %v", errs)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ValidateManifestYAML() returned unexpected manifest (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateManifestYAMLErrors(t *testing.T) {
+	tests := []struct {
+		desc string
+		yaml string
+		want []string
+	}{
+		{
+			desc: "syntax error",
+			yaml: "id: test\ngenerated_resources: [\n",
+			want: []string{"yaml: line 2"},
+		},
+		{
+			desc: "unknown field",
+			yaml: "id: test\nresources: []\n",
+			want: []string{"unknown field"},
+		},
+		{
+			desc: "wrong kind",
+			yaml: "apiVersion: apigeeregistry/v1\nkind: Lifecycle\ndata: {}\n",
+			want: []string{`line 2, column 7: unexpected kind "Lifecycle"`},
+		},
+		{
+			desc: "all entry errors are reported",
+			yaml: `id: test
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+  - pattern: apis/-/artifacts/vocabulary
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute vocabulary $resource.spec"
+  - pattern: apis/-/versions/-/specs/-
+    action: "registry compute lint $resource.spec"
+`,
+			want: []string{
+				`line 7, column 5: invalid entry "apis/-/artifacts/vocabulary": invalid reference in dependency pattern`,
+				`line 7, column 5: invalid entry "apis/-/artifacts/vocabulary": invalid reference in action`,
+				`line 11, column 5: invalid entry "apis/-/versions/-/specs/-": invalid generatedResource pattern`,
+			},
+		},
+		{
+			desc: "manifest-level errors are reported",
+			yaml: `id: test
+max_actions_per_api: -1
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+`,
+			want: []string{"invalid max_actions_per_api: -1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, errs := ValidateManifestYAML("projects/-/locations/global", []byte(test.yaml))
+			if len(errs) != len(test.want) {
+				t.Fatalf("ValidateManifestYAML() returned %d errors, want %d: %v", len(errs), len(test.want), errs)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), test.want[i]) {
+					t.Errorf("ValidateManifestYAML() error %q does not contain %q", err, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateManifestYAMLTestdata(t *testing.T) {
+	yamlBytes, err := os.ReadFile(filepath.Join("testdata", "manifest.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, errs := ValidateManifestYAML("projects/-/locations/global", yamlBytes); len(errs) > 0 {
+		t.Errorf("ValidateManifestYAML() returned errors: %v", errs)
+	}
+}
+
+func TestLoadManifests(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	lint := write("lint.yaml", `id: test
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/lint
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute lint $resource.spec"
+`)
+	vocabulary := write("vocabulary.yaml", `apiVersion: apigeeregistry/v1
+kind: Manifest
+metadata:
+  name: test
+data:
+  generatedResources:
+    - pattern: apis/-/artifacts/vocabulary
+      dependencies:
+        - pattern: $resource.api/versions/-/specs/-
+      action: "registry compute vocabulary $resource.api"
+`)
+	complexity := write("complexity.yaml", `display_name: Analysis
+max_actions_per_api: 3
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+`)
+
+	got, err := LoadManifests(lint, vocabulary, complexity)
+	if err != nil {
+		t.Fatalf("LoadManifests() returned error: %s", err)
+	}
+	want := &rpc.Manifest{
+		Id:               "test",
+		DisplayName:      "Analysis",
+		MaxActionsPerApi: 3,
+		GeneratedResources: []*rpc.GeneratedResource{
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/lint",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute lint $resource.spec",
+			},
+			{
+				Pattern:      "apis/-/artifacts/vocabulary",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.api/versions/-/specs/-"}},
+				Action:       "registry compute vocabulary $resource.api",
+			},
+			{
+				Pattern:      "apis/-/versions/-/specs/-/artifacts/complexity",
+				Dependencies: []*rpc.Dependency{{Pattern: "$resource.spec"}},
+				Action:       "registry compute complexity $resource.spec",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("LoadManifests() returned unexpected manifest (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadManifestsErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	lint := write("lint.yaml", `id: test
+generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/lint
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute lint $resource.spec"
+`)
+	duplicate := write("duplicate.yaml", `generated_resources:
+  - pattern: apis/-/versions/-/specs/-/artifacts/complexity
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute complexity $resource.spec"
+  - pattern: apis/-/versions/-/specs/-/artifacts/lint
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute lint $resource.spec --linter spectral"
+`)
+	invalid := write("invalid.yaml", `generated_resources:
+  - pattern: apis/-/artifacts/vocabulary
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute vocabulary $resource.api"
+`)
+	otherID := write("other.yaml", "id: other\n")
+	limit := write("limit.yaml", "max_actions_per_api: 3\n")
+	otherLimit := write("other-limit.yaml", "max_actions_per_api: -1\n")
+	overlap := write("overlap.yaml", `generated_resources:
+  - pattern: apis/-/versions/-/specs/openapi/artifacts/lint
+    dependencies:
+      - pattern: $resource.spec
+    action: "registry compute lint $resource.spec"
+`)
+
+	tests := []struct {
+		desc  string
+		paths []string
+		want  string
+	}{
+		{
+			desc: "no files",
+			want: "no manifest files",
+		},
+		{
+			desc:  "missing file",
+			paths: []string{lint, filepath.Join(dir, "missing.yaml")},
+			want:  "missing.yaml",
+		},
+		{
+			desc:  "duplicate entry",
+			paths: []string{lint, duplicate},
+			want:  duplicate + `:6:5: duplicate entry "apis/-/versions/-/specs/-/artifacts/lint", first defined at ` + lint + ":3:5",
+		},
+		{
+			desc:  "invalid entry",
+			paths: []string{lint, invalid},
+			want:  invalid + `:2:5: invalid entry "apis/-/artifacts/vocabulary"`,
+		},
+		{
+			desc:  "conflicting ids",
+			paths: []string{lint, otherID},
+			want:  `manifest id "other" does not match id "test"`,
+		},
+		{
+			desc:  "conflicting manifest fields",
+			paths: []string{lint, limit, otherLimit},
+			want:  otherLimit + ": manifest max_actions_per_api -1 does not match max_actions_per_api 3 of " + limit,
+		},
+		{
+			desc:  "invalid manifest fields",
+			paths: []string{lint, otherLimit},
+			want:  "invalid max_actions_per_api: -1",
+		},
+		{
+			desc:  "overlapping entries",
+			paths: []string{lint, overlap},
+			want:  overlap + `:2:5: entry "apis/-/versions/-/specs/openapi/artifacts/lint" can generate the same resource as entry "apis/-/versions/-/specs/-/artifacts/lint" at ` + lint + ":3:5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := LoadManifests(test.paths...)
+			if err == nil {
+				t.Fatalf("LoadManifests(%v) succeeded but should have failed", test.paths)
+			}
+			if !strings.Contains(err.Error(), test.want) {
+				t.Errorf("LoadManifests(%v) returned error %q, want %q", test.paths, err, test.want)
+			}
+		})
+	}
+}
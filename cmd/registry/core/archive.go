@@ -0,0 +1,104 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnknownArchiveFormat is returned for archives that are neither zip nor gzipped tar.
+var ErrUnknownArchiveFormat = errors.New("unrecognized archive format, expected zip or gzipped tar")
+
+var (
+	zipMagic      = []byte("PK\x03\x04")
+	emptyZipMagic = []byte("PK\x05\x06")
+	gzipMagic     = []byte("\x1f\x8b")
+)
+
+// UnpackArchiveToPath decompresses a zip archive or a gzipped tar archive
+// to an output directory. The format is detected from the leading bytes of b.
+// It returns the paths of the files and folders that were written.
+func UnpackArchiveToPath(b []byte, dest string) ([]string, error) {
+	switch {
+	case bytes.HasPrefix(b, zipMagic), bytes.HasPrefix(b, emptyZipMagic):
+		return UnzipArchiveToPath(b, dest)
+	case bytes.HasPrefix(b, gzipMagic):
+		return UntarGzipArchiveToPath(b, dest)
+	default:
+		return nil, ErrUnknownArchiveFormat
+	}
+}
+
+// UntarGzipArchiveToPath decompresses a gzipped tar archive, writing all files
+// and folders within the archive to an output directory.
+// Entries other than regular files and folders, such as links, are skipped.
+// Archives that decompress to more than MaxDecompressedSize bytes are rejected.
+func UntarGzipArchiveToPath(b []byte, dest string) ([]string, error) {
+	var filenames []string
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return filenames, err
+	}
+	tr := tar.NewReader(zr)
+	remaining := int64(MaxDecompressedSize)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return filenames, nil
+		} else if err != nil {
+			return filenames, err
+		}
+		fpath := filepath.Join(dest, header.Name)
+		// Check for ZipSlip. More Info: http://bit.ly/2MsjAWE
+		if !strings.HasPrefix(fpath, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return filenames, fmt.Errorf("%s: illegal file path", fpath)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			filenames = append(filenames, fpath)
+			_ = os.MkdirAll(fpath, os.ModePerm)
+		case tar.TypeReg:
+			if header.Size > remaining {
+				return filenames, fmt.Errorf("archive decompresses to more than %d bytes", MaxDecompressedSize)
+			}
+			filenames = append(filenames, fpath)
+			if err = os.MkdirAll(filepath.Dir(fpath), os.ModePerm); err != nil {
+				return filenames, err
+			}
+			outFile, err := os.OpenFile(fpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return filenames, err
+			}
+			n, err := io.Copy(outFile, io.LimitReader(tr, remaining+1))
+			// Close the file without defer to close before next iteration of loop
+			outFile.Close()
+			if err != nil {
+				return filenames, err
+			}
+			if n > remaining {
+				return filenames, fmt.Errorf("archive decompresses to more than %d bytes", MaxDecompressedSize)
+			}
+			remaining -= n
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// tarGzipArchive returns a gzipped tar archive of the named files in the order given.
+// Names ending in "/" are stored as directories.
+func tarGzipArchive(t *testing.T, files ...ArchiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for _, f := range files {
+		header := &tar.Header{Name: f.Name, Mode: 0644, Size: int64(len(f.Contents)), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(f.Name, "/") {
+			header.Mode, header.Typeflag = 0755, tar.TypeDir
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("Setup: failed to add %s to archive: %s", f.Name, err)
+		}
+		if _, err := tw.Write(f.Contents); err != nil {
+			t.Fatalf("Setup: failed to write %s to archive: %s", f.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("Setup: failed to close archive: %s", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Setup: failed to close archive: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUnpackArchiveToPath(t *testing.T) {
+	files := []ArchiveFile{
+		{Name: "a/"},
+		{Name: "a/b.proto", Contents: []byte("b")},
+		{Name: "c.proto", Contents: []byte("c")},
+	}
+	tests := []struct {
+		desc     string
+		archive  []byte
+		want     []string
+		contents map[string]string
+	}{
+		{
+			desc:     "zip",
+			archive:  zipArchive(t, files...),
+			want:     []string{"a", "a/b.proto", "c.proto"},
+			contents: map[string]string{"a/b.proto": "b", "c.proto": "c"},
+		},
+		{
+			desc:     "gzipped tar",
+			archive:  tarGzipArchive(t, files...),
+			want:     []string{"a", "a/b.proto", "c.proto"},
+			contents: map[string]string{"a/b.proto": "b", "c.proto": "c"},
+		},
+		{
+			desc:    "empty zip",
+			archive: zipArchive(t),
+			want:    []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			dest := t.TempDir()
+			paths, err := UnpackArchiveToPath(test.archive, dest)
+			if err != nil {
+				t.Fatalf("UnpackArchiveToPath() returned error: %s", err)
+			}
+			got := make([]string, len(paths))
+			for i, p := range paths {
+				rel, err := filepath.Rel(dest, p)
+				if err != nil {
+					t.Fatalf("UnpackArchiveToPath() returned %s outside of %s", p, dest)
+				}
+				got[i] = filepath.ToSlash(rel)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("UnpackArchiveToPath() returned unexpected paths (-want +got):\n%s", diff)
+			}
+			for name, want := range test.contents {
+				b, err := os.ReadFile(filepath.Join(dest, name))
+				if err != nil {
+					t.Fatalf("UnpackArchiveToPath() did not write %s: %s", name, err)
+				}
+				if string(b) != want {
+					t.Errorf("UnpackArchiveToPath() wrote %q to %s, want %q", b, name, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUnpackArchiveToPathErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		archive []byte
+		want    error
+	}{
+		{
+			desc:    "unknown format",
+			archive: []byte("syntax = \"proto3\";"),
+			want:    ErrUnknownArchiveFormat,
+		},
+		{
+			desc:    "empty",
+			archive: []byte{},
+			want:    ErrUnknownArchiveFormat,
+		},
+		{
+			desc:    "illegal zip path",
+			archive: zipArchive(t, ArchiveFile{Name: "../escape.proto"}),
+		},
+		{
+			desc:    "illegal tar path",
+			archive: tarGzipArchive(t, ArchiveFile{Name: "../escape.proto"}),
+		},
+		{
+			desc:    "truncated gzip",
+			archive: gzipMagic,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := UnpackArchiveToPath(test.archive, t.TempDir())
+			if err == nil {
+				t.Fatalf("UnpackArchiveToPath() succeeded, want error")
+			}
+			if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("UnpackArchiveToPath() returned %q, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestUntarGzipArchiveToPathTooLarge(t *testing.T) {
+	t.Run("oversized entry", func(t *testing.T) {
+		dest := t.TempDir()
+		b := tarGzipArchive(t, ArchiveFile{Name: "a", Contents: make([]byte, MaxDecompressedSize+1)})
+		_, err := UntarGzipArchiveToPath(b, dest)
+		if err == nil || !strings.Contains(err.Error(), "archive decompresses to more than") {
+			t.Errorf("UntarGzipArchiveToPath() returned %v, want a size limit error", err)
+		}
+		if _, err := os.Stat(filepath.Join(dest, "a")); !os.IsNotExist(err) {
+			t.Errorf("UntarGzipArchiveToPath() wrote the oversized entry")
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		// Each file is under the limit, but together they exceed it.
+		half := make([]byte, MaxDecompressedSize/2+1)
+		b := tarGzipArchive(t,
+			ArchiveFile{Name: "a", Contents: half},
+			ArchiveFile{Name: "b", Contents: half},
+		)
+		_, err := UntarGzipArchiveToPath(b, t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), "archive decompresses to more than") {
+			t.Errorf("UntarGzipArchiveToPath() returned %v, want a size limit error", err)
+		}
+	})
+}
+
+func TestNewLintFromZippedProtosUnknownFormat(t *testing.T) {
+	_, err := NewLintFromZippedProtos("spec", []byte("syntax = \"proto3\";"))
+	if !errors.Is(err, ErrUnknownArchiveFormat) {
+		t.Errorf("NewLintFromZippedProtos() returned %v, want %q", err, ErrUnknownArchiveFormat)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// Lister lists registry resources.
+// Code that depends on a Lister instead of a *gapic.RegistryClient
+// can be tested with hand-written fakes instead of a registry server.
+// controller.RegistryLister is the implementation backed by a registry.
+type Lister interface {
+	ListAPIs(context.Context, names.Api, string, ApiHandler) error
+	ListVersions(context.Context, names.Version, string, VersionHandler) error
+	ListSpecs(context.Context, names.Spec, string, SpecHandler) error
+	ListArtifacts(context.Context, names.Artifact, string, bool, ArtifactHandler) error
+}
+
+// ArtifactClient gets and sets artifacts.
+// scoring.RegistryArtifactClient is the implementation backed by a registry.
+type ArtifactClient interface {
+	GetArtifact(context.Context, names.Artifact, bool, ArtifactHandler) error
+	SetArtifact(context.Context, *rpc.Artifact) error
+}
@@ -0,0 +1,80 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+
+	discovery "github.com/google/gnostic/discovery"
+	metrics "github.com/google/gnostic/metrics"
+	oas2 "github.com/google/gnostic/openapiv2"
+	oas3 "github.com/google/gnostic/openapiv3"
+)
+
+// ErrNoComplexityAnalyzer is returned for specs in formats that
+// complexity metrics can't be computed for.
+var ErrNoComplexityAnalyzer = errors.New("no complexity analyzer for spec format")
+
+// NewComplexityFromSpec computes the complexity metrics of a spec with the specified contents.
+func NewComplexityFromSpec(mimeType string, contents []byte) (*metrics.Complexity, error) {
+	if IsOpenAPIv2(mimeType) {
+		document, err := oas2.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI: %s", err)
+		}
+		return SummarizeOpenAPIv2Document(document), nil
+	} else if IsOpenAPIv3(mimeType) {
+		document, err := oas3.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI: %s", err)
+		}
+		return SummarizeOpenAPIv3Document(document), nil
+	} else if IsDiscovery(mimeType) {
+		document, err := discovery.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Discovery: %s", err)
+		}
+		return SummarizeDiscoveryDocument(document), nil
+	} else if IsProto(mimeType) && IsZipArchive(mimeType) {
+		complexity, err := SummarizeZippedProtos(contents)
+		if err != nil {
+			return nil, fmt.Errorf("error processing protos: %s", err)
+		}
+		return complexity, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrNoComplexityAnalyzer, mimeType)
+}
+
+// ComputeComplexity returns the complexity metrics of a spec.
+// If the spec name has no revision, the current revision is used.
+// An error wrapping ErrNoComplexityAnalyzer is returned for specs in
+// formats that complexity metrics can't be computed for.
+func ComputeComplexity(ctx context.Context,
+	client *gapic.RegistryClient,
+	spec names.SpecRevision) (*metrics.Complexity, error) {
+	contents, err := client.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{
+		Name: spec.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewComplexityFromSpec(contents.GetContentType(), contents.GetData())
+}
@@ -0,0 +1,212 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestNewComplexityFromSpec(t *testing.T) {
+	tests := []struct {
+		desc     string
+		mimeType string
+		contents string
+		want     *metrics.Complexity
+	}{
+		{
+			desc:     "openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: vocabularyOpenAPIv2,
+			want:     &metrics.Complexity{PathCount: 1, GetCount: 1, SchemaCount: 2, SchemaPropertyCount: 1},
+		},
+		{
+			desc:     "openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: vocabularyOpenAPIv3,
+			want:     &metrics.Complexity{PathCount: 1, GetCount: 1, SchemaCount: 2, SchemaPropertyCount: 1},
+		},
+		{
+			desc:     "discovery",
+			mimeType: "application/x.discovery",
+			contents: vocabularyDiscovery,
+			want:     &metrics.Complexity{SchemaCount: 2, SchemaPropertyCount: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := NewComplexityFromSpec(test.mimeType, []byte(test.contents))
+			if err != nil {
+				t.Fatalf("NewComplexityFromSpec() returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("NewComplexityFromSpec() returned unexpected complexity (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewComplexityFromSpecErrors(t *testing.T) {
+	tests := []struct {
+		desc         string
+		mimeType     string
+		contents     string
+		wantAnalyzer bool
+	}{
+		{
+			desc:     "invalid openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: "swagger: [",
+		},
+		{
+			desc:     "invalid openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: "openapi: [",
+		},
+		{
+			desc:     "invalid discovery",
+			mimeType: "application/x.discovery",
+			contents: "{",
+		},
+		{
+			desc:     "invalid zipped protos",
+			mimeType: "application/x.protobuf+zip",
+			contents: "not a zip archive",
+		},
+		{
+			desc:         "unsupported format",
+			mimeType:     "text/plain",
+			contents:     "hello",
+			wantAnalyzer: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewComplexityFromSpec(test.mimeType, []byte(test.contents))
+			if err == nil {
+				t.Fatalf("NewComplexityFromSpec() succeeded, want error")
+			}
+			if got := errors.Is(err, ErrNoComplexityAnalyzer); got != test.wantAnalyzer {
+				t.Errorf("NewComplexityFromSpec() returned %q, errors.Is(err, ErrNoComplexityAnalyzer) = %t, want %t", err, got, test.wantAnalyzer)
+			}
+		})
+	}
+}
+
+func TestComputeComplexity(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	version := names.Version{ProjectID: "complexity-compute-test", ApiID: "a", VersionID: "v"}
+	if err := adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  version.Project().String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Setup: failed to delete project: %s", err)
+	}
+	if err := seeder.SeedSpecs(ctx, client,
+		&rpc.ApiSpec{
+			Name:     version.Spec("openapi").String(),
+			MimeType: "application/x.openapi;version=2",
+			Contents: []byte(vocabularyOpenAPIv2),
+		},
+		&rpc.ApiSpec{
+			Name:     version.Spec("text").String(),
+			MimeType: "text/plain",
+			Contents: []byte("hello"),
+		},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	first, err := registryClient.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: version.Spec("openapi").String()})
+	if err != nil {
+		t.Fatalf("Setup: failed to get spec: %s", err)
+	}
+	if _, err := registryClient.UpdateApiSpec(ctx, &rpc.UpdateApiSpecRequest{
+		ApiSpec: &rpc.ApiSpec{
+			Name:     version.Spec("openapi").String(),
+			MimeType: "application/x.discovery",
+			Contents: []byte(vocabularyDiscovery),
+		},
+	}); err != nil {
+		t.Fatalf("Setup: failed to update spec: %s", err)
+	}
+
+	tests := []struct {
+		desc string
+		spec names.SpecRevision
+		want *metrics.Complexity
+	}{
+		{
+			desc: "current revision",
+			spec: version.Spec("openapi").Revision(""),
+			want: &metrics.Complexity{SchemaCount: 2, SchemaPropertyCount: 1},
+		},
+		{
+			desc: "earlier revision",
+			spec: version.Spec("openapi").Revision(first.GetRevisionId()),
+			want: &metrics.Complexity{PathCount: 1, GetCount: 1, SchemaCount: 2, SchemaPropertyCount: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := ComputeComplexity(ctx, registryClient, test.spec)
+			if err != nil {
+				t.Fatalf("ComputeComplexity(%s) returned error: %s", test.spec, err)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("ComputeComplexity(%s) returned unexpected complexity (-want +got):\n%s", test.spec, diff)
+			}
+		})
+	}
+
+	t.Run("unsupported format", func(t *testing.T) {
+		spec := version.Spec("text").Revision("")
+		if _, err := ComputeComplexity(ctx, registryClient, spec); !errors.Is(err, ErrNoComplexityAnalyzer) {
+			t.Errorf("ComputeComplexity(%s) returned %v, want %v", spec, err, ErrNoComplexityAnalyzer)
+		}
+	})
+
+	t.Run("missing spec", func(t *testing.T) {
+		spec := version.Spec("missing").Revision("")
+		if _, err := ComputeComplexity(ctx, registryClient, spec); status.Code(err) != codes.NotFound {
+			t.Errorf("ComputeComplexity(%s) returned %v, want %s", spec, err, codes.NotFound)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// CountProjectResources returns the number of APIs, versions, specs,
+// deployments, and artifacts in a project. Revisions are not counted.
+func CountProjectResources(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Project) (int, error) {
+	count := 0
+	api := name.Api("-")
+	if err := ListAPIs(ctx, client, api, "", func(*rpc.Api) error {
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	version := api.Version("-")
+	if err := ListVersions(ctx, client, version, "", func(*rpc.ApiVersion) error {
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	spec := version.Spec("-")
+	if err := ListSpecs(ctx, client, spec, "", func(*rpc.ApiSpec) error {
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	deployment := api.Deployment("-")
+	if err := ListDeployments(ctx, client, deployment, "", func(*rpc.ApiDeployment) error {
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	for _, artifact := range []names.Artifact{
+		name.Artifact("-"),
+		api.Artifact("-"),
+		version.Artifact("-"),
+		spec.Artifact("-"),
+		deployment.Artifact("-"),
+	} {
+		if err := ListArtifacts(ctx, client, artifact, "", false, func(*rpc.Artifact) error {
+			count++
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+// DeleteProjectWithGuard deletes a project and everything it contains, but
+// only if the project holds no more than expectedResourceCount resources.
+// This protects against wiping out a populated project whose name was mistyped.
+func DeleteProjectWithGuard(ctx context.Context,
+	adminClient *gapic.AdminClient,
+	registryClient *gapic.RegistryClient,
+	name names.Project,
+	expectedResourceCount int) error {
+	count, err := CountProjectResources(ctx, registryClient, name)
+	if err != nil {
+		return fmt.Errorf("failed to count resources in %s: %s", name, err)
+	}
+	if count > expectedResourceCount {
+		return fmt.Errorf("refusing to delete %s: it contains %d resources but at most %d were expected", name, count, expectedResourceCount)
+	}
+
+	return adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  name.String(),
+		Force: true,
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seedGuardProject creates a project with seven resources:
+// an API, a version, a spec, a deployment, and three artifacts.
+func seedGuardProject(ctx context.Context, t *testing.T, client seeder.Client, projectID string) names.Project {
+	t.Helper()
+	if err := client.AdminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  "projects/" + projectID,
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Setup: failed to delete project: %s", err)
+	}
+	root := "projects/" + projectID + "/locations/global"
+	if err := seeder.SeedRegistry(ctx, client,
+		&rpc.ApiSpec{Name: root + "/apis/a/versions/v/specs/s"},
+		&rpc.ApiDeployment{Name: root + "/apis/a/deployments/d"},
+		&rpc.Artifact{Name: root + "/artifacts/x"},
+		&rpc.Artifact{Name: root + "/apis/a/artifacts/x"},
+		&rpc.Artifact{Name: root + "/apis/a/versions/v/specs/s/artifacts/x"},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	return names.Project{ProjectID: projectID}
+}
+
+func TestDeleteProjectWithGuard(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	projectExists := func(project names.Project) bool {
+		t.Helper()
+		_, err := adminClient.GetProject(ctx, &rpc.GetProjectRequest{Name: project.String()})
+		if status.Code(err) == codes.NotFound {
+			return false
+		} else if err != nil {
+			t.Fatalf("GetProject(%s) returned error: %s", project, err)
+		}
+		return true
+	}
+
+	t.Run("count matches", func(t *testing.T) {
+		project := seedGuardProject(ctx, t, client, "guard-match")
+		count, err := CountProjectResources(ctx, registryClient, project)
+		if err != nil {
+			t.Fatalf("CountProjectResources() returned error: %s", err)
+		}
+		if count != 7 {
+			t.Errorf("CountProjectResources() returned %d, want 7", count)
+		}
+		if err := DeleteProjectWithGuard(ctx, adminClient, registryClient, project, 7); err != nil {
+			t.Fatalf("DeleteProjectWithGuard() returned error: %s", err)
+		}
+		if projectExists(project) {
+			t.Errorf("DeleteProjectWithGuard() did not delete %s", project)
+		}
+	})
+
+	t.Run("count mismatches", func(t *testing.T) {
+		project := seedGuardProject(ctx, t, client, "guard-mismatch")
+		err := DeleteProjectWithGuard(ctx, adminClient, registryClient, project, 6)
+		if err == nil || !strings.Contains(err.Error(), "refusing to delete") {
+			t.Errorf("DeleteProjectWithGuard() returned %v, want a refusal", err)
+		}
+		if !projectExists(project) {
+			t.Fatalf("DeleteProjectWithGuard() deleted %s", project)
+		}
+		count, err := CountProjectResources(ctx, registryClient, project)
+		if err != nil {
+			t.Fatalf("CountProjectResources() returned error: %s", err)
+		}
+		if count != 7 {
+			t.Errorf("DeleteProjectWithGuard() left %d resources, want 7", count)
+		}
+	})
+
+	t.Run("count errors", func(t *testing.T) {
+		project := seedGuardProject(ctx, t, client, "guard-error")
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := CountProjectResources(canceled, registryClient, project); err == nil {
+			t.Errorf("CountProjectResources() with a canceled context succeeded but should have failed")
+		}
+		err := DeleteProjectWithGuard(canceled, adminClient, registryClient, project, 100)
+		if err == nil || !strings.Contains(err.Error(), "failed to count resources") {
+			t.Errorf("DeleteProjectWithGuard() returned %v, want a counting error", err)
+		}
+		if !projectExists(project) {
+			t.Errorf("DeleteProjectWithGuard() deleted %s after failing to count its resources", project)
+		}
+	})
+}
@@ -17,9 +17,30 @@ package core
 import (
 	"bytes"
 	"compress/gzip"
+	"errors"
+	"fmt"
 	"io"
 )
 
+// MaxDecompressedSize is the largest number of bytes that will be read when
+// decompressing untrusted contents, which guards against decompression bombs.
+const MaxDecompressedSize = 100 << 20
+
+var errSizeLimitExceeded = errors.New("size limit exceeded")
+
+// readWithLimit reads r to the end, failing with errSizeLimitExceeded
+// if it contains more than limit bytes.
+func readWithLimit(r io.Reader, limit int64) ([]byte, error) {
+	b, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > limit {
+		return nil, errSizeLimitExceeded
+	}
+	return b, nil
+}
+
 // GZippedBytes compresses a slice of bytes.
 func GZippedBytes(input []byte) ([]byte, error) {
 	var buf bytes.Buffer
@@ -43,3 +64,17 @@ func GUnzippedBytes(input []byte) ([]byte, error) {
 	}
 	return io.ReadAll(zr)
 }
+
+// GUnzippedBytesWithLimit uncompresses a slice of bytes,
+// failing if the result would be larger than limit bytes.
+func GUnzippedBytesWithLimit(input []byte, limit int64) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(input))
+	if err != nil {
+		return nil, err
+	}
+	b, err := readWithLimit(zr, limit)
+	if err == errSizeLimitExceeded {
+		return nil, fmt.Errorf("decompressed contents are larger than %d bytes", limit)
+	}
+	return b, err
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// LazyArtifact is an artifact whose contents are only loaded when Fetch is called.
+type LazyArtifact struct {
+	*rpc.Artifact
+	fetch   func() ([]byte, string, error)
+	fetched bool
+}
+
+// NewLazyArtifact returns a LazyArtifact that calls fetch to load its contents.
+// The contents are expected to have the artifact's MIME type.
+func NewLazyArtifact(artifact *rpc.Artifact, fetch func() ([]byte, error)) *LazyArtifact {
+	return NewLazyArtifactWithMimeType(artifact, func() ([]byte, string, error) {
+		contents, err := fetch()
+		return contents, artifact.GetMimeType(), err
+	})
+}
+
+// NewLazyArtifactWithMimeType returns a LazyArtifact that calls fetch to load
+// its contents and their MIME type. Fetch replaces the artifact's MIME type
+// with the returned one, which differs when contents are stored compressed
+// but served decompressed.
+func NewLazyArtifactWithMimeType(artifact *rpc.Artifact, fetch func() ([]byte, string, error)) *LazyArtifact {
+	return &LazyArtifact{Artifact: artifact, fetch: fetch}
+}
+
+// Fetch loads the artifact's contents, stores them in the artifact, and returns them.
+// Like GetArtifact, it sets the artifact's MIME type to the type of the contents.
+// Contents are only requested once; later calls return the stored contents.
+func (a *LazyArtifact) Fetch() ([]byte, error) {
+	if a.fetched {
+		return a.Artifact.GetContents(), nil
+	}
+	contents, mimeType, err := a.fetch()
+	if err != nil {
+		return nil, err
+	}
+	a.Artifact.Contents = contents
+	a.Artifact.MimeType = mimeType
+	a.fetched = true
+	return contents, nil
+}
+
+// fetchArtifactContents returns a function that gets the contents of an artifact and their MIME type.
+func fetchArtifactContents(ctx context.Context, client *gapic.RegistryClient, artifact *rpc.Artifact) func() ([]byte, string, error) {
+	return func() ([]byte, string, error) {
+		resp, err := client.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{
+			Name: artifact.GetName(),
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.GetData(), resp.GetContentType(), nil
+	}
+}
+
+type LazyArtifactHandler func(*LazyArtifact) error
+
+// ListArtifactsLazily is like ListArtifacts but doesn't get artifact contents.
+// Each artifact is passed to the handler as a LazyArtifact that can fetch its contents on demand.
+func ListArtifactsLazily(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Artifact,
+	filter string,
+	handler LazyArtifactHandler) error {
+	return ListArtifacts(ctx, client, name, filter, false, func(artifact *rpc.Artifact) error {
+		return handler(NewLazyArtifactWithMimeType(artifact, fetchArtifactContents(ctx, client, artifact)))
+	})
+}
+
+// GetArtifactLazily is like GetArtifact but doesn't get the artifact contents.
+// The artifact is passed to the handler as a LazyArtifact that can fetch its contents on demand.
+func GetArtifactLazily(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Artifact,
+	handler LazyArtifactHandler) error {
+	return GetArtifact(ctx, client, name, false, func(artifact *rpc.Artifact) error {
+		return handler(NewLazyArtifactWithMimeType(artifact, fetchArtifactContents(ctx, client, artifact)))
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apigee/registry/rpc"
+)
+
+// Linter is a linter that runs in the registry tool's process.
+type Linter interface {
+	// Name is the name that the linter is registered and selected with.
+	Name() string
+	// Lint lints a spec. contents are the uncompressed contents of the spec.
+	// Linters return an error for specs of types that they don't support.
+	Lint(spec *rpc.ApiSpec, contents []byte) (*rpc.Lint, error)
+}
+
+var (
+	lintersMu sync.RWMutex
+	linters   = make(map[string]Linter)
+)
+
+// RegisterLinter makes a linter available by its name.
+// It panics if the linter is nil or if a linter with the same name is already registered.
+func RegisterLinter(linter Linter) {
+	lintersMu.Lock()
+	defer lintersMu.Unlock()
+	if linter == nil {
+		panic("core: RegisterLinter linter is nil")
+	}
+	name := linter.Name()
+	if _, dup := linters[name]; dup {
+		panic("core: RegisterLinter called twice for linter " + name)
+	}
+	linters[name] = linter
+}
+
+// GetLinter returns the registered linter with the specified name.
+func GetLinter(name string) (Linter, error) {
+	lintersMu.RLock()
+	defer lintersMu.RUnlock()
+	if linter, ok := linters[name]; ok {
+		return linter, nil
+	}
+	return nil, fmt.Errorf("no such linter registered: %q (available: %s)", name, strings.Join(linterNames(), ", "))
+}
+
+// LinterNames returns the sorted names of the registered linters.
+func LinterNames() []string {
+	lintersMu.RLock()
+	defer lintersMu.RUnlock()
+	return linterNames()
+}
+
+func linterNames() []string {
+	names := make([]string, 0, len(linters))
+	for name := range linters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterLinter(openAPILinter{name: "gnostic"})
+	RegisterLinter(openAPILinter{name: "spectral"})
+	RegisterLinter(protoLinter{})
+}
+
+// openAPILinter lints OpenAPI specs with NewLintFromOpenAPI.
+type openAPILinter struct {
+	name string
+}
+
+func (l openAPILinter) Name() string {
+	return l.name
+}
+
+func (l openAPILinter) Lint(spec *rpc.ApiSpec, contents []byte) (*rpc.Lint, error) {
+	if !IsOpenAPIv2(spec.GetMimeType()) && !IsOpenAPIv3(spec.GetMimeType()) {
+		return nil, fmt.Errorf("linter %s does not support specs of type %q", l.name, spec.GetMimeType())
+	}
+	return NewLintFromOpenAPI(spec.GetName(), contents, l.name)
+}
+
+// protoLinter lints zipped protos with the AIP linter.
+type protoLinter struct{}
+
+func (protoLinter) Name() string {
+	return "aip"
+}
+
+func (protoLinter) Lint(spec *rpc.ApiSpec, contents []byte) (*rpc.Lint, error) {
+	if !IsProto(spec.GetMimeType()) || !IsZipArchive(spec.GetMimeType()) {
+		return nil, fmt.Errorf("linter aip does not support specs of type %q", spec.GetMimeType())
+	}
+	return NewLintFromZippedProtos(spec.GetName(), contents)
+}
@@ -25,6 +25,7 @@ import (
 )
 
 // NewLintFromZippedProtos runs the API linter and returns the results.
+// The protos may be in a zip archive or a gzipped tar archive.
 func NewLintFromZippedProtos(name string, b []byte) (*rpc.Lint, error) {
 	// create a tmp directory
 	root, err := os.MkdirTemp("", "registry-protos-")
@@ -33,8 +34,8 @@ func NewLintFromZippedProtos(name string, b []byte) (*rpc.Lint, error) {
 	}
 	// whenever we finish, delete the tmp directory
 	defer os.RemoveAll(root)
-	// unzip the protos to the temp directory
-	_, err = UnzipArchiveToPath(b, root+"/protos")
+	// unpack the protos to the temp directory
+	_, err = UnpackArchiveToPath(b, root+"/protos")
 	if err != nil {
 		return nil, err
 	}
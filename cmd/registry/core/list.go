@@ -56,6 +56,17 @@ func ListAPIs(ctx context.Context,
 	name names.Api,
 	filter string,
 	handler ApiHandler) error {
+	return ListAPIsWithPageSize(ctx, client, name, filter, 0, handler)
+}
+
+// ListAPIsWithPageSize is like ListAPIs but requests pages of the specified size.
+// A page size of zero uses the server's default page size.
+func ListAPIsWithPageSize(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Api,
+	filter string,
+	pageSize int32,
+	handler ApiHandler) error {
 	if id := name.ApiID; id != "" && id != "-" {
 		if len(filter) > 0 {
 			filter += " && "
@@ -64,8 +75,9 @@ func ListAPIs(ctx context.Context,
 	}
 
 	it := client.ListApis(ctx, &rpc.ListApisRequest{
-		Parent: name.Parent(),
-		Filter: filter,
+		Parent:   name.Parent(),
+		Filter:   filter,
+		PageSize: pageSize,
 	})
 	for r, err := it.Next(); err != iterator.Done; r, err = it.Next() {
 		if err != nil {
@@ -131,6 +143,17 @@ func ListVersions(ctx context.Context,
 	name names.Version,
 	filter string,
 	handler VersionHandler) error {
+	return ListVersionsWithPageSize(ctx, client, name, filter, 0, handler)
+}
+
+// ListVersionsWithPageSize is like ListVersions but requests pages of the specified size.
+// A page size of zero uses the server's default page size.
+func ListVersionsWithPageSize(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Version,
+	filter string,
+	pageSize int32,
+	handler VersionHandler) error {
 	if id := name.VersionID; id != "" && id != "-" {
 		if len(filter) > 0 {
 			filter += " && "
@@ -139,8 +162,9 @@ func ListVersions(ctx context.Context,
 	}
 
 	it := client.ListApiVersions(ctx, &rpc.ListApiVersionsRequest{
-		Parent: name.Parent(),
-		Filter: filter,
+		Parent:   name.Parent(),
+		Filter:   filter,
+		PageSize: pageSize,
 	})
 	for r, err := it.Next(); err != iterator.Done; r, err = it.Next() {
 		if err != nil {
@@ -159,6 +183,17 @@ func ListSpecs(ctx context.Context,
 	name names.Spec,
 	filter string,
 	handler SpecHandler) error {
+	return ListSpecsWithPageSize(ctx, client, name, filter, 0, handler)
+}
+
+// ListSpecsWithPageSize is like ListSpecs but requests pages of the specified size.
+// A page size of zero uses the server's default page size.
+func ListSpecsWithPageSize(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Spec,
+	filter string,
+	pageSize int32,
+	handler SpecHandler) error {
 	if id := name.SpecID; id != "" && id != "-" {
 		if len(filter) > 0 {
 			filter += " && "
@@ -167,8 +202,9 @@ func ListSpecs(ctx context.Context,
 	}
 
 	it := client.ListApiSpecs(ctx, &rpc.ListApiSpecsRequest{
-		Parent: name.Parent(),
-		Filter: filter,
+		Parent:   name.Parent(),
+		Filter:   filter,
+		PageSize: pageSize,
 	})
 	for r, err := it.Next(); err != iterator.Done; r, err = it.Next() {
 		if err != nil {
@@ -202,12 +238,31 @@ func ListSpecRevisions(ctx context.Context,
 	return nil
 }
 
+// ListArtifacts calls handler for each artifact that matches name and filter.
+// The registry only serves artifacts in pages, so artifacts are requested one
+// page at a time as handler consumes them. Iteration stops as soon as handler
+// returns an error or ctx is done, even in the middle of a page, and that error
+// is returned; handlers that stop early should return a sentinel error and
+// check for it. If getContents is true, each artifact's contents are fetched
+// before it is passed to handler.
 func ListArtifacts(ctx context.Context,
 	client *gapic.RegistryClient,
 	name names.Artifact,
 	filter string,
 	getContents bool,
 	handler ArtifactHandler) error {
+	return ListArtifactsWithPageSize(ctx, client, name, filter, getContents, 0, handler)
+}
+
+// ListArtifactsWithPageSize is like ListArtifacts but requests pages of the specified size.
+// A page size of zero uses the server's default page size.
+func ListArtifactsWithPageSize(ctx context.Context,
+	client *gapic.RegistryClient,
+	name names.Artifact,
+	filter string,
+	getContents bool,
+	pageSize int32,
+	handler ArtifactHandler) error {
 	if id := name.ArtifactID(); id != "" && id != "-" {
 		if len(filter) > 0 {
 			filter += " && "
@@ -216,13 +271,18 @@ func ListArtifacts(ctx context.Context,
 	}
 
 	it := client.ListArtifacts(ctx, &rpc.ListArtifactsRequest{
-		Parent: name.Parent(),
-		Filter: filter,
+		Parent:   name.Parent(),
+		Filter:   filter,
+		PageSize: pageSize,
 	})
 	for r, err := it.Next(); err != iterator.Done; r, err = it.Next() {
 		if err != nil {
 			return err
 		}
+		// Items of a fetched page are returned without checking ctx.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		if getContents {
 			resp, err := client.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{
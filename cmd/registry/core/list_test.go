@@ -0,0 +1,114 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListArtifactsStopsEarly(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	project := names.Project{ProjectID: "list-test"}
+	if err := adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  project.String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Setup: failed to delete project: %s", err)
+	}
+	artifacts := make([]seeder.RegistryResource, 5)
+	for i := range artifacts {
+		artifacts[i] = &rpc.Artifact{Name: project.Artifact(fmt.Sprintf("x%d", i)).String()}
+	}
+	if err := seeder.SeedRegistry(ctx, client, artifacts...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	pattern := project.Artifact("-")
+
+	// All artifacts are in a single page, so these check that iteration
+	// stops within a page that has already been fetched.
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		count := 0
+		err := ListArtifacts(ctx, registryClient, pattern, "", false, func(*rpc.Artifact) error {
+			count++
+			cancel()
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ListArtifacts() returned %v, want %v", err, context.Canceled)
+		}
+		if count != 1 {
+			t.Errorf("ListArtifacts() called handler %d times after cancellation, want 1", count)
+		}
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		stop := errors.New("stop")
+		count := 0
+		err := ListArtifacts(ctx, registryClient, pattern, "", false, func(*rpc.Artifact) error {
+			count++
+			if count == 2 {
+				return stop
+			}
+			return nil
+		})
+		if !errors.Is(err, stop) {
+			t.Errorf("ListArtifacts() returned %v, want %v", err, stop)
+		}
+		if count != 2 {
+			t.Errorf("ListArtifacts() called handler %d times, want 2", count)
+		}
+	})
+
+	t.Run("all artifacts", func(t *testing.T) {
+		count := 0
+		err := ListArtifacts(ctx, registryClient, pattern, "", false, func(*rpc.Artifact) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ListArtifacts() returned error: %s", err)
+		}
+		if count != len(artifacts) {
+			t.Errorf("ListArtifacts() called handler %d times, want %d", count, len(artifacts))
+		}
+	})
+}
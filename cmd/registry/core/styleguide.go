@@ -0,0 +1,79 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/apigee/registry/rpc"
+)
+
+// ValidateStyleGuide checks that every rule of a style guide can be enforced.
+// available maps the names of the known linters to the names of the rules
+// that they support. Each rule must name a known linter that supports its
+// linter_rulename, and each of its alternate linters must be known and
+// support the rule name given for that alternate linter.
+// One error is returned for each problem found.
+func ValidateStyleGuide(sg *rpc.StyleGuide, available map[string][]string) []error {
+	supported := make(map[string]map[string]bool, len(available))
+	for linter, rules := range available {
+		supported[linter] = make(map[string]bool, len(rules))
+		for _, rule := range rules {
+			supported[linter][rule] = true
+		}
+	}
+
+	errs := make([]error, 0)
+	for _, guideline := range sg.GetGuidelines() {
+		for _, rule := range guideline.GetRules() {
+			ruleErr := func(format string, a ...interface{}) error {
+				return fmt.Errorf("guideline %q rule %q: %s", guideline.GetId(), rule.GetId(), fmt.Sprintf(format, a...))
+			}
+
+			checkLinter := func(linter, rulename string) {
+				if linter == "" {
+					return
+				}
+				rules, ok := supported[linter]
+				if !ok {
+					errs = append(errs, ruleErr("unknown linter %q", linter))
+					return
+				}
+				if rulename != "" && !rules[rulename] {
+					errs = append(errs, ruleErr("linter %q does not support rule %q", linter, rulename))
+				}
+			}
+
+			if rule.GetLinter() == "" {
+				errs = append(errs, ruleErr("missing linter"))
+			}
+			if rule.GetLinterRulename() == "" {
+				errs = append(errs, ruleErr("missing linter_rulename"))
+			}
+			checkLinter(rule.GetLinter(), rule.GetLinterRulename())
+			for i, alternate := range rule.GetAlternateLinters() {
+				if alternate.GetLinter() == "" {
+					errs = append(errs, ruleErr("alternate linter %d: missing linter", i))
+					continue
+				}
+				if alternate.GetLinterRulename() == "" {
+					errs = append(errs, ruleErr("alternate linter %q: missing linter_rulename", alternate.GetLinter()))
+				}
+				checkLinter(alternate.GetLinter(), alternate.GetLinterRulename())
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateStyleGuide(t *testing.T) {
+	available := map[string][]string{
+		"spectral": {"operation-tags", "info-contact"},
+		"gnostic":  {"operation-tags", "contact-info"},
+	}
+	styleGuide := func(rules ...*rpc.Rule) *rpc.StyleGuide {
+		return &rpc.StyleGuide{
+			Id:         "sg",
+			Guidelines: []*rpc.Guideline{{Id: "g", Rules: rules}},
+		}
+	}
+	tests := []struct {
+		desc string
+		sg   *rpc.StyleGuide
+		want []string
+	}{
+		{
+			desc: "supported rules",
+			sg: styleGuide(
+				&rpc.Rule{Id: "r1", Linter: "spectral", LinterRulename: "info-contact"},
+				&rpc.Rule{Id: "r2", Linter: "spectral", LinterRulename: "operation-tags", AlternateLinters: []*rpc.AlternateLinter{
+					{Linter: "gnostic", LinterRulename: "operation-tags"},
+				}},
+				&rpc.Rule{Id: "r3", Linter: "spectral", LinterRulename: "info-contact", AlternateLinters: []*rpc.AlternateLinter{
+					{Linter: "gnostic", LinterRulename: "contact-info"},
+				}},
+			),
+			want: []string{},
+		},
+		{
+			desc: "no guidelines",
+			sg:   &rpc.StyleGuide{Id: "sg"},
+			want: []string{},
+		},
+		{
+			desc: "missing linter and rulename",
+			sg:   styleGuide(&rpc.Rule{Id: "r"}),
+			want: []string{
+				`guideline "g" rule "r": missing linter`,
+				`guideline "g" rule "r": missing linter_rulename`,
+			},
+		},
+		{
+			desc: "unknown linter",
+			sg:   styleGuide(&rpc.Rule{Id: "r", Linter: "unknown", LinterRulename: "info-contact"}),
+			want: []string{`guideline "g" rule "r": unknown linter "unknown"`},
+		},
+		{
+			desc: "unsupported rule",
+			sg:   styleGuide(&rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "missing-rule"}),
+			want: []string{`guideline "g" rule "r": linter "spectral" does not support rule "missing-rule"`},
+		},
+		{
+			desc: "rule unsupported by alternate linter",
+			sg: styleGuide(&rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "info-contact", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "gnostic", LinterRulename: "info-contact"},
+				{Linter: "unknown", LinterRulename: "info-contact"},
+			}}),
+			want: []string{
+				`guideline "g" rule "r": linter "gnostic" does not support rule "info-contact"`,
+				`guideline "g" rule "r": unknown linter "unknown"`,
+			},
+		},
+		{
+			desc: "incomplete alternate linters",
+			sg: styleGuide(&rpc.Rule{Id: "r", Linter: "spectral", LinterRulename: "info-contact", AlternateLinters: []*rpc.AlternateLinter{
+				{Linter: "gnostic"},
+				{LinterRulename: "contact-info"},
+			}}),
+			want: []string{
+				`guideline "g" rule "r": alternate linter "gnostic": missing linter_rulename`,
+				`guideline "g" rule "r": alternate linter 1: missing linter`,
+			},
+		},
+		{
+			desc: "problems in several guidelines",
+			sg: &rpc.StyleGuide{
+				Id: "sg",
+				Guidelines: []*rpc.Guideline{
+					{Id: "g1", Rules: []*rpc.Rule{{Id: "r1", Linter: "spectral", LinterRulename: "info-contact"}}},
+					{Id: "g2", Rules: []*rpc.Rule{
+						{Id: "r2", Linter: "gnostic"},
+						{Id: "r3", Linter: "gnostic", LinterRulename: "info-contact"},
+					}},
+				},
+			},
+			want: []string{
+				`guideline "g2" rule "r2": missing linter_rulename`,
+				`guideline "g2" rule "r3": linter "gnostic" does not support rule "info-contact"`,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			errs := ValidateStyleGuide(test.sg, available)
+			got := make([]string, len(errs))
+			for i, err := range errs {
+				got[i] = err.Error()
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("ValidateStyleGuide() returned unexpected errors (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProjectSummaryArtifactID is the id of the artifact that stores the summary of a project.
+const ProjectSummaryArtifactID = "registry-summary"
+
+// ComputeProjectSummary returns the summary of a project: the numbers of its
+// APIs, versions and specs, and statistics of its scores.
+// Counts are computed by listing resources, which doesn't read spec contents.
+// Only the contents of score artifacts are read.
+func ComputeProjectSummary(ctx context.Context,
+	client *gapic.RegistryClient,
+	project names.Project) (*rpc.ProjectSummary, error) {
+	summary := &rpc.ProjectSummary{
+		Id:   ProjectSummaryArtifactID,
+		Kind: "ProjectSummary",
+	}
+
+	api := project.Api("-")
+	if err := ListAPIs(ctx, client, api, "", func(*rpc.Api) error {
+		summary.ApiCount++
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list APIs: %s", err)
+	}
+	version := api.Version("-")
+	if err := ListVersions(ctx, client, version, "", func(*rpc.ApiVersion) error {
+		summary.VersionCount++
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list versions: %s", err)
+	}
+	spec := version.Spec("-")
+	if err := ListSpecs(ctx, client, spec, "", func(*rpc.ApiSpec) error {
+		summary.SpecCount++
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list specs: %s", err)
+	}
+
+	// Scores can be attached to the project and to all of its resources.
+	stats := make(map[string]*rpc.ProjectSummary_ScoreStatistics)
+	filter := fmt.Sprintf("mime_type == %q", MimeTypeForMessageType("google.cloud.apigeeregistry.v1.scoring.Score"))
+	for _, artifact := range []names.Artifact{
+		project.Artifact("-"),
+		api.Artifact("-"),
+		version.Artifact("-"),
+		spec.Artifact("-"),
+	} {
+		if err := ListArtifacts(ctx, client, artifact, filter, true, func(a *rpc.Artifact) error {
+			score := &rpc.Score{}
+			if err := proto.Unmarshal(a.GetContents(), score); err != nil {
+				return fmt.Errorf("invalid score %s: %s", a.GetName(), err)
+			}
+			addScore(stats, score)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to list scores: %s", err)
+		}
+	}
+
+	for _, s := range stats {
+		s.AverageValue /= float64(s.Count)
+		summary.Scores = append(summary.Scores, s)
+	}
+	sort.Slice(summary.Scores, func(i, j int) bool {
+		return summary.Scores[i].DefinitionName < summary.Scores[j].DefinitionName
+	})
+	return summary, nil
+}
+
+// addScore adds the value of score to the statistics of its definition.
+// Averages hold the sum of values until all scores have been added.
+func addScore(stats map[string]*rpc.ProjectSummary_ScoreStatistics, score *rpc.Score) {
+	var value float64
+	switch v := score.GetValue().(type) {
+	case *rpc.Score_PercentValue:
+		value = float64(v.PercentValue.GetValue())
+	case *rpc.Score_IntegerValue:
+		value = float64(v.IntegerValue.GetValue())
+	case *rpc.Score_BooleanValue:
+		if v.BooleanValue.GetValue() {
+			value = 1
+		}
+	default:
+		return
+	}
+
+	s, ok := stats[score.GetDefinitionName()]
+	if !ok {
+		stats[score.GetDefinitionName()] = &rpc.ProjectSummary_ScoreStatistics{
+			DefinitionName: score.GetDefinitionName(),
+			Count:          1,
+			AverageValue:   value,
+			MinValue:       value,
+			MaxValue:       value,
+		}
+		return
+	}
+	s.Count++
+	s.AverageValue += value
+	if value < s.MinValue {
+		s.MinValue = value
+	}
+	if value > s.MaxValue {
+		s.MaxValue = value
+	}
+}
@@ -16,7 +16,11 @@ package core
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/apigee/registry/rpc"
@@ -25,43 +29,95 @@ import (
 	metrics "github.com/google/gnostic/metrics"
 )
 
+// VersionHistoryRow holds the changes to the vocabulary of one version of a version history.
+type VersionHistoryRow struct {
+	Version          string           `json:"version"`
+	NewTermCount     int32            `json:"new_term_count"`
+	DeletedTermCount int32            `json:"deleted_term_count"`
+	NewTerms         []VocabularyTerm `json:"new_terms,omitempty"`
+	DeletedTerms     []VocabularyTerm `json:"deleted_terms,omitempty"`
+}
+
+// VocabularyTerm is a word of a vocabulary, labeled with the kind of entity that uses it.
+type VocabularyTerm struct {
+	Kind  string `json:"kind"`
+	Word  string `json:"word"`
+	Count int32  `json:"count"`
+}
+
+// A VersionHistoryWriter writes the rows of a version history to a destination.
+// It returns the location of the result, which is empty for destinations without one.
+type VersionHistoryWriter interface {
+	WriteVersionHistory(ctx context.Context, name string, rows []VersionHistoryRow) (string, error)
+}
+
+// ExportVersionHistoryToSheet exports the version history in artifact to a Google sheet
+// and returns the URL of the sheet.
 func ExportVersionHistoryToSheet(ctx context.Context, name string, artifact *rpc.Artifact) (string, error) {
-	sheetsClient, err := NewSheetsClient(ctx, "")
+	return ExportVersionHistory(ctx, name, artifact, SheetVersionHistoryWriter{})
+}
+
+// ExportVersionHistory exports the version history in artifact with w.
+func ExportVersionHistory(ctx context.Context, name string, artifact *rpc.Artifact, w VersionHistoryWriter) (string, error) {
+	rows, err := VersionHistoryRows(artifact)
 	if err != nil {
 		return "", err
 	}
+	return w.WriteVersionHistory(ctx, name, rows)
+}
+
+// VersionHistoryRows returns the rows of the version history in artifact.
+func VersionHistoryRows(artifact *rpc.Artifact) ([]VersionHistoryRow, error) {
 	versionHistory, err := getVersionHistory(artifact)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]VersionHistoryRow, 0, len(versionHistory.Versions))
+	for _, version := range versionHistory.Versions {
+		rows = append(rows, VersionHistoryRow{
+			Version:          nameForVersion(version.Name),
+			NewTermCount:     version.NewTermCount,
+			DeletedTermCount: version.DeletedTermCount,
+			NewTerms:         termsForVocabulary(version.NewTerms),
+			DeletedTerms:     termsForVocabulary(version.DeletedTerms),
+		})
+	}
+	return rows, nil
+}
+
+// SheetVersionHistoryWriter writes version histories to Google sheets.
+// Each history gets a summary sheet and sheets with the new and deleted terms of each version.
+type SheetVersionHistoryWriter struct{}
+
+func (SheetVersionHistoryWriter) WriteVersionHistory(ctx context.Context, name string, rows []VersionHistoryRow) (string, error) {
+	sheetsClient, err := NewSheetsClient(ctx, "")
 	if err != nil {
 		return "", err
 	}
 	sheetNames := []string{"Summary"}
-	for _, version := range versionHistory.Versions {
-		versionName := nameForVersion(version.Name)
-		sheetNames = append(sheetNames, versionName+"-new")
-		sheetNames = append(sheetNames, versionName+"-deleted")
+	for _, row := range rows {
+		sheetNames = append(sheetNames, row.Version+"-new")
+		sheetNames = append(sheetNames, row.Version+"-deleted")
 	}
 	sheet, err := sheetsClient.CreateSheet(name, sheetNames)
 	if err != nil {
 		return "", err
 	}
-	rows := make([][]interface{}, 0)
-	rows = append(rows, rowForVersionSummary(nil))
-	for _, version := range versionHistory.Versions {
-		rows = append(rows, rowForVersionSummary(version))
+	summary := make([][]interface{}, 0)
+	summary = append(summary, []interface{}{"version", "new terms", "deleted terms"})
+	for _, row := range rows {
+		summary = append(summary, []interface{}{row.Version, row.NewTermCount, row.DeletedTermCount})
 	}
-	_, err = sheetsClient.Update(ctx, "Summary", rows)
+	_, err = sheetsClient.Update(ctx, "Summary", summary)
 	if err != nil {
 		return "", err
 	}
-	for _, version := range versionHistory.Versions {
-		versionName := nameForVersion(version.Name)
-		rows := rowsForVocabulary(version.NewTerms)
-		_, err = sheetsClient.Update(ctx, versionName+"-new", rows)
+	for _, row := range rows {
+		_, err = sheetsClient.Update(ctx, row.Version+"-new", rowsForTerms(row.NewTerms))
 		if err != nil {
 			return "", err
 		}
-		rows = rowsForVocabulary(version.DeletedTerms)
-		_, err = sheetsClient.Update(ctx, versionName+"-deleted", rows)
+		_, err = sheetsClient.Update(ctx, row.Version+"-deleted", rowsForTerms(row.DeletedTerms))
 		if err != nil {
 			return "", err
 		}
@@ -69,9 +125,57 @@ func ExportVersionHistoryToSheet(ctx context.Context, name string, artifact *rpc
 	return sheet.SpreadsheetUrl, nil
 }
 
+// CSVVersionHistoryWriter writes version histories as CSV with one record for each changed term.
+// Versions without changes are written as a single record with empty term columns.
+type CSVVersionHistoryWriter struct {
+	W io.Writer
+}
+
+func (c CSVVersionHistoryWriter) WriteVersionHistory(ctx context.Context, name string, rows []VersionHistoryRow) (string, error) {
+	w := csv.NewWriter(c.W)
+	if err := w.Write([]string{"version", "change", "kind", "word", "count"}); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		if len(row.NewTerms) == 0 && len(row.DeletedTerms) == 0 {
+			if err := w.Write([]string{row.Version, "", "", "", ""}); err != nil {
+				return "", err
+			}
+			continue
+		}
+		for _, change := range []struct {
+			name  string
+			terms []VocabularyTerm
+		}{
+			{name: "new", terms: row.NewTerms},
+			{name: "deleted", terms: row.DeletedTerms},
+		} {
+			for _, term := range change.terms {
+				if err := w.Write([]string{row.Version, change.name, term.Kind, term.Word, strconv.Itoa(int(term.Count))}); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	w.Flush()
+	return "", w.Error()
+}
+
+// JSONVersionHistoryWriter writes version histories as a JSON array of rows.
+type JSONVersionHistoryWriter struct {
+	W io.Writer
+}
+
+func (j JSONVersionHistoryWriter) WriteVersionHistory(ctx context.Context, name string, rows []VersionHistoryRow) (string, error) {
+	enc := json.NewEncoder(j.W)
+	enc.SetIndent("", "  ")
+	return "", enc.Encode(rows)
+}
+
+// nameForVersion returns the version ID of a version name.
 func nameForVersion(version string) string {
 	parts := strings.Split(version, "/")
-	return parts[5]
+	return parts[len(parts)-1]
 }
 
 func getVersionHistory(artifact *rpc.Artifact) (*metrics.VersionHistory, error) {
@@ -89,32 +193,29 @@ func getVersionHistory(artifact *rpc.Artifact) (*metrics.VersionHistory, error)
 	}
 }
 
-func rowForVersionSummary(v *metrics.Version) []interface{} {
-	if v == nil {
-		return []interface{}{
-			"version",
-			"new terms",
-			"deleted terms",
+func termsForVocabulary(vocabulary *metrics.Vocabulary) []VocabularyTerm {
+	terms := make([]VocabularyTerm, 0)
+	for _, group := range []struct {
+		kind   string
+		counts []*metrics.WordCount
+	}{
+		{kind: "schema", counts: vocabulary.GetSchemas()},
+		{kind: "artifact", counts: vocabulary.GetProperties()},
+		{kind: "operation", counts: vocabulary.GetOperations()},
+		{kind: "parameter", counts: vocabulary.GetParameters()},
+	} {
+		for _, wc := range group.counts {
+			terms = append(terms, VocabularyTerm{Kind: group.kind, Word: wc.Word, Count: wc.Count})
 		}
 	}
-	version := v.Name
-	return []interface{}{nameForVersion(version), v.NewTermCount, v.DeletedTermCount}
+	return terms
 }
 
-func rowsForVocabulary(vocabulary *metrics.Vocabulary) [][]interface{} {
+func rowsForTerms(terms []VocabularyTerm) [][]interface{} {
 	rows := make([][]interface{}, 0)
 	rows = append(rows, rowForLabeledWordCount("", nil))
-	for _, wc := range vocabulary.Schemas {
-		rows = append(rows, rowForLabeledWordCount("schema", wc))
-	}
-	for _, wc := range vocabulary.Properties {
-		rows = append(rows, rowForLabeledWordCount("artifact", wc))
-	}
-	for _, wc := range vocabulary.Operations {
-		rows = append(rows, rowForLabeledWordCount("operation", wc))
-	}
-	for _, wc := range vocabulary.Parameters {
-		rows = append(rows, rowForLabeledWordCount("parameter", wc))
+	for _, term := range terms {
+		rows = append(rows, []interface{}{term.Kind, term.Word, term.Count})
 	}
 	return rows
 }
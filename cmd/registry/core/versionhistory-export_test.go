@@ -0,0 +1,225 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+)
+
+func versionHistoryArtifact(t *testing.T, history *metrics.VersionHistory) *rpc.Artifact {
+	t.Helper()
+	contents, err := proto.Marshal(history)
+	if err != nil {
+		t.Fatalf("Setup: failed to marshal version history: %s", err)
+	}
+	return &rpc.Artifact{
+		Name:     "projects/p/locations/global/apis/a/artifacts/vocabulary-history",
+		MimeType: MimeTypeForMessageType("gnostic.metrics.VersionHistory"),
+		Contents: contents,
+	}
+}
+
+func TestVersionHistoryRows(t *testing.T) {
+	tests := []struct {
+		desc     string
+		artifact func(t *testing.T) *rpc.Artifact
+		want     []VersionHistoryRow
+		wantErr  bool
+	}{
+		{
+			desc: "versions with changes",
+			artifact: func(t *testing.T) *rpc.Artifact {
+				return versionHistoryArtifact(t, &metrics.VersionHistory{
+					Versions: []*metrics.Version{
+						{
+							Name:         "projects/p/locations/global/apis/a/versions/v1",
+							NewTermCount: 2,
+							NewTerms: &metrics.Vocabulary{
+								Schemas:    []*metrics.WordCount{{Word: "Pet", Count: 3}},
+								Operations: []*metrics.WordCount{{Word: "listPets", Count: 1}},
+							},
+						},
+						{
+							Name:             "projects/p/locations/global/apis/a/versions/v2",
+							NewTermCount:     1,
+							DeletedTermCount: 1,
+							NewTerms: &metrics.Vocabulary{
+								Parameters: []*metrics.WordCount{{Word: "limit", Count: 2}},
+							},
+							DeletedTerms: &metrics.Vocabulary{
+								Properties: []*metrics.WordCount{{Word: "tag", Count: 1}},
+							},
+						},
+					},
+				})
+			},
+			want: []VersionHistoryRow{
+				{
+					Version:      "v1",
+					NewTermCount: 2,
+					NewTerms: []VocabularyTerm{
+						{Kind: "schema", Word: "Pet", Count: 3},
+						{Kind: "operation", Word: "listPets", Count: 1},
+					},
+					DeletedTerms: []VocabularyTerm{},
+				},
+				{
+					Version:          "v2",
+					NewTermCount:     1,
+					DeletedTermCount: 1,
+					NewTerms:         []VocabularyTerm{{Kind: "parameter", Word: "limit", Count: 2}},
+					DeletedTerms:     []VocabularyTerm{{Kind: "artifact", Word: "tag", Count: 1}},
+				},
+			},
+		},
+		{
+			desc: "version without changes",
+			artifact: func(t *testing.T) *rpc.Artifact {
+				return versionHistoryArtifact(t, &metrics.VersionHistory{
+					Versions: []*metrics.Version{{Name: "projects/p/locations/global/apis/a/versions/v1"}},
+				})
+			},
+			want: []VersionHistoryRow{
+				{Version: "v1", NewTerms: []VocabularyTerm{}, DeletedTerms: []VocabularyTerm{}},
+			},
+		},
+		{
+			desc: "empty history",
+			artifact: func(t *testing.T) *rpc.Artifact {
+				return versionHistoryArtifact(t, &metrics.VersionHistory{})
+			},
+			want: []VersionHistoryRow{},
+		},
+		{
+			desc: "not a version history",
+			artifact: func(t *testing.T) *rpc.Artifact {
+				return &rpc.Artifact{
+					Name:     "projects/p/locations/global/apis/a/artifacts/vocabulary",
+					MimeType: MimeTypeForMessageType("gnostic.metrics.Vocabulary"),
+				}
+			},
+			wantErr: true,
+		},
+		{
+			desc: "invalid contents",
+			artifact: func(t *testing.T) *rpc.Artifact {
+				return &rpc.Artifact{
+					Name:     "projects/p/locations/global/apis/a/artifacts/vocabulary-history",
+					MimeType: MimeTypeForMessageType("gnostic.metrics.VersionHistory"),
+					Contents: []byte("invalid"),
+				}
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := VersionHistoryRows(test.artifact(t))
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("VersionHistoryRows() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VersionHistoryRows() returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("VersionHistoryRows() returned unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestVersionHistoryWriters(t *testing.T) {
+	rows := []VersionHistoryRow{
+		{
+			Version:          "v2",
+			NewTermCount:     1,
+			DeletedTermCount: 1,
+			NewTerms:         []VocabularyTerm{{Kind: "parameter", Word: "limit", Count: 2}},
+			DeletedTerms:     []VocabularyTerm{{Kind: "artifact", Word: "tag", Count: 1}},
+		},
+		{Version: "v3"},
+	}
+	tests := []struct {
+		desc   string
+		writer func(*bytes.Buffer) VersionHistoryWriter
+		want   string
+	}{
+		{
+			desc:   "csv",
+			writer: func(b *bytes.Buffer) VersionHistoryWriter { return CSVVersionHistoryWriter{W: b} },
+			want: `version,change,kind,word,count
+v2,new,parameter,limit,2
+v2,deleted,artifact,tag,1
+v3,,,,
+`,
+		},
+		{
+			desc:   "json",
+			writer: func(b *bytes.Buffer) VersionHistoryWriter { return JSONVersionHistoryWriter{W: b} },
+			want: `[
+  {
+    "version": "v2",
+    "new_term_count": 1,
+    "deleted_term_count": 1,
+    "new_terms": [
+      {
+        "kind": "parameter",
+        "word": "limit",
+        "count": 2
+      }
+    ],
+    "deleted_terms": [
+      {
+        "kind": "artifact",
+        "word": "tag",
+        "count": 1
+      }
+    ]
+  },
+  {
+    "version": "v3",
+    "new_term_count": 0,
+    "deleted_term_count": 0
+  }
+]
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var b bytes.Buffer
+			location, err := test.writer(&b).WriteVersionHistory(context.Background(), "history", rows)
+			if err != nil {
+				t.Fatalf("WriteVersionHistory() returned error: %s", err)
+			}
+			if location != "" {
+				t.Errorf("WriteVersionHistory() returned location %q, want none", location)
+			}
+			if diff := cmp.Diff(test.want, b.String()); diff != "" {
+				t.Errorf("WriteVersionHistory() wrote unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
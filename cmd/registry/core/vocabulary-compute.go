@@ -0,0 +1,93 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/gnostic/metrics/vocabulary"
+
+	discovery "github.com/google/gnostic/discovery"
+	metrics "github.com/google/gnostic/metrics"
+	oas2 "github.com/google/gnostic/openapiv2"
+	oas3 "github.com/google/gnostic/openapiv3"
+)
+
+// ErrNoVocabularyExtractor is returned for specs in formats that
+// vocabularies can't be computed for.
+var ErrNoVocabularyExtractor = errors.New("no vocabulary extractor for spec format")
+
+// NewVocabularyFromSpec computes the vocabulary of a spec with the specified contents.
+func NewVocabularyFromSpec(mimeType string, contents []byte) (*metrics.Vocabulary, error) {
+	if IsOpenAPIv2(mimeType) {
+		document, err := oas2.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI: %s", err)
+		}
+		return vocabulary.NewVocabularyFromOpenAPIv2(document), nil
+	} else if IsOpenAPIv3(mimeType) {
+		document, err := oas3.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenAPI: %s", err)
+		}
+		return vocabulary.NewVocabularyFromOpenAPIv3(document), nil
+	} else if IsDiscovery(mimeType) {
+		document, err := discovery.ParseDocument(contents)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Discovery: %s", err)
+		}
+		return vocabulary.NewVocabularyFromDiscovery(document), nil
+	} else if IsProto(mimeType) && IsZipArchive(mimeType) {
+		vocab, err := NewVocabularyFromZippedProtos(contents)
+		if err != nil {
+			return nil, fmt.Errorf("error processing protos: %s", err)
+		}
+		return vocab, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrNoVocabularyExtractor, mimeType)
+}
+
+// ComputeVocabulary returns the union of the vocabularies of all specs of an API.
+// Specs that vocabularies can't be computed for are skipped with a warning.
+func ComputeVocabulary(ctx context.Context,
+	client *gapic.RegistryClient,
+	api names.Api) (*metrics.Vocabulary, error) {
+	vocabs := make([]*metrics.Vocabulary, 0)
+	err := ListSpecs(ctx, client, api.Version("-").Spec("-"), "", func(spec *rpc.ApiSpec) error {
+		contents, err := client.GetApiSpecContents(ctx, &rpc.GetApiSpecContentsRequest{
+			Name: spec.GetName(),
+		})
+		if err != nil {
+			return err
+		}
+		vocab, err := NewVocabularyFromSpec(contents.GetContentType(), contents.GetData())
+		if err != nil {
+			log.FromContext(ctx).WithError(err).Warnf("Skipping %s", spec.GetName())
+			return nil
+		}
+		vocabs = append(vocabs, vocab)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vocabulary.Union(vocabs), nil
+}
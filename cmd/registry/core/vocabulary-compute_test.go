@@ -0,0 +1,268 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	metrics "github.com/google/gnostic/metrics"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const vocabularyOpenAPIv2 = `swagger: "2.0"
+info:
+  title: Shelves
+  version: v1
+paths:
+  /shelves:
+    get:
+      operationId: listShelves
+      parameters:
+      - name: pageSize
+        in: query
+        type: integer
+      responses:
+        "200":
+          description: OK
+definitions:
+  Shelf:
+    type: object
+    properties:
+      title:
+        type: string
+`
+
+const vocabularyOpenAPIv3 = `openapi: 3.0.0
+info:
+  title: Pets
+  version: v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+      - name: limit
+        in: query
+        schema:
+          type: integer
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+const vocabularyDiscovery = `{
+  "kind": "discovery#restDescription",
+  "discoveryVersion": "v1",
+  "id": "books:v1",
+  "name": "books",
+  "version": "v1",
+  "schemas": {
+    "Book": {
+      "id": "Book",
+      "type": "object",
+      "properties": {
+        "author": {"type": "string"}
+      }
+    }
+  }
+}
+`
+
+func words(words ...string) []*metrics.WordCount {
+	counts := make([]*metrics.WordCount, len(words))
+	for i, w := range words {
+		counts[i] = &metrics.WordCount{Word: w, Count: 1}
+	}
+	return counts
+}
+
+func TestNewVocabularyFromSpec(t *testing.T) {
+	tests := []struct {
+		desc     string
+		mimeType string
+		contents string
+		want     *metrics.Vocabulary
+	}{
+		{
+			desc:     "openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: vocabularyOpenAPIv2,
+			want: &metrics.Vocabulary{
+				Schemas:    words("Shelf"),
+				Properties: words("title"),
+				Operations: words("listShelves"),
+				Parameters: words("pageSize"),
+			},
+		},
+		{
+			desc:     "openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: vocabularyOpenAPIv3,
+			want: &metrics.Vocabulary{
+				Schemas:    words("Pet"),
+				Properties: words("name"),
+				Operations: words("listPets"),
+				Parameters: words("limit"),
+			},
+		},
+		{
+			desc:     "discovery",
+			mimeType: "application/x.discovery",
+			contents: vocabularyDiscovery,
+			want: &metrics.Vocabulary{
+				Schemas:    words("Book"),
+				Properties: words("author"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := NewVocabularyFromSpec(test.mimeType, []byte(test.contents))
+			if err != nil {
+				t.Fatalf("NewVocabularyFromSpec() returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("NewVocabularyFromSpec() returned unexpected vocabulary (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewVocabularyFromSpecErrors(t *testing.T) {
+	tests := []struct {
+		desc          string
+		mimeType      string
+		contents      string
+		wantExtractor bool
+	}{
+		{
+			desc:     "invalid openapi v2",
+			mimeType: "application/x.openapi;version=2",
+			contents: "swagger: [",
+		},
+		{
+			desc:     "invalid openapi v3",
+			mimeType: "application/x.openapi;version=3",
+			contents: "openapi: [",
+		},
+		{
+			desc:     "invalid discovery",
+			mimeType: "application/x.discovery",
+			contents: "{",
+		},
+		{
+			desc:     "invalid zipped protos",
+			mimeType: "application/x.protobuf+zip",
+			contents: "not a zip archive",
+		},
+		{
+			desc:          "unsupported format",
+			mimeType:      "text/plain",
+			contents:      "hello",
+			wantExtractor: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewVocabularyFromSpec(test.mimeType, []byte(test.contents))
+			if err == nil {
+				t.Fatalf("NewVocabularyFromSpec() succeeded, want error")
+			}
+			if got := errors.Is(err, ErrNoVocabularyExtractor); got != test.wantExtractor {
+				t.Errorf("NewVocabularyFromSpec() returned %q, errors.Is(err, ErrNoVocabularyExtractor) = %t, want %t", err, got, test.wantExtractor)
+			}
+		})
+	}
+}
+
+func TestComputeVocabulary(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	api := names.Api{ProjectID: "vocabulary-compute-test", ApiID: "a"}
+	if err := adminClient.DeleteProject(ctx, &rpc.DeleteProjectRequest{
+		Name:  api.Project().String(),
+		Force: true,
+	}); err != nil && status.Code(err) != codes.NotFound {
+		t.Fatalf("Setup: failed to delete project: %s", err)
+	}
+	if err := seeder.SeedSpecs(ctx, client,
+		&rpc.ApiSpec{
+			Name:     api.Version("v1").Spec("openapi").String(),
+			MimeType: "application/x.openapi;version=3",
+			Contents: []byte(vocabularyOpenAPIv3),
+		},
+		&rpc.ApiSpec{
+			Name:     api.Version("v2").Spec("discovery").String(),
+			MimeType: "application/x.discovery",
+			Contents: []byte(vocabularyDiscovery),
+		},
+		&rpc.ApiSpec{
+			Name:     api.Version("v2").Spec("invalid").String(),
+			MimeType: "application/x.openapi;version=2",
+			Contents: []byte("swagger: ["),
+		},
+		&rpc.ApiSpec{
+			Name:     api.Version("v2").Spec("text").String(),
+			MimeType: "text/plain",
+			Contents: []byte("hello"),
+		},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	got, err := ComputeVocabulary(ctx, registryClient, api)
+	if err != nil {
+		t.Fatalf("ComputeVocabulary() returned error: %s", err)
+	}
+	want := &metrics.Vocabulary{
+		Schemas:    words("Book", "Pet"),
+		Properties: words("author", "name"),
+		Operations: words("listPets"),
+		Parameters: words("limit"),
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("ComputeVocabulary() returned unexpected vocabulary (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// ArtifactEventType describes how an artifact changed.
+type ArtifactEventType int
+
+const (
+	ArtifactCreated ArtifactEventType = iota
+	ArtifactUpdated
+	ArtifactDeleted
+)
+
+func (t ArtifactEventType) String() string {
+	switch t {
+	case ArtifactCreated:
+		return "created"
+	case ArtifactUpdated:
+		return "updated"
+	case ArtifactDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// ArtifactEvent reports a change to an artifact.
+// For deletions, Artifact holds the last known state of the artifact.
+// Artifact contents are not included.
+type ArtifactEvent struct {
+	Type     ArtifactEventType
+	Artifact *rpc.Artifact
+}
+
+// ArtifactWatcher reports changes to the artifacts that match a pattern.
+// Events are sent on the returned channel, which is closed when ctx is done.
+type ArtifactWatcher interface {
+	WatchArtifacts(ctx context.Context, pattern names.Artifact) (<-chan ArtifactEvent, error)
+}
+
+// DefaultWatchInterval is the polling interval of a PollingArtifactWatcher with no Interval.
+const DefaultWatchInterval = 30 * time.Second
+
+// PollingArtifactWatcher is an ArtifactWatcher that lists artifacts periodically
+// and compares their update times, for registries without a streaming API.
+type PollingArtifactWatcher struct {
+	Lister Lister
+	// Interval is the time between listings. Zero uses DefaultWatchInterval.
+	Interval time.Duration
+}
+
+// WatchArtifacts lists the matching artifacts once before returning and reports
+// changes relative to that listing, so existing artifacts produce no events.
+// Failed listings after the first are logged and retried at the next interval.
+func (w *PollingArtifactWatcher) WatchArtifacts(ctx context.Context, pattern names.Artifact) (<-chan ArtifactEvent, error) {
+	known, err := w.list(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	events := make(chan ArtifactEvent)
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			current, err := w.list(ctx, pattern)
+			if err != nil {
+				log.FromContext(ctx).WithError(err).Warnf("Failed to list %s", pattern)
+				continue
+			}
+			for _, e := range artifactChanges(known, current) {
+				select {
+				case events <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			known = current
+		}
+	}()
+	return events, nil
+}
+
+func (w *PollingArtifactWatcher) list(ctx context.Context, pattern names.Artifact) (map[string]*rpc.Artifact, error) {
+	artifacts := make(map[string]*rpc.Artifact)
+	err := w.Lister.ListArtifacts(ctx, pattern, "", false, func(a *rpc.Artifact) error {
+		artifacts[a.GetName()] = a
+		return nil
+	})
+	return artifacts, err
+}
+
+// artifactChanges returns the events that turn the previous listing into the current one,
+// sorted by artifact name.
+func artifactChanges(previous, current map[string]*rpc.Artifact) []ArtifactEvent {
+	events := make([]ArtifactEvent, 0)
+	for name, a := range current {
+		old, ok := previous[name]
+		if !ok {
+			events = append(events, ArtifactEvent{Type: ArtifactCreated, Artifact: a})
+		} else if !a.GetUpdateTime().AsTime().Equal(old.GetUpdateTime().AsTime()) {
+			events = append(events, ArtifactEvent{Type: ArtifactUpdated, Artifact: a})
+		}
+	}
+	for name, a := range previous {
+		if _, ok := current[name]; !ok {
+			events = append(events, ArtifactEvent{Type: ArtifactDeleted, Artifact: a})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Artifact.GetName() < events[j].Artifact.GetName()
+	})
+	return events
+}
@@ -0,0 +1,154 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeLister is a Lister that holds artifacts in memory.
+type fakeLister struct {
+	mu        sync.Mutex
+	artifacts map[string]*rpc.Artifact
+	err       error
+}
+
+func (l *fakeLister) set(name string, updated time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.artifacts[name] = &rpc.Artifact{Name: name, UpdateTime: timestamppb.New(updated)}
+}
+
+func (l *fakeLister) delete(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.artifacts, name)
+}
+
+func (l *fakeLister) ListAPIs(context.Context, names.Api, string, ApiHandler) error {
+	return nil
+}
+
+func (l *fakeLister) ListVersions(context.Context, names.Version, string, VersionHandler) error {
+	return nil
+}
+
+func (l *fakeLister) ListSpecs(context.Context, names.Spec, string, SpecHandler) error {
+	return nil
+}
+
+func (l *fakeLister) ListArtifacts(ctx context.Context, _ names.Artifact, _ string, _ bool, handler ArtifactHandler) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, a := range l.artifacts {
+		if err := handler(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPollingArtifactWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	lister := &fakeLister{artifacts: make(map[string]*rpc.Artifact)}
+	lister.set("unchanged", start)
+	lister.set("updated", start)
+	lister.set("deleted", start)
+
+	watcher := &PollingArtifactWatcher{Lister: lister, Interval: time.Millisecond}
+	events, err := watcher.WatchArtifacts(ctx, names.Project{ProjectID: "p"}.Artifact("-"))
+	if err != nil {
+		t.Fatalf("WatchArtifacts() returned error: %s", err)
+	}
+
+	lister.set("created", start)
+	lister.set("updated", start.Add(time.Second))
+	lister.delete("deleted")
+
+	want := map[string]ArtifactEventType{
+		"created": ArtifactCreated,
+		"updated": ArtifactUpdated,
+		"deleted": ArtifactDeleted,
+	}
+	got := make(map[string]ArtifactEventType)
+	timeout := time.After(10 * time.Second)
+	for len(got) < len(want) {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				t.Fatalf("WatchArtifacts() closed its channel before ctx was done")
+			}
+			if _, dup := got[e.Artifact.GetName()]; dup {
+				t.Errorf("WatchArtifacts() reported %s twice", e.Artifact.GetName())
+			}
+			got[e.Artifact.GetName()] = e.Type
+		case <-timeout:
+			t.Fatalf("WatchArtifacts() reported %v before timing out, want %v", got, want)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WatchArtifacts() reported unexpected events (-want +got):\n%s", diff)
+	}
+
+	// Later listings match the last one, so no further events are expected.
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	for e := range events {
+		t.Errorf("WatchArtifacts() reported unexpected %s event for %s", e.Type, e.Artifact.GetName())
+	}
+}
+
+func TestPollingArtifactWatcherListError(t *testing.T) {
+	lister := &fakeLister{err: errors.New("unavailable")}
+	watcher := &PollingArtifactWatcher{Lister: lister}
+	if _, err := watcher.WatchArtifacts(context.Background(), names.Project{ProjectID: "p"}.Artifact("-")); !errors.Is(err, lister.err) {
+		t.Errorf("WatchArtifacts() returned %v, want %v", err, lister.err)
+	}
+}
+
+func TestArtifactChanges(t *testing.T) {
+	at := func(name string, seconds int64) *rpc.Artifact {
+		return &rpc.Artifact{Name: name, UpdateTime: &timestamppb.Timestamp{Seconds: seconds}}
+	}
+	previous := map[string]*rpc.Artifact{"a": at("a", 1), "b": at("b", 1), "c": at("c", 1)}
+	current := map[string]*rpc.Artifact{"a": at("a", 1), "b": at("b", 2), "d": at("d", 1)}
+	want := []ArtifactEvent{
+		{Type: ArtifactUpdated, Artifact: current["b"]},
+		{Type: ArtifactDeleted, Artifact: previous["c"]},
+		{Type: ArtifactCreated, Artifact: current["d"]},
+	}
+	got := artifactChanges(previous, current)
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b *rpc.Artifact) bool { return a == b })); diff != "" {
+		t.Errorf("artifactChanges() returned unexpected events (-want +got):\n%s", diff)
+	}
+}
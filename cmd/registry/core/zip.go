@@ -22,6 +22,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -72,31 +73,59 @@ func UnzipArchiveToPath(b []byte, dest string) ([]string, error) {
 
 // UnzipArchiveToMap will decompress a zip archive to a map.
 // May be memory intensive for large zip archives.
+// Use UnzipArchiveToSlice when the files must be visited in a stable order.
 func UnzipArchiveToMap(b []byte) (map[string][]byte, error) {
-	contents := make(map[string][]byte, 0)
+	files, err := UnzipArchiveToSlice(b)
+	contents := make(map[string][]byte, len(files))
+	for _, f := range files {
+		contents[f.Name] = f.Contents
+	}
+	return contents, err
+}
+
+// ArchiveFile is a file decompressed from a zip archive.
+type ArchiveFile struct {
+	Name     string
+	Contents []byte
+}
+
+// UnzipArchiveToSlice will decompress a zip archive to a slice of files sorted by name.
+// May be memory intensive for large zip archives. Archives that decompress to
+// more than MaxDecompressedSize bytes are rejected.
+func UnzipArchiveToSlice(b []byte) ([]ArchiveFile, error) {
+	files := make([]ArchiveFile, 0)
 	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
 	if err != nil {
-		return contents, err
+		return files, err
 	}
+	remaining := int64(MaxDecompressedSize)
 	for _, f := range r.File {
 		if f.FileInfo().IsDir() {
 			continue
 		}
 		rc, err := f.Open()
 		if err != nil {
-			return contents, err
+			return files, err
 		}
-		bytes, err := io.ReadAll(rc)
-		if err != nil {
-			return contents, err
+		bytes, err := readWithLimit(rc, remaining)
+		if err == errSizeLimitExceeded {
+			rc.Close()
+			return files, fmt.Errorf("archive decompresses to more than %d bytes", MaxDecompressedSize)
+		} else if err != nil {
+			rc.Close()
+			return files, err
 		}
+		remaining -= int64(len(bytes))
 		// Close the file without defer to close before next iteration of loop
 		if err = rc.Close(); err != nil {
-			return contents, err
+			return files, err
 		}
-		contents[f.Name] = bytes
+		files = append(files, ArchiveFile{Name: f.Name, Contents: bytes})
 	}
-	return contents, nil
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
 }
 
 // ZipArchiveOfPath reads the contents of a path into a zip archive.
@@ -0,0 +1,132 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// zipArchive returns a zip archive of the named files in the order given.
+// Names ending in "/" are stored as directories.
+func zipArchive(t *testing.T, files ...ArchiveFile) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: f.Name, Method: zip.Deflate})
+		if err != nil {
+			t.Fatalf("Setup: failed to add %s to archive: %s", f.Name, err)
+		}
+		if _, err := fw.Write(f.Contents); err != nil {
+			t.Fatalf("Setup: failed to write %s to archive: %s", f.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Setup: failed to close archive: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestUnzipArchiveToSlice(t *testing.T) {
+	tests := []struct {
+		desc  string
+		files []ArchiveFile
+		want  []ArchiveFile
+	}{
+		{
+			desc: "sorted by name",
+			files: []ArchiveFile{
+				{Name: "z.proto", Contents: []byte("z")},
+				{Name: "a/b.proto", Contents: []byte("b")},
+				{Name: "a.proto", Contents: []byte("a")},
+			},
+			want: []ArchiveFile{
+				{Name: "a.proto", Contents: []byte("a")},
+				{Name: "a/b.proto", Contents: []byte("b")},
+				{Name: "z.proto", Contents: []byte("z")},
+			},
+		},
+		{
+			desc: "directories skipped",
+			files: []ArchiveFile{
+				{Name: "a/"},
+				{Name: "a/b.proto", Contents: []byte("b")},
+			},
+			want: []ArchiveFile{
+				{Name: "a/b.proto", Contents: []byte("b")},
+			},
+		},
+		{
+			desc:  "empty archive",
+			files: []ArchiveFile{},
+			want:  []ArchiveFile{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := UnzipArchiveToSlice(zipArchive(t, test.files...))
+			if err != nil {
+				t.Fatalf("UnzipArchiveToSlice() returned error: %s", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("UnzipArchiveToSlice() returned unexpected files (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnzipArchiveToSliceErrors(t *testing.T) {
+	t.Run("invalid archive", func(t *testing.T) {
+		if _, err := UnzipArchiveToSlice([]byte("not a zip archive")); err == nil {
+			t.Errorf("UnzipArchiveToSlice() succeeded, want error")
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		// Each file is under the limit, but together they exceed it.
+		half := make([]byte, MaxDecompressedSize/2+1)
+		b := zipArchive(t,
+			ArchiveFile{Name: "a", Contents: half},
+			ArchiveFile{Name: "b", Contents: half},
+		)
+		_, err := UnzipArchiveToSlice(b)
+		if err == nil || !strings.Contains(err.Error(), "archive decompresses to more than") {
+			t.Errorf("UnzipArchiveToSlice() returned %v, want a size limit error", err)
+		}
+	})
+}
+
+func TestUnzipArchiveToMap(t *testing.T) {
+	b := zipArchive(t,
+		ArchiveFile{Name: "b.proto", Contents: []byte("b")},
+		ArchiveFile{Name: "a.proto", Contents: []byte("a")},
+	)
+	got, err := UnzipArchiveToMap(b)
+	if err != nil {
+		t.Fatalf("UnzipArchiveToMap() returned error: %s", err)
+	}
+	want := map[string][]byte{
+		"a.proto": []byte("a"),
+		"b.proto": []byte("b"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UnzipArchiveToMap() returned unexpected files (-want +got):\n%s", diff)
+	}
+}
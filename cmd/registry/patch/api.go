@@ -17,6 +17,8 @@ package patch
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/gapic"
@@ -28,7 +30,7 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api, nested bool) (*models.Api, error) {
+func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api, nested bool, mode ExportMode) (*models.Api, error) {
 	apiName, err := names.ParseApi(message.Name)
 	if err != nil {
 		return nil, err
@@ -48,7 +50,7 @@ func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api,
 		versions = make([]*models.ApiVersion, 0)
 		if err = core.ListVersions(ctx, client, apiName.Version("-"), "", func(message *rpc.ApiVersion) error {
 			var version *models.ApiVersion
-			version, err := newApiVersion(ctx, client, message, true)
+			version, err := newApiVersion(ctx, client, message, true, mode)
 			if err != nil {
 				return err
 			}
@@ -64,7 +66,7 @@ func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api,
 		deployments = make([]*models.ApiDeployment, 0)
 		if err = core.ListDeployments(ctx, client, apiName.Deployment("-"), "", func(message *rpc.ApiDeployment) error {
 			var deployment *models.ApiDeployment
-			deployment, err = newApiDeployment(ctx, client, message, true)
+			deployment, err = newApiDeployment(ctx, client, message, true, mode)
 			if err != nil {
 				return err
 			}
@@ -77,13 +79,13 @@ func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api,
 		}); err != nil {
 			return nil, err
 		}
-		artifacts, err = collectChildArtifacts(ctx, client, apiName.Artifact("-"))
+		artifacts, err = collectChildArtifacts(ctx, client, apiName.Artifact("-"), mode)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	return &models.Api{
+	api := &models.Api{
 		Header: models.Header{
 			ApiVersion: RegistryV1,
 			Kind:       "API",
@@ -103,10 +105,14 @@ func newApi(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api,
 			ApiDeployments:        deployments,
 			Artifacts:             artifacts,
 		},
-	}, err
+	}
+	if mode == FullFidelity {
+		setApiServerFields(&api.Metadata, message)
+	}
+	return api, err
 }
 
-func collectChildArtifacts(ctx context.Context, client *gapic.RegistryClient, artifactPattern names.Artifact) ([]*models.Artifact, error) {
+func collectChildArtifacts(ctx context.Context, client *gapic.RegistryClient, artifactPattern names.Artifact, mode ExportMode) ([]*models.Artifact, error) {
 	artifacts := make([]*models.Artifact, 0)
 	if err := core.ListArtifacts(ctx, client, artifactPattern, "", true, func(message *rpc.Artifact) error {
 		artifact, err := newArtifact(message)
@@ -121,6 +127,9 @@ func collectChildArtifacts(ctx context.Context, client *gapic.RegistryClient, ar
 		// unset these because they can be inferred
 		artifact.ApiVersion = ""
 		artifact.Metadata.Parent = ""
+		if mode == FullFidelity {
+			setArtifactServerFields(&artifact.Metadata, message)
+		}
 		artifacts = append(artifacts, artifact)
 		return nil
 	}); err != nil {
@@ -130,8 +139,14 @@ func collectChildArtifacts(ctx context.Context, client *gapic.RegistryClient, ar
 }
 
 // ExportAPI allows an API to be individually exported as a YAML file.
+// Only fields that can be applied are included (see ApplyReady).
 func ExportAPI(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api, nested bool) ([]byte, *models.Header, error) {
-	api, err := newApi(ctx, client, message, nested)
+	return ExportAPIWithMode(ctx, client, message, nested, ApplyReady)
+}
+
+// ExportAPIWithMode exports an API as a YAML file with the fields selected by mode.
+func ExportAPIWithMode(ctx context.Context, client *gapic.RegistryClient, message *rpc.Api, nested bool, mode ExportMode) ([]byte, *models.Header, error) {
+	api, err := newApi(ctx, client, message, nested, mode)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -143,27 +158,47 @@ func ExportAPI(ctx context.Context, client *gapic.RegistryClient, message *rpc.A
 	return b.Bytes(), &api.Header, nil
 }
 
-// TODO: These functions assume that their arguments are valid names and fail the export if they aren't.
-// They are used to replace the absolute resource names stored by the API with more reusable relative
-// resource names that allow the exported files to be applied to arbitrary projects. This more
-// concise form is also more convenient for users to specify. But it requires additional validation.
-// Either we 1) only allow relative names in these fields in the YAML representation or
-// 2) allow both absolute and relative names in these fields and handle them appropriately.
-// We should probably support only one output format; for this, relative names seem preferable.
+// Registry resources refer to related resources with absolute resource names,
+// but exported files use names relative to the collections of the referring
+// resource's API, e.g. "v1" for a version or "v1/specs/openapi@latest" for a
+// spec revision. This allows exported files to be applied to arbitrary projects.
+// References to resources in other APIs are rejected because they can't be
+// represented this way.
+
+// relativeName returns the name of a resource relative to a collection of parent.
+func relativeName(parent names.Name, collection, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	relative, err := names.Relative(parent.String(), name)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(relative, collection+"/") {
+		return "", fmt.Errorf("%q is not in the %s of %q", name, collection, parent)
+	}
+	return strings.TrimPrefix(relative, collection+"/"), nil
+}
+
+// optionalName returns the name of a resource from its name relative to a collection
+// of parent if the relative name is not empty. Full resource names are rejected
+// because they would produce malformed names.
+func optionalName(parent names.Name, collection, relative string) (string, error) {
+	if relative == "" {
+		return "", nil
+	}
+	return names.Absolute(parent.String(), collection+"/"+relative)
+}
 
 // relativeVersionName returns the version id if the version is within the specified API
 func relativeVersionName(apiName names.Api, version string) (string, error) {
 	if version == "" {
 		return "", nil
 	}
-	versionName, err := names.ParseVersion(version)
-	if err != nil {
+	if _, err := names.ParseVersion(version); err != nil {
 		return "", err
 	}
-	if versionName.Api().String() == apiName.String() {
-		return versionName.VersionID, nil
-	}
-	return version, nil
+	return relativeName(apiName, "versions", version)
 }
 
 // relativeDeploymentName returns the deployment id if the deployment is within the specified API
@@ -171,35 +206,32 @@ func relativeDeploymentName(apiName names.Api, deployment string) (string, error
 	if deployment == "" {
 		return "", nil
 	}
-	deploymentName, err := names.ParseDeployment(deployment)
-	if err != nil {
+	if _, err := names.ParseDeployment(deployment); err != nil {
 		return "", err
 	}
-	if deploymentName.Api().String() == apiName.String() {
-		return deploymentName.DeploymentID, nil
-	}
-	return deployment, nil
+	return relativeName(apiName, "deployments", deployment)
 }
 
-// TODO: The following functions assume that their arguments are truly an ID,
-// but there's there's no validation (here or in the caller) to prevent a full
-// resource name from being passed in. Users specifying a full resource name will
-// end up with a malformed name being stored.
-
 // optionalVersionName returns a version name if the id is not empty
-func optionalVersionName(apiName names.Api, versionID string) string {
+func optionalVersionName(apiName names.Api, versionID string) (string, error) {
 	if versionID == "" {
-		return ""
+		return "", nil
 	}
-	return apiName.Version(versionID).String()
+	if err := names.ValidateID(versionID); err != nil {
+		return "", fmt.Errorf("invalid recommended version: %s", err)
+	}
+	return optionalName(apiName, "versions", versionID)
 }
 
 // optionalDeploymentName returns a deployment name if the id is not empty
-func optionalDeploymentName(apiName names.Api, deploymentID string) string {
+func optionalDeploymentName(apiName names.Api, deploymentID string) (string, error) {
 	if deploymentID == "" {
-		return ""
+		return "", nil
 	}
-	return apiName.Deployment(deploymentID).String()
+	if err := names.ValidateID(deploymentID); err != nil {
+		return "", fmt.Errorf("invalid recommended deployment: %s", err)
+	}
+	return optionalName(apiName, "deployments", deploymentID)
 }
 
 func applyApiPatchBytes(ctx context.Context, client connection.RegistryClient, bytes []byte, parent string) error {
@@ -212,15 +244,29 @@ func applyApiPatchBytes(ctx context.Context, client connection.RegistryClient, b
 	if err != nil {
 		return err
 	}
+	// Full resource names are rejected because they would produce malformed names.
+	// Other invalid IDs are left to the server, so that APIs created before IDs
+	// were validated can still be updated.
+	if strings.Contains(api.Metadata.Name, "/") {
+		return fmt.Errorf("invalid API name %q: must be an ID, not a resource name", api.Metadata.Name)
+	}
 	apiName := projectName.Api(api.Metadata.Name)
+	recommendedVersion, err := optionalVersionName(apiName, api.Data.RecommendedVersion)
+	if err != nil {
+		return err
+	}
+	recommendedDeployment, err := optionalDeploymentName(apiName, api.Data.RecommendedDeployment)
+	if err != nil {
+		return err
+	}
 	req := &rpc.UpdateApiRequest{
 		Api: &rpc.Api{
 			Name:                  apiName.String(),
 			DisplayName:           api.Data.DisplayName,
 			Description:           api.Data.Description,
 			Availability:          api.Data.Availability,
-			RecommendedVersion:    optionalVersionName(apiName, api.Data.RecommendedVersion),
-			RecommendedDeployment: optionalDeploymentName(apiName, api.Data.RecommendedDeployment),
+			RecommendedVersion:    recommendedVersion,
+			RecommendedDeployment: recommendedDeployment,
 			Labels:                api.Metadata.Labels,
 			Annotations:           api.Metadata.Annotations,
 		},
@@ -16,17 +16,65 @@ package patch
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
 )
 
 func Apply(ctx context.Context, client connection.RegistryClient, path, parent string, recursive bool, jobs int) error {
+	return applyFiles(ctx, client, path, parent, recursive, jobs, nil)
+}
+
+// AppliedHashAnnotation is the annotation of resources applied with ApplyChanged.
+// It records the SHA-256 hash of the file that the resource was applied from.
+const AppliedHashAnnotation = "apigeeregistry/applied-hash"
+
+// ApplyStats counts the files that were processed by ApplyChanged.
+type ApplyStats struct {
+	// Applied is the number of files that were applied successfully.
+	Applied int
+	// Skipped is the number of files that were unchanged since they were last applied.
+	Skipped int
+}
+
+// ApplyChanged is like Apply but skips files that haven't changed since they
+// were last applied with ApplyChanged. The resource of each file that is applied
+// is annotated with the hash of the file, and files are skipped if their hash
+// matches the annotation of the live resource. Changes made to the resources
+// in the registry by other means don't cause files to be applied again.
+func ApplyChanged(ctx context.Context, client connection.RegistryClient, path, parent string, recursive bool, jobs int) (ApplyStats, error) {
+	counts := &applyCounts{}
+	err := applyFiles(ctx, client, path, parent, recursive, jobs, counts)
+	return ApplyStats{
+		Applied: int(atomic.LoadInt64(&counts.applied)),
+		Skipped: int(atomic.LoadInt64(&counts.skipped)),
+	}, err
+}
+
+// applyCounts counts applied and skipped files across concurrent tasks.
+type applyCounts struct {
+	applied int64
+	skipped int64
+}
+
+// applyFiles applies the files in path. If counts is non-nil, unchanged files
+// are skipped and the applied and skipped files are counted.
+func applyFiles(ctx context.Context, client connection.RegistryClient, path, parent string, recursive bool, jobs int, counts *applyCounts) error {
 	patches := &patchGroup{}
 	err := filepath.WalkDir(path,
 		func(fileName string, entry fs.DirEntry, err error) error {
@@ -43,6 +91,7 @@ func Apply(ctx context.Context, client connection.RegistryClient, path, parent s
 				client: client,
 				path:   fileName,
 				parent: parent,
+				counts: counts,
 			})
 		})
 	if err != nil {
@@ -110,6 +159,7 @@ type applyFileTask struct {
 	path   string
 	parent string
 	kind   string
+	counts *applyCounts
 }
 
 func (task *applyFileTask) String() string {
@@ -117,21 +167,154 @@ func (task *applyFileTask) String() string {
 }
 
 func (task *applyFileTask) Run(ctx context.Context) error {
-	log.FromContext(ctx).Infof("Applying %s", task.path)
 	bytes, err := os.ReadFile(task.path)
 	if err != nil {
 		return err
 	}
-	switch task.kind {
+	if task.counts == nil {
+		log.FromContext(ctx).Infof("Applying %s", task.path)
+		return applyPatchBytes(ctx, task.client, task.kind, bytes, task.parent)
+	}
+
+	sum := sha256.Sum256(bytes)
+	hash := hex.EncodeToString(sum[:])
+	applied, err := appliedHash(ctx, task.client, task.kind, bytes, task.parent)
+	if err != nil {
+		return err
+	}
+	if applied == hash {
+		log.FromContext(ctx).Debugf("Skipping unchanged %s", task.path)
+		atomic.AddInt64(&task.counts.skipped, 1)
+		return nil
+	}
+	if bytes, err = setAnnotation(bytes, AppliedHashAnnotation, hash); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Infof("Applying %s", task.path)
+	if err := applyPatchBytes(ctx, task.client, task.kind, bytes, task.parent); err != nil {
+		return err
+	}
+	atomic.AddInt64(&task.counts.applied, 1)
+	return nil
+}
+
+// appliedHash returns the AppliedHashAnnotation of the live resource of a file,
+// or "" if the resource doesn't exist or doesn't have one.
+func appliedHash(ctx context.Context, client connection.RegistryClient, kind string, bytes []byte, parent string) (string, error) {
+	header, err := readHeader(bytes)
+	if err != nil {
+		return "", err
+	}
+	id := header.Metadata.Name
+	var annotations map[string]string
+	switch kind {
+	case "API":
+		var api *rpc.Api
+		api, err = client.GetApi(ctx, &rpc.GetApiRequest{Name: parent + "/apis/" + id})
+		annotations = api.GetAnnotations()
+	case "Version":
+		var version *rpc.ApiVersion
+		version, err = client.GetApiVersion(ctx, &rpc.GetApiVersionRequest{Name: parent + "/versions/" + id})
+		annotations = version.GetAnnotations()
+	case "Spec":
+		var spec *rpc.ApiSpec
+		spec, err = client.GetApiSpec(ctx, &rpc.GetApiSpecRequest{Name: parent + "/specs/" + id})
+		annotations = spec.GetAnnotations()
+	case "Deployment":
+		var deployment *rpc.ApiDeployment
+		deployment, err = client.GetApiDeployment(ctx, &rpc.GetApiDeploymentRequest{Name: parent + "/deployments/" + id})
+		annotations = deployment.GetAnnotations()
+	default:
+		var artifact *rpc.Artifact
+		artifact, err = client.GetArtifact(ctx, &rpc.GetArtifactRequest{Name: parent + "/artifacts/" + id})
+		annotations = artifact.GetAnnotations()
+	}
+	if status.Code(err) == codes.NotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return annotations[AppliedHashAnnotation], nil
+}
+
+// setAnnotation sets an annotation in the metadata of the YAML representation of a resource.
+func setAnnotation(b []byte, key, value string) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("invalid resource: expected a YAML mapping")
+	}
+	metadata := mappingValue(doc.Content[0], "metadata")
+	annotations := mappingValue(metadata, "annotations")
+	if v := valueForKey(annotations, key); v != nil {
+		v.SetString(value)
+	} else {
+		annotations.Content = append(annotations.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value})
+	}
+	return yaml.Marshal(&doc)
+}
+
+// mappingValue returns the mapping with the specified key in node, adding it if necessary.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if v := valueForKey(node, key); v != nil {
+		if v.Kind != yaml.MappingNode {
+			*v = yaml.Node{Kind: yaml.MappingNode}
+		}
+		return v
+	}
+	v := &yaml.Node{Kind: yaml.MappingNode}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, v)
+	return v
+}
+
+// valueForKey returns the value with the specified key in a mapping node, or nil if there isn't one.
+func valueForKey(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// ApplyReader applies a single resource whose YAML representation is read from r.
+// parent is the project location that the resource is applied to; the parent
+// in the document's metadata is relative to it. The kind in the document's
+// header selects how the resource is applied.
+func ApplyReader(ctx context.Context, client connection.RegistryClient, r io.Reader, parent string) error {
+	bytes, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	header, err := readHeader(bytes)
+	if err != nil {
+		return err
+	}
+	if header.Metadata.Parent != "" {
+		parent = parent + "/" + header.Metadata.Parent
+	}
+	return applyPatchBytes(ctx, client, header.Kind, bytes, parent)
+}
+
+// applyPatchBytes applies the YAML representation of a resource of the specified kind.
+func applyPatchBytes(ctx context.Context, client connection.RegistryClient, kind string, bytes []byte, parent string) error {
+	switch kind {
 	case "API":
-		return applyApiPatchBytes(ctx, task.client, bytes, task.parent)
+		return applyApiPatchBytes(ctx, client, bytes, parent)
 	case "Version":
-		return applyApiVersionPatchBytes(ctx, task.client, bytes, task.parent)
+		return applyApiVersionPatchBytes(ctx, client, bytes, parent)
 	case "Spec":
-		return applyApiSpecPatchBytes(ctx, task.client, bytes, task.parent)
+		return applyApiSpecPatchBytes(ctx, client, bytes, parent)
 	case "Deployment":
-		return applyApiDeploymentPatchBytes(ctx, task.client, bytes, task.parent)
+		return applyApiDeploymentPatchBytes(ctx, client, bytes, parent)
 	default: // for everything else, try an artifact type
-		return applyArtifactPatchBytes(ctx, task.client, bytes, task.parent)
+		if _, err := protoMessageForKind(kind); err != nil {
+			return fmt.Errorf("unsupported kind %q", kind)
+		}
+		return applyArtifactPatchBytes(ctx, client, bytes, parent)
 	}
 }
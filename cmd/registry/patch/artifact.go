@@ -25,6 +25,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/pkg/models"
 	"github.com/apigee/registry/rpc"
@@ -168,44 +169,89 @@ func artifactName(parent, artifactID string) (names.Artifact, error) {
 	return names.ParseArtifact(parent + "/artifacts/" + artifactID)
 }
 
-func applyArtifactPatch(ctx context.Context, client connection.RegistryClient, content *models.Artifact, parent string) error {
+// ArtifactFromYAML returns the artifact described by a YAML artifact document
+// without applying it. parent is the project location that the document would
+// be applied to; the parent in the document's metadata is relative to it.
+func ArtifactFromYAML(ctx context.Context, bytes []byte, parent string) (*rpc.Artifact, error) {
+	header, err := readHeader(bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch header.Kind {
+	case "API", "Version", "Spec", "Deployment":
+		return nil, fmt.Errorf("%s is not an artifact", header.Kind)
+	}
+	if header.Metadata.Parent != "" {
+		parent = parent + "/" + header.Metadata.Parent
+	}
+	var content models.Artifact
+	if err := yaml.Unmarshal(bytes, &content); err != nil {
+		return nil, err
+	}
+	return buildArtifact(ctx, &content, parent)
+}
+
+// buildArtifact converts the YAML representation of an artifact into an artifact.
+func buildArtifact(ctx context.Context, content *models.Artifact, parent string) (*rpc.Artifact, error) {
+	// Inline data takes precedence over data referenced by a source.
+	data := "data"
+	if content.Source != nil {
+		if content.Data.Kind != 0 {
+			log.FromContext(ctx).Warnf("Artifact %s has both data and a source, ignoring source %s", content.Metadata.Name, content.Source.URI)
+		} else if err := loadArtifactSource(ctx, content); err != nil {
+			return nil, err
+		} else {
+			data = "source " + content.Source.URI
+		}
+	}
 	// Restyle the YAML representation so that yaml.Marshal will marshal it as JSON.
 	styleForJSON(&content.Data)
 	// Marshal the YAML representation into the JSON serialization.
 	j, err := yaml.Marshal(content.Data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Populate Id and Kind fields in the contents of the artifact
 	j, err = populateIdAndKind(j, content.Kind, content.Metadata.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Unmarshal the JSON serialization into the message struct.
 	var m proto.Message
 	m, err = protoMessageForKind(content.Kind)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	err = protojson.Unmarshal(j, m)
-	if err != nil {
-		return err
+	if err := protojson.Unmarshal(j, m); err != nil {
+		return nil, fmt.Errorf("invalid %s for %s %q: not a valid %s: %s",
+			data, content.Kind, content.Metadata.Name, m.ProtoReflect().Descriptor().FullName(), err)
 	}
 	// Marshal the message struct to bytes.
 	bytes, err := proto.Marshal(m)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	name, err := artifactName(parent, content.Header.Metadata.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	artifact := &rpc.Artifact{
+	return &rpc.Artifact{
 		Name:        name.String(),
 		MimeType:    MimeTypeForKind(content.Kind),
 		Contents:    bytes,
 		Labels:      content.Metadata.Labels,
 		Annotations: content.Metadata.Annotations,
+	}, nil
+}
+
+func applyArtifactPatch(ctx context.Context, client connection.RegistryClient, content *models.Artifact, parent string) error {
+	artifact, err := buildArtifact(ctx, content, parent)
+	if err != nil {
+		return err
+	}
+	name, err := names.ParseArtifact(artifact.GetName())
+	if err != nil {
+		return err
 	}
 	req := &rpc.CreateArtifactRequest{
 		Parent:     name.Parent(),
@@ -285,6 +331,7 @@ var artifactMessageTypes map[string]messageFactory = map[string]messageFactory{
 	"google.cloud.apigeeregistry.v1.apihub.ApiSpecExtensionList": func() proto.Message { return new(rpc.ApiSpecExtensionList) },
 	"google.cloud.apigeeregistry.v1.apihub.DisplaySettings":      func() proto.Message { return new(rpc.DisplaySettings) },
 	"google.cloud.apigeeregistry.v1.apihub.Lifecycle":            func() proto.Message { return new(rpc.Lifecycle) },
+	"google.cloud.apigeeregistry.v1.apihub.ProjectSummary":       func() proto.Message { return new(rpc.ProjectSummary) },
 	"google.cloud.apigeeregistry.v1.apihub.ReferenceList":        func() proto.Message { return new(rpc.ReferenceList) },
 	"google.cloud.apigeeregistry.v1.apihub.TaxonomyList":         func() proto.Message { return new(rpc.TaxonomyList) },
 	"google.cloud.apigeeregistry.v1.controller.Manifest":         func() proto.Message { return new(rpc.Manifest) },
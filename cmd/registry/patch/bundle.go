@@ -0,0 +1,334 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"gopkg.in/yaml.v3"
+)
+
+// BundleFormat selects the archive format of a bundle.
+type BundleFormat int
+
+const (
+	// TarBundle bundles are uncompressed tar archives.
+	TarBundle BundleFormat = iota
+	// ZipBundle bundles are zip archives.
+	ZipBundle
+)
+
+// BundleManifestPath is the path of the manifest in a bundle.
+const BundleManifestPath = "manifest.yaml"
+
+// BundleManifest is the index of a bundle. It is the first file of a bundle.
+type BundleManifest struct {
+	// Contents lists the other files of the bundle in the order they appear,
+	// which is the order in which they are applied.
+	Contents []BundleEntry `yaml:"contents"`
+}
+
+// BundleEntry describes a file in a bundle.
+type BundleEntry struct {
+	// Path is the path of the file in the bundle.
+	Path string `yaml:"path"`
+	// Kind is the kind of the resource in the file.
+	Kind string `yaml:"kind"`
+	// Name is the name of the resource relative to its project,
+	// e.g. "apis/petstore" or "artifacts/lint-config".
+	Name string `yaml:"name"`
+}
+
+// bundleKindOrder ranks the kinds of bundle entries so that parents are applied first.
+func bundleKindOrder(kind string) int {
+	if kind == "API" {
+		return 0
+	}
+	return 1
+}
+
+// ExportProjectBundle writes a project to w as an archive with one YAML file
+// per API and per project-level artifact, preceded by a BundleManifest.
+// APIs are exported with their versions, specs, deployments, and all of their
+// artifacts. Only the metadata of the project's APIs and artifacts is held in
+// memory; the contents of each file are fetched and written in turn.
+// Artifacts of the generic "Artifact" kind are skipped because they cannot be
+// represented in YAML.
+func ExportProjectBundle(ctx context.Context, client *gapic.RegistryClient, projectName names.Project, w io.Writer, format BundleFormat) error {
+	if err := projectName.Validate(); err != nil {
+		return err
+	}
+	var bw bundleWriter
+	switch format {
+	case TarBundle:
+		bw = &tarBundleWriter{w: tar.NewWriter(w), modTime: time.Now()}
+	case ZipBundle:
+		bw = &zipBundleWriter{w: zip.NewWriter(w)}
+	default:
+		return fmt.Errorf("unsupported bundle format %d", format)
+	}
+
+	var manifest BundleManifest
+	var apis []*rpc.Api
+	err := core.ListAPIs(ctx, client, projectName.Api(""), "", func(message *rpc.Api) error {
+		entry, err := newBundleEntry(projectName, message.GetName(), "apis", "API")
+		if err != nil {
+			return err
+		}
+		manifest.Contents = append(manifest.Contents, entry)
+		apis = append(apis, message)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	var artifacts []*rpc.Artifact
+	err = core.ListArtifacts(ctx, client, projectName.Artifact(""), "", false, func(message *rpc.Artifact) error {
+		if _, err := protoMessageForMimeType(message.GetMimeType()); err != nil {
+			log.FromContext(ctx).Warnf("Skipped %s", message.GetName())
+			return nil
+		}
+		entry, err := newBundleEntry(projectName, message.GetName(), "artifacts", kindForMimeType(message.GetMimeType()))
+		if err != nil {
+			return err
+		}
+		manifest.Contents = append(manifest.Contents, entry)
+		artifacts = append(artifacts, message)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := yaml.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	if err := bw.WriteFile(BundleManifestPath, b); err != nil {
+		return err
+	}
+	for i, message := range apis {
+		b, _, err := ExportAPI(ctx, client, message, true)
+		if err != nil {
+			return err
+		}
+		if err := bw.WriteFile(manifest.Contents[i].Path, b); err != nil {
+			return err
+		}
+	}
+	for i, message := range artifacts {
+		b, _, err := ExportArtifact(ctx, client, message)
+		if err != nil {
+			return err
+		}
+		if err := bw.WriteFile(manifest.Contents[len(apis)+i].Path, b); err != nil {
+			return err
+		}
+	}
+	return bw.Close()
+}
+
+func newBundleEntry(projectName names.Project, name, dir, kind string) (BundleEntry, error) {
+	relative, err := names.Relative(projectName.String(), name)
+	if err != nil {
+		return BundleEntry{}, err
+	}
+	return BundleEntry{
+		Path: fmt.Sprintf("%s/%s.yaml", dir, relative[len(dir)+1:]),
+		Kind: kind,
+		Name: relative,
+	}, nil
+}
+
+// ApplyBundle applies the contents of a bundle written by ExportProjectBundle.
+// parent is the project location that the resources are applied to. The format
+// of the bundle is detected from its contents. Files are applied in the order of
+// the manifest, and bundles with files that aren't listed in their manifest or
+// that appear out of order are rejected. Tar bundles are applied as they are read;
+// zip bundles are read into memory first because zip archives are indexed at
+// their end.
+func ApplyBundle(ctx context.Context, client connection.RegistryClient, r io.Reader, parent string) error {
+	br, err := newBundleReader(r)
+	if err != nil {
+		return err
+	}
+	path, b, err := br.Next()
+	if err == io.EOF {
+		return errors.New("empty bundle")
+	} else if err != nil {
+		return err
+	}
+	if path != BundleManifestPath {
+		return fmt.Errorf("invalid bundle: the first file is %q, expected %q", path, BundleManifestPath)
+	}
+	var manifest BundleManifest
+	if err := yaml.Unmarshal(b, &manifest); err != nil {
+		return fmt.Errorf("invalid bundle manifest: %s", err)
+	}
+	for i, entry := range manifest.Contents {
+		if i > 0 && bundleKindOrder(entry.Kind) < bundleKindOrder(manifest.Contents[i-1].Kind) {
+			return fmt.Errorf("invalid bundle manifest: %s is listed after %s, which may depend on it", entry.Name, manifest.Contents[i-1].Name)
+		}
+	}
+
+	for _, entry := range manifest.Contents {
+		path, b, err := br.Next()
+		if err == io.EOF {
+			return fmt.Errorf("invalid bundle: missing %q", entry.Path)
+		} else if err != nil {
+			return err
+		}
+		if path != entry.Path {
+			return fmt.Errorf("invalid bundle: found %q, expected %q", path, entry.Path)
+		}
+		log.FromContext(ctx).Infof("Applying %s", path)
+		if err := ApplyReader(ctx, client, bytes.NewReader(b), parent); err != nil {
+			return fmt.Errorf("failed to apply %q: %s", path, err)
+		}
+	}
+	if path, _, err := br.Next(); err == nil {
+		return fmt.Errorf("invalid bundle: %q is not listed in the manifest", path)
+	} else if err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+type bundleWriter interface {
+	WriteFile(path string, b []byte) error
+	Close() error
+}
+
+type tarBundleWriter struct {
+	w       *tar.Writer
+	modTime time.Time
+}
+
+func (t *tarBundleWriter) WriteFile(path string, b []byte) error {
+	if err := t.w.WriteHeader(&tar.Header{
+		Name:    path,
+		Mode:    0644,
+		Size:    int64(len(b)),
+		ModTime: t.modTime,
+	}); err != nil {
+		return err
+	}
+	_, err := t.w.Write(b)
+	return err
+}
+
+func (t *tarBundleWriter) Close() error {
+	return t.w.Close()
+}
+
+type zipBundleWriter struct {
+	w *zip.Writer
+}
+
+func (z *zipBundleWriter) WriteFile(path string, b []byte) error {
+	f, err := z.w.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	return err
+}
+
+func (z *zipBundleWriter) Close() error {
+	return z.w.Close()
+}
+
+// bundleReader returns the files of a bundle in order.
+// Next returns io.EOF after the last file.
+type bundleReader interface {
+	Next() (string, []byte, error)
+}
+
+// zipMagic is the signature at the start of zip archives.
+var zipMagic = []byte("PK\x03\x04")
+
+func newBundleReader(r io.Reader) (bundleReader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(zipMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if !bytes.Equal(magic, zipMagic) {
+		return &tarBundleReader{r: tar.NewReader(br)}, nil
+	}
+	b, err := io.ReadAll(br)
+	if err != nil {
+		return nil, err
+	}
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	return &zipBundleReader{files: z.File}, nil
+}
+
+type tarBundleReader struct {
+	r *tar.Reader
+}
+
+func (t *tarBundleReader) Next() (string, []byte, error) {
+	for {
+		header, err := t.r.Next()
+		if err != nil {
+			return "", nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue // Skip directories and other special entries.
+		}
+		b, err := io.ReadAll(t.r)
+		return header.Name, b, err
+	}
+}
+
+type zipBundleReader struct {
+	files []*zip.File
+}
+
+func (z *zipBundleReader) Next() (string, []byte, error) {
+	for len(z.files) > 0 {
+		f := z.files[0]
+		z.files = z.files[1:]
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", nil, err
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		return f.Name, b, err
+	}
+	return "", nil, io.EOF
+}
@@ -17,7 +17,7 @@ package patch
 import (
 	"bytes"
 	"context"
-	"strings"
+	"fmt"
 
 	"github.com/apigee/registry/gapic"
 	"github.com/apigee/registry/pkg/connection"
@@ -29,7 +29,7 @@ import (
 
 // ExportAPIDeployment allows an API deployment to be individually exported as a YAML file.
 func ExportAPIDeployment(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiDeployment, nested bool) ([]byte, *models.Header, error) {
-	api, err := newApiDeployment(ctx, client, message, nested)
+	api, err := newApiDeployment(ctx, client, message, nested, ApplyReady)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -42,38 +42,32 @@ func ExportAPIDeployment(ctx context.Context, client *gapic.RegistryClient, mess
 }
 
 // relativeSpecRevisionName returns the versionid+specid if the spec is within the specified API
-func relativeSpecRevisionName(apiName names.Api, spec string) string {
-	if spec == "" {
-		return ""
-	}
-	if strings.HasPrefix(spec, apiName.String()) {
-		return strings.TrimPrefix(spec, apiName.String()+"/versions/")
-	}
-	return spec
+func relativeSpecRevisionName(apiName names.Api, spec string) (string, error) {
+	return relativeName(apiName, "versions", spec)
 }
 
 // optionalSpecRevisionName returns a spec revision name if the subpath is not empty
-func optionalSpecRevisionName(deploymentName names.Deployment, subpath string) string {
-	if subpath == "" {
-		return ""
-	}
-	return deploymentName.Api().String() + "/versions/" + subpath
+func optionalSpecRevisionName(deploymentName names.Deployment, subpath string) (string, error) {
+	return optionalName(deploymentName.Api(), "versions", subpath)
 }
 
-func newApiDeployment(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiDeployment, nested bool) (*models.ApiDeployment, error) {
+func newApiDeployment(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiDeployment, nested bool, mode ExportMode) (*models.ApiDeployment, error) {
 	deploymentName, err := names.ParseDeployment(message.Name)
 	if err != nil {
 		return nil, err
 	}
-	revisionName := relativeSpecRevisionName(deploymentName.Api(), message.ApiSpecRevision)
+	revisionName, err := relativeSpecRevisionName(deploymentName.Api(), message.ApiSpecRevision)
+	if err != nil {
+		return nil, err
+	}
 	var artifacts []*models.Artifact
 	if nested {
-		artifacts, err = collectChildArtifacts(ctx, client, deploymentName.Artifact("-"))
+		artifacts, err = collectChildArtifacts(ctx, client, deploymentName.Artifact("-"), mode)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &models.ApiDeployment{
+	deployment := &models.ApiDeployment{
 		Header: models.Header{
 			ApiVersion: RegistryV1,
 			Kind:       "Deployment",
@@ -94,7 +88,11 @@ func newApiDeployment(ctx context.Context, client *gapic.RegistryClient, message
 			ApiSpecRevision:    revisionName,
 			Artifacts:          artifacts,
 		},
-	}, nil
+	}
+	if mode == FullFidelity {
+		setDeploymentServerFields(&deployment.Metadata, message)
+	}
+	return deployment, nil
 }
 
 func applyApiDeploymentPatchBytes(ctx context.Context, client connection.RegistryClient, bytes []byte, parent string) error {
@@ -137,9 +135,9 @@ func applyApiDeploymentPatch(
 		},
 		AllowMissing: true,
 	}
-	req.ApiDeployment.ApiSpecRevision = optionalSpecRevisionName(name, deployment.Data.ApiSpecRevision)
+	req.ApiDeployment.ApiSpecRevision, err = optionalSpecRevisionName(name, deployment.Data.ApiSpecRevision)
 	if err != nil {
-		return err
+		return fmt.Errorf("invalid spec revision: %s", err)
 	}
 	_, err = client.UpdateApiDeployment(ctx, req)
 	if err != nil {
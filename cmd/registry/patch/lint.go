@@ -0,0 +1,201 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apigee/registry/pkg/models"
+)
+
+// LintSeverity indicates how serious a LintFinding is.
+type LintSeverity int
+
+const (
+	// LintWarning marks something that can be applied but is probably a mistake.
+	LintWarning LintSeverity = iota
+	// LintError marks something that will fail or be wrong when applied.
+	LintError
+)
+
+func (s LintSeverity) String() string {
+	if s == LintError {
+		return "error"
+	}
+	return "warning"
+}
+
+// LintFinding describes a problem found by Lint.
+type LintFinding struct {
+	Severity LintSeverity
+	// Path locates the field using YAML field names, e.g. "data.versions[0].metadata.labels".
+	Path    string
+	Message string
+}
+
+func (f LintFinding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Path, f.Message)
+}
+
+const (
+	maxLabels      = 64
+	maxLabelLength = 64
+	systemLabel    = "apigeeregistry.googleapis.com/"
+)
+
+// Label keys and values can only contain lowercase letters, numeric characters,
+// underscores and dashes. International characters are allowed.
+var labelRegexp = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]*$`)
+
+// Lint checks an API model for semantic problems that YAML parsing doesn't catch,
+// including problems in any nested versions, specs, deployments, and artifacts.
+// Findings are returned in the order that their fields appear in the model.
+func Lint(api *models.Api) []LintFinding {
+	l := &linter{findings: make([]LintFinding, 0)}
+	l.metadata("metadata", api.Metadata)
+	if api.Data.DisplayName == "" {
+		l.warn("data.displayName", "display name is empty")
+	}
+
+	versions := make([]string, len(api.Data.ApiVersions))
+	for i, v := range api.Data.ApiVersions {
+		versions[i] = v.Metadata.Name
+	}
+	deployments := make([]string, len(api.Data.ApiDeployments))
+	for i, d := range api.Data.ApiDeployments {
+		deployments[i] = d.Metadata.Name
+	}
+	l.reference("data.recommendedVersion", "version", api.Data.RecommendedVersion, versions)
+	l.reference("data.recommendedDeployment", "deployment", api.Data.RecommendedDeployment, deployments)
+
+	l.duplicates("data.versions", versions)
+	for i, v := range api.Data.ApiVersions {
+		l.version(fmt.Sprintf("data.versions[%d]", i), v)
+	}
+	l.duplicates("data.deployments", deployments)
+	for i, d := range api.Data.ApiDeployments {
+		path := fmt.Sprintf("data.deployments[%d]", i)
+		l.metadata(path+".metadata", d.Metadata)
+		l.artifacts(path+".data.artifacts", d.Data.Artifacts)
+	}
+	l.artifacts("data.artifacts", api.Data.Artifacts)
+	return l.findings
+}
+
+type linter struct {
+	findings []LintFinding
+}
+
+func (l *linter) warn(path, format string, args ...interface{}) {
+	l.findings = append(l.findings, LintFinding{Severity: LintWarning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) error(path, format string, args ...interface{}) {
+	l.findings = append(l.findings, LintFinding{Severity: LintError, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) version(path string, version *models.ApiVersion) {
+	l.metadata(path+".metadata", version.Metadata)
+	if version.Data.DisplayName == "" {
+		l.warn(path+".data.displayName", "display name is empty")
+	}
+	specs := make([]string, len(version.Data.ApiSpecs))
+	for i, s := range version.Data.ApiSpecs {
+		specs[i] = s.Metadata.Name
+	}
+	l.duplicates(path+".data.specs", specs)
+	for i, s := range version.Data.ApiSpecs {
+		specPath := fmt.Sprintf("%s.data.specs[%d]", path, i)
+		l.metadata(specPath+".metadata", s.Metadata)
+		l.artifacts(specPath+".data.artifacts", s.Data.Artifacts)
+	}
+	l.artifacts(path+".data.artifacts", version.Data.Artifacts)
+}
+
+func (l *linter) artifacts(path string, artifacts []*models.Artifact) {
+	ids := make([]string, len(artifacts))
+	for i, a := range artifacts {
+		ids[i] = a.Metadata.Name
+	}
+	l.duplicates(path, ids)
+	for i, a := range artifacts {
+		l.metadata(fmt.Sprintf("%s[%d].metadata", path, i), a.Metadata)
+	}
+}
+
+func (l *linter) metadata(path string, metadata models.Metadata) {
+	if metadata.Name == "" {
+		l.error(path+".name", "name is empty")
+	}
+	if len(metadata.Labels) > maxLabels {
+		l.error(path+".labels", "%d labels exceeds the limit of %d", len(metadata.Labels), maxLabels)
+	}
+	for _, k := range sortedKeys(metadata.Labels) {
+		v := metadata.Labels[k]
+		labelPath := path + ".labels." + k
+		switch {
+		case strings.HasPrefix(k, systemLabel):
+			l.warn(labelPath, "label key %q is reserved for system use", k)
+		case k == "":
+			l.error(labelPath, "label key is empty")
+		case !labelRegexp.MatchString(k):
+			l.error(labelPath, "label key %q may only contain lowercase letters, numbers, underscores, and dashes", k)
+		case utf8.RuneCountInString(k) > maxLabelLength:
+			l.error(labelPath, "label key %q is longer than %d characters", k, maxLabelLength)
+		}
+		if !labelRegexp.MatchString(v) {
+			l.error(labelPath, "label value %q may only contain lowercase letters, numbers, underscores, and dashes", v)
+		} else if utf8.RuneCountInString(v) > maxLabelLength {
+			l.error(labelPath, "label value %q is longer than %d characters", v, maxLabelLength)
+		}
+	}
+}
+
+// reference checks that a relative reference names one of the listed children.
+// Absolute references and references from APIs without listed children aren't checked.
+func (l *linter) reference(path, kind, ref string, ids []string) {
+	if ref == "" || len(ids) == 0 || strings.Contains(ref, "/") {
+		return
+	}
+	for _, id := range ids {
+		if id == ref {
+			return
+		}
+	}
+	l.error(path, "recommended %s %q does not match any listed %s", kind, ref, kind)
+}
+
+func (l *linter) duplicates(path string, ids []string) {
+	seen := make(map[string]bool, len(ids))
+	for i, id := range ids {
+		if id != "" && seen[id] {
+			l.error(fmt.Sprintf("%s[%d].metadata.name", path, i), "duplicate name %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,344 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/pkg/models"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		desc string
+		yaml string
+		want []LintFinding
+	}{
+		{
+			desc: "no findings",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    owner: team-a
+data:
+  displayName: A
+  recommendedVersion: v1
+  recommendedDeployment: prod
+  versions:
+  - metadata:
+      name: v1
+    data:
+      displayName: V1
+      specs:
+      - metadata:
+          name: openapi
+  deployments:
+  - metadata:
+      name: prod
+`,
+			want: []LintFinding{},
+		},
+		{
+			desc: "empty name",
+			yaml: `
+metadata:
+  name: ""
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.name", Message: "name is empty"},
+			},
+		},
+		{
+			desc: "empty display names",
+			yaml: `
+metadata:
+  name: a
+data:
+  versions:
+  - metadata:
+      name: v1
+`,
+			want: []LintFinding{
+				{Severity: LintWarning, Path: "data.displayName", Message: "display name is empty"},
+				{Severity: LintWarning, Path: "data.versions[0].data.displayName", Message: "display name is empty"},
+			},
+		},
+		{
+			desc: "too many labels",
+			yaml: "metadata:\n  name: a\n  labels:\n" + manyLabels(65) + "data:\n  displayName: A\n",
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels", Message: "65 labels exceeds the limit of 64"},
+			},
+		},
+		{
+			desc: "reserved label key",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    apigeeregistry.googleapis.com/kind: api
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintWarning, Path: "metadata.labels.apigeeregistry.googleapis.com/kind", Message: `label key "apigeeregistry.googleapis.com/kind" is reserved for system use`},
+			},
+		},
+		{
+			desc: "empty label key",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    "": x
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels.", Message: "label key is empty"},
+			},
+		},
+		{
+			desc: "invalid label key",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    Owner: x
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels.Owner", Message: `label key "Owner" may only contain lowercase letters, numbers, underscores, and dashes`},
+			},
+		},
+		{
+			desc: "long label key",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    ` + strings.Repeat("k", 65) + `: x
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels." + strings.Repeat("k", 65), Message: `label key "` + strings.Repeat("k", 65) + `" is longer than 64 characters`},
+			},
+		},
+		{
+			desc: "invalid label value",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    owner: Team A
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels.owner", Message: `label value "Team A" may only contain lowercase letters, numbers, underscores, and dashes`},
+			},
+		},
+		{
+			desc: "long label value",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    owner: ` + strings.Repeat("v", 65) + `
+data:
+  displayName: A
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "metadata.labels.owner", Message: `label value "` + strings.Repeat("v", 65) + `" is longer than 64 characters`},
+			},
+		},
+		{
+			desc: "international label",
+			yaml: `
+metadata:
+  name: a
+  labels:
+    équipe: 東京
+data:
+  displayName: A
+`,
+			want: []LintFinding{},
+		},
+		{
+			desc: "unknown recommended version and deployment",
+			yaml: `
+metadata:
+  name: a
+data:
+  displayName: A
+  recommendedVersion: v2
+  recommendedDeployment: staging
+  versions:
+  - metadata:
+      name: v1
+    data:
+      displayName: V1
+  deployments:
+  - metadata:
+      name: prod
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "data.recommendedVersion", Message: `recommended version "v2" does not match any listed version`},
+				{Severity: LintError, Path: "data.recommendedDeployment", Message: `recommended deployment "staging" does not match any listed deployment`},
+			},
+		},
+		{
+			desc: "unchecked recommended version and deployment",
+			yaml: `
+metadata:
+  name: a
+data:
+  displayName: A
+  recommendedVersion: projects/p/locations/global/apis/a/versions/v2
+  recommendedDeployment: staging
+`,
+			want: []LintFinding{},
+		},
+		{
+			desc: "duplicate names",
+			yaml: `
+metadata:
+  name: a
+data:
+  displayName: A
+  versions:
+  - metadata:
+      name: v1
+    data:
+      displayName: V1
+      specs:
+      - metadata:
+          name: openapi
+      - metadata:
+          name: openapi
+  - metadata:
+      name: v1
+    data:
+      displayName: V1
+  deployments:
+  - metadata:
+      name: prod
+  - metadata:
+      name: prod
+  artifacts:
+  - metadata:
+      name: x
+  - metadata:
+      name: x
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "data.versions[1].metadata.name", Message: `duplicate name "v1"`},
+				{Severity: LintError, Path: "data.versions[0].data.specs[1].metadata.name", Message: `duplicate name "openapi"`},
+				{Severity: LintError, Path: "data.deployments[1].metadata.name", Message: `duplicate name "prod"`},
+				{Severity: LintError, Path: "data.artifacts[1].metadata.name", Message: `duplicate name "x"`},
+			},
+		},
+		{
+			desc: "nested metadata",
+			yaml: `
+metadata:
+  name: a
+data:
+  displayName: A
+  versions:
+  - metadata:
+      name: v1
+    data:
+      displayName: V1
+      specs:
+      - metadata:
+          name: openapi
+        data:
+          artifacts:
+          - metadata:
+              name: ""
+      artifacts:
+      - metadata:
+          name: x
+          labels:
+            Bad: x
+  deployments:
+  - metadata:
+      name: ""
+    data:
+      artifacts:
+      - metadata:
+          name: ""
+`,
+			want: []LintFinding{
+				{Severity: LintError, Path: "data.versions[0].data.specs[0].data.artifacts[0].metadata.name", Message: "name is empty"},
+				{Severity: LintError, Path: "data.versions[0].data.artifacts[0].metadata.labels.Bad", Message: `label key "Bad" may only contain lowercase letters, numbers, underscores, and dashes`},
+				{Severity: LintError, Path: "data.deployments[0].metadata.name", Message: "name is empty"},
+				{Severity: LintError, Path: "data.deployments[0].data.artifacts[0].metadata.name", Message: "name is empty"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			api := &models.Api{}
+			if err := yaml.Unmarshal([]byte(test.yaml), api); err != nil {
+				t.Fatalf("Setup: failed to parse API: %s", err)
+			}
+			got := Lint(api)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("Lint() returned unexpected findings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLintFindingString(t *testing.T) {
+	tests := []struct {
+		finding LintFinding
+		want    string
+	}{
+		{
+			finding: LintFinding{Severity: LintError, Path: "metadata.name", Message: "name is empty"},
+			want:    "error: metadata.name: name is empty",
+		},
+		{
+			finding: LintFinding{Severity: LintWarning, Path: "data.displayName", Message: "display name is empty"},
+			want:    "warning: data.displayName: display name is empty",
+		},
+	}
+	for _, test := range tests {
+		if got := test.finding.String(); got != test.want {
+			t.Errorf("String() returned %q, want %q", got, test.want)
+		}
+	}
+}
+
+// manyLabels returns YAML for n distinct labels indented under a metadata field.
+func manyLabels(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "    l%d: v\n", i)
+	}
+	return b.String()
+}
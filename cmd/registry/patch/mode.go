@@ -0,0 +1,82 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"time"
+
+	"github.com/apigee/registry/pkg/models"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportMode selects which fields are included in exported YAML.
+type ExportMode int
+
+const (
+	// ApplyReady exports only the fields that can be set with "registry apply":
+	// the name, parent, labels, and annotations in metadata and the data of
+	// each resource. Exporting the same resources twice gives identical output.
+	ApplyReady ExportMode = iota
+	// FullFidelity also exports fields that are populated by the server, which
+	// is useful for backups. These fields are added to metadata and are ignored
+	// by "registry apply":
+	//   - APIs and versions: createTime, updateTime
+	//   - specs: createTime, revisionID, revisionCreateTime, revisionUpdateTime, hash, sizeBytes
+	//   - deployments: createTime, revisionID, revisionCreateTime, revisionUpdateTime
+	//   - artifacts: createTime, updateTime, hash, sizeBytes
+	FullFidelity
+)
+
+// serverTime formats a server-populated timestamp for export.
+func serverTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339Nano)
+}
+
+func setApiServerFields(metadata *models.Metadata, message *rpc.Api) {
+	metadata.CreateTime = serverTime(message.CreateTime)
+	metadata.UpdateTime = serverTime(message.UpdateTime)
+}
+
+func setVersionServerFields(metadata *models.Metadata, message *rpc.ApiVersion) {
+	metadata.CreateTime = serverTime(message.CreateTime)
+	metadata.UpdateTime = serverTime(message.UpdateTime)
+}
+
+func setSpecServerFields(metadata *models.Metadata, message *rpc.ApiSpec) {
+	metadata.CreateTime = serverTime(message.CreateTime)
+	metadata.RevisionID = message.RevisionId
+	metadata.RevisionCreateTime = serverTime(message.RevisionCreateTime)
+	metadata.RevisionUpdateTime = serverTime(message.RevisionUpdateTime)
+	metadata.Hash = message.Hash
+	metadata.SizeBytes = message.SizeBytes
+}
+
+func setDeploymentServerFields(metadata *models.Metadata, message *rpc.ApiDeployment) {
+	metadata.CreateTime = serverTime(message.CreateTime)
+	metadata.RevisionID = message.RevisionId
+	metadata.RevisionCreateTime = serverTime(message.RevisionCreateTime)
+	metadata.RevisionUpdateTime = serverTime(message.RevisionUpdateTime)
+}
+
+func setArtifactServerFields(metadata *models.Metadata, message *rpc.Artifact) {
+	metadata.CreateTime = serverTime(message.CreateTime)
+	metadata.UpdateTime = serverTime(message.UpdateTime)
+	metadata.Hash = message.Hash
+	metadata.SizeBytes = message.SizeBytes
+}
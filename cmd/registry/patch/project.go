@@ -15,8 +15,10 @@
 package patch
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 
@@ -61,6 +63,108 @@ func ExportProject(ctx context.Context, client *gapic.RegistryClient, projectNam
 	})
 }
 
+// ExportProjectStream returns a reader that streams a project as a sequence of
+// YAML documents separated by "---". Documents are produced as the reader is
+// consumed, so the project is never held in memory in its entirety.
+//
+// APIs are written first, in the order they are listed by the registry, and
+// each API document contains its versions, specs, and deployments. When nested
+// is true, these include their child artifacts and the stream ends with the
+// project-level artifacts. Since every document only refers to resources that
+// appear in it or before it, the stream can be reapplied in order.
+// Artifacts of the generic "Artifact" kind are skipped because they cannot be
+// represented in YAML.
+//
+// Callers must close the reader. Closing it or canceling ctx stops the export,
+// and reads after ctx is canceled return its error.
+func ExportProjectStream(ctx context.Context, client *gapic.RegistryClient, projectName names.Project, nested bool) (io.ReadCloser, error) {
+	if err := projectName.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	r, w := io.Pipe()
+	go func() {
+		defer cancel()
+		w.CloseWithError(exportProjectDocuments(ctx, client, projectName, nested, w))
+	}()
+	go func() {
+		// Closing the writer unblocks a write that is waiting for a reader.
+		// It has no effect if the export has already finished.
+		<-ctx.Done()
+		w.CloseWithError(ctx.Err())
+	}()
+	return &projectStream{PipeReader: r, cancel: cancel}, nil
+}
+
+// projectStream is the reader of a project export.
+type projectStream struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+// Close stops the export.
+func (s *projectStream) Close() error {
+	s.cancel()
+	return s.PipeReader.CloseWithError(context.Canceled)
+}
+
+func exportProjectDocuments(ctx context.Context, client *gapic.RegistryClient, projectName names.Project, nested bool, w io.Writer) error {
+	enc := yamlEncoder(w)
+	err := core.ListAPIs(ctx, client, projectName.Api(""), "", func(message *rpc.Api) error {
+		api, err := newApi(ctx, client, message, nested, ApplyReady)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(api)
+	})
+	if err != nil {
+		return err
+	}
+
+	if nested {
+		if err := encodeProjectArtifacts(ctx, client, projectName, enc); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+// ExportProjectArtifacts returns the artifacts of a project as a sequence of
+// YAML documents separated by "---". Only artifacts that belong directly to
+// the project are included; artifacts of APIs and their children are exported
+// with them. Artifacts of the generic "Artifact" kind are skipped because they
+// cannot be represented in YAML.
+func ExportProjectArtifacts(ctx context.Context, client *gapic.RegistryClient, projectName names.Project) ([]byte, error) {
+	if err := projectName.Validate(); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	enc := yamlEncoder(&b)
+	if err := encodeProjectArtifacts(ctx, client, projectName, enc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func encodeProjectArtifacts(ctx context.Context, client *gapic.RegistryClient, projectName names.Project, enc *YAMLEncoder) error {
+	return core.ListArtifacts(ctx, client, projectName.Artifact(""), "", true, func(message *rpc.Artifact) error {
+		artifact, err := newArtifact(message)
+		if err != nil {
+			log.FromContext(ctx).Warnf("Skipped %s: %s", message.Name, err)
+			return nil
+		}
+		if artifact.Kind == "Artifact" { // "Artifact" is the generic artifact type
+			log.FromContext(ctx).Warnf("Skipped %s", message.Name)
+			return nil
+		}
+		return enc.Encode(artifact)
+	})
+}
+
 type exportAPITask struct {
 	client  connection.RegistryClient
 	message *rpc.Api
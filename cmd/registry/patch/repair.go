@@ -0,0 +1,71 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/server/registry/names"
+)
+
+// collections are the collection segments of resource names.
+var collections = map[string]bool{
+	"projects":    true,
+	"locations":   true,
+	"apis":        true,
+	"versions":    true,
+	"specs":       true,
+	"deployments": true,
+	"artifacts":   true,
+}
+
+// RepairArtifactName returns the name that a stored artifact name was meant
+// to have. Malformed names were stored when full or relative resource names
+// were used where IDs were expected, e.g.
+// "projects/p/locations/global/apis/projects/p/locations/global/apis/a/artifacts/x"
+// or "projects/p/locations/global/apis/a/versions/versions/v/artifacts/x".
+// Valid names are returned unchanged. Names that can't be repaired, including
+// names that embed a name from another project, return an error.
+func RepairArtifactName(stored string) (fixed string, changed bool, err error) {
+	if _, err := names.ParseArtifact(stored); err == nil {
+		return stored, false, nil
+	}
+
+	fixed = stored
+	// An embedded full name replaces everything before it.
+	if i := strings.LastIndex(fixed, "/projects/"); i >= 0 {
+		fixed = fixed[i+1:]
+	}
+	// An embedded relative name repeats its collection, e.g. "versions/versions/v".
+	segments := strings.Split(fixed, "/")
+	kept := make([]string, 0, len(segments))
+	for i, s := range segments {
+		if collections[s] && i+1 < len(segments) && segments[i+1] == s {
+			continue
+		}
+		kept = append(kept, s)
+	}
+	fixed = strings.Join(kept, "/")
+
+	name, err := names.ParseArtifact(fixed)
+	if err != nil {
+		return "", false, fmt.Errorf("can't repair %q: %s", stored, err)
+	}
+	if !strings.HasPrefix(stored, fmt.Sprintf("projects/%s/", name.ProjectID())) {
+		return "", false, fmt.Errorf("can't repair %q: it contains the name of an artifact in project %q", stored, name.ProjectID())
+	}
+	return name.String(), true, nil
+}
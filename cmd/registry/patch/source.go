@@ -0,0 +1,149 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/apigee/registry/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// MaxArtifactSourceSize is the maximum size in bytes of artifact data
+// that is fetched from the source URI of an artifact.
+var MaxArtifactSourceSize int64 = 32 << 20
+
+// ContentFetcher fetches the file that a URL refers to.
+// Fetchers should read at most limit+1 bytes so that oversized
+// files can be rejected without reading them completely.
+type ContentFetcher func(ctx context.Context, u *url.URL, limit int64) ([]byte, error)
+
+var (
+	fetchersMu sync.RWMutex
+	fetchers   = map[string]ContentFetcher{
+		"http":  fetchHTTP,
+		"https": fetchHTTP,
+	}
+)
+
+// RegisterContentFetcher registers the fetcher used for artifact sources
+// with the specified URL scheme, e.g. "gs" for Cloud Storage.
+// It replaces any fetcher previously registered for the scheme.
+func RegisterContentFetcher(scheme string, f ContentFetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+	fetchers[strings.ToLower(scheme)] = f
+}
+
+func contentFetcher(scheme string) (ContentFetcher, bool) {
+	fetchersMu.RLock()
+	defer fetchersMu.RUnlock()
+	f, ok := fetchers[strings.ToLower(scheme)]
+	return f, ok
+}
+
+func fetchHTTP(ctx context.Context, u *url.URL, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, limit+1))
+}
+
+// fetchArtifactSource returns the file that source refers to after checking
+// its size and, if source has a digest, its digest.
+func fetchArtifactSource(ctx context.Context, source *models.ArtifactSource) ([]byte, error) {
+	u, err := url.Parse(source.URI)
+	if err != nil {
+		return nil, err
+	}
+	fetch, ok := contentFetcher(u.Scheme)
+	if !ok {
+		return nil, fmt.Errorf("unsupported source %q: no fetcher is registered for scheme %q", source.URI, u.Scheme)
+	}
+	b, err := fetch(ctx, u, MaxArtifactSourceSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %s", source.URI, err)
+	}
+	if int64(len(b)) > MaxArtifactSourceSize {
+		return nil, fmt.Errorf("source %s is larger than %d bytes", source.URI, MaxArtifactSourceSize)
+	}
+	if err := verifyDigest(b, source.Digest); err != nil {
+		return nil, fmt.Errorf("source %s: %s", source.URI, err)
+	}
+	return b, nil
+}
+
+// verifyDigest returns an error if b doesn't match digest.
+// An empty digest matches everything.
+func verifyDigest(b []byte, digest string) error {
+	if digest == "" {
+		return nil
+	}
+	algorithm, want, ok := strings.Cut(digest, ":")
+	if !ok {
+		return fmt.Errorf("invalid digest %q, want {algorithm}:{hex}", digest)
+	}
+	var sum []byte
+	switch strings.ToLower(algorithm) {
+	case "sha256":
+		s := sha256.Sum256(b)
+		sum = s[:]
+	case "sha512":
+		s := sha512.Sum512(b)
+		sum = s[:]
+	default:
+		return fmt.Errorf("unsupported digest algorithm %q", algorithm)
+	}
+	if got := hex.EncodeToString(sum); !strings.EqualFold(got, want) {
+		return fmt.Errorf("digest mismatch: want %s, got %s:%s", digest, algorithm, got)
+	}
+	return nil
+}
+
+// loadArtifactSource replaces the data of artifact with the file that its source refers to.
+func loadArtifactSource(ctx context.Context, artifact *models.Artifact) error {
+	b, err := fetchArtifactSource(ctx, artifact.Source)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("invalid source %s: %s", artifact.Source.URI, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 {
+		return errors.New("invalid source " + artifact.Source.URI + ": expected a single YAML or JSON document")
+	}
+	artifact.Data = *doc.Content[0]
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apigee/registry/pkg/models"
+	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const sourceLifecycle = `{"displayName": "Source", "stages": [{"id": "concept"}]}`
+
+func sha256Digest(b []byte) string {
+	s := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(s[:])
+}
+
+func sha512Digest(b []byte) string {
+	s := sha512.Sum512(b)
+	return "sha512:" + hex.EncodeToString(s[:])
+}
+
+// serveSource starts a server that serves body at every path and counts requests.
+func serveSource(t *testing.T, body string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(s.Close)
+	return s, &requests
+}
+
+func TestFetchArtifactSource(t *testing.T) {
+	s, _ := serveSource(t, sourceLifecycle)
+	body := []byte(sourceLifecycle)
+
+	RegisterContentFetcher("Test-Source", func(ctx context.Context, u *url.URL, limit int64) ([]byte, error) {
+		if u.Host == "fail" {
+			return nil, fmt.Errorf("no such bucket %q", u.Host)
+		}
+		return []byte("custom:" + u.Host + u.Path), nil
+	})
+
+	tests := []struct {
+		desc    string
+		source  *models.ArtifactSource
+		want    string
+		wantErr string
+	}{
+		{
+			desc:   "no digest",
+			source: &models.ArtifactSource{URI: s.URL + "/lifecycle.json"},
+			want:   sourceLifecycle,
+		},
+		{
+			desc:   "sha256 match",
+			source: &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: sha256Digest(body)},
+			want:   sourceLifecycle,
+		},
+		{
+			desc:   "sha512 match",
+			source: &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: sha512Digest(body)},
+			want:   sourceLifecycle,
+		},
+		{
+			desc:   "uppercase digest",
+			source: &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: strings.ToUpper(sha256Digest(body))},
+			want:   sourceLifecycle,
+		},
+		{
+			desc:    "digest mismatch",
+			source:  &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: sha256Digest([]byte("other"))},
+			wantErr: "digest mismatch",
+		},
+		{
+			desc:    "invalid digest",
+			source:  &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: "abc"},
+			wantErr: "invalid digest",
+		},
+		{
+			desc:    "unsupported digest algorithm",
+			source:  &models.ArtifactSource{URI: s.URL + "/lifecycle.json", Digest: "md5:abc"},
+			wantErr: "unsupported digest algorithm",
+		},
+		{
+			desc:    "http error",
+			source:  &models.ArtifactSource{URI: s.URL + "/missing"},
+			wantErr: "404",
+		},
+		{
+			desc:    "unknown scheme",
+			source:  &models.ArtifactSource{URI: "ftp://example.com/lifecycle.json"},
+			wantErr: `no fetcher is registered for scheme "ftp"`,
+		},
+		{
+			desc:   "registered fetcher",
+			source: &models.ArtifactSource{URI: "test-source://bucket/lifecycle.json"},
+			want:   "custom:bucket/lifecycle.json",
+		},
+		{
+			desc:    "registered fetcher error",
+			source:  &models.ArtifactSource{URI: "test-source://fail/lifecycle.json"},
+			wantErr: "no such bucket",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := fetchArtifactSource(context.Background(), test.source)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("fetchArtifactSource(%+v) returned error %v, want %q", test.source, err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetchArtifactSource(%+v) returned error: %s", test.source, err)
+			}
+			if string(got) != test.want {
+				t.Errorf("fetchArtifactSource(%+v) returned %q, want %q", test.source, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFetchArtifactSourceSizeLimit(t *testing.T) {
+	body := strings.Repeat("a", 100)
+	s, _ := serveSource(t, body)
+
+	limit := MaxArtifactSourceSize
+	t.Cleanup(func() { MaxArtifactSourceSize = limit })
+
+	MaxArtifactSourceSize = int64(len(body))
+	if _, err := fetchArtifactSource(context.Background(), &models.ArtifactSource{URI: s.URL}); err != nil {
+		t.Errorf("fetchArtifactSource() returned error for a source of exactly %d bytes: %s", len(body), err)
+	}
+
+	MaxArtifactSourceSize = int64(len(body) - 1)
+	_, err := fetchArtifactSource(context.Background(), &models.ArtifactSource{URI: s.URL})
+	if err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("fetchArtifactSource() returned error %v for a source of %d bytes, want size limit error", err, len(body))
+	}
+}
+
+func TestBuildArtifactSource(t *testing.T) {
+	s, requests := serveSource(t, sourceLifecycle)
+
+	tests := []struct {
+		desc         string
+		yaml         string
+		want         string
+		wantRequests int32
+	}{
+		{
+			desc: "source",
+			yaml: fmt.Sprintf(`apiVersion: apigeeregistry/v1
+kind: Lifecycle
+metadata:
+  name: lifecycle
+source:
+  uri: %s/lifecycle.json
+  digest: %s
+`, s.URL, sha256Digest([]byte(sourceLifecycle))),
+			want:         "Source",
+			wantRequests: 1,
+		},
+		{
+			desc: "inline data takes precedence",
+			yaml: fmt.Sprintf(`apiVersion: apigeeregistry/v1
+kind: Lifecycle
+metadata:
+  name: lifecycle
+data:
+  displayName: Inline
+source:
+  uri: %s/lifecycle.json
+`, s.URL),
+			want:         "Inline",
+			wantRequests: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			atomic.StoreInt32(requests, 0)
+			artifact, err := ArtifactFromYAML(context.Background(), []byte(test.yaml), "projects/source-test/locations/global")
+			if err != nil {
+				t.Fatalf("ArtifactFromYAML() returned error: %s", err)
+			}
+			m := &rpc.Lifecycle{}
+			if err := proto.Unmarshal(artifact.GetContents(), m); err != nil {
+				t.Fatalf("Failed to unmarshal contents: %s", err)
+			}
+			if m.GetDisplayName() != test.want {
+				t.Errorf("ArtifactFromYAML() returned display name %q, want %q", m.GetDisplayName(), test.want)
+			}
+			if got := atomic.LoadInt32(requests); got != test.wantRequests {
+				t.Errorf("ArtifactFromYAML() made %d requests to the source, want %d", got, test.wantRequests)
+			}
+		})
+	}
+}
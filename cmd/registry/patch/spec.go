@@ -35,7 +35,7 @@ import (
 
 // ExportAPISpec allows an API spec to be individually exported as a YAML file.
 func ExportAPISpec(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiSpec, nested bool) ([]byte, *models.Header, error) {
-	api, err := newApiSpec(ctx, client, message, nested)
+	api, err := newApiSpec(ctx, client, message, nested, ApplyReady)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -47,19 +47,20 @@ func ExportAPISpec(ctx context.Context, client *gapic.RegistryClient, message *r
 	return b.Bytes(), &api.Header, nil
 }
 
-func newApiSpec(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiSpec, nested bool) (*models.ApiSpec, error) {
+func newApiSpec(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiSpec, nested bool, mode ExportMode) (*models.ApiSpec, error) {
 	specName, err := names.ParseSpec(message.Name)
 	if err != nil {
 		return nil, err
 	}
 	var artifacts []*models.Artifact
 	if nested {
-		artifacts, err = collectChildArtifacts(ctx, client, specName.Artifact("-"))
+		artifacts, err = collectChildArtifacts(ctx, client, specName.Artifact("-"), mode)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &models.ApiSpec{
+	// RevisionTags are left empty because the API has no method that lists the tags of a revision.
+	spec := &models.ApiSpec{
 		Header: models.Header{
 			ApiVersion: RegistryV1,
 			Kind:       "Spec",
@@ -77,7 +78,11 @@ func newApiSpec(ctx context.Context, client *gapic.RegistryClient, message *rpc.
 			SourceURI:   message.SourceUri,
 			Artifacts:   artifacts,
 		},
-	}, nil
+	}
+	if mode == FullFidelity {
+		setSpecServerFields(&spec.Metadata, message)
+	}
+	return spec, nil
 }
 
 func applyApiSpecPatchBytes(
@@ -183,10 +188,20 @@ func applyApiSpecPatch(
 			}
 		}
 	}
-	_, err = client.UpdateApiSpec(ctx, req)
+	response, err := client.UpdateApiSpec(ctx, req)
 	if err != nil {
 		return err
 	}
+	// Tag the current revision, which is unchanged if the contents are unchanged.
+	for _, tag := range spec.Data.RevisionTags {
+		_, err = client.TagApiSpecRevision(ctx, &rpc.TagApiSpecRevisionRequest{
+			Name: name.Revision(response.GetRevisionId()).String(),
+			Tag:  tag,
+		})
+		if err != nil {
+			return err
+		}
+	}
 	for _, artifactPatch := range spec.Data.Artifacts {
 		err = applyArtifactPatch(ctx, client, artifactPatch, name.String())
 		if err != nil {
@@ -29,7 +29,7 @@ import (
 
 // ExportAPIVersion allows an API version to be individually exported as a YAML file.
 func ExportAPIVersion(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiVersion, nested bool) ([]byte, *models.Header, error) {
-	api, err := newApiVersion(ctx, client, message, nested)
+	api, err := newApiVersion(ctx, client, message, nested, ApplyReady)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -41,7 +41,7 @@ func ExportAPIVersion(ctx context.Context, client *gapic.RegistryClient, message
 	return b.Bytes(), &api.Header, nil
 }
 
-func newApiVersion(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiVersion, nested bool) (*models.ApiVersion, error) {
+func newApiVersion(ctx context.Context, client *gapic.RegistryClient, message *rpc.ApiVersion, nested bool, mode ExportMode) (*models.ApiVersion, error) {
 	versionName, err := names.ParseVersion(message.Name)
 	if err != nil {
 		return nil, err
@@ -52,7 +52,7 @@ func newApiVersion(ctx context.Context, client *gapic.RegistryClient, message *r
 	if nested {
 		specs = make([]*models.ApiSpec, 0)
 		if err = core.ListSpecs(ctx, client, versionName.Spec("-"), "", func(message *rpc.ApiSpec) error {
-			spec, err := newApiSpec(ctx, client, message, true)
+			spec, err := newApiSpec(ctx, client, message, true, mode)
 			if err != nil {
 				return err
 			}
@@ -65,12 +65,12 @@ func newApiVersion(ctx context.Context, client *gapic.RegistryClient, message *r
 		}); err != nil {
 			return nil, err
 		}
-		artifacts, err = collectChildArtifacts(ctx, client, versionName.Artifact("-"))
+		artifacts, err = collectChildArtifacts(ctx, client, versionName.Artifact("-"), mode)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return &models.ApiVersion{
+	version := &models.ApiVersion{
 		Header: models.Header{
 			ApiVersion: RegistryV1,
 			Kind:       "Version",
@@ -88,7 +88,11 @@ func newApiVersion(ctx context.Context, client *gapic.RegistryClient, message *r
 			ApiSpecs:    specs,
 			Artifacts:   artifacts,
 		},
-	}, nil
+	}
+	if mode == FullFidelity {
+		setVersionServerFields(&version.Metadata, message)
+	}
+	return version, nil
 }
 
 func applyApiVersionPatchBytes(
@@ -15,14 +15,170 @@
 package patch
 
 import (
+	"bytes"
+	"fmt"
 	"io"
 
 	"gopkg.in/yaml.v3"
 )
 
+// YAMLOptions controls the formatting of exported YAML.
+// Long strings are never wrapped, regardless of these options.
+type YAMLOptions struct {
+	// Indent is the number of spaces used for each level of indentation.
+	Indent int
+	// FlowMaxEntries is the largest number of entries in a map of scalar values
+	// that is written in flow style ("{a: b, c: d}"). Zero disables flow style.
+	FlowMaxEntries int
+	// ArtifactAnchors writes artifacts that are identical to an earlier
+	// artifact in the same document as aliases of it. The earlier artifact
+	// is given an anchor named after it.
+	ArtifactAnchors bool
+}
+
+// DefaultYAMLOptions uses tighter 2-space indentation and block style for all maps.
+// Export functions write YAML with these options; use FormatYAML to apply others.
+var DefaultYAMLOptions = YAMLOptions{Indent: 2}
+
+// FormatYAML re-encodes a stream of YAML documents, such as an exported file,
+// with the formatting of opts.
+func FormatYAML(b []byte, opts YAMLOptions) ([]byte, error) {
+	var out bytes.Buffer
+	enc := NewYAMLEncoder(&out, opts)
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	for {
+		var doc yaml.Node
+		if err := dec.Decode(&doc); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if err := enc.Encode(doc.Content[0]); err != nil {
+			return nil, err
+		}
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// YAMLEncoder writes YAML documents with the formatting of its YAMLOptions.
+type YAMLEncoder struct {
+	*yaml.Encoder
+	opts YAMLOptions
+}
+
+// NewYAMLEncoder returns an encoder that writes to w with the formatting of opts.
+func NewYAMLEncoder(w io.Writer, opts YAMLOptions) *YAMLEncoder {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(opts.Indent)
+	return &YAMLEncoder{Encoder: enc, opts: opts}
+}
+
 // Prefer this encoder because it uses tighter 2-space indentation.
-func yamlEncoder(dst io.Writer) *yaml.Encoder {
-	enc := yaml.NewEncoder(dst)
-	enc.SetIndent(2)
-	return enc
+func yamlEncoder(dst io.Writer) *YAMLEncoder {
+	return NewYAMLEncoder(dst, DefaultYAMLOptions)
+}
+
+// Encode writes v as a YAML document.
+func (e *YAMLEncoder) Encode(v interface{}) error {
+	if e.opts.FlowMaxEntries <= 0 && !e.opts.ArtifactAnchors {
+		return e.Encoder.Encode(v)
+	}
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return err
+	}
+	if e.opts.FlowMaxEntries > 0 {
+		// The top-level map is always written in block style.
+		for _, child := range node.Content {
+			setFlowStyle(child, e.opts.FlowMaxEntries)
+		}
+	}
+	if e.opts.ArtifactAnchors {
+		if err := setArtifactAnchors(&node); err != nil {
+			return err
+		}
+	}
+	return e.Encoder.Encode(&node)
+}
+
+// setFlowStyle marks small maps of scalars below node for flow style.
+func setFlowStyle(node *yaml.Node, maxEntries int) {
+	if node.Kind == yaml.MappingNode && len(node.Content) > 0 && len(node.Content)/2 <= maxEntries {
+		scalars := true
+		for _, child := range node.Content {
+			if child.Kind != yaml.ScalarNode {
+				scalars = false
+				break
+			}
+		}
+		if scalars {
+			node.Style |= yaml.FlowStyle
+			return
+		}
+	}
+	for _, child := range node.Content {
+		setFlowStyle(child, maxEntries)
+	}
+}
+
+// setArtifactAnchors replaces artifacts below node that are identical to an
+// earlier artifact with aliases of the earlier artifact.
+func setArtifactAnchors(node *yaml.Node) error {
+	anchors := make(map[string]*yaml.Node) // keyed by the YAML of the artifact
+	names := make(map[string]bool)         // anchor names that are in use
+	var walk func(node *yaml.Node) error
+	walk = func(node *yaml.Node) error {
+		for i, child := range node.Content {
+			isArtifacts := node.Kind == yaml.MappingNode && i%2 == 1 &&
+				node.Content[i-1].Value == "artifacts" && child.Kind == yaml.SequenceNode
+			if !isArtifacts {
+				if err := walk(child); err != nil {
+					return err
+				}
+				continue
+			}
+			for j, artifact := range child.Content {
+				b, err := yaml.Marshal(artifact)
+				if err != nil {
+					return err
+				}
+				key := string(b)
+				if first, ok := anchors[key]; ok {
+					if first.Anchor == "" {
+						first.Anchor = anchorName(first, names)
+					}
+					child.Content[j] = &yaml.Node{Kind: yaml.AliasNode, Value: first.Anchor, Alias: first}
+				} else {
+					anchors[key] = artifact
+				}
+			}
+		}
+		return nil
+	}
+	return walk(node)
+}
+
+// anchorName returns an unused anchor name for an artifact based on its name.
+func anchorName(artifact *yaml.Node, names map[string]bool) string {
+	base := "artifact"
+	for i := 0; i+1 < len(artifact.Content); i += 2 {
+		if artifact.Content[i].Value != "metadata" {
+			continue
+		}
+		metadata := artifact.Content[i+1]
+		for j := 0; j+1 < len(metadata.Content); j += 2 {
+			if metadata.Content[j].Value == "name" && metadata.Content[j+1].Value != "" {
+				base = metadata.Content[j+1].Value
+			}
+		}
+	}
+	name := base
+	for n := 2; names[name]; n++ {
+		name = fmt.Sprintf("%s-%d", base, n)
+	}
+	names[name] = true
+	return name
 }
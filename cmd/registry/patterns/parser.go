@@ -24,6 +24,24 @@ import (
 
 const ResourceKW = "$resource"
 
+// ProjectLocation returns the name of the location that resource patterns of
+// project are relative to, e.g. "projects/demo/locations/global".
+// Resource names are only parsed in the location names.Location.
+func ProjectLocation(project string) string {
+	return ProjectLocationWithID(project, "")
+}
+
+// ProjectLocationWithID returns the name of the location of project with the
+// specified ID, e.g. "projects/demo/locations/us-central1". An empty ID is the
+// location names.Location. Commands use the location of the active
+// configuration when they name the resources that they create.
+func ProjectLocationWithID(project, location string) string {
+	if location == "" {
+		location = names.Location
+	}
+	return fmt.Sprintf("%s/locations/%s", project, location)
+}
+
 func parseResourceCollection(resourcePattern string) (ResourceName, error) {
 	if project, err := names.ParseProjectCollection(resourcePattern); err == nil {
 		return ProjectName{Name: project}, nil
@@ -85,7 +103,11 @@ func SubstituteReferenceEntity(resourcePattern string, referred ResourceName) (R
 	// resourcePattern: "$resource.api/versions/-"
 	// Returns "projects/demo/locations/global/apis/-/versions/-"
 
-	entity, entityType, err := GetReferenceEntityType(resourcePattern)
+	// referred: "projects/demo/locations/global/apis/-/versions/-"
+	// resourcePattern: "$resource.project/artifacts/lint-config"
+	// Returns "projects/demo/locations/global/artifacts/lint-config"
+
+	_, entityType, err := GetReferenceEntityType(resourcePattern)
 	if err != nil {
 		return nil, err
 	}
@@ -93,19 +115,19 @@ func SubstituteReferenceEntity(resourcePattern string, referred ResourceName) (R
 	// no $resource reference present
 	// simply prepend the projectname and return full resource name
 	if entityType == "default" {
-		resourceName, err := ParseResourcePattern(fmt.Sprintf("%s/locations/global/%s", referred.Project(), resourcePattern))
+		resourceName, err := ParseResourcePattern(fmt.Sprintf("%s/%s", ProjectLocation(referred.Project()), resourcePattern))
 		if err != nil {
 			return nil, err
 		}
 		return resourceName, nil
 	}
 
-	entityVal, err := GetReferenceEntityValue(resourcePattern, referred)
+	extendedPattern, err := Substitute(resourcePattern, NewResourceContext(referred))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid combination referred: %q resourcePattern: %q, %s", referred, resourcePattern, err)
 	}
 
-	extendedName, err := ParseResourcePattern(strings.Replace(resourcePattern, entity, entityVal, 1))
+	extendedName, err := ParseResourcePattern(extendedPattern)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +151,7 @@ func GetReferenceEntityType(resourcePattern string) (entity, entityType string,
 	// Example result for the following regex
 	// dependencyPattern: "$resource.api/artifacts/score"
 	// matches: ["$resource.api/", "$resource.api", "api"]
-	entityRegex := regexp.MustCompile(fmt.Sprintf(`(\%s\.(api|version|spec|artifact))(/|$)`, ResourceKW))
+	entityRegex := regexp.MustCompile(fmt.Sprintf(`(\%s\.(project|api|version|spec|artifact))(/|$)`, ResourceKW))
 	matches := entityRegex.FindStringSubmatch(resourcePattern)
 	if len(matches) <= 2 {
 		entity, entityType = "", ""
@@ -155,6 +177,11 @@ func GetReferenceEntityValue(resourcePattern string, referred ResourceName) (str
 	}
 
 	switch entityType {
+	case "project":
+		if len(referred.Project()) == 0 {
+			return "", fmt.Errorf("invalid combination referred: %q resourcePattern: %q", referred, resourcePattern)
+		}
+		return ProjectLocation(referred.Project()), nil
 	case "api":
 		entityVal := referred.Api()
 		if len(entityVal) == 0 {
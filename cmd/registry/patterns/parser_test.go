@@ -87,6 +87,24 @@ func TestSubstituteReferenceEntity(t *testing.T) {
 			dependencyPattern: "$resource.version/artifacts/lintstats",
 			want:              "projects/demo/locations/global/apis/-/versions/-/artifacts/lintstats",
 		},
+		{
+			desc:              "spec depends on api",
+			resourcePattern:   "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/score",
+			dependencyPattern: "$resource.api/artifacts/lint-config",
+			want:              "projects/demo/locations/global/apis/petstore/artifacts/lint-config",
+		},
+		{
+			desc:              "version depends on project",
+			resourcePattern:   "projects/demo/locations/global/apis/-/versions/-/artifacts/score",
+			dependencyPattern: "$resource.project/artifacts/lint-config",
+			want:              "projects/demo/locations/global/artifacts/lint-config",
+		},
+		{
+			desc:              "version depends on project without reference",
+			resourcePattern:   "projects/demo/locations/global/apis/-/versions/-/artifacts/score",
+			dependencyPattern: "artifacts/lint-config",
+			want:              "projects/demo/locations/global/artifacts/lint-config",
+		},
 		{
 			desc:              "no reference",
 			resourcePattern:   "projects/demo/locations/global/apis/-/artifacts/lintstats",
@@ -234,6 +252,12 @@ func TestGetReferenceEntityValue(t *testing.T) {
 		referred        ResourceName
 		want            string
 	}{
+		{
+			desc:            "project group",
+			resourcePattern: "$resource.project/artifacts/-",
+			referred:        generateSpecName(t, "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"),
+			want:            "projects/demo/locations/global",
+		},
 		{
 			desc:            "api group",
 			resourcePattern: "$resource.api/versions/-/specs/-",
@@ -317,3 +341,27 @@ func TestGetReferenceEntityValueError(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectLocation(t *testing.T) {
+	const want = "projects/demo/locations/global"
+	if got := ProjectLocation("projects/demo"); got != want {
+		t.Errorf("ProjectLocation(%q) returned %q, want %q", "projects/demo", got, want)
+	}
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{location: "", want: "projects/demo/locations/global"},
+		{location: "global", want: "projects/demo/locations/global"},
+		{location: "us-central1", want: "projects/demo/locations/us-central1"},
+	}
+	for _, test := range tests {
+		if got := ProjectLocationWithID("projects/demo", test.location); got != test.want {
+			t.Errorf("ProjectLocationWithID(%q, %q) returned %q, want %q", "projects/demo", test.location, got, test.want)
+		}
+	}
+	// Resource names are only valid in the location names.Location.
+	if _, err := ParseResourcePattern("projects/demo/locations/us-central1/apis/-"); err == nil {
+		t.Errorf("ParseResourcePattern() accepted a resource outside of location %q", names.Location)
+	}
+}
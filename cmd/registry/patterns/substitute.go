@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patterns
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ResourceContext holds the names that $resource references are replaced with.
+// Levels that aren't available to a resource are empty, e.g. Artifact is
+// empty for a spec.
+type ResourceContext struct {
+	Project  string
+	Api      string
+	Version  string
+	Spec     string
+	Artifact string
+}
+
+// NewResourceContext returns the context of $resource references for name.
+func NewResourceContext(name ResourceName) ResourceContext {
+	var project string
+	if name.Project() != "" {
+		project = ProjectLocation(name.Project())
+	}
+	return ResourceContext{
+		Project:  project,
+		Api:      name.Api(),
+		Version:  name.Version(),
+		Spec:     name.Spec(),
+		Artifact: name.Artifact(),
+	}
+}
+
+// value returns the name that replaces $resource.<entityType>.
+func (c ResourceContext) value(entityType string) (string, bool) {
+	switch entityType {
+	case "project":
+		return c.Project, true
+	case "api":
+		return c.Api, true
+	case "version":
+		return c.Version, true
+	case "spec":
+		return c.Spec, true
+	case "artifact":
+		return c.Artifact, true
+	default:
+		return "", false
+	}
+}
+
+// A token is any word that starts with $resource, so that misspelled
+// references like "$resource.specs" or "$resourcespec" aren't left in place.
+var resourceTokenRegexp = regexp.MustCompile(fmt.Sprintf(`\%s[A-Za-z0-9_.]*`, ResourceKW))
+
+// Substitute replaces the $resource references in pattern with the names in ctx.
+// Example:
+// pattern: "registry compute score $resource.spec/artifacts/complexity"
+// ctx.Spec: "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+// returns "registry compute score projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity"
+// References to unknown entities and to levels that are empty in ctx are errors.
+func Substitute(pattern string, ctx ResourceContext) (string, error) {
+	var err error
+	result := resourceTokenRegexp.ReplaceAllStringFunc(pattern, func(token string) string {
+		if err != nil {
+			return token
+		}
+		entityType := ""
+		if len(token) > len(ResourceKW) && token[len(ResourceKW)] == '.' {
+			entityType = token[len(ResourceKW)+1:]
+		}
+		value, ok := ctx.value(entityType)
+		if !ok {
+			err = fmt.Errorf("unknown reference %q", token)
+			return token
+		}
+		if value == "" {
+			err = fmt.Errorf("reference %q is not available for this resource", token)
+			return token
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patterns
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewResourceContext(t *testing.T) {
+	name := generateSpecName(t, "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml@abc")
+	want := ResourceContext{
+		Project: "projects/demo/locations/global",
+		Api:     "projects/demo/locations/global/apis/petstore",
+		Version: "projects/demo/locations/global/apis/petstore/versions/1.0.0",
+		Spec:    "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml@abc",
+	}
+	if diff := cmp.Diff(want, NewResourceContext(name)); diff != "" {
+		t.Errorf("NewResourceContext(%s) returned unexpected diff (-want +got):\n%s", name, diff)
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	ctx := ResourceContext{
+		Project: "projects/demo/locations/global",
+		Api:     "projects/demo/locations/global/apis/petstore",
+		Version: "projects/demo/locations/global/apis/petstore/versions/1.0.0",
+		Spec:    "projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+	}
+	tests := []struct {
+		desc    string
+		pattern string
+		want    string
+	}{
+		{
+			desc:    "no references",
+			pattern: "registry compute search-index",
+			want:    "registry compute search-index",
+		},
+		{
+			desc:    "single reference",
+			pattern: "$resource.api/versions/-",
+			want:    "projects/demo/locations/global/apis/petstore/versions/-",
+		},
+		{
+			desc:    "multiple references",
+			pattern: "compute score $resource.spec/artifacts/complexity --version $resource.version",
+			want:    "compute score projects/demo/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity --version projects/demo/locations/global/apis/petstore/versions/1.0.0",
+		},
+		{
+			desc:    "project reference",
+			pattern: "$resource.project/artifacts/lint-config",
+			want:    "projects/demo/locations/global/artifacts/lint-config",
+		},
+		{
+			desc:    "repeated reference",
+			pattern: "$resource.api $resource.api",
+			want:    "projects/demo/locations/global/apis/petstore projects/demo/locations/global/apis/petstore",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got, err := Substitute(test.pattern, ctx)
+			if err != nil {
+				t.Fatalf("Substitute(%q) returned unexpected error: %s", test.pattern, err)
+			}
+			if got != test.want {
+				t.Errorf("Substitute(%q) returned %q, want %q", test.pattern, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSubstituteError(t *testing.T) {
+	ctx := ResourceContext{
+		Api: "projects/demo/locations/global/apis/petstore",
+	}
+	tests := []struct {
+		desc    string
+		pattern string
+	}{
+		{
+			desc:    "unknown entity",
+			pattern: "$resource.apispec",
+		},
+		{
+			desc:    "missing entity",
+			pattern: "$resource/artifacts/score",
+		},
+		{
+			desc:    "missing separator",
+			pattern: "$resourceapi",
+		},
+		{
+			desc:    "unavailable entity",
+			pattern: "$resource.api $resource.spec",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got, err := Substitute(test.pattern, ctx); err == nil {
+				t.Errorf("Substitute(%q) returned %q, want error", test.pattern, got)
+			}
+		})
+	}
+}
@@ -2,31 +2,182 @@ package scoring
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/log"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type artifactClient interface {
-	GetArtifact(context.Context, names.Artifact, bool, core.ArtifactHandler) error
-	SetArtifact(context.Context, *rpc.Artifact) error
+	core.ArtifactClient
 	ListArtifacts(context.Context, names.Artifact, string, bool, core.ArtifactHandler) error
+	ListArtifactsLazily(context.Context, names.Artifact, string, core.LazyArtifactHandler) error
+	GetArtifactLazily(context.Context, names.Artifact, core.LazyArtifactHandler) error
 }
 
 type RegistryArtifactClient struct {
 	RegistryClient connection.RegistryClient
+	// RetryPolicy controls retries of SetArtifact. Nil uses DefaultRetryPolicy.
+	RetryPolicy *RetryPolicy
+}
+
+// stalenessWindow returns the staleness_window of definition if it is set,
+// and threshold otherwise.
+func stalenessWindow(definition *rpc.ScoreDefinition, threshold time.Duration) time.Duration {
+	if definition.GetStalenessWindow() == nil {
+		return threshold
+	}
+	return definition.GetStalenessWindow().AsDuration()
+}
+
+// RetryPolicy controls how failed calls are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one.
+	// Values less than 1 are treated as 1, which disables retries.
+	MaxAttempts int
+	// Backoff determines the pause before each retry.
+	Backoff gax.Backoff
+	// Codes are the status codes of failures that are retried.
+	Codes []codes.Code
+}
+
+// DefaultRetryPolicy retries calls that fail because the server is
+// temporarily unavailable, such as during a rollout.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	Backoff: gax.Backoff{
+		Initial:    250 * time.Millisecond,
+		Max:        5 * time.Second,
+		Multiplier: 2,
+	},
+	Codes: []codes.Code{codes.Unavailable},
+}
+
+// do calls f until it succeeds, fails with a status code that isn't retried,
+// or has been called MaxAttempts times. It doesn't retry when ctx is done or
+// when ctx's deadline would pass before the next attempt.
+func (p RetryPolicy) do(ctx context.Context, f func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= p.MaxAttempts || !p.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+		pause := p.Backoff.Pause()
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(pause).After(deadline) {
+			return err
+		}
+		log.FromContext(ctx).WithError(err).Debugf("Retrying in %s (attempt %d of %d)", pause, attempt+1, p.MaxAttempts)
+		if gax.Sleep(ctx, pause) != nil {
+			return err
+		}
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range p.Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
 }
 
 func (r *RegistryArtifactClient) GetArtifact(ctx context.Context, artifact names.Artifact, getContents bool, handler core.ArtifactHandler) error {
 	return core.GetArtifact(ctx, r.RegistryClient, artifact, getContents, handler)
 }
 
+// SetArtifact creates or replaces an artifact. Since this is an upsert,
+// failed calls are safely retried according to the client's RetryPolicy.
 func (r *RegistryArtifactClient) SetArtifact(ctx context.Context, artifact *rpc.Artifact) error {
-	return core.SetArtifact(ctx, r.RegistryClient, artifact)
+	policy := DefaultRetryPolicy
+	if r.RetryPolicy != nil {
+		policy = *r.RetryPolicy
+	}
+	return policy.do(ctx, func() error {
+		return core.SetArtifact(ctx, r.RegistryClient, artifact)
+	})
 }
 
 func (r *RegistryArtifactClient) ListArtifacts(ctx context.Context, artifact names.Artifact, filter string, contents bool, handler core.ArtifactHandler) error {
 	return core.ListArtifacts(ctx, r.RegistryClient, artifact, filter, contents, handler)
 }
+
+func (r *RegistryArtifactClient) ListArtifactsLazily(ctx context.Context, artifact names.Artifact, filter string, handler core.LazyArtifactHandler) error {
+	return core.ListArtifactsLazily(ctx, r.RegistryClient, artifact, filter, handler)
+}
+
+func (r *RegistryArtifactClient) GetArtifactLazily(ctx context.Context, artifact names.Artifact, handler core.LazyArtifactHandler) error {
+	return core.GetArtifactLazily(ctx, r.RegistryClient, artifact, handler)
+}
+
+// BatchGetArtifacts gets the artifacts with the specified names, including their contents.
+// Artifacts are returned in the order of names. The registry doesn't have a batch API,
+// so the artifacts are fetched with concurrent calls; see batchGetArtifacts.
+func (r *RegistryArtifactClient) BatchGetArtifacts(ctx context.Context, names []string) ([]*rpc.Artifact, error) {
+	return batchGetArtifacts(ctx, r, names, true)
+}
+
+// maxBatchParallelism limits the calls that batchGetArtifacts makes at the same time.
+const maxBatchParallelism = 8
+
+// batchGetArtifacts gets the artifacts with the specified names in the order of names,
+// making up to maxBatchParallelism concurrent calls. If any of the artifacts can't be
+// fetched, the remaining calls are canceled and the error for the first such name is returned.
+func batchGetArtifacts(ctx context.Context, client artifactClient, artifactNames []string, getContents bool) ([]*rpc.Artifact, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	artifacts := make([]*rpc.Artifact, len(artifactNames))
+	errs := make([]error, len(artifactNames))
+	sem := make(chan struct{}, maxBatchParallelism)
+	var wg sync.WaitGroup
+	for i, name := range artifactNames {
+		sem <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			errs[i] = err
+			continue
+		}
+		wg.Add(1)
+		go func(i int, name string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			artifacts[i], errs[i] = getArtifact(ctx, client, name, getContents)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	// Calls that were canceled fail too, so the first error that isn't a cancellation is reported.
+	var canceled error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("failed to fetch artifact %s: %s", artifactNames[i], err)
+		if status.Code(errs[i]) != codes.Canceled && !errors.Is(errs[i], context.Canceled) {
+			return nil, err
+		}
+		if canceled == nil {
+			canceled = err
+		}
+	}
+	if canceled != nil {
+		return nil, canceled
+	}
+	return artifacts, nil
+}
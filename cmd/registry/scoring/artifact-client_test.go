@@ -0,0 +1,186 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     gax.Backoff{Initial: time.Millisecond, Max: time.Millisecond},
+		Codes:       []codes.Code{codes.Unavailable},
+	}
+	unavailable := status.Error(codes.Unavailable, "unavailable")
+	tests := []struct {
+		desc      string
+		policy    RetryPolicy
+		timeout   time.Duration
+		failures  []error
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			desc:      "success",
+			policy:    policy,
+			wantCalls: 1,
+			wantCode:  codes.OK,
+		},
+		{
+			desc:      "transient failure",
+			policy:    policy,
+			failures:  []error{unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			desc:      "max attempts",
+			policy:    policy,
+			failures:  []error{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 3,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			desc:      "not retried",
+			policy:    policy,
+			failures:  []error{status.Error(codes.InvalidArgument, "invalid")},
+			wantCalls: 1,
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			desc:      "retries disabled",
+			policy:    RetryPolicy{Codes: []codes.Code{codes.Unavailable}},
+			failures:  []error{unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			desc: "deadline before retry",
+			// Pauses are randomly chosen up to the backoff, so the backoff
+			// is long enough that pauses are almost never shorter than the timeout.
+			policy: RetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     gax.Backoff{Initial: 10000 * time.Hour, Max: 10000 * time.Hour},
+				Codes:       []codes.Code{codes.Unavailable},
+			},
+			timeout:   time.Second,
+			failures:  []error{unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+			calls := 0
+			err := test.policy.do(ctx, func() error {
+				calls++
+				if calls <= len(test.failures) {
+					return test.failures[calls-1]
+				}
+				return nil
+			})
+			if calls != test.wantCalls {
+				t.Errorf("do() made %d calls, want %d", calls, test.wantCalls)
+			}
+			if status.Code(err) != test.wantCode {
+				t.Errorf("do() returned error %v, want code %s", err, test.wantCode)
+			}
+		})
+	}
+}
+
+// concurrencyTrackingArtifactClient records the largest number of concurrent GetArtifact calls.
+type concurrencyTrackingArtifactClient struct {
+	artifactClient
+	mu          sync.Mutex
+	active, max int
+}
+
+func (c *concurrencyTrackingArtifactClient) GetArtifact(ctx context.Context, artifact names.Artifact, getContents bool, handler core.ArtifactHandler) error {
+	c.mu.Lock()
+	c.active++
+	if c.active > c.max {
+		c.max = c.active
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.active--
+		c.mu.Unlock()
+	}()
+	time.Sleep(time.Millisecond)
+	return c.artifactClient.GetArtifact(ctx, artifact, getContents, handler)
+}
+
+func TestBatchGetArtifacts(t *testing.T) {
+	ctx := context.Background()
+	const spec = "projects/batch-test/locations/global/apis/a/versions/v/specs/s"
+	artifactNames := make([]string, 0)
+	artifacts := make([]*rpc.Artifact, 0)
+	for i := 0; i < 3*maxBatchParallelism; i++ {
+		name := fmt.Sprintf("%s/artifacts/a%d", spec, i)
+		artifactNames = append(artifactNames, name)
+		artifacts = append(artifacts, &rpc.Artifact{Name: name, Contents: []byte(name)})
+	}
+	client := &concurrencyTrackingArtifactClient{artifactClient: NewMemoryArtifactClient(artifacts...)}
+
+	got, err := batchGetArtifacts(ctx, client, artifactNames, true)
+	if err != nil {
+		t.Fatalf("batchGetArtifacts() returned error: %s", err)
+	}
+	if len(got) != len(artifactNames) {
+		t.Fatalf("batchGetArtifacts() returned %d artifacts, want %d", len(got), len(artifactNames))
+	}
+	for i, a := range got {
+		if a.GetName() != artifactNames[i] || string(a.GetContents()) != artifactNames[i] {
+			t.Errorf("batchGetArtifacts() returned %q with contents %q at %d, want %q", a.GetName(), a.GetContents(), i, artifactNames[i])
+		}
+	}
+	if client.max > maxBatchParallelism {
+		t.Errorf("batchGetArtifacts() made %d concurrent calls, want at most %d", client.max, maxBatchParallelism)
+	}
+
+	got, err = batchGetArtifacts(ctx, client, artifactNames[:2], false)
+	if err != nil {
+		t.Fatalf("batchGetArtifacts() returned error: %s", err)
+	}
+	if len(got[0].GetContents()) != 0 {
+		t.Errorf("batchGetArtifacts() without contents returned contents")
+	}
+
+	missing := append([]string{spec + "/artifacts/missing"}, artifactNames...)
+	if _, err := batchGetArtifacts(ctx, client, missing, false); err == nil || !strings.Contains(err.Error(), missing[0]) {
+		t.Errorf("batchGetArtifacts() with a missing artifact returned %v, want error for %s", err, missing[0])
+	}
+}
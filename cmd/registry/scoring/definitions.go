@@ -0,0 +1,101 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"google.golang.org/protobuf/proto"
+)
+
+// ScoreDefinitionInfo summarizes a ScoreDefinition artifact.
+type ScoreDefinitionInfo struct {
+	// Name is the resource name of the definition artifact.
+	Name        string
+	ID          string
+	DisplayName string
+	// TargetPattern and TargetFilter select the resources that are scored.
+	TargetPattern string
+	TargetFilter  string
+	// ScoreType is "percent", "integer", or "boolean", or empty if unset.
+	ScoreType string
+	// Definition is the parsed definition.
+	Definition *rpc.ScoreDefinition
+}
+
+// InvalidScoreDefinition identifies a ScoreDefinition artifact that couldn't be parsed.
+type InvalidScoreDefinition struct {
+	Name string
+	Err  error
+}
+
+// ListScoreDefinitions returns summaries of all score definitions in a project.
+// Artifacts with the ScoreDefinition type that can't be parsed are returned
+// in a separate list instead of being skipped.
+func ListScoreDefinitions(
+	ctx context.Context,
+	client artifactClient,
+	project string) ([]ScoreDefinitionInfo, []InvalidScoreDefinition, error) {
+	artifact, err := names.ParseArtifact(fmt.Sprintf("%s/artifacts/-", patterns.ProjectLocation(project)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	definitions := make([]ScoreDefinitionInfo, 0)
+	invalid := make([]InvalidScoreDefinition, 0)
+	listFilter := fmt.Sprintf("mime_type == %q", patch.MimeTypeForKind("ScoreDefinition"))
+	err = client.ListArtifacts(ctx, artifact, listFilter, true,
+		func(artifact *rpc.Artifact) error {
+			definition := &rpc.ScoreDefinition{}
+			if err := proto.Unmarshal(artifact.GetContents(), definition); err != nil {
+				invalid = append(invalid, InvalidScoreDefinition{Name: artifact.GetName(), Err: err})
+				return nil
+			}
+
+			definitions = append(definitions, ScoreDefinitionInfo{
+				Name:          artifact.GetName(),
+				ID:            definition.GetId(),
+				DisplayName:   definition.GetDisplayName(),
+				TargetPattern: definition.GetTargetResource().GetPattern(),
+				TargetFilter:  definition.GetTargetResource().GetFilter(),
+				ScoreType:     scoreType(definition),
+				Definition:    definition,
+			})
+			return nil
+		})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return definitions, invalid, nil
+}
+
+func scoreType(definition *rpc.ScoreDefinition) string {
+	switch definition.GetType().(type) {
+	case *rpc.ScoreDefinition_Percent:
+		return "percent"
+	case *rpc.ScoreDefinition_Integer:
+		return "integer"
+	case *rpc.ScoreDefinition_Boolean:
+		return "boolean"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apigee/registry/pkg/connection"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestListScoreDefinitions(t *testing.T) {
+	const definitionType = "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition"
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name:     "projects/list-definitions-test/locations/global/artifacts/lint-error",
+			MimeType: definitionType,
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id:          "lint-error",
+				DisplayName: "Lint Error",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+					Filter:  "mime_type.contains('openapi')",
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{MaxValue: 10},
+				},
+			}),
+		},
+		&rpc.Artifact{
+			Name:     "projects/list-definitions-test/locations/global/artifacts/lint-approval",
+			MimeType: definitionType,
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint-approval",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-",
+				},
+				Type: &rpc.ScoreDefinition_Boolean{
+					Boolean: &rpc.BooleanType{},
+				},
+			}),
+		},
+		&rpc.Artifact{
+			Name:     "projects/list-definitions-test/locations/global/artifacts/malformed",
+			MimeType: definitionType,
+			Contents: []byte{0xff, 0xff, 0xff},
+		},
+	}
+
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "list-definitions-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "list-definitions-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	got, invalid, err := ListScoreDefinitions(ctx, artifactClient, "projects/list-definitions-test")
+	if err != nil {
+		t.Fatalf("ListScoreDefinitions() returned error: %s", err)
+	}
+
+	want := []ScoreDefinitionInfo{
+		{
+			Name:          "projects/list-definitions-test/locations/global/artifacts/lint-approval",
+			ID:            "lint-approval",
+			TargetPattern: "apis/-/versions/-",
+			ScoreType:     "boolean",
+		},
+		{
+			Name:          "projects/list-definitions-test/locations/global/artifacts/lint-error",
+			ID:            "lint-error",
+			DisplayName:   "Lint Error",
+			TargetPattern: "apis/-/versions/-/specs/-",
+			TargetFilter:  "mime_type.contains('openapi')",
+			ScoreType:     "integer",
+		},
+	}
+	sortInfo := cmpopts.SortSlices(func(a, b ScoreDefinitionInfo) bool { return a.Name < b.Name })
+	ignoreDefinition := cmpopts.IgnoreFields(ScoreDefinitionInfo{}, "Definition")
+	if diff := cmp.Diff(want, got, sortInfo, ignoreDefinition); diff != "" {
+		t.Errorf("ListScoreDefinitions() returned unexpected definitions (-want +got):\n%s", diff)
+	}
+	for _, info := range got {
+		if info.Definition.GetId() != info.ID {
+			t.Errorf("ListScoreDefinitions() returned definition %v for %q, want the parsed definition", info.Definition, info.Name)
+		}
+	}
+
+	if len(invalid) != 1 {
+		t.Fatalf("ListScoreDefinitions() returned %d invalid definitions, want 1", len(invalid))
+	}
+	if invalid[0].Name != "projects/list-definitions-test/locations/global/artifacts/malformed" || invalid[0].Err == nil {
+		t.Errorf("ListScoreDefinitions() returned unexpected invalid definition %+v", invalid[0])
+	}
+}
@@ -15,21 +15,32 @@
 package scoring
 
 import (
+	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/scoring/extensions"
 	"github.com/apigee/registry/rpc"
 	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/interpreter/functions"
 	metrics "github.com/google/gnostic/metrics"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
 )
 
 // https://github.com/google/cel-spec/blob/master/doc/langdef.md#dynamic-values
-func evaluateScoreExpression(expression string, artifactMap map[string]interface{}) (interface{}, error) {
-	env, err := cel.NewEnv(extensions.Extensions())
+func evaluateScoreExpression(expression string, artifactMap map[string]interface{}, opts ...cel.EnvOption) (interface{}, error) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{extensions.Extensions()}, opts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("error creating CEL environment: %s", err)
 	}
@@ -56,10 +67,119 @@ func evaluateScoreExpression(expression string, artifactMap map[string]interface
 	}
 }
 
+// weightedAverage returns a CEL option that declares weightedAverage(),
+// which computes sum(weight*value)/sum(weight) over the values of a rollup.
+// values and weights are keyed by reference_id.
+func weightedAverage(values map[string]interface{}, weights map[string]float64) cel.EnvOption {
+	return cel.Lib(weightedAverageLib{values: values, weights: weights})
+}
+
+type weightedAverageLib struct {
+	values  map[string]interface{}
+	weights map[string]float64
+}
+
+func (weightedAverageLib) CompileOptions() []cel.EnvOption {
+	return []cel.EnvOption{
+		cel.Declarations(
+			decls.NewFunction("weightedAverage",
+				decls.NewOverload("weighted_average",
+					[]*exprpb.Type{},
+					decls.Double),
+			),
+		),
+	}
+}
+
+func (l weightedAverageLib) ProgramOptions() []cel.ProgramOption {
+	fn := func(args ...ref.Val) ref.Val {
+		avg, err := l.average()
+		if err != nil {
+			return types.NewErr("weightedAverage: %s", err)
+		}
+		return types.Double(avg)
+	}
+	return []cel.ProgramOption{
+		cel.Functions(
+			&functions.Overload{Operator: "weightedAverage", Function: fn},
+			&functions.Overload{Operator: "weighted_average", Function: fn},
+		),
+	}
+}
+
+func (l weightedAverageLib) average() (float64, error) {
+	var sum, totalWeight float64
+	for refId, v := range l.values {
+		weight := l.weights[refId]
+		if weight < 0 {
+			return 0, fmt.Errorf("invalid weight %v for %q: weights must be non-negative", weight, refId)
+		}
+		var value float64
+		switch v := v.(type) {
+		case int64:
+			value = float64(v)
+		case float64:
+			value = v
+		default:
+			return 0, fmt.Errorf("value of %q has type %T: should be one of [int, double]", refId, v)
+		}
+		sum += weight * value
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return 0, fmt.Errorf("all weights are zero")
+	}
+	return sum / totalWeight, nil
+}
+
+// A MapDecoder converts artifact contents to the map that score expressions read.
+type MapDecoder func(contents []byte) (map[string]interface{}, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = make(map[string]MapDecoder)
+)
+
+// RegisterMapDecoder registers a decoder for artifacts with the specified MIME type,
+// which allows score expressions to read artifacts of custom types.
+// Registered decoders take precedence over the built-in ones.
+func RegisterMapDecoder(mimeType string, decoder MapDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[mimeType] = decoder
+}
+
+func registeredMapDecoder(mimeType string) (MapDecoder, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	decoder, ok := decoders[mimeType]
+	return decoder, ok
+}
+
+// getMap converts artifact contents to a map. Registered decoders are used first,
+// then known Protocol Buffer types, and contents of other MIME types are decoded
+// as JSON or YAML. Contents with a "+gzip" MIME type are decompressed first.
+// Contents fetched from the registry are already decompressed and have the
+// MIME type returned with them, which doesn't end with "+gzip".
 func getMap(contents []byte, mimeType string) (map[string]interface{}, error) {
+	if core.IsGZipCompressed(mimeType) {
+		var err error
+		contents, err = core.GUnzippedBytesWithLimit(contents, core.MaxDecompressedSize)
+		if errors.Is(err, gzip.ErrHeader) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("contents of type %q are not gzip-compressed: %s", mimeType, err)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decompress contents of type %q: %s", mimeType, err)
+		}
+		mimeType = strings.Replace(mimeType, "+gzip", "", 1)
+	}
+
+	if decoder, ok := registeredMapDecoder(mimeType); ok {
+		return decoder(contents)
+	}
+
 	messageType, err := core.MessageTypeForMimeType(mimeType)
 	if err != nil {
-		return nil, fmt.Errorf("failed extracting message type from %q", mimeType)
+		return decodeJSONOrYAML(contents, mimeType)
 	}
 
 	switch messageType {
@@ -83,12 +203,28 @@ func getMap(contents []byte, mimeType string) (map[string]interface{}, error) {
 		return unmarshalAndMap(contents, &rpc.Score{})
 	case "google.cloud.apigeeregistry.v1.scoring.ScoreCard":
 		return unmarshalAndMap(contents, &rpc.ScoreCard{})
-	// TODO: Add support for JSON artifacts
 	default:
 		return nil, fmt.Errorf("unsupported artifact type: %s", messageType)
 	}
 }
 
+// decodeJSONOrYAML decodes contents of a MIME type without a registered decoder.
+func decodeJSONOrYAML(contents []byte, mimeType string) (map[string]interface{}, error) {
+	var jsonMap map[string]interface{}
+	jsonErr := json.Unmarshal(contents, &jsonMap)
+	if jsonErr == nil {
+		return jsonMap, nil
+	}
+	var yamlMap map[string]interface{}
+	yamlErr := yaml.Unmarshal(contents, &yamlMap)
+	if yamlErr == nil && yamlMap != nil {
+		return yamlMap, nil
+	} else if yamlErr == nil {
+		yamlErr = errors.New("empty document")
+	}
+	return nil, fmt.Errorf("unsupported artifact type %q: no registered decoder, failed decoding as JSON (%s) and as YAML (%s)", mimeType, jsonErr, yamlErr)
+}
+
 func unmarshalAndMap(contents []byte, message proto.Message) (map[string]interface{}, error) {
 	// Convert to proto
 	err := proto.Unmarshal(contents, message)
@@ -15,8 +15,10 @@
 package scoring
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/rpc"
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/protobuf/proto"
@@ -119,6 +121,173 @@ func TestGetMapError(t *testing.T) {
 	}
 }
 
+func TestGetMapGzip(t *testing.T) {
+	lint, err := proto.Marshal(&rpc.Lint{Name: "openapi.yaml"})
+	if err != nil {
+		t.Fatalf("Failed to marshal lint: %s", err)
+	}
+	zippedLint, err := core.GZippedBytes(lint)
+	if err != nil {
+		t.Fatalf("Failed to compress lint: %s", err)
+	}
+	zippedJSON, err := core.GZippedBytes([]byte(`{"count": 3}`))
+	if err != nil {
+		t.Fatalf("Failed to compress JSON: %s", err)
+	}
+
+	tests := []struct {
+		desc     string
+		contents []byte
+		mimeType string
+		wantMap  map[string]interface{}
+	}{
+		{
+			desc:     "protocol buffer",
+			contents: zippedLint,
+			mimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint+gzip",
+			wantMap:  map[string]interface{}{"name": "openapi.yaml"},
+		},
+		{
+			desc:     "json",
+			contents: zippedJSON,
+			mimeType: "application/json+gzip",
+			wantMap:  map[string]interface{}{"count": float64(3)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotMap, err := getMap(test.contents, test.mimeType)
+			if err != nil {
+				t.Fatalf("getMap() returned unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(test.wantMap, gotMap); diff != "" {
+				t.Errorf("getMap returned unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetMapGzipError(t *testing.T) {
+	bomb, err := core.GZippedBytes(make([]byte, core.MaxDecompressedSize+1))
+	if err != nil {
+		t.Fatalf("Failed to compress contents: %s", err)
+	}
+
+	tests := []struct {
+		desc     string
+		contents []byte
+		wantErr  string
+	}{
+		{
+			desc:     "not compressed",
+			contents: []byte(`{"count": 3}`),
+			wantErr:  "not gzip-compressed",
+		},
+		{
+			desc:     "empty",
+			contents: []byte{},
+			wantErr:  "not gzip-compressed",
+		},
+		{
+			desc:     "too large",
+			contents: bomb,
+			wantErr:  "larger than",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := getMap(test.contents, "application/json+gzip")
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("getMap() returned error %v, want error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetMapCustomTypes(t *testing.T) {
+	RegisterMapDecoder("text/x-count", func(contents []byte) (map[string]interface{}, error) {
+		return map[string]interface{}{"count": int64(len(contents))}, nil
+	})
+	t.Cleanup(func() {
+		decodersMu.Lock()
+		defer decodersMu.Unlock()
+		delete(decoders, "text/x-count")
+	})
+
+	tests := []struct {
+		desc     string
+		contents string
+		mimeType string
+		wantMap  map[string]interface{}
+	}{
+		{
+			desc:     "json",
+			contents: `{"name": "petstore", "count": 3}`,
+			mimeType: "application/x.custom+json",
+			wantMap:  map[string]interface{}{"name": "petstore", "count": float64(3)},
+		},
+		{
+			desc:     "yaml",
+			contents: "name: petstore\ntags:\n- a\n",
+			mimeType: "application/x.custom+yaml",
+			wantMap:  map[string]interface{}{"name": "petstore", "tags": []interface{}{"a"}},
+		},
+		{
+			desc:     "registered decoder",
+			contents: "abc",
+			mimeType: "text/x-count",
+			wantMap:  map[string]interface{}{"count": int64(3)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotMap, gotErr := getMap([]byte(test.contents), test.mimeType)
+			if gotErr != nil {
+				t.Errorf("getMap() returned unexpected error: %s", gotErr)
+			}
+			if diff := cmp.Diff(test.wantMap, gotMap); diff != "" {
+				t.Errorf("getMap returned unexpected response (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetMapCustomTypesError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		contents string
+		mimeType string
+	}{
+		{
+			desc:     "plain text",
+			contents: "not a map",
+			mimeType: "text/plain",
+		},
+		{
+			desc:     "empty",
+			contents: "",
+			mimeType: "application/x.custom+json",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, gotErr := getMap([]byte(test.contents), test.mimeType)
+			if gotErr == nil {
+				t.Fatalf("getMap(%q, %s) did not return an error", test.contents, test.mimeType)
+			}
+			for _, want := range []string{"JSON", "YAML"} {
+				if !strings.Contains(gotErr.Error(), want) {
+					t.Errorf("getMap(%q, %s) returned error %q, expected it to mention %s", test.contents, test.mimeType, gotErr, want)
+				}
+			}
+		})
+	}
+}
+
 func TestEvaluateScoreExpression(t *testing.T) {
 	tests := []struct {
 		desc        string
@@ -247,3 +416,80 @@ func TestEvaluateScoreExpressionError(t *testing.T) {
 		})
 	}
 }
+
+func TestWeightedAverage(t *testing.T) {
+	tests := []struct {
+		desc       string
+		expression string
+		values     map[string]interface{}
+		weights    map[string]float64
+		wantValue  interface{}
+	}{
+		{
+			desc:       "mixed types",
+			expression: "weightedAverage()",
+			values:     map[string]interface{}{"lint": int64(10), "complexity": float64(40)},
+			weights:    map[string]float64{"lint": 3, "complexity": 1},
+			wantValue:  float64(17.5),
+		},
+		{
+			desc:       "zero weight ignored",
+			expression: "weightedAverage()",
+			values:     map[string]interface{}{"lint": int64(10), "complexity": float64(40)},
+			weights:    map[string]float64{"lint": 2},
+			wantValue:  float64(10),
+		},
+		{
+			desc:       "within expression",
+			expression: "weightedAverage() > 15.0",
+			values:     map[string]interface{}{"lint": int64(10), "complexity": int64(20)},
+			weights:    map[string]float64{"lint": 1, "complexity": 1},
+			wantValue:  false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotValue, gotErr := evaluateScoreExpression(test.expression, test.values, weightedAverage(test.values, test.weights))
+			if gotErr != nil {
+				t.Errorf("evaluateScoreExpression() returned unexpected error: %s", gotErr)
+			}
+			if !cmp.Equal(test.wantValue, gotValue) {
+				t.Errorf("evaluateScoreExpression() returned unexpected value, want: %v, got: %v", test.wantValue, gotValue)
+			}
+		})
+	}
+}
+
+func TestWeightedAverageError(t *testing.T) {
+	tests := []struct {
+		desc    string
+		values  map[string]interface{}
+		weights map[string]float64
+	}{
+		{
+			desc:    "all weights zero",
+			values:  map[string]interface{}{"lint": int64(10), "complexity": float64(40)},
+			weights: map[string]float64{},
+		},
+		{
+			desc:    "negative weight",
+			values:  map[string]interface{}{"lint": int64(10), "complexity": float64(40)},
+			weights: map[string]float64{"lint": 2, "complexity": -1},
+		},
+		{
+			desc:    "unsupported value type",
+			values:  map[string]interface{}{"approved": true},
+			weights: map[string]float64{"approved": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, gotErr := evaluateScoreExpression("weightedAverage()", test.values, weightedAverage(test.values, test.weights))
+			if gotErr == nil {
+				t.Errorf("evaluateScoreExpression(weightedAverage(), %v) did not return an error", test.values)
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/ext"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MemoryArtifactClient stores artifacts in memory so that scores can be
+// calculated without a registry, e.g. to develop score definitions offline.
+// Artifacts that are saved with SetArtifact are recorded and returned by Writes.
+// It is safe for concurrent use.
+type MemoryArtifactClient struct {
+	mu        sync.RWMutex
+	artifacts map[string]*rpc.Artifact
+	writes    []*rpc.Artifact
+}
+
+// NewMemoryArtifactClient returns a client that stores the specified artifacts.
+// Artifacts without update times are given the current time.
+func NewMemoryArtifactClient(artifacts ...*rpc.Artifact) *MemoryArtifactClient {
+	c := &MemoryArtifactClient{artifacts: make(map[string]*rpc.Artifact)}
+	for _, a := range artifacts {
+		c.store(a)
+	}
+	return c
+}
+
+// LoadDirectory adds the artifacts in the files below dir to the client.
+// YAML files hold artifacts in the format used by "registry apply" and
+// parent is the project location that they are loaded into, e.g.
+// "projects/my-project/locations/global". Files with a ".pb" extension hold
+// serialized Artifact messages with full resource names. Other files are ignored.
+func (c *MemoryArtifactClient) LoadDirectory(ctx context.Context, dir, parent string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		var artifact *rpc.Artifact
+		switch filepath.Ext(path) {
+		case ".yaml":
+			bytes, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if artifact, err = patch.ArtifactFromYAML(ctx, bytes, parent); err != nil {
+				return fmt.Errorf("failed to load %s: %s", path, err)
+			}
+		case ".pb":
+			bytes, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			artifact = &rpc.Artifact{}
+			if err := proto.Unmarshal(bytes, artifact); err != nil {
+				return fmt.Errorf("failed to load %s: %s", path, err)
+			}
+			name, err := names.ParseArtifact(artifact.GetName())
+			if err != nil {
+				return fmt.Errorf("failed to load %s: %s", path, err)
+			}
+			artifact.Name = name.String()
+		default:
+			return nil
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.store(artifact)
+		return nil
+	})
+}
+
+// store saves a copy of artifact. Callers must hold the lock or have exclusive access.
+func (c *MemoryArtifactClient) store(artifact *rpc.Artifact) *rpc.Artifact {
+	a := proto.Clone(artifact).(*rpc.Artifact)
+	now := timestamppb.Now()
+	if existing, ok := c.artifacts[a.GetName()]; ok {
+		a.CreateTime = existing.GetCreateTime()
+	}
+	if a.CreateTime == nil {
+		a.CreateTime = now
+	}
+	if a.UpdateTime == nil {
+		a.UpdateTime = now
+	}
+	a.SizeBytes = int32(len(a.GetContents()))
+	c.artifacts[a.GetName()] = a
+	return a
+}
+
+// Writes returns the artifacts that were saved with SetArtifact in the order they were saved.
+func (c *MemoryArtifactClient) Writes() []*rpc.Artifact {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	writes := make([]*rpc.Artifact, len(c.writes))
+	for i, a := range c.writes {
+		writes[i] = proto.Clone(a).(*rpc.Artifact)
+	}
+	return writes
+}
+
+func (c *MemoryArtifactClient) GetArtifact(ctx context.Context, artifact names.Artifact, getContents bool, handler core.ArtifactHandler) error {
+	c.mu.RLock()
+	a, ok := c.artifacts[artifact.String()]
+	if ok {
+		a = copyArtifact(a, getContents)
+	}
+	c.mu.RUnlock()
+	if !ok {
+		return status.Errorf(codes.NotFound, "%q not found", artifact)
+	}
+	return handler(a)
+}
+
+// SetArtifact creates or replaces an artifact and sets its update time to the current time.
+func (c *MemoryArtifactClient) SetArtifact(ctx context.Context, artifact *rpc.Artifact) error {
+	name, err := names.ParseArtifact(artifact.GetName())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	a := proto.Clone(artifact).(*rpc.Artifact)
+	a.Name = name.String()
+	a.UpdateTime = nil
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writes = append(c.writes, c.store(a))
+	return nil
+}
+
+// ListArtifacts calls handler for the artifacts that match the pattern and filter in name order.
+// Filters support the fields that the registry supports for artifacts.
+func (c *MemoryArtifactClient) ListArtifacts(ctx context.Context, artifact names.Artifact, filter string, contents bool, handler core.ArtifactHandler) error {
+	program, err := newArtifactFilter(filter)
+	if err != nil {
+		return err
+	}
+
+	// Handlers are called without holding the lock so that they can use the client.
+	matches := make([]*rpc.Artifact, 0)
+	c.mu.RLock()
+	for name, a := range c.artifacts {
+		if matchesArtifactPattern(artifact.String(), name) {
+			matches = append(matches, copyArtifact(a, contents))
+		}
+	}
+	c.mu.RUnlock()
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].GetName() < matches[j].GetName()
+	})
+
+	for _, a := range matches {
+		if program != nil {
+			out, _, err := program.Eval(artifactFilterVariables(a))
+			if err != nil {
+				return status.Error(codes.InvalidArgument, err.Error())
+			}
+			if match, ok := out.Value().(bool); !ok {
+				return status.Errorf(codes.InvalidArgument, "filter expression evaluation returned unexpected type: got %T, want bool", out.Value())
+			} else if !match {
+				continue
+			}
+		}
+		if err := handler(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *MemoryArtifactClient) ListArtifactsLazily(ctx context.Context, artifact names.Artifact, filter string, handler core.LazyArtifactHandler) error {
+	return c.ListArtifacts(ctx, artifact, filter, false, func(a *rpc.Artifact) error {
+		return handler(c.lazyArtifact(a))
+	})
+}
+
+func (c *MemoryArtifactClient) GetArtifactLazily(ctx context.Context, artifact names.Artifact, handler core.LazyArtifactHandler) error {
+	return c.GetArtifact(ctx, artifact, false, func(a *rpc.Artifact) error {
+		return handler(c.lazyArtifact(a))
+	})
+}
+
+func (c *MemoryArtifactClient) lazyArtifact(a *rpc.Artifact) *core.LazyArtifact {
+	return core.NewLazyArtifact(a, func() ([]byte, error) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		stored, ok := c.artifacts[a.GetName()]
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "%q not found", a.GetName())
+		}
+		return stored.GetContents(), nil
+	})
+}
+
+// copyArtifact returns a copy of a that can be modified by callers.
+func copyArtifact(a *rpc.Artifact, contents bool) *rpc.Artifact {
+	c := proto.Clone(a).(*rpc.Artifact)
+	if !contents {
+		c.Contents = nil
+	}
+	return c
+}
+
+// matchesArtifactPattern reports whether the artifact name matches pattern,
+// which may use "-" as a wildcard for any of its IDs.
+func matchesArtifactPattern(pattern, name string) bool {
+	patternSegments := strings.Split(pattern, "/")
+	nameSegments := strings.Split(name, "/")
+	if len(patternSegments) != len(nameSegments) {
+		return false
+	}
+	for i := range patternSegments {
+		if patternSegments[i] != "-" && patternSegments[i] != nameSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// newArtifactFilter returns a program that evaluates filter, or nil if the filter is empty.
+func newArtifactFilter(filter string) (cel.Program, error) {
+	if filter == "" {
+		return nil, nil
+	}
+	declarations := []*exprpb.Decl{
+		decls.NewConst("create_time", decls.Timestamp, nil),
+		decls.NewConst("update_time", decls.Timestamp, nil),
+		decls.NewConst("size_bytes", decls.Int, nil),
+		decls.NewConst("labels", decls.NewMapType(decls.String, decls.String), nil),
+	}
+	for _, name := range []string{"name", "project_id", "api_id", "version_id", "spec_id", "deployment_id", "artifact_id", "mime_type"} {
+		declarations = append(declarations, decls.NewConst(name, decls.String, nil))
+	}
+	env, err := cel.NewEnv(cel.Container("filter"), cel.Declarations(declarations...), ext.Strings())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ast, iss := env.Compile(filter)
+	if iss.Err() != nil {
+		return nil, status.Error(codes.InvalidArgument, iss.Err().Error())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return program, nil
+}
+
+func artifactFilterVariables(a *rpc.Artifact) map[string]interface{} {
+	name, _ := names.ParseArtifact(a.GetName())
+	labels := a.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return map[string]interface{}{
+		"name":          a.GetName(),
+		"project_id":    name.ProjectID(),
+		"api_id":        name.ApiID(),
+		"version_id":    name.VersionID(),
+		"spec_id":       name.SpecID(),
+		"deployment_id": name.DeploymentID(),
+		"artifact_id":   name.ArtifactID(),
+		"create_time":   a.GetCreateTime().AsTime(),
+		"update_time":   a.GetUpdateTime().AsTime(),
+		"mime_type":     a.GetMimeType(),
+		"size_bytes":    int64(a.GetSizeBytes()),
+		"labels":        labels,
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apigee/registry/cmd/registry/core"
+	"github.com/apigee/registry/cmd/registry/patch"
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+const memorySpec = "projects/memory-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+
+func TestMemoryArtifactClientCalculateScore(t *testing.T) {
+	ctx := context.Background()
+	client := NewMemoryArtifactClient(
+		&rpc.Artifact{
+			Name:     memorySpec + "/artifacts/lint-spectral",
+			MimeType: patch.MimeTypeForKind("Lint"),
+			Contents: protoMarshal(&rpc.Lint{
+				Name: "openapi.yaml",
+				Files: []*rpc.LintFile{
+					{
+						FilePath: "openapi.yaml",
+						Problems: []*rpc.LintProblem{{Message: "lint-error"}},
+					},
+				},
+			}),
+		},
+		&rpc.Artifact{
+			Name:     "projects/memory-test/locations/global/artifacts/lint-error",
+			MimeType: patch.MimeTypeForKind("ScoreDefinition"),
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint-error",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{MinValue: 0, MaxValue: 10},
+				},
+			}),
+		},
+	)
+
+	definitions, err := FetchScoreDefinitions(ctx, client, "projects/memory-test")
+	if err != nil {
+		t.Fatalf("FetchScoreDefinitions() returned error: %s", err)
+	}
+	if len(definitions) != 1 {
+		t.Fatalf("FetchScoreDefinitions() returned %d definitions, want 1", len(definitions))
+	}
+	resource := patterns.SpecResource{Spec: &rpc.ApiSpec{Name: memorySpec}}
+	if _, err := CalculateScore(ctx, client, definitions[0], resource, false); err != nil {
+		t.Fatalf("CalculateScore() returned error: %s", err)
+	}
+
+	writes := client.Writes()
+	if len(writes) != 1 {
+		t.Fatalf("Writes() returned %d artifacts, want 1", len(writes))
+	}
+	if want := memorySpec + "/artifacts/score-lint-error"; writes[0].GetName() != want {
+		t.Errorf("Writes() returned %q, want %q", writes[0].GetName(), want)
+	}
+	got := &rpc.Score{}
+	if err := proto.Unmarshal(writes[0].GetContents(), got); err != nil {
+		t.Fatalf("Failed to unmarshal score: %s", err)
+	}
+	want := &rpc.Score{
+		Id:             "score-lint-error",
+		Kind:           "Score",
+		DefinitionName: "projects/memory-test/locations/global/artifacts/lint-error",
+		SeverityLabel:  "SEVERITY_UNSPECIFIED",
+		Value: &rpc.Score_IntegerValue{
+			IntegerValue: &rpc.IntegerValue{Value: 1, MinValue: 0, MaxValue: 10},
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("CalculateScore() saved unexpected score (-want +got):\n%s", diff)
+	}
+
+	// The score is up-to-date, so calculating it again doesn't write it.
+	if _, err := CalculateScore(ctx, client, definitions[0], resource, false); err != nil {
+		t.Fatalf("CalculateScore() returned error: %s", err)
+	}
+	if len(client.Writes()) != 1 {
+		t.Errorf("CalculateScore() rewrote an up-to-date score")
+	}
+}
+
+func TestMemoryArtifactClientLoadDirectory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	yaml := `apiVersion: apigeeregistry/v1
+kind: ReferenceList
+metadata:
+  name: references
+  parent: apis/petstore/versions/1.0.0/specs/openapi.yaml
+  labels:
+    team: pets
+data:
+  references:
+    - id: docs
+      displayName: Documentation
+      uri: https://docs.example.com
+`
+	if err := os.WriteFile(filepath.Join(dir, "references.yaml"), []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "nested"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	pb, err := proto.Marshal(&rpc.Artifact{
+		Name:     memorySpec + "/artifacts/notes",
+		MimeType: "text/plain",
+		Contents: []byte("notes"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "nested", "notes.pb"), pb, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewMemoryArtifactClient()
+	if err := client.LoadDirectory(ctx, dir, "projects/memory-test/locations/global"); err != nil {
+		t.Fatalf("LoadDirectory() returned error: %s", err)
+	}
+	if len(client.Writes()) != 0 {
+		t.Errorf("LoadDirectory() recorded loaded artifacts as writes")
+	}
+
+	list := func(pattern, filter string) []string {
+		t.Helper()
+		name, err := names.ParseArtifact(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]string, 0)
+		if err := client.ListArtifacts(ctx, name, filter, false, func(a *rpc.Artifact) error {
+			if len(a.GetContents()) != 0 {
+				t.Errorf("ListArtifacts() without contents returned contents for %s", a.GetName())
+			}
+			got = append(got, a.GetName())
+			return nil
+		}); err != nil {
+			t.Fatalf("ListArtifacts(%q, %q) returned error: %s", pattern, filter, err)
+		}
+		return got
+	}
+	all := []string{memorySpec + "/artifacts/notes", memorySpec + "/artifacts/references"}
+	if diff := cmp.Diff(all, list("projects/memory-test/locations/global/apis/-/versions/-/specs/-/artifacts/-", "")); diff != "" {
+		t.Errorf("ListArtifacts() returned unexpected artifacts (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(all[1:], list(memorySpec+"/artifacts/-", "'team' in labels && labels.team == 'pets'")); diff != "" {
+		t.Errorf("ListArtifacts() with filter returned unexpected artifacts (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(all[:1], list(memorySpec+"/artifacts/-", "mime_type == 'text/plain' && size_bytes == 5")); diff != "" {
+		t.Errorf("ListArtifacts() with filter returned unexpected artifacts (-want +got):\n%s", diff)
+	}
+	if got := list("projects/memory-test/locations/global/apis/-/versions/-/artifacts/-", ""); len(got) != 0 {
+		t.Errorf("ListArtifacts() returned spec artifacts for a version pattern: %v", got)
+	}
+
+	name, _ := names.ParseArtifact(memorySpec + "/artifacts/references")
+	if err := client.GetArtifactLazily(ctx, name, func(a *core.LazyArtifact) error {
+		contents, err := a.Fetch()
+		if err != nil {
+			return err
+		}
+		return proto.Unmarshal(contents, &rpc.ReferenceList{})
+	}); err != nil {
+		t.Errorf("GetArtifactLazily() returned error: %s", err)
+	}
+	missing, _ := names.ParseArtifact(memorySpec + "/artifacts/missing")
+	err = client.GetArtifact(ctx, missing, false, func(*rpc.Artifact) error { return nil })
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetArtifact() of a missing artifact returned %v, want NotFound", err)
+	}
+}
+
+func TestMemoryArtifactClientConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	client := NewMemoryArtifactClient()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			artifact := &rpc.Artifact{Name: fmt.Sprintf("%s/artifacts/a%d", memorySpec, i%5)}
+			if err := client.SetArtifact(ctx, artifact); err != nil {
+				t.Errorf("SetArtifact() returned error: %s", err)
+			}
+			name, _ := names.ParseArtifact(memorySpec + "/artifacts/-")
+			if err := client.ListArtifacts(ctx, name, "", false, func(*rpc.Artifact) error { return nil }); err != nil {
+				t.Errorf("ListArtifacts() returned error: %s", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(client.Writes()); got != 20 {
+		t.Errorf("Writes() returned %d artifacts, want 20", got)
+	}
+	count := 0
+	name, _ := names.ParseArtifact(memorySpec + "/artifacts/-")
+	if err := client.ListArtifacts(ctx, name, "", false, func(*rpc.Artifact) error {
+		count++
+		return nil
+	}); err != nil {
+		t.Fatalf("ListArtifacts() returned error: %s", err)
+	}
+	if count != 5 {
+		t.Errorf("ListArtifacts() returned %d artifacts, want 5", count)
+	}
+}
@@ -16,6 +16,7 @@ package scoring
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -51,18 +52,79 @@ func ValidateScoreDefinition(parent string, scoreDefinition *rpc.ScoreDefinition
 			if formula.RollupFormula.GetRollupExpression() == "" {
 				totalErrs = append(totalErrs, fmt.Errorf("missing rollup_formula.rollup_expression"))
 			}
+			errs := validateWeights(formula.RollupFormula)
+			totalErrs = append(totalErrs, errs...)
 		default:
 			totalErrs = append(totalErrs, fmt.Errorf("missing formula, either 'score_formula' or 'rollup_formula' should be set"))
 		}
 	}
 
+	totalErrs = append(totalErrs, validateScoreType(scoreDefinition)...)
+
+	// The value of a schema_validation determines the type of the score
+	if validation := scoreDefinition.GetScoreFormula().GetSchemaValidation(); validation != nil {
+		if validation.GetCountViolations() && scoreDefinition.GetInteger() == nil {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid type, 'integer' should be set to count the violations of schema_validation"))
+		} else if !validation.GetCountViolations() && scoreDefinition.GetBoolean() == nil {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid type, 'boolean' should be set for schema_validation unless count_violations is set"))
+		}
+		if len(scoreDefinition.GetOutputs()) > 0 {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid outputs, they can't be used with schema_validation"))
+		}
+	}
+
+	// staleness_window overrides the update threshold of the client
+	if window := scoreDefinition.GetStalenessWindow(); window != nil {
+		if err := window.CheckValid(); err != nil {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid staleness_window: %s", err))
+		} else if window.AsDuration() < 0 {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid staleness_window: %s, it should not be negative", window.AsDuration()))
+		}
+	}
+
+	// Validate outputs
+	ids := make(map[string]bool)
+	for _, output := range scoreDefinition.GetOutputs() {
+		if scoreDefinition.GetScoreFormula() == nil {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid outputs, they can only be set with a 'score_formula'"))
+			break
+		}
+		id := output.GetId()
+		if id == "" {
+			totalErrs = append(totalErrs, fmt.Errorf("missing outputs.id"))
+		} else if !outputIDRegexp.MatchString(id) {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid outputs.id: %q, it should contain only lowercase letters, digits and hyphens", id))
+		} else if ids[id] {
+			totalErrs = append(totalErrs, fmt.Errorf("duplicate outputs.id: %q", id))
+		}
+		ids[id] = true
+		if output.GetScoreExpression() == "" {
+			totalErrs = append(totalErrs, fmt.Errorf("missing outputs.score_expression for output %q", id))
+		}
+		for _, err := range validateScoreType(outputDefinition(scoreDefinition, output)) {
+			totalErrs = append(totalErrs, fmt.Errorf("invalid output %q: %s", id, err))
+		}
+	}
+
+	return totalErrs
+}
+
+var outputIDRegexp = regexp.MustCompile("^[a-z0-9]([a-z0-9-]*[a-z0-9])?$")
+
+func validateScoreType(scoreDefinition *rpc.ScoreDefinition) []error {
+	totalErrs := make([]error, 0)
+
 	// Validate threshold
+	required := scoreDefinition.GetRequireThresholdCoverage()
 	switch scoreType := scoreDefinition.GetType().(type) {
 	case *rpc.ScoreDefinition_Percent:
 		// minValue: 0 maxValue:100
 		// validate that the set thresholds are within these bounds
 		errs := validateNumberThresholds(scoreType.Percent.GetThresholds(), 0, 100)
 		totalErrs = append(totalErrs, errs...)
+		if required && len(scoreType.Percent.GetThresholds()) == 0 {
+			totalErrs = append(totalErrs, fmt.Errorf("incomplete coverage: missing coverage between %d and %d", 0, 100))
+		}
 	case *rpc.ScoreDefinition_Integer:
 		// defaults if not set: minValue: 0 maxValue:0
 		minValue := scoreType.Integer.GetMinValue()
@@ -74,8 +136,12 @@ func ValidateScoreDefinition(parent string, scoreDefinition *rpc.ScoreDefinition
 		} else { // validate that the set thresholds are within minValue and maxValue limits
 			errs := validateNumberThresholds(scoreType.Integer.GetThresholds(), minValue, maxValue)
 			totalErrs = append(totalErrs, errs...)
+			if required && len(scoreType.Integer.GetThresholds()) == 0 {
+				totalErrs = append(totalErrs, fmt.Errorf("incomplete coverage: missing coverage between %d and %d", minValue, maxValue))
+			}
 		}
 	case *rpc.ScoreDefinition_Boolean:
+		// Boolean thresholds always need to cover both values.
 		errs := validateBooleanThresholds(scoreType.Boolean.GetThresholds())
 		totalErrs = append(totalErrs, errs...)
 	default:
@@ -116,6 +182,9 @@ func ValidateScoreCardDefinition(parent string, scoreCardDefinition *rpc.ScoreCa
 		}
 	}
 
+	// Validate the parameters of the severity aggregation strategy
+	totalErrs = append(totalErrs, validateSeverityAggregation(scorePatterns, scoreCardDefinition.GetSeverityAggregation())...)
+
 	return totalErrs
 }
 
@@ -128,7 +197,7 @@ func validateReferencesInPattern(targetName patterns.ResourceName, pattern strin
 		errs = append(errs, fmt.Errorf("invalid pattern: %q, %s", pattern, err))
 	} else if entityType == "default" {
 		// pattern should always start with a $resource reference
-		errs = append(errs, fmt.Errorf("invalid pattern: %q, must always start with '$resource.(api|version|spec|artifact)'", pattern))
+		errs = append(errs, fmt.Errorf("invalid pattern: %q, must always start with '$resource.(project|api|version|spec|artifact)'", pattern))
 	} else if _, err = patterns.GetReferenceEntityValue(pattern, targetName); err != nil {
 		// $resource should have valid entity reference wrt target_resource
 		errs = append(errs, fmt.Errorf("invalid pattern: %q, invalid $resource reference in pattern: %s", pattern, err))
@@ -142,22 +211,75 @@ func validateScoreFormula(targetName patterns.ResourceName, scoreFormula *rpc.Sc
 
 	// Validation checks for score_formula.artifact.pattern
 	pattern := scoreFormula.GetArtifact().GetPattern()
+	if pattern != "" || len(scoreFormula.GetArtifacts()) == 0 {
+		errs = append(errs, validateArtifactPattern(targetName, pattern)...)
+	}
 
+	// Validation checks for score_formula.artifacts
+	aliases := make(map[string]bool)
+	for _, artifact := range scoreFormula.GetArtifacts() {
+		alias := artifact.GetAlias()
+		if alias == "" {
+			errs = append(errs, fmt.Errorf("missing score_formula.artifacts.alias for pattern %q", artifact.GetArtifact().GetPattern()))
+		} else if !aliasRegexp.MatchString(alias) {
+			errs = append(errs, fmt.Errorf("invalid score_formula.artifacts.alias: %q, it should start with a letter and contain only letters, digits and underscores", alias))
+		} else if aliases[alias] {
+			errs = append(errs, fmt.Errorf("duplicate score_formula.artifacts.alias: %q", alias))
+		}
+		aliases[alias] = true
+		errs = append(errs, validateArtifactPattern(targetName, artifact.GetArtifact().GetPattern())...)
+	}
+
+	if validation := scoreFormula.GetSchemaValidation(); validation != nil {
+		if scoreFormula.GetScoreExpression() != "" {
+			errs = append(errs, fmt.Errorf("invalid score_formula, score_expression and schema_validation can't both be set"))
+		}
+		if len(scoreFormula.GetArtifacts()) > 0 {
+			errs = append(errs, fmt.Errorf("invalid score_formula, artifacts can't be used with schema_validation"))
+		}
+		if _, err := compileSchema(validation); err != nil {
+			errs = append(errs, fmt.Errorf("invalid score_formula.schema_validation.schema: %s", err))
+		}
+	} else if scoreFormula.GetScoreExpression() == "" {
+		errs = append(errs, fmt.Errorf("missing score_formula.score_expression"))
+	}
+
+	if refId := scoreFormula.GetReferenceId(); refId != "" && strings.Contains(refId, "-") {
+		errs = append(errs, fmt.Errorf("invalid score_formula.reference_id: %s, it should not contain hyphens '-'", refId))
+	}
+
+	return errs
+}
+
+var aliasRegexp = regexp.MustCompile("^[A-Za-z][A-Za-z0-9_]*$")
+
+func validateArtifactPattern(targetName patterns.ResourceName, pattern string) []error {
 	// Should have valid $resource references
-	patternErrs := validateReferencesInPattern(targetName, pattern)
-	errs = append(errs, patternErrs...)
+	errs := validateReferencesInPattern(targetName, pattern)
 
 	// Should not end with a "-"
 	if strings.HasSuffix(pattern, "/-") {
 		errs = append(errs, fmt.Errorf("invalid score_formula.artifact.pattern : %q, it should end with a resourceID and not a \"-\"", pattern))
 	}
+	return errs
+}
 
-	if scoreFormula.GetScoreExpression() == "" {
-		errs = append(errs, fmt.Errorf("missing score_formula.score_expression"))
+func validateWeights(rollupFormula *rpc.RollUpFormula) []error {
+	errs := make([]error, 0)
+
+	hasWeight := false
+	for _, scoreFormula := range rollupFormula.GetScoreFormulas() {
+		weight := scoreFormula.GetWeight()
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("invalid score_formula.weight: %v for reference_id %q, weights should be non-negative", weight, scoreFormula.GetReferenceId()))
+		} else if weight > 0 {
+			hasWeight = true
+		}
 	}
 
-	if refId := scoreFormula.GetReferenceId(); refId != "" && strings.Contains(refId, "-") {
-		errs = append(errs, fmt.Errorf("invalid score_formula.reference_id: %s, it should not contain hyphens '-'", refId))
+	// weights only matter if the rollup uses them
+	if !hasWeight && len(errs) == 0 && strings.Contains(rollupFormula.GetRollupExpression(), "weightedAverage(") {
+		errs = append(errs, fmt.Errorf("invalid score_formula.weight: weightedAverage() requires at least one score_formula with a weight greater than zero"))
 	}
 
 	return errs
@@ -260,11 +382,10 @@ func validateBooleanThresholds(thresholds []*rpc.BooleanThreshold) []error {
 func GenerateCombinedPattern(targetPattern *rpc.ResourcePattern, inputPatternName patterns.ResourceName, inputFilter string) (string, string, error) {
 	projectID := strings.Split(inputPatternName.Project(), "/")[1]
 	// Generate a common list pattern based on the supplied input and the targetPattern in the definition
-	targetPatternName, err := patterns.ParseResourcePattern(fmt.Sprintf("projects/%s/locations/global/%s", projectID, targetPattern.GetPattern()))
+	targetPatternName, err := patterns.ParseResourcePattern(fmt.Sprintf("%s/%s", patterns.ProjectLocation("projects/"+projectID), targetPattern.GetPattern()))
 	if err != nil {
 		return "", "", fmt.Errorf("invalid targetPattern in ScoreDefinition: %s", err)
 	}
-	fmt.Printf("%s", targetPatternName.String())
 
 	// Merge the two patterns into one
 	switch tp := targetPatternName.(type) {
@@ -16,9 +16,11 @@ package scoring
 
 import (
 	"testing"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/rpc"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestValidateScoreDefinition(t *testing.T) {
@@ -738,6 +740,275 @@ func TestValidateScoreDefinition(t *testing.T) {
 			},
 			wantNumErr: 1,
 		},
+		{
+			desc:   "score formula with outputs",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/conformance-report",
+						},
+						ScoreExpression: "count(errors)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 100,
+					},
+				},
+				Outputs: []*rpc.ScoreOutput{
+					{
+						Id:              "warnings",
+						ScoreExpression: "count(warnings)",
+						Type: &rpc.ScoreOutput_Integer{
+							Integer: &rpc.IntegerType{
+								MinValue: 0,
+								MaxValue: 100,
+							},
+						},
+					},
+					{
+						Id:              "approved",
+						ScoreExpression: "count(errors) == 0",
+						Type: &rpc.ScoreOutput_Boolean{
+							Boolean: &rpc.BooleanType{
+								Thresholds: []*rpc.BooleanThreshold{
+									{
+										Severity: rpc.Severity_OK,
+										Value:    true,
+									},
+									{
+										Severity: rpc.Severity_ALERT,
+										Value:    false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:   "outputs with rollup formula",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_RollupFormula{
+					RollupFormula: &rpc.RollUpFormula{
+						ScoreFormulas: []*rpc.ScoreFormula{
+							{
+								Artifact: &rpc.ResourcePattern{
+									Pattern: "$resource.spec/artifacts/conformance-report",
+								},
+								ScoreExpression: "count(errors)",
+								ReferenceId:     "lint_errors",
+							},
+						},
+						RollupExpression: "lint_errors",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 100,
+					},
+				},
+				Outputs: []*rpc.ScoreOutput{
+					{
+						Id:              "warnings",
+						ScoreExpression: "count(warnings)",
+						Type: &rpc.ScoreOutput_Integer{
+							Integer: &rpc.IntegerType{
+								MinValue: 0,
+								MaxValue: 100,
+							},
+						},
+					},
+					{
+						Id:              "approved",
+						ScoreExpression: "count(errors) == 0",
+						Type: &rpc.ScoreOutput_Boolean{
+							Boolean: &rpc.BooleanType{
+								Thresholds: []*rpc.BooleanThreshold{
+									{
+										Severity: rpc.Severity_OK,
+										Value:    true,
+									},
+									{
+										Severity: rpc.Severity_ALERT,
+										Value:    false,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantNumErr: 1,
+		},
+		{
+			desc:   "invalid outputs",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/conformance-report",
+						},
+						ScoreExpression: "count(errors)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 100,
+					},
+				},
+				Outputs: []*rpc.ScoreOutput{
+					{
+						Id:              "warnings",
+						ScoreExpression: "count(warnings)",
+						Type: &rpc.ScoreOutput_Percent{
+							Percent: &rpc.PercentType{},
+						},
+					},
+					{
+						Id: "warnings",
+						Type: &rpc.ScoreOutput_Integer{
+							Integer: &rpc.IntegerType{
+								MinValue: 10,
+								MaxValue: 0,
+							},
+						},
+					},
+					{
+						Id:              "Invalid_ID",
+						ScoreExpression: "true",
+					},
+				},
+			},
+			wantNumErr: 5,
+		},
+		{
+			desc:   "required threshold coverage",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/complexity",
+						},
+						ScoreExpression: "count(errors)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+				Outputs: []*rpc.ScoreOutput{
+					{
+						Id:              "warnings",
+						ScoreExpression: "count(warnings)",
+						Type: &rpc.ScoreOutput_Percent{
+							Percent: &rpc.PercentType{
+								Thresholds: []*rpc.NumberThreshold{
+									{
+										Severity: rpc.Severity_OK,
+										Range:    &rpc.NumberThreshold_NumberRange{Min: 0, Max: 100},
+									},
+								},
+							},
+						},
+					},
+					{
+						Id:              "errors",
+						ScoreExpression: "count(errors)",
+						Type: &rpc.ScoreOutput_Percent{
+							Percent: &rpc.PercentType{},
+						},
+					},
+				},
+				RequireThresholdCoverage: true,
+			},
+			wantNumErr: 2,
+		},
+		{
+			desc:   "zero staleness_window",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/conformance-report",
+						},
+						ScoreExpression: "count(errors)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 100,
+					},
+				},
+				StalenessWindow: durationpb.New(0),
+			},
+			wantNumErr: 0,
+		},
+		{
+			desc:   "negative staleness_window",
+			parent: "projects/demo/locations/global",
+			scoreDefinition: &rpc.ScoreDefinition{
+				Id:   "test-score-definition",
+				Kind: "ScoreDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/conformance-report",
+						},
+						ScoreExpression: "count(errors)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 100,
+					},
+				},
+				StalenessWindow: durationpb.New(-time.Minute),
+			},
+			wantNumErr: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -763,6 +1034,16 @@ func TestValidateReferencesInPattern(t *testing.T) {
 			targetPattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
 			pattern:       "$resource.spec/artifacts/conformance-report",
 		},
+		{
+			desc:          "api reference from spec",
+			targetPattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			pattern:       "$resource.api/artifacts/lint-config",
+		},
+		{
+			desc:          "project reference from version",
+			targetPattern: "projects/demo/locations/global/apis/-/versions/-",
+			pattern:       "$resource.project/artifacts/style-guide",
+		},
 		// errors
 		{
 			desc:          "invalid $resource reference",
@@ -815,6 +1096,25 @@ func TestValidateScoreFormula(t *testing.T) {
 				ScoreExpression: "count(errors)",
 			},
 		},
+		{
+			desc: "named artifacts",
+			targetPattern: &rpc.ResourcePattern{
+				Pattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			},
+			scoreFormula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+					{
+						Alias:    "complexity",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/complexity"},
+					},
+				},
+				ScoreExpression: "size(lint.files) < complexity.path_count",
+			},
+		},
 		// Single errors
 		{
 			desc: "$resource error",
@@ -896,6 +1196,73 @@ func TestValidateScoreFormula(t *testing.T) {
 			},
 			wantNumErr: 2,
 		},
+		{
+			desc: "missing alias",
+			targetPattern: &rpc.ResourcePattern{
+				Pattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			},
+			scoreFormula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+				},
+				ScoreExpression: "size(files)",
+			},
+			wantNumErr: 1,
+		},
+		{
+			desc: "invalid alias",
+			targetPattern: &rpc.ResourcePattern{
+				Pattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			},
+			scoreFormula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint-spectral",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+				},
+				ScoreExpression: "size(files)",
+			},
+			wantNumErr: 1,
+		},
+		{
+			desc: "duplicate alias",
+			targetPattern: &rpc.ResourcePattern{
+				Pattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			},
+			scoreFormula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-gnostic"},
+					},
+				},
+				ScoreExpression: "size(lint.files)",
+			},
+			wantNumErr: 1,
+		},
+		{
+			desc: "invalid named artifact pattern",
+			targetPattern: &rpc.ResourcePattern{
+				Pattern: "projects/demo/locations/global/apis/-/versions/-/specs/-",
+			},
+			scoreFormula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/-"},
+					},
+				},
+				ScoreExpression: "size(lint.files)",
+			},
+			wantNumErr: 1,
+		},
 		// missing components
 		{
 			desc: "missing artifact",
@@ -931,6 +1298,65 @@ func TestValidateScoreFormula(t *testing.T) {
 	}
 }
 
+func TestValidateWeights(t *testing.T) {
+	tests := []struct {
+		desc          string
+		rollupFormula *rpc.RollUpFormula
+		wantNumErr    int
+	}{
+		{
+			desc: "weighted average",
+			rollupFormula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{ReferenceId: "lint", Weight: 2},
+					{ReferenceId: "complexity"},
+				},
+				RollupExpression: "weightedAverage()",
+			},
+		},
+		{
+			desc: "no weights without weighted average",
+			rollupFormula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{ReferenceId: "lint"},
+					{ReferenceId: "complexity"},
+				},
+				RollupExpression: "lint + complexity",
+			},
+		},
+		{
+			desc: "all weights zero",
+			rollupFormula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{ReferenceId: "lint"},
+					{ReferenceId: "complexity"},
+				},
+				RollupExpression: "weightedAverage()",
+			},
+			wantNumErr: 1,
+		},
+		{
+			desc: "negative weights",
+			rollupFormula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{ReferenceId: "lint", Weight: -1},
+					{ReferenceId: "complexity", Weight: -2},
+				},
+				RollupExpression: "weightedAverage()",
+			},
+			wantNumErr: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotErrs := validateWeights(test.rollupFormula)
+			if len(gotErrs) != test.wantNumErr {
+				t.Errorf("validateWeights(%v) returned unexpected no. of errors: want %d, got %s", test.rollupFormula, test.wantNumErr, gotErrs)
+			}
+		})
+	}
+}
+
 func TestValidateNumberThresholds(t *testing.T) {
 	tests := []struct {
 		desc       string
@@ -1711,6 +2137,29 @@ func TestValidateScoreCardDefinition(t *testing.T) {
 			},
 			wantNumErr: 4,
 		},
+		{
+			desc:   "invalid severity_aggregation",
+			parent: "projects/demo/locations/global",
+			scoreCardDefinition: &rpc.ScoreCardDefinition{
+				Id:   "test-scorecard-definition",
+				Kind: "ScoreCardDefinition",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				ScorePatterns: []string{
+					"$resource.spec/artifacts/score-lint-error",
+					"$resource.spec/artifacts/score-lang-reuse",
+				},
+				SeverityAggregation: &rpc.SeverityAggregation{
+					Strategy: &rpc.SeverityAggregation_Weighted{
+						Weighted: &rpc.WeightedSeverityAggregation{
+							Threshold: 2, //error
+						},
+					},
+				},
+			},
+			wantNumErr: 1,
+		},
 	}
 
 	for _, test := range tests {
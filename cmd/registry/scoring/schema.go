@@ -0,0 +1,154 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apigee/registry/rpc"
+	"github.com/getkin/kin-openapi/openapi3"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxDescribedViolations limits the violations that are listed in the description of a score.
+const maxDescribedViolations = 10
+
+// compileSchema converts the schema of a schema_validation into a schema that
+// can validate values, or returns an error if the schema is invalid.
+func compileSchema(validation *rpc.SchemaValidation) (*openapi3.Schema, error) {
+	if validation.GetSchema() == nil {
+		return nil, fmt.Errorf("missing schema_validation.schema")
+	}
+	j, err := protojson.Marshal(validation.GetSchema())
+	if err != nil {
+		return nil, err
+	}
+	schema := &openapi3.Schema{}
+	if err := json.Unmarshal(j, schema); err != nil {
+		return nil, err
+	}
+	if err := schema.Validate(context.Background()); err != nil {
+		return nil, errors.New(strings.Join(schemaViolations(err), "; "))
+	}
+	return schema, nil
+}
+
+// evaluateSchemaValidation validates the contents of the artifact of formula against
+// the schema of its schema_validation. The value is whether the contents are valid,
+// or the number of violations if count_violations is set.
+func evaluateSchemaValidation(formula *rpc.ScoreFormula, inputs formulaInputs, needsUpdate bool) scoreResult {
+	violations, err := schemaValidationViolations(formula.GetSchemaValidation(), inputs)
+	if err != nil {
+		return scoreResult{
+			value:       nil,
+			needsUpdate: false,
+			err:         err,
+		}
+	}
+	var value interface{} = len(violations) == 0
+	if formula.GetSchemaValidation().GetCountViolations() {
+		value = int64(len(violations))
+	}
+	return scoreResult{
+		value:       value,
+		needsUpdate: needsUpdate,
+		err:         nil,
+		violations:  violations,
+	}
+}
+
+// schemaValidationViolations returns descriptions of the violations of the schema
+// of validation by the contents of the artifact in inputs.
+func schemaValidationViolations(validation *rpc.SchemaValidation, inputs formulaInputs) ([]string, error) {
+	schema, err := compileSchema(validation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema_validation.schema: %s", err)
+	}
+	if len(inputs.artifacts) != 1 {
+		return nil, fmt.Errorf("schema_validation requires exactly one artifact, got %d", len(inputs.artifacts))
+	}
+	artifact := inputs.artifacts[0].artifact
+	contents, err := artifact.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch contents of artifact %s: %s", artifact.GetName(), err)
+	}
+	contentsMap, err := getMap(contents, artifact.GetMimeType())
+	if err != nil {
+		return nil, err
+	}
+	// Round-trip the contents through JSON so that they only contain the types
+	// that the validator expects, e.g. float64 for all numbers.
+	j, err := json.Marshal(contentsMap)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(j, &value); err != nil {
+		return nil, err
+	}
+	if err := schema.VisitJSON(value, openapi3.MultiErrors()); err != nil {
+		return schemaViolations(err), nil
+	}
+	return nil, nil
+}
+
+// schemaViolations flattens a validation error into descriptions of its violations.
+// Descriptions start with the JSON pointer of the invalid value unless it is the root.
+func schemaViolations(err error) []string {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var violations []string
+		for _, err := range e {
+			violations = append(violations, schemaViolations(err)...)
+		}
+		return violations
+	case *openapi3.SchemaError:
+		if e.Origin != nil {
+			return schemaViolations(e.Origin)
+		}
+		reason := e.Reason
+		if reason == "" {
+			reason = fmt.Sprintf("doesn't match schema %q", e.SchemaField)
+		}
+		if pointer := e.JSONPointer(); len(pointer) > 0 {
+			reason = fmt.Sprintf("/%s: %s", strings.Join(pointer, "/"), reason)
+		}
+		return []string{reason}
+	default:
+		return []string{err.Error()}
+	}
+}
+
+// describeViolations appends the violations of a schema_validation to the description of a score.
+func describeViolations(description string, violations []string) string {
+	var b strings.Builder
+	b.WriteString(description)
+	if description != "" {
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(&b, "Schema violations (%d):", len(violations))
+	for i, v := range violations {
+		if i == maxDescribedViolations {
+			fmt.Fprintf(&b, "\n- ... and %d more", len(violations)-i)
+			break
+		}
+		b.WriteString("\n- " + v)
+	}
+	return b.String()
+}
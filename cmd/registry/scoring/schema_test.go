@@ -0,0 +1,313 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apigee/registry/cmd/registry/patterns"
+	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/test/seeder"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func mustStruct(t *testing.T, m map[string]interface{}) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(m)
+	if err != nil {
+		t.Fatalf("Setup: invalid schema: %s", err)
+	}
+	return s
+}
+
+// lintSchema requires lint reports of "openapi.json" without problems.
+func lintSchema(t *testing.T) *structpb.Struct {
+	return mustStruct(t, map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"name", "files"},
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type": "string",
+				"enum": []interface{}{"openapi.json"},
+			},
+			"files": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"problems": map[string]interface{}{
+							"type":     "array",
+							"items":    map[string]interface{}{},
+							"maxItems": 0,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+var booleanType = &rpc.BooleanType{
+	Thresholds: []*rpc.BooleanThreshold{
+		{Severity: rpc.Severity_ALERT, Value: false},
+		{Severity: rpc.Severity_OK, Value: true},
+	},
+}
+
+func TestValidateSchemaValidation(t *testing.T) {
+	tests := []struct {
+		desc       string
+		formula    *rpc.ScoreFormula
+		scoreType  interface{}
+		outputs    []*rpc.ScoreOutput
+		wantNumErr int
+	}{
+		{
+			desc: "boolean",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			},
+			scoreType: &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+		},
+		{
+			desc: "count violations",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t), CountViolations: true},
+			},
+			scoreType: &rpc.ScoreDefinition_Integer{Integer: &rpc.IntegerType{MaxValue: 10}},
+		},
+		{
+			desc: "missing schema",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{},
+			},
+			scoreType:  &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			wantNumErr: 1,
+		},
+		{
+			desc: "invalid schema",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{Schema: mustStruct(t, map[string]interface{}{
+					"type":    "string",
+					"pattern": "[",
+				})},
+			},
+			scoreType:  &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			wantNumErr: 1,
+		},
+		{
+			desc: "score_expression and schema_validation",
+			formula: &rpc.ScoreFormula{
+				ScoreExpression:  "true",
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			},
+			scoreType:  &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			wantNumErr: 1,
+		},
+		{
+			desc: "named artifacts",
+			formula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+				},
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			},
+			scoreType:  &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			wantNumErr: 1,
+		},
+		{
+			desc: "wrong type",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			},
+			scoreType:  &rpc.ScoreDefinition_Integer{Integer: &rpc.IntegerType{MaxValue: 10}},
+			wantNumErr: 1,
+		},
+		{
+			desc: "outputs",
+			formula: &rpc.ScoreFormula{
+				SchemaValidation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			},
+			scoreType: &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			outputs: []*rpc.ScoreOutput{
+				{
+					Id:              "count",
+					ScoreExpression: "1",
+					Type:            &rpc.ScoreOutput_Integer{Integer: &rpc.IntegerType{MaxValue: 10}},
+				},
+			},
+			wantNumErr: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.formula.GetArtifact() == nil && len(test.formula.GetArtifacts()) == 0 {
+				test.formula.Artifact = &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"}
+			}
+			definition := &rpc.ScoreDefinition{
+				Id:             "lint-schema",
+				TargetResource: &rpc.ResourcePattern{Pattern: "apis/-/versions/-/specs/-"},
+				Formula:        &rpc.ScoreDefinition_ScoreFormula{ScoreFormula: test.formula},
+				Outputs:        test.outputs,
+			}
+			switch scoreType := test.scoreType.(type) {
+			case *rpc.ScoreDefinition_Boolean:
+				definition.Type = scoreType
+			case *rpc.ScoreDefinition_Integer:
+				definition.Type = scoreType
+			}
+			errs := ValidateScoreDefinition("projects/demo/locations/global", definition)
+			if len(errs) != test.wantNumErr {
+				t.Errorf("ValidateScoreDefinition() returned %d errors, want %d: %v", len(errs), test.wantNumErr, errs)
+			}
+		})
+	}
+}
+
+func TestCalculateScoreSchemaValidation(t *testing.T) {
+	const (
+		specName = "projects/schema-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+		defName  = "projects/schema-test/locations/global/artifacts/lint-schema"
+	)
+	tests := []struct {
+		desc       string
+		validation *rpc.SchemaValidation
+		scoreType  interface{}
+		problems   int
+		wantValue  interface{}
+		wantCount  int
+	}{
+		{
+			desc:       "invalid",
+			validation: &rpc.SchemaValidation{Schema: lintSchema(t)},
+			scoreType:  &rpc.ScoreDefinition_Boolean{Boolean: booleanType},
+			problems:   2,
+			wantValue:  false,
+			wantCount:  2,
+		},
+		{
+			desc:       "count violations",
+			validation: &rpc.SchemaValidation{Schema: lintSchema(t), CountViolations: true},
+			scoreType:  &rpc.ScoreDefinition_Integer{Integer: &rpc.IntegerType{MaxValue: 10}},
+			problems:   2,
+			wantValue:  int32(2),
+			wantCount:  2,
+		},
+		{
+			desc:       "no problems",
+			validation: &rpc.SchemaValidation{Schema: lintSchema(t), CountViolations: true},
+			scoreType:  &rpc.ScoreDefinition_Integer{Integer: &rpc.IntegerType{MaxValue: 10}},
+			problems:   0,
+			wantValue:  int32(1),
+			wantCount:  1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			definition := &rpc.ScoreDefinition{
+				Id:             "lint-schema",
+				Description:    "Lint report conformance",
+				TargetResource: &rpc.ResourcePattern{Pattern: "apis/-/versions/-/specs/-"},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact:         &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+						SchemaValidation: test.validation,
+					},
+				},
+			}
+			switch scoreType := test.scoreType.(type) {
+			case *rpc.ScoreDefinition_Boolean:
+				definition.Type = scoreType
+			case *rpc.ScoreDefinition_Integer:
+				definition.Type = scoreType
+			}
+			problems := make([]*rpc.LintProblem, test.problems)
+			for i := range problems {
+				problems[i] = &rpc.LintProblem{Message: "lint-error"}
+			}
+			client := &fakeArtifactClient{}
+			if err := seeder.SeedRegistry(ctx, client,
+				&rpc.Artifact{
+					Name:     specName + "/artifacts/lint-spectral",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name:  "openapi.yaml",
+						Files: []*rpc.LintFile{{FilePath: "openapi.yaml", Problems: problems}},
+					}),
+				},
+				&rpc.Artifact{
+					Name:     defName,
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.ScoreDefinition",
+					Contents: protoMarshal(definition),
+				},
+			); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+			defArtifact, err := getArtifact(ctx, client, defName, true)
+			if err != nil {
+				t.Fatalf("Setup: failed to get definition: %s", err)
+			}
+
+			computed, err := CalculateScore(ctx, client, defArtifact, patterns.SpecResource{Spec: &rpc.ApiSpec{Name: specName}}, true)
+			if err != nil {
+				t.Fatalf("CalculateScore() returned error: %s", err)
+			}
+			if len(computed) != 1 {
+				t.Fatalf("CalculateScore() returned %d scores, want 1", len(computed))
+			}
+			score := computed[0].Score
+			var got interface{}
+			switch test.wantValue.(type) {
+			case bool:
+				got = score.GetBooleanValue().GetValue()
+			case int32:
+				got = score.GetIntegerValue().GetValue()
+			}
+			if got != test.wantValue {
+				t.Errorf("CalculateScore() returned value %v, want %v", got, test.wantValue)
+			}
+			description := score.GetDescription()
+			if !strings.HasPrefix(description, "Lint report conformance\n\nSchema violations") {
+				t.Errorf("CalculateScore() returned description %q, want the definition's description followed by the violations", description)
+			}
+			if got := strings.Count(description, "\n- "); got != test.wantCount {
+				t.Errorf("CalculateScore() described %d violations, want %d:\n%s", got, test.wantCount, description)
+			}
+			if !strings.Contains(description, "/name: ") {
+				t.Errorf("CalculateScore() returned description %q, want a violation of /name", description)
+			}
+		})
+	}
+}
+
+func TestDescribeViolations(t *testing.T) {
+	violations := make([]string, maxDescribedViolations+5)
+	for i := range violations {
+		violations[i] = "/a: invalid"
+	}
+	got := describeViolations("", violations)
+	if !strings.HasPrefix(got, "Schema violations (15):") {
+		t.Errorf("describeViolations() returned %q, want the number of violations first", got)
+	}
+	if !strings.HasSuffix(got, "\n- ... and 5 more") {
+		t.Errorf("describeViolations() returned %q, want the remaining violations to be counted", got)
+	}
+}
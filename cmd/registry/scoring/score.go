@@ -16,21 +16,75 @@ package scoring
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/patch"
 	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/log"
+	"github.com/apigee/registry/pkg/tracing"
 	"github.com/apigee/registry/rpc"
 	"github.com/apigee/registry/server/registry/names"
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrConcurrentUpdate is returned when a score isn't saved because the
+// score artifact was updated by another writer after the score was computed.
+var ErrConcurrentUpdate = errors.New("score artifact was updated concurrently")
+
+// Options control how scores and score cards are computed and saved.
+type Options struct {
+	// UpdateThreshold is the staleness window used to decide whether scores
+	// and score cards are outdated: a result is recomputed if its inputs or
+	// definition were updated less than UpdateThreshold before it was saved.
+	// This guards against inputs that change while a result is computed
+	// (https://github.com/apigee/registry/issues/641). Larger values cause
+	// more results to be recomputed after their inputs stop changing; smaller
+	// values risk missing updates that race with the computation.
+	// Values less than or equal to 0 use patterns.ResourceUpdateThreshold.
+	// Score definitions can override it with a staleness_window.
+	UpdateThreshold time.Duration
+	// SeverityChangesOnly skips saving recomputed scores that have the same
+	// severity as the saved score, even if their values differ. This keeps
+	// pipelines that alert on score updates quiet until a score crosses a
+	// severity boundary. Scores are saved on every change by default.
+	SeverityChangesOnly bool
+	// CheckConcurrentUpdates re-reads the update time of each score artifact
+	// just before it is saved. Scores whose artifacts were updated after they
+	// were read aren't saved, and ErrConcurrentUpdate is returned. This protects
+	// scores saved by concurrent scoring passes at the cost of an extra call
+	// for each saved score. Scores are saved without checking by default.
+	CheckConcurrentUpdates bool
+}
+
+// updateThreshold returns the staleness window of o.
+func (o Options) updateThreshold() time.Duration {
+	if o.UpdateThreshold > 0 {
+		return o.UpdateThreshold
+	}
+	return patterns.ResourceUpdateThreshold
+}
+
+// DefinitionHashAnnotation is the annotation of score artifacts that records
+// the SHA-256 hash of the contents of the ScoreDefinition that produced them.
+const DefinitionHashAnnotation = "apigeeregistry/definition-hash"
+
+// definitionHash returns the value of DefinitionHashAnnotation for scores of defArtifact.
+func definitionHash(defArtifact *rpc.Artifact) string {
+	sum := sha256.Sum256(defArtifact.GetContents())
+	return hex.EncodeToString(sum[:])
+}
+
 func scoreID(definitionID string) string {
 	return fmt.Sprintf("score-%s", definitionID)
 }
@@ -41,7 +95,7 @@ func FetchScoreDefinitions(
 	project string) ([]*rpc.Artifact, error) {
 	defArtifacts := make([]*rpc.Artifact, 0)
 
-	artifact, err := names.ParseArtifact(fmt.Sprintf("%s/locations/global/artifacts/-", project))
+	artifact, err := names.ParseArtifact(fmt.Sprintf("%s/artifacts/-", patterns.ProjectLocation(project)))
 	if err != nil {
 		return nil, err
 	}
@@ -51,7 +105,7 @@ func FetchScoreDefinitions(
 			definition := &rpc.ScoreDefinition{}
 			if err1 := proto.Unmarshal(artifact.GetContents(), definition); err1 != nil {
 				// don't return err, to proccess the rest of the artifacts from the list.
-				log.Debugf(ctx, "Skipping definition %q: %s", artifact.GetName(), err1)
+				log.FromContext(ctx).WithError(err1).WithField("definition", artifact.GetName()).Debug("Skipping definition")
 				return nil
 			}
 
@@ -66,64 +120,501 @@ func FetchScoreDefinitions(
 	return defArtifacts, nil
 }
 
+// DefinitionsForResource returns the score definitions of the resource's
+// project whose target_resource pattern matches the resource. Definitions
+// match if their pattern is for the resource's type and each of its segments
+// is "-" or equal to the resource's. Filters of target_resource are not
+// evaluated, so scores of some of the returned definitions might not apply.
+func DefinitionsForResource(
+	ctx context.Context,
+	client artifactClient,
+	resource patterns.ResourceInstance) ([]*rpc.ScoreDefinition, error) {
+	name := resource.ResourceName()
+	all, invalid, err := ListScoreDefinitions(ctx, client, name.Project())
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range invalid {
+		log.FromContext(ctx).WithError(definition.Err).WithField("definition", definition.Name).Warn("Skipping invalid definition")
+	}
+
+	definitions := make([]*rpc.ScoreDefinition, 0)
+	for _, info := range all {
+		definition := info.Definition
+		if _, _, err := GenerateCombinedPattern(definition.GetTargetResource(), name, ""); err != nil {
+			log.FromContext(ctx).WithError(err).WithField("definition", definition.GetId()).Debug("Definition does not apply")
+			continue
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, nil
+}
+
+// ComputedScore is a score computed by CalculateScore.
+type ComputedScore struct {
+	// Score is the computed score.
+	Score *rpc.Score
+	// Previous is the score that was stored before, nil if there wasn't one.
+	Previous *rpc.Score
+	// Changed is true if Score differs from Previous.
+	Changed bool
+	// SeverityChanged is true if the severity of Score differs from Previous.
+	SeverityChanged bool
+	// Written is true if Score was saved to the registry.
+	Written bool
+}
+
+func newComputedScore(score *rpc.Score, scoreArtifact *rpc.Artifact) *ComputedScore {
+	computed := &ComputedScore{Score: score, Changed: true, SeverityChanged: true}
+	if scoreArtifact != nil {
+		previous := &rpc.Score{}
+		if err := proto.Unmarshal(scoreArtifact.GetContents(), previous); err == nil {
+			computed.Previous = previous
+			computed.Changed = !proto.Equal(score, previous)
+			computed.SeverityChanged = score.GetSeverity() != previous.GetSeverity()
+		}
+	}
+	return computed
+}
+
+// CalculateScore computes the scores of a definition for resource and saves
+// them unless dryRun is true. It returns the scores that were computed;
+// scores that are already up-to-date are neither computed nor returned.
 func CalculateScore(
 	ctx context.Context,
 	client artifactClient,
 	defArtifact *rpc.Artifact,
 	resource patterns.ResourceInstance,
-	dryRun bool) error {
-	log.Debugf(ctx, "Calculating score for %q with definition %q", resource.ResourceName().String(), defArtifact.GetName())
+	dryRun bool) ([]*ComputedScore, error) {
+	return CalculateScoreWithOptions(ctx, client, defArtifact, resource, dryRun, Options{})
+}
+
+// CalculateScoreWithOptions is like CalculateScore, but computes and saves the scores with opts.
+func CalculateScoreWithOptions(
+	ctx context.Context,
+	client artifactClient,
+	defArtifact *rpc.Artifact,
+	resource patterns.ResourceInstance,
+	dryRun bool,
+	opts Options) (computed []*ComputedScore, err error) {
+	ctx, span := tracing.Start(ctx, "CalculateScore",
+		attribute.String("definition.name", defArtifact.GetName()),
+		attribute.String("resource.name", resource.ResourceName().String()))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+		}
+		span.End()
+	}()
+
+	ctx = log.NewContext(ctx, log.FromContext(ctx).WithFields(map[string]interface{}{
+		"resource":   resource.ResourceName().String(),
+		"definition": defArtifact.GetName(),
+	}))
+	log.FromContext(ctx).Debug("Calculating score")
 
-	project := fmt.Sprintf("%s/locations/global", resource.ResourceName().Project())
+	project := patterns.ProjectLocation(resource.ResourceName().Project())
 
 	// Extract definition
 	definition := &rpc.ScoreDefinition{}
 	if err := proto.Unmarshal(defArtifact.GetContents(), definition); err != nil {
-		return err
+		return nil, err
 	}
+	span.SetAttributes(attribute.String("definition.id", definition.GetId()))
+	ctx = log.NewContext(ctx, log.FromContext(ctx).WithField("definitionID", definition.GetId()))
+	threshold := stalenessWindow(definition, opts.updateThreshold())
 
-	var takeAction bool
+	if len(definition.GetOutputs()) > 0 {
+		return calculateScoreOutputs(ctx, client, defArtifact, definition, resource, project, threshold, dryRun, opts)
+	}
 
 	// Fetch the to be generated score artifact (if present)
 	artifactName := fmt.Sprintf("%s/artifacts/%s", resource.ResourceName().String(), scoreID(definition.GetId()))
-	scoreArtifact, err := getArtifact(ctx, client, artifactName, false)
+	scoreArtifact, takeAction, err := fetchScoreArtifact(ctx, client, defArtifact, artifactName, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate the expression and return a scoreValue
+	result := processFormula(ctx, client, definition, resource, scoreArtifact, takeAction, threshold)
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	span.SetAttributes(attribute.Bool("score.recomputed", result.needsUpdate))
+	if result.needsUpdate {
+		// generate a score proto from the scoreValue
+		score, err := processScoreType(definition, result.value, project)
+		if err != nil {
+			return nil, err
+		}
+		score.ReferenceValues = referenceValues(result.references)
+		if len(result.violations) > 0 {
+			score.Description = describeViolations(score.Description, result.violations)
+		}
+
+		written := false
+		if !dryRun {
+			if written, err = uploadScore(ctx, client, resource, score, scoreArtifact, definitionHash(defArtifact), opts); err != nil {
+				return nil, err
+			}
+		}
+		computed := newComputedScore(score, scoreArtifact)
+		computed.Written = written
+		logScoreDecision(ctx, artifactName, computed, dryRun)
+		return []*ComputedScore{computed}, nil
+	}
+
+	log.FromContext(ctx).WithFields(map[string]interface{}{
+		"score":    artifactName,
+		"decision": "skip",
+	}).Debug("Score is already up-to-date")
+	return nil, nil
+}
+
+// CalculateScoreForResource calculates the scores of the definition with
+// definitionID for the single resource named by resourceName.
+// The definition is read from the project of the resource and its target
+// pattern must be at the same level as the resource.
+func CalculateScoreForResource(
+	ctx context.Context,
+	client *RegistryArtifactClient,
+	definitionID string,
+	resourceName string,
+	dryRun bool) ([]*ComputedScore, error) {
+	return CalculateScoreForResourceWithOptions(ctx, client, definitionID, resourceName, dryRun, Options{})
+}
+
+// CalculateScoreForResourceWithOptions is like CalculateScoreForResource, but computes and saves the scores with opts.
+func CalculateScoreForResourceWithOptions(
+	ctx context.Context,
+	client *RegistryArtifactClient,
+	definitionID string,
+	resourceName string,
+	dryRun bool,
+	opts Options) ([]*ComputedScore, error) {
+	name, err := patterns.ParseResourcePattern(resourceName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource name %q: %s", resourceName, err)
+	}
+	if strings.Contains(name.String(), "/-") {
+		return nil, fmt.Errorf("invalid resource name %q: it should name a single resource", resourceName)
+	}
+
+	defName := fmt.Sprintf("%s/artifacts/%s", patterns.ProjectLocation(name.Project()), definitionID)
+	defArtifact, err := getArtifact(ctx, client, defName, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ScoreDefinition %q: %s", defName, err)
+	}
+	if defArtifact.GetMimeType() != patch.MimeTypeForKind("ScoreDefinition") {
+		return nil, fmt.Errorf("artifact %q is not a ScoreDefinition", defName)
+	}
+	definition := &rpc.ScoreDefinition{}
+	if err := proto.Unmarshal(defArtifact.GetContents(), definition); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ScoreDefinition %q: %s", defName, err)
+	}
+
+	targetName, err := patterns.ParseResourcePattern(fmt.Sprintf("%s/%s", patterns.ProjectLocation(name.Project()), definition.GetTargetResource().GetPattern()))
+	if err != nil {
+		return nil, fmt.Errorf("invalid target_resource in ScoreDefinition %q: %s", defName, err)
+	}
+	if got, want := resourceLevel(name), resourceLevel(targetName); got != want {
+		return nil, fmt.Errorf("ScoreDefinition %q targets resources of type %s, %q is of type %s", defName, want, resourceName, got)
+	}
+
+	pattern, filter, err := GenerateCombinedPattern(definition.GetTargetResource(), name, "")
+	if err != nil {
+		return nil, fmt.Errorf("ScoreDefinition %q does not apply to %q: %s", defName, resourceName, err)
+	}
+	resources, err := patterns.ListResources(ctx, client.RegistryClient, pattern, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("%q does not match the target_resource of ScoreDefinition %q", resourceName, defName)
+	}
+	return CalculateScoreWithOptions(ctx, client, defArtifact, resources[0], dryRun, opts)
+}
+
+// ScoreError is the error of computing the scores of a resource.
+type ScoreError struct {
+	Resource string
+	Err      error
+}
+
+func (e *ScoreError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Resource, e.Err)
+}
+
+func (e *ScoreError) Unwrap() error {
+	return e.Err
+}
+
+// ScoreErrors holds the errors of the resources whose scores couldn't be computed.
+type ScoreErrors []*ScoreError
+
+func (e ScoreErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return fmt.Sprintf("failed to compute scores for %d resources: %s", len(e), strings.Join(s, "; "))
+}
+
+// CalculateScoresPool computes the scores of a definition for resources with
+// up to concurrency calls of CalculateScore at the same time, and returns the
+// computed scores in the order of resources. Resources that are listed more
+// than once are scored once, since concurrent passes over the same resource
+// would race to save its scores. Scores that weren't saved because of
+// ErrConcurrentUpdate are skipped. If the scores of any resources can't be
+// computed, the scores of the other resources are returned with ScoreErrors
+// that name the failed resources.
+func CalculateScoresPool(
+	ctx context.Context,
+	client artifactClient,
+	defArtifact *rpc.Artifact,
+	resources []patterns.ResourceInstance,
+	concurrency int,
+	dryRun bool) ([]*ComputedScore, error) {
+	return CalculateScoresPoolWithOptions(ctx, client, defArtifact, resources, concurrency, dryRun, Options{})
+}
+
+// CalculateScoresPoolWithOptions is like CalculateScoresPool, but computes and saves the scores with opts.
+func CalculateScoresPoolWithOptions(
+	ctx context.Context,
+	client artifactClient,
+	defArtifact *rpc.Artifact,
+	resources []patterns.ResourceInstance,
+	concurrency int,
+	dryRun bool,
+	opts Options) ([]*ComputedScore, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	unique := make([]patterns.ResourceInstance, 0, len(resources))
+	seen := make(map[string]bool)
+	for _, r := range resources {
+		name := r.ResourceName().String()
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, r)
+		}
+	}
+
+	scores := make([][]*ComputedScore, len(unique))
+	errs := make([]error, len(unique))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, r := range unique {
+		sem <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			errs[i] = err
+			continue
+		}
+		wg.Add(1)
+		go func(i int, r patterns.ResourceInstance) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			scores[i], errs[i] = CalculateScoreWithOptions(ctx, client, defArtifact, r, dryRun, opts)
+			if errors.Is(errs[i], ErrConcurrentUpdate) {
+				log.FromContext(ctx).WithError(errs[i]).WithField("resource", r.ResourceName().String()).Debug("Skipped score")
+				errs[i] = nil
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	var computed []*ComputedScore
+	var failed ScoreErrors
+	for i, r := range unique {
+		if errs[i] != nil {
+			failed = append(failed, &ScoreError{Resource: r.ResourceName().String(), Err: errs[i]})
+			continue
+		}
+		computed = append(computed, scores[i]...)
+	}
+	if len(failed) > 0 {
+		return computed, failed
+	}
+	return computed, nil
+}
+
+// resourceLevel returns the type of resource that name refers to.
+func resourceLevel(name patterns.ResourceName) string {
+	switch name.(type) {
+	case patterns.SpecName:
+		return "spec"
+	case patterns.VersionName:
+		return "version"
+	case patterns.ApiName:
+		return "api"
+	case patterns.ArtifactName:
+		return "artifact"
+	default:
+		return "project"
+	}
+}
+
+// fetchScoreArtifact returns the score artifact named artifactName, or nil if
+// it doesn't exist, and whether the score should be calculated regardless of
+// the update times of the artifacts it is derived from. threshold is the
+// staleness window used to compare the score with its definition.
+func fetchScoreArtifact(ctx context.Context, client artifactClient, defArtifact *rpc.Artifact, artifactName string, threshold time.Duration) (*rpc.Artifact, bool, error) {
+	var takeAction bool
+	scoreArtifact, err := getArtifact(ctx, client, artifactName, true)
 	if err != nil {
 		// Calculate score if the score artifact doesn't exist
-		if status.Code(err) == codes.NotFound {
-			takeAction = true
-		} else {
-			return fmt.Errorf("failed to fetch artifact %q: %s", artifactName, err)
+		if status.Code(err) != codes.NotFound {
+			return nil, false, fmt.Errorf("failed to fetch artifact %q: %s", artifactName, err)
 		}
+		takeAction = true
 	}
 
 	// Calculate score if the definition has been updated
-	// This condition is required to avoid the scenario mentioned here: https://github.com/apigee/registry/issues/641
-	if scoreArtifact != nil && defArtifact.GetUpdateTime().AsTime().Add(patterns.ResourceUpdateThreshold).After(scoreArtifact.GetUpdateTime().AsTime()) {
+	if scoreArtifact != nil && definitionChanged(defArtifact, scoreArtifact, threshold) {
 		takeAction = true
 	}
+	return scoreArtifact, takeAction, nil
+}
 
-	// evaluate the expression and return a scoreValue
-	result := processFormula(ctx, client, definition, resource, scoreArtifact, takeAction)
-	if result.err != nil {
-		return result.err
+// definitionChanged reports whether defArtifact may have changed since scoreArtifact was computed.
+// Scores that record the hash of their definition are compared by hash. Scores saved without
+// one are compared by update time, which also recomputes scores whose definitions were updated
+// while they were computed: https://github.com/apigee/registry/issues/641
+func definitionChanged(defArtifact, scoreArtifact *rpc.Artifact, threshold time.Duration) bool {
+	if hash, ok := scoreArtifact.GetAnnotations()[DefinitionHashAnnotation]; ok {
+		return hash != definitionHash(defArtifact)
 	}
+	return defArtifact.GetUpdateTime().AsTime().Add(threshold).After(scoreArtifact.GetUpdateTime().AsTime())
+}
 
-	if result.needsUpdate {
-		// generate a score proto from the scoreValue
-		score, err := processScoreType(definition, result.value, project)
+// calculateScoreOutputs calculates the score of a definition with outputs and
+// the scores of each of its outputs. The artifacts of the score_formula are
+// fetched once and shared by all of the expressions.
+func calculateScoreOutputs(
+	ctx context.Context,
+	client artifactClient,
+	defArtifact *rpc.Artifact,
+	definition *rpc.ScoreDefinition,
+	resource patterns.ResourceInstance,
+	project string,
+	threshold time.Duration,
+	dryRun bool,
+	opts Options) ([]*ComputedScore, error) {
+	formula := definition.GetScoreFormula()
+	if formula == nil {
+		return nil, fmt.Errorf("invalid ScoreDefinition %q: outputs are only supported with a score_formula", definition.GetId())
+	}
+	if formula.GetSchemaValidation() != nil {
+		return nil, fmt.Errorf("invalid ScoreDefinition %q: outputs can't be used with a schema_validation", definition.GetId())
+	}
+	if formula.GetScoreExpression() == "" {
+		return nil, fmt.Errorf("missing score_formula.score_expression for {%v}", formula)
+	}
+
+	definitions := []*rpc.ScoreDefinition{definition}
+	expressions := []string{formula.GetScoreExpression()}
+	for _, output := range definition.GetOutputs() {
+		if output.GetScoreExpression() == "" {
+			return nil, fmt.Errorf("missing outputs.score_expression for output %q", output.GetId())
+		}
+		definitions = append(definitions, outputDefinition(definition, output))
+		expressions = append(expressions, output.GetScoreExpression())
+	}
+
+	inputs, err := fetchFormulaInputs(ctx, client, formula, resource)
+	if err != nil {
+		return nil, err
+	}
+	inputs.setResource(resource, expressions...)
+
+	// The contents of the artifacts are only fetched if a score is outdated.
+	var vars map[string]interface{}
+	var computed []*ComputedScore
+	for i, d := range definitions {
+		artifactName := fmt.Sprintf("%s/artifacts/%s", resource.ResourceName().String(), scoreID(d.GetId()))
+		scoreArtifact, takeAction, err := fetchScoreArtifact(ctx, client, defArtifact, artifactName, threshold)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if !takeAction && !inputs.updatedAfter(scoreArtifact, threshold) {
+			log.FromContext(ctx).WithFields(map[string]interface{}{
+				"score":    artifactName,
+				"decision": "skip",
+			}).Debug("Score is already up-to-date")
+			continue
 		}
 
-		if dryRun {
-			core.PrintMessage(score)
-			return nil
+		if vars == nil {
+			if vars, err = inputs.variables(); err != nil {
+				return nil, err
+			}
+		}
+		value, err := evaluateScoreExpression(expressions[i], vars)
+		if err != nil {
+			return nil, err
+		}
+		score, err := processScoreType(d, value, project)
+		if err != nil {
+			return nil, err
+		}
+		// Scores of outputs refer to the definition that they are declared in.
+		score.DefinitionName = fmt.Sprintf("%s/artifacts/%s", project, definition.GetId())
+
+		written := false
+		if !dryRun {
+			if written, err = uploadScore(ctx, client, resource, score, scoreArtifact, definitionHash(defArtifact), opts); err != nil {
+				return nil, err
+			}
 		}
-		return uploadScore(ctx, client, resource, score)
+		c := newComputedScore(score, scoreArtifact)
+		c.Written = written
+		logScoreDecision(ctx, artifactName, c, dryRun)
+		computed = append(computed, c)
 	}
+	return computed, nil
+}
+
+// logScoreDecision logs that the score artifactName was computed.
+func logScoreDecision(ctx context.Context, artifactName string, computed *ComputedScore, dryRun bool) {
+	log.FromContext(ctx).WithFields(map[string]interface{}{
+		"score":           artifactName,
+		"decision":        "update",
+		"changed":         computed.Changed,
+		"severityChanged": computed.SeverityChanged,
+		"written":         computed.Written,
+		"severity":        computed.Score.GetSeverity().String(),
+		"dryRun":          dryRun,
+	}).Debug("Computed score")
+}
 
-	log.Debugf(ctx, "Score %s is already up-to-date.", artifactName)
-	return nil
+// outputDefinition returns a definition of the score of output,
+// which is used to convert the value of output into a score.
+func outputDefinition(definition *rpc.ScoreDefinition, output *rpc.ScoreOutput) *rpc.ScoreDefinition {
+	d := &rpc.ScoreDefinition{
+		Id:                       fmt.Sprintf("%s-%s", definition.GetId(), output.GetId()),
+		Kind:                     definition.GetKind(),
+		DisplayName:              output.GetDisplayName(),
+		Description:              output.GetDescription(),
+		Uri:                      definition.GetUri(),
+		UriDisplayName:           definition.GetUriDisplayName(),
+		TargetResource:           definition.GetTargetResource(),
+		SeverityDisplays:         definition.GetSeverityDisplays(),
+		RequireThresholdCoverage: definition.GetRequireThresholdCoverage(),
+	}
+	switch t := output.GetType().(type) {
+	case *rpc.ScoreOutput_Percent:
+		d.Type = &rpc.ScoreDefinition_Percent{Percent: t.Percent}
+	case *rpc.ScoreOutput_Integer:
+		d.Type = &rpc.ScoreDefinition_Integer{Integer: t.Integer}
+	case *rpc.ScoreOutput_Boolean:
+		d.Type = &rpc.ScoreDefinition_Boolean{Boolean: t.Boolean}
+	}
+	return d
 }
 
 // Response returned after applying the score_expression on score_formula.artifact s.
@@ -136,6 +627,10 @@ type scoreResult struct {
 	needsUpdate bool
 	// Represents the error generated while applying the score_expression.
 	err error
+	// Represents the values of the score_formulas of a rollup_formula, keyed by reference_id
+	references map[string]interface{}
+	// Represents the violations found by a schema_validation
+	violations []string
 }
 
 func processFormula(
@@ -144,13 +639,14 @@ func processFormula(
 	definition *rpc.ScoreDefinition,
 	resource patterns.ResourceInstance,
 	scoreArtifact *rpc.Artifact,
-	takeAction bool) scoreResult {
+	takeAction bool,
+	threshold time.Duration) scoreResult {
 	// Apply score formula
 	switch formula := definition.GetFormula().(type) {
 	case *rpc.ScoreDefinition_ScoreFormula:
-		return processScoreFormula(ctx, client, formula.ScoreFormula, resource, scoreArtifact, takeAction)
+		return processScoreFormulaIfOutdated(ctx, client, formula.ScoreFormula, resource, scoreArtifact, takeAction, threshold)
 	case *rpc.ScoreDefinition_RollupFormula:
-		return processRollUpFormula(ctx, client, formula.RollupFormula, resource, scoreArtifact, takeAction)
+		return processRollUpFormula(ctx, client, formula.RollupFormula, resource, scoreArtifact, takeAction, threshold)
 	default:
 		return scoreResult{
 			value:       nil,
@@ -166,16 +662,44 @@ func processScoreFormula(
 	formula *rpc.ScoreFormula,
 	resource patterns.ResourceInstance,
 	scoreArtifact *rpc.Artifact,
-	takeAction bool) scoreResult {
-	extendedArtifact, err := patterns.SubstituteReferenceEntity(formula.GetArtifact().GetPattern(), resource.ResourceName())
+	takeAction bool,
+	threshold time.Duration) scoreResult {
+	if formula.GetScoreExpression() == "" && formula.GetSchemaValidation() == nil {
+		return scoreResult{
+			value:       nil,
+			needsUpdate: false,
+			err:         fmt.Errorf("missing score_formula.score_expression for {%v}", formula),
+		}
+	}
+
+	inputs, err := fetchFormulaInputs(ctx, client, formula, resource)
 	if err != nil {
 		return scoreResult{
 			value:       nil,
 			needsUpdate: false,
-			err:         fmt.Errorf("invalid score_formula.artifact.pattern: %s for {%v}, %s", formula.GetArtifact().GetPattern(), formula, err),
+			err:         err,
 		}
 	}
-	if formula.GetScoreExpression() == "" {
+
+	// Apply the scoreExpression by default. This value will be required by the rollup_formula in the case where
+	// another formula from rollup_formula.score_formulas makes the score outdated.
+	// Update required tells the calling function if the score artifact needs to be updated
+	// This condition is required to avoid the scenario mentioned here: https://github.com/apigee/registry/issues/641
+	return evaluateScoreFormula(formula, inputs, takeAction || inputs.updatedAfter(scoreArtifact, threshold))
+}
+
+// processScoreFormulaIfOutdated is like processScoreFormula but only fetches
+// the contents of the artifacts and applies the score_expression if the
+// score artifact needs an update.
+func processScoreFormulaIfOutdated(
+	ctx context.Context,
+	client artifactClient,
+	formula *rpc.ScoreFormula,
+	resource patterns.ResourceInstance,
+	scoreArtifact *rpc.Artifact,
+	takeAction bool,
+	threshold time.Duration) scoreResult {
+	if formula.GetScoreExpression() == "" && formula.GetSchemaValidation() == nil {
 		return scoreResult{
 			value:       nil,
 			needsUpdate: false,
@@ -183,25 +707,31 @@ func processScoreFormula(
 		}
 	}
 
-	// Fetch the artifact
-	artifact, err := getArtifact(ctx, client, extendedArtifact.String(), true)
+	inputs, err := fetchFormulaInputs(ctx, client, formula, resource)
 	if err != nil {
 		return scoreResult{
 			value:       nil,
 			needsUpdate: false,
-			err:         fmt.Errorf("failed to fetch artifact %s: %s", extendedArtifact.String(), err),
+			err:         err,
 		}
 	}
+	if !takeAction && !inputs.updatedAfter(scoreArtifact, threshold) {
+		return scoreResult{
+			value:       nil,
+			needsUpdate: false,
+			err:         nil,
+		}
+	}
+	return evaluateScoreFormula(formula, inputs, true)
+}
 
-	// Update required tells the calling function if the score artifact needs to be updated
-	// This condition is required to avoid the scenario mentioned here: https://github.com/apigee/registry/issues/641
-	updateRequired := takeAction || artifact.GetUpdateTime().AsTime().Add(patterns.ResourceUpdateThreshold).After(scoreArtifact.GetUpdateTime().AsTime())
-
-	// Apply the scoreExpression by default. This value will be required by the rollup_formula in the case where
-	// another formula from rollup_formula.score_formulas makes the score outdated.
-
-	// Convert artifact contents to map[string]interface{}
-	artifactMap, err := getMap(artifact.GetContents(), artifact.GetMimeType())
+// evaluateScoreFormula applies the score_expression of formula to inputs,
+// or validates them against the schema of its schema_validation.
+func evaluateScoreFormula(formula *rpc.ScoreFormula, inputs formulaInputs, needsUpdate bool) scoreResult {
+	if formula.GetSchemaValidation() != nil {
+		return evaluateSchemaValidation(formula, inputs, needsUpdate)
+	}
+	vars, err := inputs.variables()
 	if err != nil {
 		return scoreResult{
 			value:       nil,
@@ -209,9 +739,7 @@ func processScoreFormula(
 			err:         err,
 		}
 	}
-
-	// Apply the score_expression
-	value, err := evaluateScoreExpression(formula.GetScoreExpression(), artifactMap)
+	value, err := evaluateScoreExpression(formula.GetScoreExpression(), vars)
 	if err != nil {
 		return scoreResult{
 			value:       nil,
@@ -221,18 +749,303 @@ func processScoreFormula(
 	}
 	return scoreResult{
 		value:       value,
-		needsUpdate: updateRequired,
+		needsUpdate: needsUpdate,
 		err:         nil,
 	}
 }
 
+// formulaInputs holds the artifacts of a score_formula.
+// The contents of the artifacts are only fetched when the variables of the
+// expressions are needed, so up-to-date scores can be detected from the
+// artifact metadata alone.
+type formulaInputs struct {
+	// Represents the artifacts in the order that their variables are added
+	artifacts []formulaInput
+	// Represents the update time of the most recently updated artifact,
+	// or of the resource if the expressions reference its fields
+	updateTime time.Time
+	// Represents the scored resource, whose fields are the _resource variable
+	resource patterns.ResourceInstance
+}
+
+// formulaInput is an artifact of a score_formula.
+type formulaInput struct {
+	// Represents the name of the variable that holds the artifact,
+	// or "" if the fields of the artifact are top-level variables
+	alias    string
+	artifact *core.LazyArtifact
+}
+
+// add adds an artifact to the inputs.
+func (in *formulaInputs) add(alias string, artifact *core.LazyArtifact) {
+	if t := artifact.GetUpdateTime().AsTime(); t.After(in.updateTime) {
+		in.updateTime = t
+	}
+	in.artifacts = append(in.artifacts, formulaInput{alias: alias, artifact: artifact})
+}
+
+// setResource sets the scored resource of the inputs. If any of expressions
+// reference the fields of the resource, its update time is an input too.
+func (in *formulaInputs) setResource(resource patterns.ResourceInstance, expressions ...string) {
+	in.resource = resource
+	for _, e := range expressions {
+		if strings.Contains(e, resourceVariable) {
+			if t := resource.UpdateTimestamp(); t.After(in.updateTime) {
+				in.updateTime = t
+			}
+			return
+		}
+	}
+}
+
+// updatedAfter reports whether any of the inputs were updated after scoreArtifact,
+// or less than threshold before it.
+func (in formulaInputs) updatedAfter(scoreArtifact *rpc.Artifact, threshold time.Duration) bool {
+	return in.updateTime.Add(threshold).After(scoreArtifact.GetUpdateTime().AsTime())
+}
+
+// variables fetches the contents of the artifacts and converts them into expression variables.
+func (in formulaInputs) variables() (map[string]interface{}, error) {
+	vars := make(map[string]interface{})
+	for _, input := range in.artifacts {
+		contents, err := input.artifact.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch contents of artifact %s: %s", input.artifact.GetName(), err)
+		}
+
+		// Convert artifact contents to map[string]interface{}
+		contentsMap, err := getMap(contents, input.artifact.GetMimeType())
+		if err != nil {
+			return nil, err
+		}
+		if input.alias != "" {
+			addMetadataVariables(contentsMap, input.artifact.Artifact)
+			vars[input.alias] = contentsMap
+		} else {
+			for k, v := range contentsMap {
+				vars[k] = v
+			}
+			addMetadataVariables(vars, input.artifact.Artifact)
+		}
+	}
+	if in.resource != nil {
+		vars[resourceVariable] = resourceVariables(in.resource)
+	}
+	return vars, nil
+}
+
+// fetchFormulaInputs fetches the metadata of the artifacts of formula.
+func fetchFormulaInputs(
+	ctx context.Context,
+	client artifactClient,
+	formula *rpc.ScoreFormula,
+	resource patterns.ResourceInstance) (formulaInputs, error) {
+	inputs, artifactNames, err := formulaArtifacts(formula, resource)
+	if err != nil {
+		return formulaInputs{}, err
+	}
+
+	result := formulaInputs{artifacts: make([]formulaInput, 0, len(inputs))}
+	for i, input := range inputs {
+		// Fetch the artifact metadata
+		artifact, err := getLazyArtifact(ctx, client, artifactNames[i])
+		if err != nil {
+			return formulaInputs{}, fmt.Errorf("failed to fetch artifact %s: %s", artifactNames[i], err)
+		}
+		result.add(input.GetAlias(), artifact)
+	}
+	result.setResource(resource, formula.GetScoreExpression())
+	return result, nil
+}
+
+// formulaArtifacts returns the artifacts of formula and their names for resource.
+func formulaArtifacts(formula *rpc.ScoreFormula, resource patterns.ResourceInstance) ([]*rpc.ScoreArtifact, []string, error) {
+	// The unnamed artifact provides top-level variables, named artifacts are variables themselves.
+	inputs := make([]*rpc.ScoreArtifact, 0, 1+len(formula.GetArtifacts()))
+	if formula.GetArtifact().GetPattern() != "" || len(formula.GetArtifacts()) == 0 {
+		inputs = append(inputs, &rpc.ScoreArtifact{Artifact: formula.GetArtifact()})
+	}
+	inputs = append(inputs, formula.GetArtifacts()...)
+
+	artifactNames := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		extendedArtifact, err := patterns.SubstituteReferenceEntity(input.GetArtifact().GetPattern(), resource.ResourceName())
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid score_formula.artifact.pattern: %s for {%v}, %s", input.GetArtifact().GetPattern(), formula, err)
+		}
+		artifactNames = append(artifactNames, extendedArtifact.String())
+	}
+	return inputs, artifactNames, nil
+}
+
+// fetchRollUpInputs fetches the metadata of the artifacts of all of the formulas with one batch call.
+func fetchRollUpInputs(
+	ctx context.Context,
+	client artifactClient,
+	formulas []*rpc.ScoreFormula,
+	resource patterns.ResourceInstance) ([]formulaInputs, error) {
+	aliases := make([][]string, 0, len(formulas))
+	artifactNames := make([]string, 0, len(formulas))
+	for _, f := range formulas {
+		inputs, names, err := formulaArtifacts(f, resource)
+		if err != nil {
+			return nil, err
+		}
+		formulaAliases := make([]string, 0, len(inputs))
+		for _, input := range inputs {
+			formulaAliases = append(formulaAliases, input.GetAlias())
+		}
+		aliases = append(aliases, formulaAliases)
+		artifactNames = append(artifactNames, names...)
+	}
+
+	artifacts, err := batchGetArtifacts(ctx, client, artifactNames, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// The contents of the artifacts of each formula are fetched with a batch call when
+	// any of them is needed, so formulas whose values are reused don't fetch anything.
+	result := make([]formulaInputs, 0, len(formulas))
+	i := 0
+	for f, formulaAliases := range aliases {
+		start := i
+		var (
+			once     sync.Once
+			contents []*rpc.Artifact
+			fetchErr error
+		)
+		fetch := func(index int) ([]byte, string, error) {
+			once.Do(func() {
+				contents, fetchErr = batchGetArtifacts(ctx, client, artifactNames[start:start+len(formulaAliases)], true)
+			})
+			if fetchErr != nil {
+				return nil, "", fetchErr
+			}
+			return contents[index-start].GetContents(), contents[index-start].GetMimeType(), nil
+		}
+		in := formulaInputs{artifacts: make([]formulaInput, 0, len(formulaAliases))}
+		for _, alias := range formulaAliases {
+			index := i
+			in.add(alias, core.NewLazyArtifactWithMimeType(artifacts[index], func() ([]byte, string, error) {
+				return fetch(index)
+			}))
+			i++
+		}
+		in.setResource(resource, formulas[f].GetScoreExpression())
+		result = append(result, in)
+	}
+	return result, nil
+}
+
+// Names of the variables that hold artifact metadata in score expressions.
+// Protobuf JSON field names never begin with an underscore, so these can't
+// collide with the keys of artifact contents.
+const (
+	labelsVariable      = "_labels"
+	annotationsVariable = "_annotations"
+	resourceVariable    = "_resource"
+)
+
+// resourceVariables returns the fields of the scored resource that score
+// expressions can reference as _resource.<field>. Fields use their protobuf
+// names and are always present, with zero values if unset:
+//   - projects: name
+//   - APIs: name, display_name, description, availability,
+//     recommended_version, recommended_deployment, labels, annotations,
+//     create_time, update_time
+//   - versions: name, display_name, description, state, primary_spec,
+//     labels, annotations, create_time, update_time
+//   - specs: name, filename, description, mime_type, size_bytes, hash,
+//     source_uri, revision_id, labels, annotations, create_time,
+//     revision_create_time, revision_update_time
+//   - artifacts: name, mime_type, size_bytes, hash, labels, annotations,
+//     create_time, update_time
+func resourceVariables(resource patterns.ResourceInstance) map[string]interface{} {
+	switch r := resource.(type) {
+	case patterns.ApiResource:
+		return map[string]interface{}{
+			"name":                   r.Api.GetName(),
+			"display_name":           r.Api.GetDisplayName(),
+			"description":            r.Api.GetDescription(),
+			"availability":           r.Api.GetAvailability(),
+			"recommended_version":    r.Api.GetRecommendedVersion(),
+			"recommended_deployment": r.Api.GetRecommendedDeployment(),
+			"labels":                 stringMap(r.Api.GetLabels()),
+			"annotations":            stringMap(r.Api.GetAnnotations()),
+			"create_time":            r.Api.GetCreateTime().AsTime(),
+			"update_time":            r.Api.GetUpdateTime().AsTime(),
+		}
+	case patterns.VersionResource:
+		return map[string]interface{}{
+			"name":         r.Version.GetName(),
+			"display_name": r.Version.GetDisplayName(),
+			"description":  r.Version.GetDescription(),
+			"state":        r.Version.GetState(),
+			"primary_spec": r.Version.GetPrimarySpec(),
+			"labels":       stringMap(r.Version.GetLabels()),
+			"annotations":  stringMap(r.Version.GetAnnotations()),
+			"create_time":  r.Version.GetCreateTime().AsTime(),
+			"update_time":  r.Version.GetUpdateTime().AsTime(),
+		}
+	case patterns.SpecResource:
+		return map[string]interface{}{
+			"name":                 r.Spec.GetName(),
+			"filename":             r.Spec.GetFilename(),
+			"description":          r.Spec.GetDescription(),
+			"mime_type":            r.Spec.GetMimeType(),
+			"size_bytes":           int64(r.Spec.GetSizeBytes()),
+			"hash":                 r.Spec.GetHash(),
+			"source_uri":           r.Spec.GetSourceUri(),
+			"revision_id":          r.Spec.GetRevisionId(),
+			"labels":               stringMap(r.Spec.GetLabels()),
+			"annotations":          stringMap(r.Spec.GetAnnotations()),
+			"create_time":          r.Spec.GetCreateTime().AsTime(),
+			"revision_create_time": r.Spec.GetRevisionCreateTime().AsTime(),
+			"revision_update_time": r.Spec.GetRevisionUpdateTime().AsTime(),
+		}
+	case patterns.ArtifactResource:
+		return map[string]interface{}{
+			"name":        r.Artifact.GetName(),
+			"mime_type":   r.Artifact.GetMimeType(),
+			"size_bytes":  int64(r.Artifact.GetSizeBytes()),
+			"hash":        r.Artifact.GetHash(),
+			"labels":      stringMap(r.Artifact.GetLabels()),
+			"annotations": stringMap(r.Artifact.GetAnnotations()),
+			"create_time": r.Artifact.GetCreateTime().AsTime(),
+			"update_time": r.Artifact.GetUpdateTime().AsTime(),
+		}
+	default:
+		vars := map[string]interface{}{"name": ""}
+		if name := resource.ResourceName(); name != nil {
+			vars["name"] = name.String()
+		}
+		return vars
+	}
+}
+
+// addMetadataVariables adds the labels and annotations of artifact to vars.
+// Missing metadata is added as an empty map so that expressions can index it.
+func addMetadataVariables(vars map[string]interface{}, artifact *rpc.Artifact) {
+	vars[labelsVariable] = stringMap(artifact.GetLabels())
+	vars[annotationsVariable] = stringMap(artifact.GetAnnotations())
+}
+
+func stringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
 func processRollUpFormula(
 	ctx context.Context,
 	client artifactClient,
 	formula *rpc.RollUpFormula,
 	resource patterns.ResourceInstance,
 	scoreArtifact *rpc.Artifact,
-	takeAction bool) scoreResult {
+	takeAction bool,
+	threshold time.Duration) scoreResult {
 	// Validate required fields
 	if len(formula.GetScoreFormulas()) == 0 {
 		return scoreResult{
@@ -249,63 +1062,133 @@ func processRollUpFormula(
 		}
 	}
 
-	// Update required tells the calling function if the score artifact needs to be updated
-	updateRequired := takeAction
-	rollUpMap := make(map[string]interface{}, 0)
 	for _, f := range formula.GetScoreFormulas() {
-		result := processScoreFormula(ctx, client, f, resource, scoreArtifact, takeAction)
-		if result.err != nil {
+		if f.GetScoreExpression() == "" && f.GetSchemaValidation() == nil {
 			return scoreResult{
 				value:       nil,
 				needsUpdate: false,
-				err:         fmt.Errorf("error processing rollup_formula.score_formulas: %s", result.err),
+				err:         fmt.Errorf("error processing rollup_formula.score_formulas: missing score_formula.score_expression for {%v}", f),
 			}
 		}
-
-		refId := f.GetReferenceId()
-		if refId == "" {
+		if refId := f.GetReferenceId(); refId == "" {
 			return scoreResult{
 				value:       nil,
 				needsUpdate: false,
 				err:         fmt.Errorf("missing reference_id for score_formula {%v}", f),
 			}
-		}
-		if strings.Contains(refId, "-") {
+		} else if strings.Contains(refId, "-") {
 			return scoreResult{
 				value:       nil,
 				needsUpdate: false,
 				err:         fmt.Errorf("invalid reference_id for score_formula {%v}: cannot contain '-'", f),
 			}
 		}
-		rollUpMap[refId] = result.value
+	}
 
-		updateRequired = updateRequired || result.needsUpdate
+	// Update required tells the calling function if the score artifact needs to be updated
+	// The metadata of the artifacts of all of the formulas is checked before any contents are fetched.
+	inputs, err := fetchRollUpInputs(ctx, client, formula.GetScoreFormulas(), resource)
+	if err != nil {
+		return scoreResult{
+			value:       nil,
+			needsUpdate: false,
+			err:         fmt.Errorf("error processing rollup_formula.score_formulas: %s", err),
+		}
+	}
+	updateRequired := takeAction
+	for _, in := range inputs {
+		updateRequired = updateRequired || in.updatedAfter(scoreArtifact, threshold)
+	}
+	if !updateRequired {
+		return scoreResult{
+			value:       nil,
+			needsUpdate: false,
+			err:         nil,
+		}
 	}
 
-	// Apply the rollup_expression
-	if updateRequired {
-		value, err := evaluateScoreExpression(formula.GetRollupExpression(), rollUpMap)
-		if err != nil {
+	// Formulas whose artifacts haven't changed since the score was saved reuse their saved values.
+	saved := savedReferenceValues(scoreArtifact)
+	rollUpMap := make(map[string]interface{}, 0)
+	weights := make(map[string]float64, 0)
+	for i, f := range formula.GetScoreFormulas() {
+		weights[f.GetReferenceId()] = float64(f.GetWeight())
+		if value, ok := saved[f.GetReferenceId()]; ok && !takeAction && !inputs[i].updatedAfter(scoreArtifact, threshold) {
+			rollUpMap[f.GetReferenceId()] = value
+			continue
+		}
+
+		result := evaluateScoreFormula(f, inputs[i], true)
+		if result.err != nil {
 			return scoreResult{
 				value:       nil,
 				needsUpdate: false,
-				err:         err,
+				err:         fmt.Errorf("error processing rollup_formula.score_formulas: %s", result.err),
 			}
 		}
+
+		rollUpMap[f.GetReferenceId()] = result.value
+	}
+
+	// Apply the rollup_expression
+	value, err := evaluateScoreExpression(formula.GetRollupExpression(), rollUpMap, weightedAverage(rollUpMap, weights))
+	if err != nil {
 		return scoreResult{
-			value:       value,
-			needsUpdate: true,
-			err:         nil,
+			value:       nil,
+			needsUpdate: false,
+			err:         err,
 		}
 	}
-
 	return scoreResult{
-		value:       nil,
-		needsUpdate: false,
+		value:       value,
+		needsUpdate: true,
 		err:         nil,
+		references:  rollUpMap,
 	}
 }
 
+// savedReferenceValues returns the values of the score_formulas of a rollup_formula
+// that are saved in scoreArtifact, keyed by reference_id. Nothing is returned if
+// scoreArtifact was read without its contents or doesn't contain a score.
+func savedReferenceValues(scoreArtifact *rpc.Artifact) map[string]interface{} {
+	score := &rpc.Score{}
+	if err := proto.Unmarshal(scoreArtifact.GetContents(), score); err != nil {
+		return nil
+	}
+	values := make(map[string]interface{}, len(score.GetReferenceValues()))
+	for id, v := range score.GetReferenceValues() {
+		switch v := v.GetValue().(type) {
+		case *rpc.ReferenceValue_IntegerValue:
+			values[id] = v.IntegerValue
+		case *rpc.ReferenceValue_DoubleValue:
+			values[id] = v.DoubleValue
+		case *rpc.ReferenceValue_BooleanValue:
+			values[id] = v.BooleanValue
+		}
+	}
+	return values
+}
+
+// referenceValues converts the values of the score_formulas of a rollup_formula
+// into the form in which they are saved in scores.
+func referenceValues(values map[string]interface{}) map[string]*rpc.ReferenceValue {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]*rpc.ReferenceValue, len(values))
+	for id, v := range values {
+		switch v := v.(type) {
+		case int64:
+			result[id] = &rpc.ReferenceValue{Value: &rpc.ReferenceValue_IntegerValue{IntegerValue: v}}
+		case float64:
+			result[id] = &rpc.ReferenceValue{Value: &rpc.ReferenceValue_DoubleValue{DoubleValue: v}}
+		case bool:
+			result[id] = &rpc.ReferenceValue{Value: &rpc.ReferenceValue_BooleanValue{BooleanValue: v}}
+		}
+	}
+	return result
+}
+
 func processScoreType(definition *rpc.ScoreDefinition, scoreValue interface{}, project string) (*rpc.Score, error) {
 	// Initialize Score proto
 	score := &rpc.Score{
@@ -431,25 +1314,93 @@ func processScoreType(definition *rpc.ScoreDefinition, scoreValue interface{}, p
 		}
 	}
 
+	// Populate the display attributes of the severity, falling back to the
+	// name of the severity level if the definition doesn't have a label for it.
+	score.SeverityLabel = score.GetSeverity().String()
+	for _, d := range definition.GetSeverityDisplays() {
+		if d.GetSeverity() == score.GetSeverity() {
+			if d.GetLabel() != "" {
+				score.SeverityLabel = d.GetLabel()
+			}
+			score.SeverityColor = d.GetColor()
+			break
+		}
+	}
+
 	return score, nil
 }
 
-func uploadScore(ctx context.Context, client artifactClient, resource patterns.ResourceInstance, score *rpc.Score) error {
+// uploadScore saves a score. Scores that are equal to existing, the saved
+// score artifact, aren't written again, and neither are scores with the saved
+// severity if opts.SeverityChangesOnly is set; the returned bool
+// reports whether the score was written. existing is nil if the score
+// artifact didn't exist. If opts.CheckConcurrentUpdates is set, scores aren't
+// saved if the score artifact has changed since existing was read.
+// If defHash is set, it is saved as the DefinitionHashAnnotation of the score.
+func uploadScore(ctx context.Context, client artifactClient, resource patterns.ResourceInstance, score *rpc.Score, existing *rpc.Artifact, defHash string, opts Options) (bool, error) {
 	artifactBytes, err := proto.Marshal(score)
 	if err != nil {
-		return err
+		return false, err
 	}
 	artifact := &rpc.Artifact{
 		Name:     fmt.Sprintf("%s/artifacts/%s", resource.ResourceName().String(), score.GetId()),
 		Contents: artifactBytes,
 		MimeType: patch.MimeTypeForKind("Score"),
 	}
-	log.Debugf(ctx, "Uploading %s", artifact.GetName())
+	if defHash != "" {
+		artifact.Annotations = map[string]string{DefinitionHashAnnotation: defHash}
+	}
+	if opts.CheckConcurrentUpdates {
+		// Re-read the update time of the score artifact to avoid overwriting
+		// a value saved by a concurrent scoring pass that started after this one.
+		current, err := getArtifact(ctx, client, artifact.GetName(), false)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return false, fmt.Errorf("failed to fetch artifact %q: %s", artifact.GetName(), err)
+		}
+		if current != nil && (existing == nil || current.GetUpdateTime().AsTime().After(existing.GetUpdateTime().AsTime())) {
+			return false, ErrConcurrentUpdate
+		}
+	}
+	if existing != nil {
+		// Unchanged scores are saved anyway if they were computed with another
+		// definition, so that they record the hash of the current one.
+		saved := &rpc.Score{}
+		sameDefinition := defHash == "" || existing.GetAnnotations()[DefinitionHashAnnotation] == defHash
+		if err := proto.Unmarshal(existing.GetContents(), saved); err == nil && sameDefinition {
+			if ScoresEqual(saved, score) {
+				log.FromContext(ctx).WithField("score", artifact.GetName()).Debug("Score is unchanged, skipping upload")
+				return false, nil
+			}
+			if opts.SeverityChangesOnly && saved.GetSeverity() == score.GetSeverity() {
+				log.FromContext(ctx).WithField("score", artifact.GetName()).Debug("Score severity is unchanged, skipping upload")
+				return false, nil
+			}
+		}
+	}
+
+	log.FromContext(ctx).WithField("score", artifact.GetName()).Debug("Uploading score")
 	if err = client.SetArtifact(ctx, artifact); err != nil {
-		return fmt.Errorf("failed to save artifact %s: %s", artifact.GetName(), err)
+		return false, fmt.Errorf("failed to save artifact %s: %s", artifact.GetName(), err)
 	}
 
-	return nil
+	return true, nil
+}
+
+// ScoresEqual reports whether two scores have the same value, severity and
+// display fields. Fields that identify the score (id, kind and
+// definition_name) are ignored since they are determined by where the
+// score is stored.
+func ScoresEqual(a, b *rpc.Score) bool {
+	return proto.Equal(a.GetPercentValue(), b.GetPercentValue()) &&
+		proto.Equal(a.GetIntegerValue(), b.GetIntegerValue()) &&
+		proto.Equal(a.GetBooleanValue(), b.GetBooleanValue()) &&
+		a.GetSeverity() == b.GetSeverity() &&
+		a.GetSeverityLabel() == b.GetSeverityLabel() &&
+		a.GetSeverityColor() == b.GetSeverityColor() &&
+		a.GetDisplayName() == b.GetDisplayName() &&
+		a.GetDescription() == b.GetDescription() &&
+		a.GetUri() == b.GetUri() &&
+		a.GetUriDisplayName() == b.GetUriDisplayName()
 }
 
 func getArtifact(ctx context.Context, client artifactClient, artifactPattern string, getContents bool) (*rpc.Artifact, error) {
@@ -459,12 +1410,33 @@ func getArtifact(ctx context.Context, client artifactClient, artifactPattern str
 	}
 
 	gotArtifact := &rpc.Artifact{}
-	err = client.GetArtifact(ctx, artifactName, true, func(artifact *rpc.Artifact) error {
+	err = client.GetArtifact(ctx, artifactName, getContents, func(artifact *rpc.Artifact) error {
+		gotArtifact = artifact
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gotArtifact, nil
+}
+
+// getLazyArtifact gets the metadata of an artifact. Its contents are fetched when they are needed.
+func getLazyArtifact(ctx context.Context, client artifactClient, artifactPattern string) (*core.LazyArtifact, error) {
+	artifactName, err := names.ParseArtifact(artifactPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid artifact pattern %q: %s", artifactPattern, err)
+	}
+
+	var gotArtifact *core.LazyArtifact
+	err = client.GetArtifactLazily(ctx, artifactName, func(artifact *core.LazyArtifact) error {
 		gotArtifact = artifact
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	if gotArtifact == nil {
+		return nil, fmt.Errorf("artifact %q not found", artifactPattern)
+	}
 	return gotArtifact, nil
 }
@@ -16,14 +16,21 @@ package scoring
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/patterns"
 	"github.com/apigee/registry/pkg/connection"
 	"github.com/apigee/registry/rpc"
+	"github.com/apigee/registry/server/registry/names"
 	"github.com/apigee/registry/server/registry/test/seeder"
 	metrics "github.com/google/gnostic/metrics"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 )
@@ -186,6 +193,7 @@ func TestCalculateScore(t *testing.T) {
 				Kind:           "Score",
 				DefinitionName: "projects/score-formula-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_SEVERITY_UNSPECIFIED,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -253,6 +261,7 @@ func TestCalculateScore(t *testing.T) {
 				Kind:           "Score",
 				DefinitionName: "projects/score-formula-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_SEVERITY_UNSPECIFIED,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -320,6 +329,7 @@ func TestCalculateScore(t *testing.T) {
 				Kind:           "Score",
 				DefinitionName: "projects/score-formula-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_SEVERITY_UNSPECIFIED,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -387,6 +397,7 @@ func TestCalculateScore(t *testing.T) {
 				Kind:           "Score",
 				DefinitionName: "projects/score-formula-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_SEVERITY_UNSPECIFIED,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -439,7 +450,7 @@ func TestCalculateScore(t *testing.T) {
 				t.Errorf("failed to fetch the definition Artifact from setup: %s", err)
 			}
 
-			gotErr := CalculateScore(ctx, artifactClient, defArtifact, resource, false)
+			_, gotErr := CalculateScore(ctx, artifactClient, defArtifact, resource, false)
 			if gotErr != nil {
 				t.Errorf("CalculateScore(ctx, client, %v, %v) returned unexpected error: %s", defArtifact, resource, gotErr)
 			}
@@ -466,7 +477,23 @@ func TestCalculateScore(t *testing.T) {
 	}
 }
 
-func TestProcessScoreFormula(t *testing.T) {
+// countingArtifactClient counts the artifacts that are fetched with GetArtifact and GetArtifactLazily.
+type countingArtifactClient struct {
+	artifactClient
+	gets map[string]int
+}
+
+func (c *countingArtifactClient) GetArtifact(ctx context.Context, artifact names.Artifact, getContents bool, handler core.ArtifactHandler) error {
+	c.gets[artifact.String()]++
+	return c.artifactClient.GetArtifact(ctx, artifact, getContents, handler)
+}
+
+func (c *countingArtifactClient) GetArtifactLazily(ctx context.Context, artifact names.Artifact, handler core.LazyArtifactHandler) error {
+	c.gets[artifact.String()]++
+	return c.artifactClient.GetArtifactLazily(ctx, artifact, handler)
+}
+
+func TestCalculateScoreOutputs(t *testing.T) {
 	ctx := context.Background()
 	registryClient, err := connection.NewRegistryClient(ctx)
 	if err != nil {
@@ -480,17 +507,18 @@ func TestProcessScoreFormula(t *testing.T) {
 	}
 	t.Cleanup(func() { adminClient.Close() })
 
-	deleteProject(ctx, adminClient, t, "score-formula-test")
-	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-test") })
+	deleteProject(ctx, adminClient, t, "score-outputs-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-outputs-test") })
 
 	client := seeder.Client{
 		RegistryClient: registryClient,
 		AdminClient:    adminClient,
 	}
-
+	specName := "projects/score-outputs-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+	lintName := specName + "/artifacts/lint-spectral"
 	seed := []seeder.RegistryResource{
 		&rpc.Artifact{
-			Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			Name:     lintName,
 			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
 			Contents: protoMarshal(&rpc.Lint{
 				Name: "openapi.yaml",
@@ -498,240 +526,144 @@ func TestProcessScoreFormula(t *testing.T) {
 					{
 						FilePath: "openapi.yaml",
 						Problems: []*rpc.LintProblem{
-							{
-								Message: "lint-error",
+							{Message: "lint-error"},
+							{Message: "lint-error"},
+						},
+					},
+				},
+			}),
+		},
+		&rpc.Artifact{
+			Name:     "projects/score-outputs-test/locations/global/artifacts/lint",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.ScoreDefinition",
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+				Outputs: []*rpc.ScoreOutput{
+					{
+						Id:              "approved",
+						DisplayName:     "Lint Approval",
+						ScoreExpression: "size(files[0].problems) == 0",
+						Type: &rpc.ScoreOutput_Boolean{
+							Boolean: &rpc.BooleanType{
+								Thresholds: []*rpc.BooleanThreshold{
+									{Severity: rpc.Severity_ALERT, Value: false},
+									{Severity: rpc.Severity_OK, Value: true},
+								},
 							},
 						},
 					},
+					{
+						Id:              "percent",
+						ScoreExpression: "size(files[0].problems) * 10",
+						Type: &rpc.ScoreOutput_Percent{
+							Percent: &rpc.PercentType{},
+						},
+					},
 				},
 			}),
 		},
 	}
-
 	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
 		t.Fatalf("Setup: failed to seed registry: %s", err)
 	}
 
-	// arguments
-	formula := &rpc.ScoreFormula{
-		Artifact: &rpc.ResourcePattern{
-			Pattern: "$resource.spec/artifacts/lint-spectral",
-		},
-		ScoreExpression: "size(files[0].problems)",
+	artifactClient := &countingArtifactClient{
+		artifactClient: &RegistryArtifactClient{RegistryClient: registryClient},
+		gets:           make(map[string]int),
 	}
-	resource := patterns.SpecResource{
-		Spec: &rpc.ApiSpec{
-			Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-		},
+	defArtifact, err := getArtifact(ctx, artifactClient, "projects/score-outputs-test/locations/global/artifacts/lint", true)
+	if err != nil {
+		t.Fatalf("failed to fetch the definition Artifact from setup: %s", err)
 	}
+	resource := patterns.SpecResource{Spec: &rpc.ApiSpec{Name: specName}}
 
-	wantResult := scoreResult{
-		value:       int64(1),
-		needsUpdate: true,
-		err:         nil,
+	computed, err := CalculateScore(ctx, artifactClient, defArtifact, resource, false)
+	if err != nil {
+		t.Fatalf("CalculateScore(ctx, client, %v, %v) returned unexpected error: %s", defArtifact, resource, err)
 	}
-
-	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
-
-	gotResult := processScoreFormula(ctx, artifactClient, formula, resource, &rpc.Artifact{}, true)
-
-	opts := cmp.AllowUnexported(scoreResult{})
-	if !cmp.Equal(wantResult, gotResult, opts) {
-		t.Errorf("processScoreFormula() returned unexpected response, (-want +got):\n%s", cmp.Diff(wantResult, gotResult, opts))
+	if len(computed) != 3 {
+		t.Errorf("CalculateScore() returned %d scores, want 3", len(computed))
+	}
+	if got := artifactClient.gets[lintName]; got != 1 {
+		t.Errorf("CalculateScore() fetched %s %d times, want 1", lintName, got)
 	}
-}
 
-func TestProcessScoreFormulaError(t *testing.T) {
-	tests := []struct {
-		desc     string
-		seed     []seeder.RegistryResource
-		setup    func(context.Context, connection.RegistryClient, connection.AdminClient)
-		formula  *rpc.ScoreFormula
-		resource patterns.ResourceInstance
-	}{
-		{
-			desc: "invalid reference",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-			formula: &rpc.ScoreFormula{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.specs/artifacts/lint-spectral", //error
-				},
-				ScoreExpression: "size(files[0].problems)",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-		},
-		{
-			desc: "invalid extended pattern",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-			formula: &rpc.ScoreFormula{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifact/lint-spectral", // error
-				},
-				ScoreExpression: "size(files[0].problems)",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-		},
-		{
-			desc: "missing artifact",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-			formula: &rpc.ScoreFormula{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifacts/lint-spectral",
-				},
-				ScoreExpression: "size(files[0].problems)",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-		},
+	definitionName := "projects/score-outputs-test/locations/global/artifacts/lint"
+	wantScores := []*rpc.Score{
 		{
-			desc: "unsupported artifact type",
-			seed: []seeder.RegistryResource{
-				&rpc.Artifact{
-					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/score-definition",
-					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.applications.v1alpha1.ScoreDefinition",
-					Contents: protoMarshal(&rpc.ScoreDefinition{
-						Id:             "dummy-score-definition",
-						TargetResource: &rpc.ResourcePattern{},
-						Formula:        nil,
-						Type:           nil,
-					}),
-				},
-			},
-			formula: &rpc.ScoreFormula{},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			Id:             "score-lint",
+			Kind:           "Score",
+			DefinitionName: definitionName,
+			SeverityLabel:  "SEVERITY_UNSPECIFIED",
+			Value: &rpc.Score_IntegerValue{
+				IntegerValue: &rpc.IntegerValue{
+					Value:    2,
+					MaxValue: 10,
 				},
 			},
 		},
 		{
-			desc: "invalid expression",
-			seed: []seeder.RegistryResource{
-				&rpc.Artifact{
-					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
-					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
-					Contents: protoMarshal(&rpc.Lint{
-						Name: "openapi.yaml",
-						Files: []*rpc.LintFile{
-							{
-								FilePath: "openapi.yaml",
-								Problems: []*rpc.LintProblem{
-									{
-										Message: "lint-error",
-									},
-								},
-							},
-						},
-					}),
-				},
-			},
-			formula: &rpc.ScoreFormula{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifacts/lint-spectral",
-				},
-				ScoreExpression: "size(files[0].problem)", // invalid expression
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			Id:             "score-lint-approved",
+			Kind:           "Score",
+			DisplayName:    "Lint Approval",
+			DefinitionName: definitionName,
+			Severity:       rpc.Severity_ALERT,
+			SeverityLabel:  "ALERT",
+			Value: &rpc.Score_BooleanValue{
+				BooleanValue: &rpc.BooleanValue{
+					Value:        false,
+					DisplayValue: "false",
 				},
 			},
 		},
 		{
-			desc: "missing expression",
-			seed: []seeder.RegistryResource{
-				&rpc.Artifact{
-					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
-					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
-					Contents: protoMarshal(&rpc.Lint{
-						Name: "openapi.yaml",
-						Files: []*rpc.LintFile{
-							{
-								FilePath: "openapi.yaml",
-								Problems: []*rpc.LintProblem{
-									{
-										Message: "lint-error",
-									},
-								},
-							},
-						},
-					}),
-				},
-			},
-			formula: &rpc.ScoreFormula{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifacts/lint-spectral",
-				},
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			Id:             "score-lint-percent",
+			Kind:           "Score",
+			DefinitionName: definitionName,
+			SeverityLabel:  "SEVERITY_UNSPECIFIED",
+			Value: &rpc.Score_PercentValue{
+				PercentValue: &rpc.PercentValue{
+					Value: 20,
 				},
 			},
 		},
 	}
-
-	for _, test := range tests {
-		t.Run(test.desc, func(t *testing.T) {
-			ctx := context.Background()
-			registryClient, err := connection.NewRegistryClient(ctx)
-			if err != nil {
-				t.Fatalf("Failed to create client: %+v", err)
-			}
-			t.Cleanup(func() { registryClient.Close() })
-
-			adminClient, err := connection.NewAdminClient(ctx)
-			if err != nil {
-				t.Fatalf("Failed to create client: %+v", err)
-			}
-			t.Cleanup(func() { adminClient.Close() })
-
-			deleteProject(ctx, adminClient, t, "score-formula-test")
-			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-test") })
-
-			client := seeder.Client{
-				RegistryClient: registryClient,
-				AdminClient:    adminClient,
-			}
-
-			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
-				t.Fatalf("Setup: failed to seed registry: %s", err)
-			}
-
-			artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
-
-			gotResult := processScoreFormula(ctx, artifactClient, test.formula, test.resource, &rpc.Artifact{}, true)
-			if gotResult.err == nil {
-				t.Errorf("processScoreFormula(ctx, client, %v, %v) did not return an error", test.formula, test.resource)
-			}
-		})
+	for _, want := range wantScores {
+		scoreArtifact, err := getArtifact(ctx, artifactClient, specName+"/artifacts/"+want.GetId(), true)
+		if err != nil {
+			t.Errorf("failed to get score artifact %s: %s", want.GetId(), err)
+			continue
+		}
+		got := &rpc.Score{}
+		if err := proto.Unmarshal(scoreArtifact.GetContents(), got); err != nil {
+			t.Errorf("failed unmarshalling score artifact from registry: %s", err)
+			continue
+		}
+		opts := cmp.Options{protocmp.Transform()}
+		if !cmp.Equal(want, got, opts) {
+			t.Errorf("CalculateScore() returned unexpected response (-want +got):\n%s", cmp.Diff(want, got, opts))
+		}
 	}
 }
 
-func TestProcessRollUpFormula(t *testing.T) {
+func TestCalculateScoreDryRun(t *testing.T) {
 	ctx := context.Background()
 	registryClient, err := connection.NewRegistryClient(ctx)
 	if err != nil {
@@ -745,18 +677,29 @@ func TestProcessRollUpFormula(t *testing.T) {
 	}
 	t.Cleanup(func() { adminClient.Close() })
 
-	deleteProject(ctx, adminClient, t, "rollup-formula-test")
-	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "rollup-formula-test") })
+	deleteProject(ctx, adminClient, t, "score-dry-run-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-dry-run-test") })
 
 	client := seeder.Client{
 		RegistryClient: registryClient,
 		AdminClient:    adminClient,
 	}
-
+	specName := "projects/score-dry-run-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+	definitionName := "projects/score-dry-run-test/locations/global/artifacts/lint-error"
+	previous := &rpc.Score{
+		Id:             "score-lint-error",
+		Kind:           "Score",
+		DefinitionName: definitionName,
+		Value: &rpc.Score_IntegerValue{
+			IntegerValue: &rpc.IntegerValue{
+				Value:    3,
+				MaxValue: 10,
+			},
+		},
+	}
 	seed := []seeder.RegistryResource{
-		// lint artifact
 		&rpc.Artifact{
-			Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			Name:     specName + "/artifacts/lint-spectral",
 			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
 			Contents: protoMarshal(&rpc.Lint{
 				Name: "openapi.yaml",
@@ -764,365 +707,1132 @@ func TestProcessRollUpFormula(t *testing.T) {
 					{
 						FilePath: "openapi.yaml",
 						Problems: []*rpc.LintProblem{
-							{
-								Message: "lint-error",
-							},
-							{
-								Message: "lint-error",
-							},
+							{Message: "lint-error"},
 						},
 					},
 				},
 			}),
 		},
-		// complexity artifact
 		&rpc.Artifact{
-			Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
-			MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
-			Contents: protoMarshal(&metrics.Complexity{
-				GetCount:    1,
-				PostCount:   1,
-				PutCount:    1,
-				DeleteCount: 1,
+			Name:     specName + "/artifacts/score-lint-error",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.Score",
+			Contents: protoMarshal(previous),
+		},
+		// The definition is updated after the score, so the score is recomputed.
+		&rpc.Artifact{
+			Name:     definitionName,
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.ScoreDefinition",
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint-error",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
 			}),
 		},
 	}
-
 	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
 		t.Fatalf("Setup: failed to seed registry: %s", err)
 	}
 
-	// arguments
-	formula := &rpc.RollUpFormula{
-		ScoreFormulas: []*rpc.ScoreFormula{
-			{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifacts/lint-spectral",
-				},
-				ScoreExpression: "size(files[0].problems)",
-				ReferenceId:     "numErrors",
-			},
-			{
-				Artifact: &rpc.ResourcePattern{
-					Pattern: "$resource.spec/artifacts/complexity",
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	defArtifact, err := getArtifact(ctx, artifactClient, definitionName, true)
+	if err != nil {
+		t.Fatalf("failed to fetch the definition Artifact from setup: %s", err)
+	}
+	resource := patterns.SpecResource{Spec: &rpc.ApiSpec{Name: specName}}
+
+	got, err := CalculateScore(ctx, artifactClient, defArtifact, resource, true)
+	if err != nil {
+		t.Fatalf("CalculateScore(ctx, client, %v, %v) returned unexpected error: %s", defArtifact, resource, err)
+	}
+	want := []*ComputedScore{
+		{
+			Score: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DefinitionName: definitionName,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
+				Value: &rpc.Score_IntegerValue{
+					IntegerValue: &rpc.IntegerValue{
+						Value:    1,
+						MaxValue: 10,
+					},
 				},
-				ScoreExpression: "getCount + postCount + putCount + deleteCount",
-				ReferenceId:     "numOperations",
 			},
+			Previous: previous,
+			Changed:  true,
 		},
-		RollupExpression: "double(numErrors)/numOperations",
 	}
-	resource := patterns.SpecResource{
-		Spec: &rpc.ApiSpec{
-			Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-		},
+	opts := cmp.Options{protocmp.Transform()}
+	if !cmp.Equal(want, got, opts) {
+		t.Errorf("CalculateScore() returned unexpected response (-want +got):\n%s", cmp.Diff(want, got, opts))
 	}
 
-	wantResult := scoreResult{
-		value:       float64(0.5),
-		needsUpdate: true,
-		err:         nil,
+	// A dry run doesn't change the stored score.
+	scoreArtifact, err := getArtifact(ctx, artifactClient, specName+"/artifacts/score-lint-error", true)
+	if err != nil {
+		t.Fatalf("failed to get the score artifact: %s", err)
 	}
+	stored := &rpc.Score{}
+	if err := proto.Unmarshal(scoreArtifact.GetContents(), stored); err != nil {
+		t.Fatalf("failed unmarshalling score artifact from registry: %s", err)
+	}
+	if !cmp.Equal(previous, stored, opts) {
+		t.Errorf("CalculateScore() with dry run changed the stored score (-want +got):\n%s", cmp.Diff(previous, stored, opts))
+	}
+}
 
-	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
-
-	gotResult := processRollUpFormula(ctx, artifactClient, formula, resource, &rpc.Artifact{}, true)
+func TestCalculateScoreForResource(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
 
-	opts := cmp.AllowUnexported(scoreResult{})
-	if !cmp.Equal(wantResult, gotResult, opts) {
-		t.Errorf("processRollUpFormula() returned unexpected value, (-want, +got):\n%s", cmp.Diff(wantResult, gotResult, opts))
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
 	}
-}
+	t.Cleanup(func() { adminClient.Close() })
 
-func TestProcessRollUpFormulaError(t *testing.T) {
-	tests := []struct {
-		desc     string
-		seed     []seeder.RegistryResource
-		formula  *rpc.RollUpFormula
-		resource patterns.ResourceInstance
-	}{
-		{
-			desc: "missing score_formulas",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-			formula: &rpc.RollUpFormula{
-				RollupExpression: "double(numErrors)/numOperations",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
+	deleteProject(ctx, adminClient, t, "score-resource-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-resource-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	specName := "projects/score-resource-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+	definitionName := "projects/score-resource-test/locations/global/artifacts/lint-error"
+	seed := []seeder.RegistryResource{
+		&rpc.ApiSpec{
+			Name: specName,
 		},
-		{
-			desc: "missing rollup_expression",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
-			formula: &rpc.RollUpFormula{
-				ScoreFormulas: []*rpc.ScoreFormula{
+		&rpc.Artifact{
+			Name:     specName + "/artifacts/lint-spectral",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents: protoMarshal(&rpc.Lint{
+				Name: "openapi.yaml",
+				Files: []*rpc.LintFile{
 					{
+						FilePath: "openapi.yaml",
+						Problems: []*rpc.LintProblem{
+							{Message: "lint-error"},
+							{Message: "lint-error"},
+						},
+					},
+				},
+			}),
+		},
+		&rpc.Artifact{
+			Name:     definitionName,
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition",
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint-error",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
 						Artifact: &rpc.ResourcePattern{
 							Pattern: "$resource.spec/artifacts/lint-spectral",
 						},
 						ScoreExpression: "size(files[0].problems)",
-						ReferenceId:     "numErrors",
 					},
-					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/complexity",
-						},
-						ScoreExpression: "getCount + postCount + putCount + deleteCount",
-						ReferenceId:     "numOperations",
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 10,
 					},
 				},
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			}),
+		},
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+	got, err := CalculateScoreForResource(ctx, artifactClient, "lint-error", specName, true)
+	if err != nil {
+		t.Fatalf("CalculateScoreForResource(ctx, client, %q, %q) returned unexpected error: %s", "lint-error", specName, err)
+	}
+	want := []*ComputedScore{
+		{
+			Score: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DefinitionName: definitionName,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
+				Value: &rpc.Score_IntegerValue{
+					IntegerValue: &rpc.IntegerValue{
+						Value:    2,
+						MaxValue: 10,
+					},
 				},
 			},
+			Changed:         true,
+			SeverityChanged: true,
 		},
+	}
+	opts := cmp.Options{protocmp.Transform()}
+	if !cmp.Equal(want, got, opts) {
+		t.Errorf("CalculateScoreForResource() returned unexpected response (-want +got):\n%s", cmp.Diff(want, got, opts))
+	}
+
+	errorTests := []struct {
+		desc         string
+		definitionID string
+		resourceName string
+	}{
 		{
-			desc: "invalid score_expression",
-			seed: []seeder.RegistryResource{
-				// lint artifact
-				&rpc.Artifact{
-					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
-					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
-					Contents: protoMarshal(&rpc.Lint{
-						Name: "openapi.yaml",
-						Files: []*rpc.LintFile{
+			desc:         "missing definition",
+			definitionID: "missing",
+			resourceName: specName,
+		},
+		{
+			desc:         "artifact is not a definition",
+			definitionID: "lint-spectral",
+			resourceName: specName,
+		},
+		{
+			desc:         "resource level mismatch",
+			definitionID: "lint-error",
+			resourceName: "projects/score-resource-test/locations/global/apis/petstore/versions/1.0.0",
+		},
+		{
+			desc:         "missing resource",
+			definitionID: "lint-error",
+			resourceName: "projects/score-resource-test/locations/global/apis/petstore/versions/1.0.0/specs/missing.yaml",
+		},
+		{
+			desc:         "collection name",
+			definitionID: "lint-error",
+			resourceName: "projects/score-resource-test/locations/global/apis/-/versions/-/specs/-",
+		},
+	}
+	for _, test := range errorTests {
+		t.Run(test.desc, func(t *testing.T) {
+			if _, err := CalculateScoreForResource(ctx, artifactClient, test.definitionID, test.resourceName, true); err == nil {
+				t.Errorf("expected CalculateScoreForResource(ctx, client, %q, %q) to return error", test.definitionID, test.resourceName)
+			}
+		})
+	}
+}
+
+func TestProcessScoreFormula(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "score-formula-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents: protoMarshal(&rpc.Lint{
+				Name: "openapi.yaml",
+				Files: []*rpc.LintFile{
+					{
+						FilePath: "openapi.yaml",
+						Problems: []*rpc.LintProblem{
 							{
-								FilePath: "openapi.yaml",
-								Problems: []*rpc.LintProblem{
-									{
-										Message: "lint-error",
-									},
-									{
-										Message: "lint-error",
-									},
-								},
+								Message: "lint-error",
 							},
 						},
-					}),
-				},
-				// complexity artifact
-				&rpc.Artifact{
-					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
-					MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
-					Contents: protoMarshal(&metrics.Complexity{
-						GetCount:    1,
-						PostCount:   1,
-						PutCount:    1,
-						DeleteCount: 1,
-					}),
-				},
-			},
-			formula: &rpc.RollUpFormula{
-				ScoreFormulas: []*rpc.ScoreFormula{
-					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/lint-spectral",
-						},
-						ScoreExpression: "size(files.problems)", // invalid field reference
-						ReferenceId:     "numErrors",
-					},
-					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/complexity",
-						},
-						ScoreExpression: "getCount + postCount + putCount + deleteCount",
-						ReferenceId:     "numOperations",
 					},
 				},
-				RollupExpression: "double(numErrors)/numOperations",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
-			},
+			}),
 		},
-		{
-			desc: "invalid rollup_expression",
-			seed: []seeder.RegistryResource{
-				// lint artifact
-				&rpc.Artifact{
-					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
-					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
-					Contents: protoMarshal(&rpc.Lint{
-						Name: "openapi.yaml",
-						Files: []*rpc.LintFile{
+	}
+
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	// arguments
+	formula := &rpc.ScoreFormula{
+		Artifact: &rpc.ResourcePattern{
+			Pattern: "$resource.spec/artifacts/lint-spectral",
+		},
+		ScoreExpression: "size(files[0].problems)",
+	}
+	resource := patterns.SpecResource{
+		Spec: &rpc.ApiSpec{
+			Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		},
+	}
+
+	wantResult := scoreResult{
+		value:       int64(1),
+		needsUpdate: true,
+		err:         nil,
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+	gotResult := processScoreFormula(ctx, artifactClient, formula, resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
+
+	opts := cmp.AllowUnexported(scoreResult{})
+	if !cmp.Equal(wantResult, gotResult, opts) {
+		t.Errorf("processScoreFormula() returned unexpected response, (-want +got):\n%s", cmp.Diff(wantResult, gotResult, opts))
+	}
+}
+
+func TestProcessScoreFormulaNamedArtifacts(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "score-formula-named-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-named-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name:     "projects/score-formula-named-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Labels:   map[string]string{"tier": "gold"},
+			Contents: protoMarshal(&rpc.Lint{
+				Name: "openapi.yaml",
+				Files: []*rpc.LintFile{
+					{
+						FilePath: "openapi.yaml",
+						Problems: []*rpc.LintProblem{
 							{
-								FilePath: "openapi.yaml",
-								Problems: []*rpc.LintProblem{
-									{
-										Message: "lint-error",
-									},
-									{
-										Message: "lint-error",
-									},
-								},
+								Message: "lint-error",
+							},
+							{
+								Message: "lint-error",
 							},
 						},
-					}),
-				},
-				// complexity artifact
-				&rpc.Artifact{
-					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
-					MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
-					Contents: protoMarshal(&metrics.Complexity{
-						GetCount:    1,
-						PostCount:   1,
-						PutCount:    1,
-						DeleteCount: 1,
-					}),
+					},
 				},
-			},
-			formula: &rpc.RollUpFormula{
-				ScoreFormulas: []*rpc.ScoreFormula{
+			}),
+		},
+		&rpc.Artifact{
+			Name:     "projects/score-formula-named-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
+			MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
+			Contents: protoMarshal(&metrics.Complexity{
+				PathCount: 4,
+			}),
+		},
+	}
+
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	resource := patterns.SpecResource{
+		Spec: &rpc.ApiSpec{
+			Name: "projects/score-formula-named-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		},
+	}
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+	tests := []struct {
+		desc    string
+		formula *rpc.ScoreFormula
+		want    interface{}
+	}{
+		{
+			desc: "named artifacts",
+			formula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
 					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/lint-spectral",
-						},
-						ScoreExpression: "size(files[0].problems)",
-						ReferenceId:     "numErrors",
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
 					},
 					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/complexity",
-						},
-						ScoreExpression: "getCount + postCount + putCount + deleteCount",
-						ReferenceId:     "numOperations",
+						Alias:    "complexity",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/complexity"},
 					},
 				},
-				RollupExpression: "numError/numOperation", // should be numErrors/numOperations
+				ScoreExpression: "double(size(lint.files[0].problems)) / double(complexity.pathCount)",
 			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			want: 0.5,
+		},
+		{
+			desc: "unnamed and named artifacts",
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "complexity",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/complexity"},
+					},
 				},
+				ScoreExpression: "size(files[0].problems) < complexity.pathCount",
 			},
+			want: true,
 		},
 		{
-			desc: "invalid reference_id",
-			seed: []seeder.RegistryResource{
-				&rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
+			desc: "artifact labels",
+			formula: &rpc.ScoreFormula{
+				Artifact:        &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+				ScoreExpression: "_labels['tier'] == 'gold' && size(_annotations) == 0",
 			},
-			formula: &rpc.RollUpFormula{
-				ScoreFormulas: []*rpc.ScoreFormula{
+			want: true,
+		},
+		{
+			desc: "named artifact labels",
+			formula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
 					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/lint-spectral",
-						},
-						ScoreExpression: "size(files[0].problems)",
-						ReferenceId:     "num-errors",
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
 					},
 					{
-						Artifact: &rpc.ResourcePattern{
-							Pattern: "$resource.spec/artifacts/complexity",
-						},
-						ScoreExpression: "getCount + postCount + putCount + deleteCount",
-						ReferenceId:     "num-operations",
+						Alias:    "complexity",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/complexity"},
 					},
 				},
-				RollupExpression: "num-errors/num-operations",
-			},
-			resource: patterns.SpecResource{
-				Spec: &rpc.ApiSpec{
-					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
-				},
+				ScoreExpression: "'tier' in lint._labels && size(complexity._labels) == 0",
 			},
+			want: true,
 		},
 	}
-
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			ctx := context.Background()
-			registryClient, err := connection.NewRegistryClient(ctx)
-			if err != nil {
-				t.Fatalf("Failed to create client: %+v", err)
-			}
-			t.Cleanup(func() { registryClient.Close() })
-
-			adminClient, err := connection.NewAdminClient(ctx)
-			if err != nil {
-				t.Fatalf("Failed to create client: %+v", err)
+			wantResult := scoreResult{
+				value:       test.want,
+				needsUpdate: true,
+				err:         nil,
 			}
-			t.Cleanup(func() { adminClient.Close() })
 
-			deleteProject(ctx, adminClient, t, "rollup-formula-test")
-			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "rollup-formula-test") })
+			gotResult := processScoreFormula(ctx, artifactClient, test.formula, resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
 
-			client := seeder.Client{
-				RegistryClient: registryClient,
-				AdminClient:    adminClient,
+			opts := cmp.AllowUnexported(scoreResult{})
+			if !cmp.Equal(wantResult, gotResult, opts) {
+				t.Errorf("processScoreFormula() returned unexpected response, (-want +got):\n%s", cmp.Diff(wantResult, gotResult, opts))
 			}
+		})
+	}
+}
 
-			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
-				t.Fatalf("Setup: failed to seed registry: %s", err)
-			}
+func TestProcessScoreFormulaGzippedArtifact(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
 
-			artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
 
-			gotResult := processRollUpFormula(ctx, artifactClient, test.formula, test.resource, &rpc.Artifact{}, true)
-			if gotResult.err == nil {
-				t.Errorf("processRollUpFormula(ctx, client, %v, %v) did not return an error", test.formula, test.resource)
-			}
-		})
+	deleteProject(ctx, adminClient, t, "score-formula-gzip-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-gzip-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
 	}
-}
 
-func TestProcessScoreType(t *testing.T) {
-	tests := []struct {
-		desc       string
-		definition *rpc.ScoreDefinition
-		scoreValue interface{}
-		wantScore  *rpc.Score
-	}{
-		{
-			desc:       "happy path integer",
-			definition: integerDefinition,
-			scoreValue: int64(1),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error",
-				Kind:           "Score",
-				DisplayName:    "Lint Error",
-				Description:    "Number of errors found by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
-				Severity:       rpc.Severity_OK,
-				Value: &rpc.Score_IntegerValue{
-					IntegerValue: &rpc.IntegerValue{
-						Value:    1,
-						MinValue: 0,
-						MaxValue: 10,
+	contents, err := core.GZippedBytes(protoMarshal(&rpc.Lint{
+		Name: "openapi.yaml",
+		Files: []*rpc.LintFile{
+			{
+				FilePath: "openapi.yaml",
+				Problems: []*rpc.LintProblem{
+					{
+						Message: "lint-error",
 					},
 				},
 			},
 		},
-		{
-			desc:       "happy path integer with float value",
-			definition: integerDefinition,
-			scoreValue: float64(1),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error",
-				Kind:           "Score",
-				DisplayName:    "Lint Error",
-				Description:    "Number of errors found by linter",
-				Uri:            "http://some/test/uri",
+	}))
+	if err != nil {
+		t.Fatalf("Setup: failed to compress contents: %s", err)
+	}
+	seed := []seeder.RegistryResource{
+		&rpc.Artifact{
+			Name:     "projects/score-formula-gzip-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint+gzip",
+			Contents: contents,
+		},
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	resource := patterns.SpecResource{
+		Spec: &rpc.ApiSpec{
+			Name: "projects/score-formula-gzip-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		},
+	}
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+	tests := []struct {
+		desc    string
+		formula *rpc.ScoreFormula
+	}{
+		{
+			desc: "unnamed artifact",
+			formula: &rpc.ScoreFormula{
+				Artifact:        &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+				ScoreExpression: "size(files[0].problems)",
+			},
+		},
+		{
+			desc: "named artifact",
+			formula: &rpc.ScoreFormula{
+				Artifacts: []*rpc.ScoreArtifact{
+					{
+						Alias:    "lint",
+						Artifact: &rpc.ResourcePattern{Pattern: "$resource.spec/artifacts/lint-spectral"},
+					},
+				},
+				ScoreExpression: "size(lint.files[0].problems)",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := processScoreFormula(ctx, artifactClient, test.formula, resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
+			if got.err != nil {
+				t.Fatalf("processScoreFormula() returned error: %s", got.err)
+			}
+			if got.value != int64(1) {
+				t.Errorf("processScoreFormula() returned %v, want 1", got.value)
+			}
+		})
+	}
+}
+
+func TestProcessScoreFormulaError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		seed     []seeder.RegistryResource
+		setup    func(context.Context, connection.RegistryClient, connection.AdminClient)
+		formula  *rpc.ScoreFormula
+		resource patterns.ResourceInstance
+	}{
+		{
+			desc: "invalid reference",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.specs/artifacts/lint-spectral", //error
+				},
+				ScoreExpression: "size(files[0].problems)",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "invalid extended pattern",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifact/lint-spectral", // error
+				},
+				ScoreExpression: "size(files[0].problems)",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "missing artifact",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/lint-spectral",
+				},
+				ScoreExpression: "size(files[0].problems)",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "unsupported artifact type",
+			seed: []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/score-definition",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.applications.v1alpha1.ScoreDefinition",
+					Contents: protoMarshal(&rpc.ScoreDefinition{
+						Id:             "dummy-score-definition",
+						TargetResource: &rpc.ResourcePattern{},
+						Formula:        nil,
+						Type:           nil,
+					}),
+				},
+			},
+			formula: &rpc.ScoreFormula{},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "invalid expression",
+			seed: []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name: "openapi.yaml",
+						Files: []*rpc.LintFile{
+							{
+								FilePath: "openapi.yaml",
+								Problems: []*rpc.LintProblem{
+									{
+										Message: "lint-error",
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/lint-spectral",
+				},
+				ScoreExpression: "size(files[0].problem)", // invalid expression
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "missing expression",
+			seed: []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name:     "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name: "openapi.yaml",
+						Files: []*rpc.LintFile{
+							{
+								FilePath: "openapi.yaml",
+								Problems: []*rpc.LintProblem{
+									{
+										Message: "lint-error",
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+			formula: &rpc.ScoreFormula{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/lint-spectral",
+				},
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "score-formula-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-formula-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+
+			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+			gotResult := processScoreFormula(ctx, artifactClient, test.formula, test.resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
+			if gotResult.err == nil {
+				t.Errorf("processScoreFormula(ctx, client, %v, %v) did not return an error", test.formula, test.resource)
+			}
+		})
+	}
+}
+
+func TestProcessRollUpFormula(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "rollup-formula-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "rollup-formula-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+
+	seed := []seeder.RegistryResource{
+		// lint artifact
+		&rpc.Artifact{
+			Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents: protoMarshal(&rpc.Lint{
+				Name: "openapi.yaml",
+				Files: []*rpc.LintFile{
+					{
+						FilePath: "openapi.yaml",
+						Problems: []*rpc.LintProblem{
+							{
+								Message: "lint-error",
+							},
+							{
+								Message: "lint-error",
+							},
+						},
+					},
+				},
+			}),
+		},
+		// complexity artifact
+		&rpc.Artifact{
+			Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
+			MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
+			Contents: protoMarshal(&metrics.Complexity{
+				GetCount:    1,
+				PostCount:   1,
+				PutCount:    1,
+				DeleteCount: 1,
+			}),
+		},
+	}
+
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	// arguments
+	formula := &rpc.RollUpFormula{
+		ScoreFormulas: []*rpc.ScoreFormula{
+			{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/lint-spectral",
+				},
+				ScoreExpression: "size(files[0].problems)",
+				ReferenceId:     "numErrors",
+			},
+			{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/complexity",
+				},
+				ScoreExpression: "getCount + postCount + putCount + deleteCount",
+				ReferenceId:     "numOperations",
+			},
+		},
+		RollupExpression: "double(numErrors)/numOperations",
+	}
+	resource := patterns.SpecResource{
+		Spec: &rpc.ApiSpec{
+			Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+		},
+	}
+
+	wantResult := scoreResult{
+		value:       float64(0.5),
+		needsUpdate: true,
+		err:         nil,
+		references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+	gotResult := processRollUpFormula(ctx, artifactClient, formula, resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
+
+	opts := cmp.AllowUnexported(scoreResult{})
+	if !cmp.Equal(wantResult, gotResult, opts) {
+		t.Errorf("processRollUpFormula() returned unexpected value, (-want, +got):\n%s", cmp.Diff(wantResult, gotResult, opts))
+	}
+}
+
+func TestProcessRollUpFormulaError(t *testing.T) {
+	tests := []struct {
+		desc     string
+		seed     []seeder.RegistryResource
+		formula  *rpc.RollUpFormula
+		resource patterns.ResourceInstance
+	}{
+		{
+			desc: "missing score_formulas",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.RollUpFormula{
+				RollupExpression: "double(numErrors)/numOperations",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "missing rollup_expression",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+						ReferenceId:     "numErrors",
+					},
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/complexity",
+						},
+						ScoreExpression: "getCount + postCount + putCount + deleteCount",
+						ReferenceId:     "numOperations",
+					},
+				},
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "invalid score_expression",
+			seed: []seeder.RegistryResource{
+				// lint artifact
+				&rpc.Artifact{
+					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name: "openapi.yaml",
+						Files: []*rpc.LintFile{
+							{
+								FilePath: "openapi.yaml",
+								Problems: []*rpc.LintProblem{
+									{
+										Message: "lint-error",
+									},
+									{
+										Message: "lint-error",
+									},
+								},
+							},
+						},
+					}),
+				},
+				// complexity artifact
+				&rpc.Artifact{
+					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
+					MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
+					Contents: protoMarshal(&metrics.Complexity{
+						GetCount:    1,
+						PostCount:   1,
+						PutCount:    1,
+						DeleteCount: 1,
+					}),
+				},
+			},
+			formula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files.problems)", // invalid field reference
+						ReferenceId:     "numErrors",
+					},
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/complexity",
+						},
+						ScoreExpression: "getCount + postCount + putCount + deleteCount",
+						ReferenceId:     "numOperations",
+					},
+				},
+				RollupExpression: "double(numErrors)/numOperations",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "invalid rollup_expression",
+			seed: []seeder.RegistryResource{
+				// lint artifact
+				&rpc.Artifact{
+					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/lint-spectral",
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name: "openapi.yaml",
+						Files: []*rpc.LintFile{
+							{
+								FilePath: "openapi.yaml",
+								Problems: []*rpc.LintProblem{
+									{
+										Message: "lint-error",
+									},
+									{
+										Message: "lint-error",
+									},
+								},
+							},
+						},
+					}),
+				},
+				// complexity artifact
+				&rpc.Artifact{
+					Name:     "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml/artifacts/complexity",
+					MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
+					Contents: protoMarshal(&metrics.Complexity{
+						GetCount:    1,
+						PostCount:   1,
+						PutCount:    1,
+						DeleteCount: 1,
+					}),
+				},
+			},
+			formula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+						ReferenceId:     "numErrors",
+					},
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/complexity",
+						},
+						ScoreExpression: "getCount + postCount + putCount + deleteCount",
+						ReferenceId:     "numOperations",
+					},
+				},
+				RollupExpression: "numError/numOperation", // should be numErrors/numOperations
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+		{
+			desc: "invalid reference_id",
+			seed: []seeder.RegistryResource{
+				&rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+			formula: &rpc.RollUpFormula{
+				ScoreFormulas: []*rpc.ScoreFormula{
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+						ReferenceId:     "num-errors",
+					},
+					{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/complexity",
+						},
+						ScoreExpression: "getCount + postCount + putCount + deleteCount",
+						ReferenceId:     "num-operations",
+					},
+				},
+				RollupExpression: "num-errors/num-operations",
+			},
+			resource: patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			registryClient, err := connection.NewRegistryClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { registryClient.Close() })
+
+			adminClient, err := connection.NewAdminClient(ctx)
+			if err != nil {
+				t.Fatalf("Failed to create client: %+v", err)
+			}
+			t.Cleanup(func() { adminClient.Close() })
+
+			deleteProject(ctx, adminClient, t, "rollup-formula-test")
+			t.Cleanup(func() { deleteProject(ctx, adminClient, t, "rollup-formula-test") })
+
+			client := seeder.Client{
+				RegistryClient: registryClient,
+				AdminClient:    adminClient,
+			}
+
+			if err := seeder.SeedRegistry(ctx, client, test.seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+
+			artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+
+			gotResult := processRollUpFormula(ctx, artifactClient, test.formula, test.resource, &rpc.Artifact{}, true, patterns.ResourceUpdateThreshold)
+			if gotResult.err == nil {
+				t.Errorf("processRollUpFormula(ctx, client, %v, %v) did not return an error", test.formula, test.resource)
+			}
+		})
+	}
+}
+
+func TestProcessScoreType(t *testing.T) {
+	tests := []struct {
+		desc       string
+		definition *rpc.ScoreDefinition
+		scoreValue interface{}
+		wantScore  *rpc.Score
+	}{
+		{
+			desc:       "happy path integer",
+			definition: integerDefinition,
+			scoreValue: int64(1),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DisplayName:    "Lint Error",
+				Description:    "Number of errors found by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
+				Severity:       rpc.Severity_OK,
+				SeverityLabel:  "OK",
+				Value: &rpc.Score_IntegerValue{
+					IntegerValue: &rpc.IntegerValue{
+						Value:    1,
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+			},
+		},
+		{
+			desc:       "happy path integer with float value",
+			definition: integerDefinition,
+			scoreValue: float64(1),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DisplayName:    "Lint Error",
+				Description:    "Number of errors found by linter",
+				Uri:            "http://some/test/uri",
 				UriDisplayName: "Test URI",
 				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_OK,
+				SeverityLabel:  "OK",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -1133,193 +1843,832 @@ func TestProcessScoreType(t *testing.T) {
 			},
 		},
 		{
-			desc:       "greater than max integer",
-			definition: integerDefinition,
-			scoreValue: int64(11),
+			desc:       "greater than max integer",
+			definition: integerDefinition,
+			scoreValue: int64(11),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DisplayName:    "Lint Error",
+				Description:    "Number of errors found by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
+				Severity:       rpc.Severity_ALERT,
+				SeverityLabel:  "ALERT",
+				Value: &rpc.Score_IntegerValue{
+					IntegerValue: &rpc.IntegerValue{
+						Value:    11,
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+			},
+		},
+		{
+			desc:       "less than min integer",
+			definition: integerDefinition,
+			scoreValue: int64(-1),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error",
+				Kind:           "Score",
+				DisplayName:    "Lint Error",
+				Description:    "Number of errors found by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
+				Severity:       rpc.Severity_ALERT,
+				SeverityLabel:  "ALERT",
+				Value: &rpc.Score_IntegerValue{
+					IntegerValue: &rpc.IntegerValue{
+						Value:    -1,
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+			},
+		},
+		{
+			desc:       "happy path percent",
+			definition: percentDefinition,
+			scoreValue: float64(50),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error-percent",
+				Kind:           "Score",
+				DisplayName:    "Lint Error Percentage",
+				Description:    "Percentage errors found by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
+				Severity:       rpc.Severity_WARNING,
+				SeverityLabel:  "WARNING",
+				Value: &rpc.Score_PercentValue{
+					PercentValue: &rpc.PercentValue{
+						Value: 50,
+					},
+				},
+			},
+		},
+		{
+			desc:       "happy path percent with integer value",
+			definition: percentDefinition,
+			scoreValue: int64(50),
+			wantScore: &rpc.Score{
+				Id:             "score-lint-error-percent",
+				Kind:           "Score",
+				DisplayName:    "Lint Error Percentage",
+				Description:    "Percentage errors found by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
+				Severity:       rpc.Severity_WARNING,
+				SeverityLabel:  "WARNING",
+				Value: &rpc.Score_PercentValue{
+					PercentValue: &rpc.PercentValue{
+						Value: 50,
+					},
+				},
+			},
+		},
+		{
+			desc:       "greater than max percent",
+			definition: percentDefinition,
+			scoreValue: int64(101),
 			wantScore: &rpc.Score{
-				Id:             "score-lint-error",
+				Id:             "score-lint-error-percent",
 				Kind:           "Score",
-				DisplayName:    "Lint Error",
-				Description:    "Number of errors found by linter",
+				DisplayName:    "Lint Error Percentage",
+				Description:    "Percentage errors found by linter",
 				Uri:            "http://some/test/uri",
 				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
 				Severity:       rpc.Severity_ALERT,
-				Value: &rpc.Score_IntegerValue{
-					IntegerValue: &rpc.IntegerValue{
-						Value:    11,
-						MinValue: 0,
-						MaxValue: 10,
+				SeverityLabel:  "ALERT",
+				Value: &rpc.Score_PercentValue{
+					PercentValue: &rpc.PercentValue{
+						Value: 101,
 					},
 				},
 			},
 		},
 		{
-			desc:       "less than min integer",
-			definition: integerDefinition,
+			desc:       "less than min percent",
+			definition: percentDefinition,
 			scoreValue: int64(-1),
 			wantScore: &rpc.Score{
-				Id:             "score-lint-error",
+				Id:             "score-lint-error-percent",
 				Kind:           "Score",
-				DisplayName:    "Lint Error",
-				Description:    "Number of errors found by linter",
+				DisplayName:    "Lint Error Percentage",
+				Description:    "Percentage errors found by linter",
 				Uri:            "http://some/test/uri",
 				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
 				Severity:       rpc.Severity_ALERT,
-				Value: &rpc.Score_IntegerValue{
-					IntegerValue: &rpc.IntegerValue{
-						Value:    -1,
-						MinValue: 0,
-						MaxValue: 10,
+				SeverityLabel:  "ALERT",
+				Value: &rpc.Score_PercentValue{
+					PercentValue: &rpc.PercentValue{
+						Value: -1,
+					},
+				},
+			},
+		},
+		{
+			desc:       "happy path boolean",
+			definition: booleanDefinition,
+			scoreValue: true,
+			wantScore: &rpc.Score{
+				Id:             "score-lint-approval",
+				Kind:           "Score",
+				DisplayName:    "Lint Approval",
+				Description:    "Approval by linter",
+				Uri:            "http://some/test/uri",
+				UriDisplayName: "Test URI",
+				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-approval",
+				Severity:       rpc.Severity_OK,
+				SeverityLabel:  "OK",
+				Value: &rpc.Score_BooleanValue{
+					BooleanValue: &rpc.BooleanValue{
+						Value:        true,
+						DisplayValue: "Approved",
 					},
 				},
 			},
 		},
-		{
-			desc:       "happy path percent",
-			definition: percentDefinition,
-			scoreValue: float64(50),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error-percent",
-				Kind:           "Score",
-				DisplayName:    "Lint Error Percentage",
-				Description:    "Percentage errors found by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
-				Severity:       rpc.Severity_WARNING,
-				Value: &rpc.Score_PercentValue{
-					PercentValue: &rpc.PercentValue{
-						Value: 50,
-					},
-				},
-			},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotScore, gotErr := processScoreType(test.definition, test.scoreValue, "projects/score-type-test/locations/global")
+			if gotErr != nil {
+				t.Errorf("processScoreType() returned unexpected error: %s", gotErr)
+			}
+
+			opts := cmp.Options{protocmp.Transform()}
+			if !cmp.Equal(test.wantScore, gotScore, opts) {
+				t.Errorf("processScoreType() returned unexpected response (-want +got):\n%s", cmp.Diff(test.wantScore, gotScore, opts))
+			}
+		})
+	}
+}
+
+func TestProcessScoreTypeError(t *testing.T) {
+	tests := []struct {
+		desc       string
+		definition *rpc.ScoreDefinition
+		scoreValue interface{}
+	}{
+		{
+			desc:       "type mismatch integer",
+			definition: integerDefinition,
+			scoreValue: true,
+		},
+		{
+			desc:       "type mismatch percent",
+			definition: percentDefinition,
+			scoreValue: false,
+		},
+		{
+			desc:       "type mismatch boolean",
+			definition: booleanDefinition,
+			scoreValue: int64(1),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, gotErr := processScoreType(test.definition, test.scoreValue, "projects/score-type-test/locations/global")
+			if gotErr == nil {
+				t.Errorf("processScoreType(%v, %v, %s) did not return an error", test.definition, test.scoreValue, "projects/score-type-test/locations/global")
+			}
+		})
+	}
+}
+
+func TestUploadScoreConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "upload-score-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "upload-score-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	spec := &rpc.ApiSpec{
+		Name: "projects/upload-score-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+	}
+	if err := seeder.SeedSpecs(ctx, client, spec); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	opts := Options{CheckConcurrentUpdates: true}
+	resource := patterns.SpecResource{Spec: spec}
+	score := &rpc.Score{Id: "score-lint-error", Kind: "Score"}
+	scoreName := spec.GetName() + "/artifacts/score-lint-error"
+
+	// The first writer creates the score artifact.
+	if written, err := uploadScore(ctx, artifactClient, resource, score, nil, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if !written {
+		t.Errorf("uploadScore() didn't write a new score")
+	}
+	first, err := getArtifact(ctx, artifactClient, scoreName, false)
+	if err != nil {
+		t.Fatalf("Failed to get score artifact: %s", err)
+	}
+
+	// A writer that started before the artifact existed must not overwrite it.
+	if _, err := uploadScore(ctx, artifactClient, resource, score, nil, "", opts); !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("uploadScore() returned %v, want %v", err, ErrConcurrentUpdate)
+	}
+
+	// An unchanged score isn't written again.
+	if written, err := uploadScore(ctx, artifactClient, resource, score, first, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if written {
+		t.Errorf("uploadScore() wrote an unchanged score")
+	}
+
+	// A writer that read the current artifact can replace it.
+	changed := &rpc.Score{Id: "score-lint-error", Kind: "Score", Severity: rpc.Severity_ALERT}
+	if written, err := uploadScore(ctx, artifactClient, resource, changed, first, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if !written {
+		t.Errorf("uploadScore() didn't write a changed score")
+	}
+
+	// The first artifact is now stale.
+	if _, err := uploadScore(ctx, artifactClient, resource, score, first, "", opts); !errors.Is(err, ErrConcurrentUpdate) {
+		t.Errorf("uploadScore() with stale artifact returned %v, want %v", err, ErrConcurrentUpdate)
+	}
+
+	// Clients that don't check for concurrent updates overwrite it.
+	opts.CheckConcurrentUpdates = false
+	warning := &rpc.Score{Id: "score-lint-error", Kind: "Score", Severity: rpc.Severity_WARNING}
+	if written, err := uploadScore(ctx, artifactClient, resource, warning, first, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if !written {
+		t.Errorf("uploadScore() didn't overwrite a stale artifact")
+	}
+}
+
+func TestUploadScoreSeverityChangesOnly(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "upload-severity-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "upload-severity-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	spec := &rpc.ApiSpec{
+		Name: "projects/upload-severity-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+	}
+	if err := seeder.SeedSpecs(ctx, client, spec); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	opts := Options{SeverityChangesOnly: true}
+	resource := patterns.SpecResource{Spec: spec}
+	scoreName := spec.GetName() + "/artifacts/score-lint-error"
+	score := func(value int32, severity rpc.Severity) *rpc.Score {
+		return &rpc.Score{
+			Id:       "score-lint-error",
+			Kind:     "Score",
+			Severity: severity,
+			Value: &rpc.Score_IntegerValue{
+				IntegerValue: &rpc.IntegerValue{Value: value, MinValue: 0, MaxValue: 100},
+			},
+		}
+	}
+
+	if written, err := uploadScore(ctx, artifactClient, resource, score(60, rpc.Severity_ALERT), nil, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if !written {
+		t.Errorf("uploadScore() didn't write a new score")
+	}
+	first, err := getArtifact(ctx, artifactClient, scoreName, true)
+	if err != nil {
+		t.Fatalf("Failed to get score artifact: %s", err)
+	}
+
+	// A changed value with the same severity isn't written.
+	if written, err := uploadScore(ctx, artifactClient, resource, score(62, rpc.Severity_ALERT), first, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if written {
+		t.Errorf("uploadScore() wrote a score with an unchanged severity")
+	}
+	if computed := newComputedScore(score(62, rpc.Severity_ALERT), first); !computed.Changed || computed.SeverityChanged {
+		t.Errorf("newComputedScore() returned Changed %t and SeverityChanged %t, want true and false", computed.Changed, computed.SeverityChanged)
+	}
+
+	// A changed severity is written.
+	if written, err := uploadScore(ctx, artifactClient, resource, score(80, rpc.Severity_WARNING), first, "", opts); err != nil {
+		t.Fatalf("uploadScore() returned error: %s", err)
+	} else if !written {
+		t.Errorf("uploadScore() didn't write a score with a changed severity")
+	}
+	if computed := newComputedScore(score(80, rpc.Severity_WARNING), first); !computed.SeverityChanged {
+		t.Errorf("newComputedScore() returned SeverityChanged false, want true")
+	}
+}
+
+func TestScoresEqual(t *testing.T) {
+	score := &rpc.Score{
+		Id:             "score-lint-error",
+		Kind:           "Score",
+		DisplayName:    "Lint Error",
+		DefinitionName: "projects/demo/locations/global/artifacts/lint-error",
+		Severity:       rpc.Severity_OK,
+		SeverityLabel:  "Healthy",
+		Value: &rpc.Score_IntegerValue{
+			IntegerValue: &rpc.IntegerValue{Value: 1, MinValue: 0, MaxValue: 10},
+		},
+	}
+	tests := []struct {
+		desc   string
+		modify func(*rpc.Score)
+		want   bool
+	}{
+		{
+			desc:   "same score",
+			modify: func(s *rpc.Score) {},
+			want:   true,
+		},
+		{
+			desc:   "different id",
+			modify: func(s *rpc.Score) { s.Id = "other" },
+			want:   true,
+		},
+		{
+			desc:   "different definition",
+			modify: func(s *rpc.Score) { s.DefinitionName = "projects/demo/locations/global/artifacts/other" },
+			want:   true,
+		},
+		{
+			desc:   "different value",
+			modify: func(s *rpc.Score) { s.GetIntegerValue().Value = 2 },
+			want:   false,
+		},
+		{
+			desc:   "different value type",
+			modify: func(s *rpc.Score) { s.Value = &rpc.Score_PercentValue{PercentValue: &rpc.PercentValue{Value: 1}} },
+			want:   false,
+		},
+		{
+			desc:   "different severity",
+			modify: func(s *rpc.Score) { s.Severity = rpc.Severity_WARNING },
+			want:   false,
+		},
+		{
+			desc:   "different severity label",
+			modify: func(s *rpc.Score) { s.SeverityLabel = "OK" },
+			want:   false,
+		},
+		{
+			desc:   "different display name",
+			modify: func(s *rpc.Score) { s.DisplayName = "Lint Errors" },
+			want:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			other := proto.Clone(score).(*rpc.Score)
+			test.modify(other)
+			if got := ScoresEqual(score, other); got != test.want {
+				t.Errorf("ScoresEqual() returned %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProcessScoreTypeSeverityDisplay(t *testing.T) {
+	definition := proto.Clone(integerDefinition).(*rpc.ScoreDefinition)
+	definition.SeverityDisplays = []*rpc.SeverityDisplay{
+		{Severity: rpc.Severity_OK, Label: "Healthy", Color: "green"},
+		{Severity: rpc.Severity_WARNING, Color: "yellow"},
+	}
+
+	tests := []struct {
+		desc       string
+		scoreValue interface{}
+		wantLabel  string
+		wantColor  string
+	}{
+		{
+			desc:       "label and color",
+			scoreValue: int64(1),
+			wantLabel:  "Healthy",
+			wantColor:  "green",
+		},
+		{
+			desc:       "color only",
+			scoreValue: int64(5),
+			wantLabel:  "WARNING",
+			wantColor:  "yellow",
+		},
+		{
+			desc:       "no display",
+			scoreValue: int64(11),
+			wantLabel:  "ALERT",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			gotScore, err := processScoreType(definition, test.scoreValue, "projects/score-type-test/locations/global")
+			if err != nil {
+				t.Fatalf("processScoreType() returned unexpected error: %s", err)
+			}
+			if gotScore.GetSeverityLabel() != test.wantLabel || gotScore.GetSeverityColor() != test.wantColor {
+				t.Errorf("processScoreType() returned label %q and color %q, want %q and %q",
+					gotScore.GetSeverityLabel(), gotScore.GetSeverityColor(), test.wantLabel, test.wantColor)
+			}
+		})
+	}
+
+	// Definitions without displays fall back to the name of the severity level.
+	gotScore, err := processScoreType(integerDefinition, int64(5), "projects/score-type-test/locations/global")
+	if err != nil {
+		t.Fatalf("processScoreType() returned unexpected error: %s", err)
+	}
+	if gotScore.GetSeverityLabel() != "WARNING" || gotScore.GetSeverityColor() != "" {
+		t.Errorf("processScoreType() without displays returned label %q and color %q, want %q and %q",
+			gotScore.GetSeverityLabel(), gotScore.GetSeverityColor(), "WARNING", "")
+	}
+}
+
+func TestFetchScoreDefinitions(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "fetch-definitions-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "fetch-definitions-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	if err := seeder.SeedRegistry(ctx, client,
+		&rpc.Artifact{
+			Name:     "projects/fetch-definitions-test/locations/global/artifacts/lint-error",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition",
+			Contents: protoMarshal(integerDefinition),
+		},
+		&rpc.Artifact{
+			Name:     "projects/fetch-definitions-test/locations/global/artifacts/malformed",
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition",
+			Contents: []byte{0xff, 0xff, 0xff},
 		},
-		{
-			desc:       "happy path percent with integer value",
-			definition: percentDefinition,
-			scoreValue: int64(50),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error-percent",
-				Kind:           "Score",
-				DisplayName:    "Lint Error Percentage",
-				Description:    "Percentage errors found by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
-				Severity:       rpc.Severity_WARNING,
-				Value: &rpc.Score_PercentValue{
-					PercentValue: &rpc.PercentValue{
-						Value: 50,
-					},
-				},
-			},
+		&rpc.Artifact{
+			Name:     "projects/fetch-definitions-test/locations/global/artifacts/other",
+			MimeType: "text/plain",
+			Contents: []byte("not a definition"),
 		},
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	got, err := FetchScoreDefinitions(ctx, artifactClient, "projects/fetch-definitions-test")
+	if err != nil {
+		t.Fatalf("FetchScoreDefinitions() returned error: %s", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("FetchScoreDefinitions() returned %d definitions, want 1", len(got))
+	}
+	if got[0].GetName() != "projects/fetch-definitions-test/locations/global/artifacts/lint-error" {
+		t.Errorf("FetchScoreDefinitions() returned unexpected definition %q", got[0].GetName())
+	}
+	definition := &rpc.ScoreDefinition{}
+	if err := proto.Unmarshal(got[0].GetContents(), definition); err != nil {
+		t.Fatalf("Failed to unmarshal definition contents: %s", err)
+	}
+	if diff := cmp.Diff(integerDefinition, definition, protocmp.Transform()); diff != "" {
+		t.Errorf("FetchScoreDefinitions() returned unexpected contents (-want +got):\n%s", diff)
+	}
+}
+
+func TestDefinitionsForResource(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "definitions-for-resource-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "definitions-for-resource-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	definition := func(id, pattern string) seeder.RegistryResource {
+		return &rpc.Artifact{
+			Name:     "projects/definitions-for-resource-test/locations/global/artifacts/" + id,
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition",
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id:             id,
+				TargetResource: &rpc.ResourcePattern{Pattern: pattern},
+			}),
+		}
+	}
+	if err := seeder.SeedRegistry(ctx, client,
+		definition("all-specs", "apis/-/versions/-/specs/-"),
+		definition("petstore-specs", "apis/petstore/versions/-/specs/-"),
+		definition("other-specs", "apis/other/versions/-/specs/-"),
+		definition("all-versions", "apis/-/versions/-"),
+	); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	tests := []struct {
+		desc     string
+		resource patterns.ResourceInstance
+		want     []string
+	}{
 		{
-			desc:       "greater than max percent",
-			definition: percentDefinition,
-			scoreValue: int64(101),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error-percent",
-				Kind:           "Score",
-				DisplayName:    "Lint Error Percentage",
-				Description:    "Percentage errors found by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
-				Severity:       rpc.Severity_ALERT,
-				Value: &rpc.Score_PercentValue{
-					PercentValue: &rpc.PercentValue{
-						Value: 101,
-					},
-				},
-			},
+			desc: "spec",
+			resource: patterns.SpecResource{Spec: &rpc.ApiSpec{
+				Name: "projects/definitions-for-resource-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+			}},
+			want: []string{"all-specs", "petstore-specs"},
 		},
 		{
-			desc:       "less than min percent",
-			definition: percentDefinition,
-			scoreValue: int64(-1),
-			wantScore: &rpc.Score{
-				Id:             "score-lint-error-percent",
-				Kind:           "Score",
-				DisplayName:    "Lint Error Percentage",
-				Description:    "Percentage errors found by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-error-percent",
-				Severity:       rpc.Severity_ALERT,
-				Value: &rpc.Score_PercentValue{
-					PercentValue: &rpc.PercentValue{
-						Value: -1,
-					},
-				},
-			},
+			desc: "version",
+			resource: patterns.VersionResource{Version: &rpc.ApiVersion{
+				Name: "projects/definitions-for-resource-test/locations/global/apis/petstore/versions/1.0.0",
+			}},
+			want: []string{"all-versions"},
 		},
 		{
-			desc:       "happy path boolean",
-			definition: booleanDefinition,
-			scoreValue: true,
-			wantScore: &rpc.Score{
-				Id:             "score-lint-approval",
-				Kind:           "Score",
-				DisplayName:    "Lint Approval",
-				Description:    "Approval by linter",
-				Uri:            "http://some/test/uri",
-				UriDisplayName: "Test URI",
-				DefinitionName: "projects/score-type-test/locations/global/artifacts/lint-approval",
-				Severity:       rpc.Severity_OK,
-				Value: &rpc.Score_BooleanValue{
-					BooleanValue: &rpc.BooleanValue{
-						Value:        true,
-						DisplayValue: "Approved",
-					},
-				},
-			},
+			desc: "api",
+			resource: patterns.ApiResource{Api: &rpc.Api{
+				Name: "projects/definitions-for-resource-test/locations/global/apis/petstore",
+			}},
+			want: []string{},
 		},
 	}
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			gotScore, gotErr := processScoreType(test.definition, test.scoreValue, "projects/score-type-test/locations/global")
-			if gotErr != nil {
-				t.Errorf("processScoreType() returned unexpected error: %s", gotErr)
+			definitions, err := DefinitionsForResource(ctx, artifactClient, test.resource)
+			if err != nil {
+				t.Fatalf("DefinitionsForResource() returned error: %s", err)
 			}
-
-			opts := cmp.Options{protocmp.Transform()}
-			if !cmp.Equal(test.wantScore, gotScore, opts) {
-				t.Errorf("processScoreType() returned unexpected response (-want +got):\n%s", cmp.Diff(test.wantScore, gotScore, opts))
+			got := make([]string, len(definitions))
+			for i, d := range definitions {
+				got[i] = d.GetId()
+			}
+			if diff := cmp.Diff(test.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("DefinitionsForResource() returned unexpected definitions (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestProcessScoreTypeError(t *testing.T) {
+func TestCalculateScoresPool(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "score-pool-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "score-pool-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	definitionName := "projects/score-pool-test/locations/global/artifacts/lint-error"
+	seed := []seeder.RegistryResource{
+		&rpc.ApiSpec{
+			Name: "projects/score-pool-test/locations/global/apis/petstore/versions/1.0.0/specs/missing-lint.yaml",
+		},
+		&rpc.Artifact{
+			Name:     definitionName,
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.scoring.ScoreDefinition",
+			Contents: protoMarshal(&rpc.ScoreDefinition{
+				Id: "lint-error",
+				TargetResource: &rpc.ResourcePattern{
+					Pattern: "apis/-/versions/-/specs/-",
+				},
+				Formula: &rpc.ScoreDefinition_ScoreFormula{
+					ScoreFormula: &rpc.ScoreFormula{
+						Artifact: &rpc.ResourcePattern{
+							Pattern: "$resource.spec/artifacts/lint-spectral",
+						},
+						ScoreExpression: "size(files[0].problems)",
+					},
+				},
+				Type: &rpc.ScoreDefinition_Integer{
+					Integer: &rpc.IntegerType{
+						MinValue: 0,
+						MaxValue: 10,
+					},
+				},
+			}),
+		},
+	}
+	const numSpecs = 6
+	for i := 0; i < numSpecs; i++ {
+		problems := make([]*rpc.LintProblem, i+1)
+		for j := range problems {
+			problems[j] = &rpc.LintProblem{Message: "lint-error"}
+		}
+		seed = append(seed, &rpc.Artifact{
+			Name:     fmt.Sprintf("projects/score-pool-test/locations/global/apis/petstore/versions/1.0.0/specs/spec-%d.yaml/artifacts/lint-spectral", i),
+			MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents: protoMarshal(&rpc.Lint{
+				Name:  "openapi.yaml",
+				Files: []*rpc.LintFile{{FilePath: "openapi.yaml", Problems: problems}},
+			}),
+		})
+	}
+	if err := seeder.SeedRegistry(ctx, client, seed...); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	resources, err := patterns.ListResources(ctx, registryClient, "projects/score-pool-test/locations/global/apis/-/versions/-/specs/-", "")
+	if err != nil {
+		t.Fatalf("Setup: failed to list specs: %s", err)
+	}
+	// Duplicates are only scored once.
+	resources = append(resources, resources...)
+
+	defArtifact, err := getArtifact(ctx, &RegistryArtifactClient{RegistryClient: registryClient}, definitionName, true)
+	if err != nil {
+		t.Fatalf("Setup: failed to get definition: %s", err)
+	}
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	got, err := CalculateScoresPool(ctx, artifactClient, defArtifact, resources, 3, false)
+
+	var failed ScoreErrors
+	if !errors.As(err, &failed) {
+		t.Fatalf("CalculateScoresPool() returned error %v, want ScoreErrors", err)
+	}
+	// Resource names include the revision IDs of specs.
+	wantFailed := "projects/score-pool-test/locations/global/apis/petstore/versions/1.0.0/specs/missing-lint.yaml@"
+	if len(failed) != 1 || !strings.HasPrefix(failed[0].Resource, wantFailed) {
+		t.Errorf("CalculateScoresPool() returned errors for %v, want only %q", failed, wantFailed)
+	}
+
+	if len(got) != numSpecs {
+		t.Fatalf("CalculateScoresPool() returned %d scores, want %d", len(got), numSpecs)
+	}
+	for _, s := range got {
+		if !s.Written {
+			t.Errorf("CalculateScoresPool() didn't save score %+v", s.Score)
+		}
+	}
+	for i := 0; i < numSpecs; i++ {
+		name := fmt.Sprintf("projects/score-pool-test/locations/global/apis/petstore/versions/1.0.0/specs/spec-%d.yaml/artifacts/score-lint-error", i)
+		artifact, err := getArtifact(ctx, artifactClient, name, true)
+		if err != nil {
+			t.Fatalf("Failed to get score %q: %s", name, err)
+		}
+		score := &rpc.Score{}
+		if err := proto.Unmarshal(artifact.GetContents(), score); err != nil {
+			t.Fatalf("Failed to unmarshal score %q: %s", name, err)
+		}
+		if v := score.GetIntegerValue().GetValue(); v != int32(i+1) {
+			t.Errorf("Score %q has value %d, want %d", name, v, i+1)
+		}
+	}
+}
+
+func TestUploadScoreDefinitionHash(t *testing.T) {
+	ctx := context.Background()
+	registryClient, err := connection.NewRegistryClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { registryClient.Close() })
+
+	adminClient, err := connection.NewAdminClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create client: %+v", err)
+	}
+	t.Cleanup(func() { adminClient.Close() })
+
+	deleteProject(ctx, adminClient, t, "definition-hash-test")
+	t.Cleanup(func() { deleteProject(ctx, adminClient, t, "definition-hash-test") })
+
+	client := seeder.Client{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}
+	spec := &rpc.ApiSpec{
+		Name: "projects/definition-hash-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml",
+	}
+	if err := seeder.SeedSpecs(ctx, client, spec); err != nil {
+		t.Fatalf("Setup: failed to seed registry: %s", err)
+	}
+
+	artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
+	resource := patterns.SpecResource{Spec: spec}
+	score := &rpc.Score{Id: "score-lint-error", Kind: "Score"}
+	scoreName := spec.GetName() + "/artifacts/score-lint-error"
+
+	// Unchanged scores are only written again if their definition changed.
+	var existing *rpc.Artifact
+	for _, test := range []struct {
+		hash        string
+		wantWritten bool
+	}{
+		{hash: "h1", wantWritten: true},
+		{hash: "h1", wantWritten: false},
+		{hash: "h2", wantWritten: true},
+	} {
+		written, err := uploadScore(ctx, artifactClient, resource, score, existing, test.hash, Options{})
+		if err != nil {
+			t.Fatalf("uploadScore() returned error: %s", err)
+		}
+		if written != test.wantWritten {
+			t.Errorf("uploadScore() with hash %q returned %t, want %t", test.hash, written, test.wantWritten)
+		}
+		existing, err = getArtifact(ctx, artifactClient, scoreName, false)
+		if err != nil {
+			t.Fatalf("Failed to get score artifact: %s", err)
+		}
+		if got := existing.GetAnnotations()[DefinitionHashAnnotation]; got != test.hash {
+			t.Errorf("Score has definition hash %q, want %q", got, test.hash)
+		}
+	}
+}
+
+func TestUpdateThreshold(t *testing.T) {
 	tests := []struct {
-		desc       string
-		definition *rpc.ScoreDefinition
-		scoreValue interface{}
+		desc string
+		opts Options
+		want time.Duration
 	}{
 		{
-			desc:       "type mismatch integer",
-			definition: integerDefinition,
-			scoreValue: true,
+			desc: "default",
+			want: patterns.ResourceUpdateThreshold,
 		},
 		{
-			desc:       "type mismatch percent",
-			definition: percentDefinition,
-			scoreValue: false,
+			desc: "configured",
+			opts: Options{UpdateThreshold: 10 * time.Second},
+			want: 10 * time.Second,
 		},
 		{
-			desc:       "type mismatch boolean",
-			definition: booleanDefinition,
-			scoreValue: int64(1),
+			desc: "negative",
+			opts: Options{UpdateThreshold: -time.Second},
+			want: patterns.ResourceUpdateThreshold,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			_, gotErr := processScoreType(test.definition, test.scoreValue, "projects/score-type-test/locations/global")
-			if gotErr == nil {
-				t.Errorf("processScoreType(%v, %v, %s) did not return an error", test.definition, test.scoreValue, "projects/score-type-test/locations/global")
+			if got := test.opts.updateThreshold(); got != test.want {
+				t.Errorf("updateThreshold() returned %s, want %s", got, test.want)
 			}
 		})
 	}
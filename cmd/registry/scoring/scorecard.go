@@ -17,6 +17,7 @@ package scoring
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/apigee/registry/cmd/registry/core"
 	"github.com/apigee/registry/cmd/registry/patch"
@@ -39,7 +40,7 @@ func FetchScoreCardDefinitions(
 	project string) ([]*rpc.Artifact, error) {
 	defArtifacts := make([]*rpc.Artifact, 0)
 
-	artifact, err := names.ParseArtifact(fmt.Sprintf("%s/locations/global/artifacts/-", project))
+	artifact, err := names.ParseArtifact(fmt.Sprintf("%s/artifacts/-", patterns.ProjectLocation(project)))
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +71,18 @@ func CalculateScoreCard(
 	defArtifact *rpc.Artifact,
 	resource patterns.ResourceInstance,
 	dryRun bool) error {
-	project := fmt.Sprintf("%s/locations/global", resource.ResourceName().Project())
+	return CalculateScoreCardWithOptions(ctx, client, defArtifact, resource, dryRun, Options{})
+}
+
+// CalculateScoreCardWithOptions is like CalculateScoreCard, but computes and saves the score card with opts.
+func CalculateScoreCardWithOptions(
+	ctx context.Context,
+	client artifactClient,
+	defArtifact *rpc.Artifact,
+	resource patterns.ResourceInstance,
+	dryRun bool,
+	opts Options) error {
+	project := patterns.ProjectLocation(resource.ResourceName().Project())
 
 	// Extract definition
 	definition := &rpc.ScoreCardDefinition{}
@@ -94,11 +106,11 @@ func CalculateScoreCard(
 
 	// Generate ScoreCard if the definition has been updated
 	// This condition is required to avoid the scenario mentioned here: https://github.com/apigee/registry/issues/641
-	if scoreCardArtifact != nil && defArtifact.GetUpdateTime().AsTime().Add(patterns.ResourceUpdateThreshold).After(scoreCardArtifact.GetUpdateTime().AsTime()) {
+	if scoreCardArtifact != nil && defArtifact.GetUpdateTime().AsTime().Add(opts.updateThreshold()).After(scoreCardArtifact.GetUpdateTime().AsTime()) {
 		takeAction = true
 	}
 
-	result := processScorePatterns(ctx, client, definition, resource, scoreCardArtifact, takeAction, project)
+	result := processScorePatterns(ctx, client, definition, resource, scoreCardArtifact, takeAction, opts.updateThreshold(), project)
 	if result.err != nil {
 		return result.err
 	}
@@ -134,7 +146,17 @@ func processScorePatterns(
 	resource patterns.ResourceInstance,
 	scoreCardArtifact *rpc.Artifact,
 	takeAction bool,
+	threshold time.Duration,
 	project string) scoreCardResult {
+	aggregator, err := newSeverityAggregator(definition.GetScorePatterns(), definition.GetSeverityAggregation())
+	if err != nil {
+		return scoreCardResult{
+			scoreCard:   nil,
+			needsUpdate: false,
+			err:         err,
+		}
+	}
+
 	var needsUpdate bool
 	scoreArtifacts := make([]*rpc.Score, 0)
 
@@ -160,7 +182,7 @@ func processScorePatterns(
 
 		// needsUpdate tells the calling function if the ScoreCard artifact needs to be updated
 		// This condition is required to avoid the scenario mentioned here: https://github.com/apigee/registry/issues/641
-		needsUpdate = needsUpdate || takeAction || artifact.GetUpdateTime().AsTime().Add(patterns.ResourceUpdateThreshold).After(scoreCardArtifact.GetUpdateTime().AsTime())
+		needsUpdate = needsUpdate || takeAction || artifact.GetUpdateTime().AsTime().Add(threshold).After(scoreCardArtifact.GetUpdateTime().AsTime())
 		// Extract Score from the fetched artifact
 		score := &rpc.Score{}
 		if err := proto.Unmarshal(artifact.GetContents(), score); err != nil {
@@ -183,6 +205,7 @@ func processScorePatterns(
 			Description:    definition.GetDescription(),
 			DefinitionName: fmt.Sprintf("%s/artifacts/%s", project, definition.GetId()),
 			Scores:         scoreArtifacts,
+			Severity:       aggregator.aggregate(definition.GetScorePatterns(), scoreArtifacts),
 		}
 
 		return scoreCardResult{
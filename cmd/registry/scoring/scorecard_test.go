@@ -118,6 +118,7 @@ func TestCalculateScoreCard(t *testing.T) {
 						},
 					},
 				},
+				Severity: rpc.Severity_ALERT,
 			},
 		},
 		{
@@ -240,6 +241,7 @@ func TestCalculateScoreCard(t *testing.T) {
 						},
 					},
 				},
+				Severity: rpc.Severity_ALERT,
 			},
 		},
 		{
@@ -362,6 +364,7 @@ func TestCalculateScoreCard(t *testing.T) {
 						},
 					},
 				},
+				Severity: rpc.Severity_ALERT,
 			},
 		},
 		{
@@ -484,6 +487,7 @@ func TestCalculateScoreCard(t *testing.T) {
 						},
 					},
 				},
+				Severity: rpc.Severity_ALERT,
 			},
 		},
 	}
@@ -676,6 +680,7 @@ func TestProcessScorePatterns(t *testing.T) {
 							},
 						},
 					},
+					Severity: rpc.Severity_ALERT,
 				},
 				needsUpdate: true,
 				err:         nil,
@@ -790,6 +795,7 @@ func TestProcessScorePatterns(t *testing.T) {
 							},
 						},
 					},
+					Severity: rpc.Severity_ALERT,
 				},
 				needsUpdate: true,
 				err:         nil,
@@ -904,6 +910,7 @@ func TestProcessScorePatterns(t *testing.T) {
 							},
 						},
 					},
+					Severity: rpc.Severity_ALERT,
 				},
 				needsUpdate: true,
 				err:         nil,
@@ -1018,6 +1025,7 @@ func TestProcessScorePatterns(t *testing.T) {
 							},
 						},
 					},
+					Severity: rpc.Severity_ALERT,
 				},
 				needsUpdate: true,
 				err:         nil,
@@ -1074,7 +1082,7 @@ func TestProcessScorePatterns(t *testing.T) {
 				t.Errorf("failed to fetch the scoreCardArtifact from setup: %s", err)
 			}
 
-			gotResult := processScorePatterns(ctx, artifactClient, definition, test.resource, scoreCardArtifact, test.takeAction, "projects/score-patterns-test/locations/global")
+			gotResult := processScorePatterns(ctx, artifactClient, definition, test.resource, scoreCardArtifact, test.takeAction, patterns.ResourceUpdateThreshold, "projects/score-patterns-test/locations/global")
 
 			opts := cmp.Options{
 				cmp.AllowUnexported(scoreCardResult{}),
@@ -1221,7 +1229,7 @@ func TestProcessScorePatternsError(t *testing.T) {
 
 			artifactClient := &RegistryArtifactClient{RegistryClient: registryClient}
 
-			gotResult := processScorePatterns(ctx, artifactClient, test.definition, resource, &rpc.Artifact{}, test.takeAction, "projects/score-patterns-test/locations/global")
+			gotResult := processScorePatterns(ctx, artifactClient, test.definition, resource, &rpc.Artifact{}, test.takeAction, patterns.ResourceUpdateThreshold, "projects/score-patterns-test/locations/global")
 
 			if gotResult.err == nil {
 				t.Errorf("processScorePatterns(ctx, client, %v, %v) did not return an error", test.definition, resource)
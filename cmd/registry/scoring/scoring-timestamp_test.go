@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -68,6 +70,22 @@ func (f *fakeArtifactClient) ListArtifacts(ctx context.Context, artifact names.A
 	return nil
 }
 
+func (f *fakeArtifactClient) ListArtifactsLazily(ctx context.Context, artifact names.Artifact, filter string, handler core.LazyArtifactHandler) error {
+	return f.ListArtifacts(ctx, artifact, filter, false, func(a *rpc.Artifact) error {
+		return handler(core.NewLazyArtifact(a, func() ([]byte, error) {
+			return a.GetContents(), nil
+		}))
+	})
+}
+
+func (f *fakeArtifactClient) GetArtifactLazily(ctx context.Context, artifact names.Artifact, handler core.LazyArtifactHandler) error {
+	return f.GetArtifact(ctx, artifact, false, func(a *rpc.Artifact) error {
+		return handler(core.NewLazyArtifact(a, func() ([]byte, error) {
+			return a.GetContents(), nil
+		}))
+	})
+}
+
 // These functions are needed to use the fakeLister with the seeder package.
 func (f *fakeArtifactClient) CreateProject(ctx context.Context, req *rpc.CreateProjectRequest) (*rpc.Project, error) {
 	project := &rpc.Project{
@@ -259,6 +277,7 @@ func TestTimestampCalculateScore(t *testing.T) {
 				Kind:           "Score",
 				DefinitionName: "projects/score-formula-test/locations/global/artifacts/lint-error",
 				Severity:       rpc.Severity_SEVERITY_UNSPECIFIED,
+				SeverityLabel:  "SEVERITY_UNSPECIFIED",
 				Value: &rpc.Score_IntegerValue{
 					IntegerValue: &rpc.IntegerValue{
 						Value:    1,
@@ -291,7 +310,7 @@ func TestTimestampCalculateScore(t *testing.T) {
 				t.Errorf("failed to fetch the definition Artifact from setup: %s", err)
 			}
 
-			gotErr := CalculateScore(ctx, client, defArtifact, resource, false)
+			_, gotErr := CalculateScore(ctx, client, defArtifact, resource, false)
 			if gotErr != nil {
 				t.Errorf("CalculateScore(ctx, client, %v, %v) returned unexpected error: %s", defArtifact, resource, gotErr)
 			}
@@ -596,7 +615,7 @@ func TestProcessScoreFormulaTimestamp(t *testing.T) {
 				ScoreExpression: "size(files[0].problems)",
 			}
 
-			gotResult := processScoreFormula(ctx, client, formula, test.resource, scoreArtifact, test.takeAction)
+			gotResult := processScoreFormula(ctx, client, formula, test.resource, scoreArtifact, test.takeAction, patterns.ResourceUpdateThreshold)
 
 			opts := cmp.AllowUnexported(scoreResult{})
 			if !cmp.Equal(test.wantResult, gotResult, opts) {
@@ -606,6 +625,301 @@ func TestProcessScoreFormulaTimestamp(t *testing.T) {
 	}
 }
 
+// contentsCountingArtifactClient counts the artifacts whose contents are fetched,
+// either lazily or with GetArtifact.
+type contentsCountingArtifactClient struct {
+	artifactClient
+	mu      sync.Mutex
+	fetches map[string]int
+}
+
+func (c *contentsCountingArtifactClient) count(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetches[name]++
+}
+
+func (c *contentsCountingArtifactClient) GetArtifact(ctx context.Context, artifact names.Artifact, getContents bool, handler core.ArtifactHandler) error {
+	if getContents {
+		c.count(artifact.String())
+	}
+	return c.artifactClient.GetArtifact(ctx, artifact, getContents, handler)
+}
+
+func (c *contentsCountingArtifactClient) GetArtifactLazily(ctx context.Context, artifact names.Artifact, handler core.LazyArtifactHandler) error {
+	return c.artifactClient.GetArtifactLazily(ctx, artifact, func(a *core.LazyArtifact) error {
+		return handler(core.NewLazyArtifact(a.Artifact, func() ([]byte, error) {
+			c.count(a.GetName())
+			return a.Fetch()
+		}))
+	})
+}
+
+func TestProcessFormulaFetchesContentsTimestamp(t *testing.T) {
+	const (
+		specName  = "projects/score-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+		lintName  = specName + "/artifacts/lint-spectral"
+		scoreName = specName + "/artifacts/score-lint-error"
+	)
+	scoreFormula := &rpc.ScoreFormula{
+		Artifact: &rpc.ResourcePattern{
+			Pattern: "$resource.spec/artifacts/lint-spectral",
+		},
+		ScoreExpression: "size(files[0].problems)",
+		ReferenceId:     "lint",
+	}
+	definitions := []struct {
+		desc       string
+		definition *rpc.ScoreDefinition
+	}{
+		{
+			desc: "score_formula",
+			definition: &rpc.ScoreDefinition{
+				Formula: &rpc.ScoreDefinition_ScoreFormula{ScoreFormula: scoreFormula},
+			},
+		},
+		{
+			desc: "rollup_formula",
+			definition: &rpc.ScoreDefinition{
+				Formula: &rpc.ScoreDefinition_RollupFormula{
+					RollupFormula: &rpc.RollUpFormula{
+						ScoreFormulas:    []*rpc.ScoreFormula{scoreFormula},
+						RollupExpression: "lint",
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		desc        string
+		scoreTime   time.Time
+		wantUpdate  bool
+		wantFetches int
+	}{
+		{
+			desc:        "score is outdated",
+			scoreTime:   time.Now().Add(-time.Minute),
+			wantUpdate:  true,
+			wantFetches: 1,
+		},
+		{
+			desc:        "score is up-to-date",
+			scoreTime:   time.Now().Add(time.Minute),
+			wantUpdate:  false,
+			wantFetches: 0,
+		},
+	}
+
+	for _, d := range definitions {
+		for _, test := range tests {
+			t.Run(d.desc+" "+test.desc, func(t *testing.T) {
+				ctx := context.Background()
+				client := &contentsCountingArtifactClient{
+					artifactClient: &fakeArtifactClient{},
+					fetches:        make(map[string]int),
+				}
+				seed := []seeder.RegistryResource{
+					&rpc.Artifact{
+						Name:     lintName,
+						MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+						Contents: protoMarshal(&rpc.Lint{
+							Name: "openapi.yaml",
+							Files: []*rpc.LintFile{
+								{
+									FilePath: "openapi.yaml",
+									Problems: []*rpc.LintProblem{
+										{
+											Message: "lint-error",
+										},
+									},
+								},
+							},
+						}),
+						UpdateTime: timestamppb.Now(),
+					},
+					&rpc.Artifact{
+						Name:       scoreName,
+						MimeType:   "application/octet-stream;type=google.cloud.apigeeregistry.v1.Score",
+						UpdateTime: timestamppb.New(test.scoreTime),
+					},
+				}
+				if err := seeder.SeedRegistry(ctx, client.artifactClient.(*fakeArtifactClient), seed...); err != nil {
+					t.Fatalf("Setup: failed to seed registry: %s", err)
+				}
+				scoreArtifact, err := getArtifact(ctx, client, scoreName, false)
+				if err != nil {
+					t.Fatalf("failed to fetch the scoreArtifact from setup: %s", err)
+				}
+				resource := patterns.SpecResource{
+					Spec: &rpc.ApiSpec{
+						Name: specName,
+					},
+				}
+
+				result := processFormula(ctx, client, d.definition, resource, scoreArtifact, false, patterns.ResourceUpdateThreshold)
+				if result.err != nil {
+					t.Fatalf("processFormula() returned unexpected error: %s", result.err)
+				}
+				if result.needsUpdate != test.wantUpdate {
+					t.Errorf("processFormula() returned needsUpdate %t, want %t", result.needsUpdate, test.wantUpdate)
+				}
+				if got := client.fetches[lintName]; got != test.wantFetches {
+					t.Errorf("processFormula() fetched the contents of %s %d times, want %d", lintName, got, test.wantFetches)
+				}
+			})
+		}
+	}
+}
+
+func TestProcessRollUpFormulaReusesValuesTimestamp(t *testing.T) {
+	const (
+		specName       = "projects/rollup-formula-test/locations/global/apis/petstore/versions/1.0.0/specs/openapi.yaml"
+		lintName       = specName + "/artifacts/lint-spectral"
+		complexityName = specName + "/artifacts/complexity"
+		scoreName      = specName + "/artifacts/score-lint-error"
+	)
+	formula := &rpc.RollUpFormula{
+		ScoreFormulas: []*rpc.ScoreFormula{
+			{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/lint-spectral",
+				},
+				ScoreExpression: "size(files[0].problems)",
+				ReferenceId:     "numErrors",
+			},
+			{
+				Artifact: &rpc.ResourcePattern{
+					Pattern: "$resource.spec/artifacts/complexity",
+				},
+				ScoreExpression: "getCount + postCount + putCount + deleteCount",
+				ReferenceId:     "numOperations",
+			},
+		},
+		RollupExpression: "double(numErrors)/numOperations",
+	}
+	tests := []struct {
+		desc           string
+		savedValues    map[string]*rpc.ReferenceValue
+		takeAction     bool
+		wantValue      float64
+		wantReferences map[string]interface{}
+		wantFetches    int
+	}{
+		{
+			desc: "unchanged value is reused",
+			savedValues: map[string]*rpc.ReferenceValue{
+				"numErrors":     {Value: &rpc.ReferenceValue_IntegerValue{IntegerValue: 1}},
+				"numOperations": {Value: &rpc.ReferenceValue_DoubleValue{DoubleValue: 8}},
+			},
+			wantValue:      0.25,
+			wantReferences: map[string]interface{}{"numErrors": int64(2), "numOperations": float64(8)},
+			wantFetches:    0,
+		},
+		{
+			desc: "missing value is computed",
+			savedValues: map[string]*rpc.ReferenceValue{
+				"numErrors": {Value: &rpc.ReferenceValue_IntegerValue{IntegerValue: 1}},
+			},
+			wantValue:      0.5,
+			wantReferences: map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
+			wantFetches:    1,
+		},
+		{
+			desc: "takeAction computes all values",
+			savedValues: map[string]*rpc.ReferenceValue{
+				"numErrors":     {Value: &rpc.ReferenceValue_IntegerValue{IntegerValue: 1}},
+				"numOperations": {Value: &rpc.ReferenceValue_DoubleValue{DoubleValue: 8}},
+			},
+			takeAction:     true,
+			wantValue:      0.5,
+			wantReferences: map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
+			wantFetches:    1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			client := &contentsCountingArtifactClient{
+				artifactClient: &fakeArtifactClient{},
+				fetches:        make(map[string]int),
+			}
+			seed := []seeder.RegistryResource{
+				&rpc.Artifact{
+					Name:     lintName,
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+					Contents: protoMarshal(&rpc.Lint{
+						Name: "openapi.yaml",
+						Files: []*rpc.LintFile{
+							{
+								FilePath: "openapi.yaml",
+								Problems: []*rpc.LintProblem{
+									{
+										Message: "lint-error",
+									},
+									{
+										Message: "lint-error",
+									},
+								},
+							},
+						},
+					}),
+					UpdateTime: timestamppb.Now(),
+				},
+				&rpc.Artifact{
+					Name:     complexityName,
+					MimeType: "application/octet-stream;type=gnostic.metrics.Complexity",
+					Contents: protoMarshal(&metrics.Complexity{
+						GetCount:    1,
+						PostCount:   1,
+						PutCount:    1,
+						DeleteCount: 1,
+					}),
+					UpdateTime: timestamppb.New(time.Now().Add(-time.Hour)),
+				},
+				&rpc.Artifact{
+					Name:     scoreName,
+					MimeType: "application/octet-stream;type=google.cloud.apigeeregistry.v1.Score",
+					Contents: protoMarshal(&rpc.Score{
+						Id:              "score-lint-error",
+						ReferenceValues: test.savedValues,
+					}),
+					UpdateTime: timestamppb.New(time.Now().Add(-time.Minute)),
+				},
+			}
+			if err := seeder.SeedRegistry(ctx, client.artifactClient.(*fakeArtifactClient), seed...); err != nil {
+				t.Fatalf("Setup: failed to seed registry: %s", err)
+			}
+			scoreArtifact, err := getArtifact(ctx, client, scoreName, true)
+			if err != nil {
+				t.Fatalf("failed to fetch the scoreArtifact from setup: %s", err)
+			}
+			resource := patterns.SpecResource{
+				Spec: &rpc.ApiSpec{
+					Name: specName,
+				},
+			}
+
+			want := scoreResult{
+				value:       test.wantValue,
+				needsUpdate: true,
+				references:  test.wantReferences,
+			}
+			got := processRollUpFormula(ctx, client, formula, resource, scoreArtifact, test.takeAction, patterns.ResourceUpdateThreshold)
+			opts := cmp.AllowUnexported(scoreResult{})
+			if !cmp.Equal(want, got, opts) {
+				t.Errorf("processRollUpFormula() returned unexpected response, (-want, +got):\n%s", cmp.Diff(want, got, opts))
+			}
+			if got := client.fetches[lintName]; got != 1 {
+				t.Errorf("processRollUpFormula() fetched the contents of %s %d times, want 1", lintName, got)
+			}
+			if got := client.fetches[complexityName]; got != test.wantFetches {
+				t.Errorf("processRollUpFormula() fetched the contents of %s %d times, want %d", complexityName, got, test.wantFetches)
+			}
+		})
+	}
+}
+
 func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 	tests := []struct {
 		desc       string
@@ -665,6 +979,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 		{
@@ -718,6 +1033,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 		{
@@ -774,6 +1090,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 		{
@@ -827,6 +1144,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 		{
@@ -880,6 +1198,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 		{
@@ -992,6 +1311,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				value:       float64(0.5),
 				needsUpdate: true,
 				err:         nil,
+				references:  map[string]interface{}{"numErrors": int64(2), "numOperations": float64(4)},
 			},
 		},
 	}
@@ -1031,7 +1351,7 @@ func TestProcessRollUpFormulaTimestamp(t *testing.T) {
 				RollupExpression: "double(numErrors)/numOperations",
 			}
 
-			gotResult := processRollUpFormula(ctx, client, formula, test.resource, scoreArtifact, test.takeAction)
+			gotResult := processRollUpFormula(ctx, client, formula, test.resource, scoreArtifact, test.takeAction, patterns.ResourceUpdateThreshold)
 
 			opts := cmp.AllowUnexported(scoreResult{})
 			if !cmp.Equal(test.wantResult, gotResult, opts) {
@@ -1248,6 +1568,7 @@ func TestProcessScorePatternsTimestamp(t *testing.T) {
 							},
 						},
 					},
+					Severity: rpc.Severity_ALERT,
 				},
 				needsUpdate: true,
 				err:         nil,
@@ -1284,7 +1605,7 @@ func TestProcessScorePatternsTimestamp(t *testing.T) {
 				t.Errorf("failed to fetch the scoreCardArtifact from setup: %s", err)
 			}
 
-			gotResult := processScorePatterns(ctx, client, definition, test.resource, scoreCardArtifact, test.takeAction, "projects/score-patterns-test/locations/global")
+			gotResult := processScorePatterns(ctx, client, definition, test.resource, scoreCardArtifact, test.takeAction, patterns.ResourceUpdateThreshold, "projects/score-patterns-test/locations/global")
 
 			opts := cmp.Options{
 				cmp.AllowUnexported(scoreCardResult{}),
@@ -1298,3 +1619,237 @@ func TestProcessScorePatternsTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateThresholdBoundary(t *testing.T) {
+	const (
+		definitionName = "projects/threshold-test/locations/global/artifacts/score-lint-error"
+		scoreName      = "projects/threshold-test/locations/global/apis/petstore/artifacts/score-lint-error"
+	)
+	// The score was saved 5s after its definition and its inputs were updated.
+	updated := time.Now().Add(-time.Hour)
+	saved := updated.Add(5 * time.Second)
+
+	tests := []struct {
+		desc      string
+		threshold time.Duration
+		want      bool
+	}{
+		{
+			desc:      "default threshold",
+			threshold: patterns.ResourceUpdateThreshold,
+			want:      false,
+		},
+		{
+			desc:      "inside the window",
+			threshold: 5*time.Second + time.Nanosecond,
+			want:      true,
+		},
+		{
+			desc:      "at the edge of the window",
+			threshold: 5 * time.Second,
+			want:      false,
+		},
+		{
+			desc:      "outside the window",
+			threshold: 5*time.Second - time.Nanosecond,
+			want:      false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctx := context.Background()
+			scoreArtifact := &rpc.Artifact{Name: scoreName, UpdateTime: timestamppb.New(saved)}
+			client := &fakeArtifactClient{artifacts: []*rpc.Artifact{scoreArtifact}}
+			defArtifact := &rpc.Artifact{Name: definitionName, UpdateTime: timestamppb.New(updated)}
+			threshold := Options{UpdateThreshold: test.threshold}.updateThreshold()
+
+			_, takeAction, err := fetchScoreArtifact(ctx, client, defArtifact, scoreName, threshold)
+			if err != nil {
+				t.Fatalf("fetchScoreArtifact() returned error: %s", err)
+			}
+			if takeAction != test.want {
+				t.Errorf("fetchScoreArtifact() returned takeAction=%t, want %t", takeAction, test.want)
+			}
+
+			inputs := formulaInputs{updateTime: updated}
+			if got := inputs.updatedAfter(scoreArtifact, threshold); got != test.want {
+				t.Errorf("updatedAfter() returned %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStalenessWindow(t *testing.T) {
+	tests := []struct {
+		desc   string
+		window *durationpb.Duration
+		want   time.Duration
+	}{
+		{
+			desc: "unset",
+			want: time.Second,
+		},
+		{
+			desc:   "longer",
+			window: durationpb.New(time.Hour),
+			want:   time.Hour,
+		},
+		{
+			desc:   "zero",
+			window: durationpb.New(0),
+			want:   0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			definition := &rpc.ScoreDefinition{StalenessWindow: test.window}
+			if got := stalenessWindow(definition, time.Second); got != test.want {
+				t.Errorf("stalenessWindow() returned %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProcessScoreFormulaResourceVariables(t *testing.T) {
+	const (
+		apiName  = "projects/resource-test/locations/global/apis/petstore"
+		specName = apiName + "/versions/1.0.0/specs/openapi.yaml"
+	)
+	// The lint artifacts were saved before their score, and the spec was updated after it.
+	saved := time.Now().Add(-time.Hour)
+	lint := protoMarshal(&rpc.Lint{Files: []*rpc.LintFile{{FilePath: "openapi.yaml"}}})
+	client := &fakeArtifactClient{artifacts: []*rpc.Artifact{
+		{
+			Name:       apiName + "/artifacts/lint",
+			MimeType:   "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents:   lint,
+			UpdateTime: timestamppb.New(saved.Add(-time.Hour)),
+		},
+		{
+			Name:       specName + "/artifacts/lint",
+			MimeType:   "application/octet-stream;type=google.cloud.apigeeregistry.v1.style.Lint",
+			Contents:   lint,
+			UpdateTime: timestamppb.New(saved.Add(-time.Hour)),
+		},
+	}}
+	spec := patterns.SpecResource{Spec: &rpc.ApiSpec{
+		Name:               specName,
+		MimeType:           "application/x.openapi;version=3",
+		Labels:             map[string]string{"tier": "gold"},
+		RevisionUpdateTime: timestamppb.New(saved.Add(time.Minute)),
+	}}
+	api := patterns.ApiResource{Api: &rpc.Api{
+		Name:         apiName,
+		Availability: "GA",
+		UpdateTime:   timestamppb.New(saved.Add(-time.Hour)),
+	}}
+	scoreArtifact := &rpc.Artifact{UpdateTime: timestamppb.New(saved)}
+
+	tests := []struct {
+		desc       string
+		resource   patterns.ResourceInstance
+		pattern    string
+		expression string
+		want       scoreResult
+		wantErr    bool
+	}{
+		{
+			desc:       "spec fields",
+			resource:   spec,
+			pattern:    "$resource.spec/artifacts/lint",
+			expression: `_resource.mime_type.startsWith("application/x.openapi") && _resource.labels["tier"] == "gold" && size(files) == 1`,
+			want:       scoreResult{value: true, needsUpdate: true},
+		},
+		{
+			desc:       "timestamps",
+			resource:   spec,
+			pattern:    "$resource.spec/artifacts/lint",
+			expression: `_resource.revision_update_time > timestamp("2020-01-01T00:00:00Z")`,
+			want:       scoreResult{value: true, needsUpdate: true},
+		},
+		{
+			desc:       "resource updates are ignored unless referenced",
+			resource:   spec,
+			pattern:    "$resource.spec/artifacts/lint",
+			expression: `size(files) == 1`,
+			want:       scoreResult{value: true, needsUpdate: false},
+		},
+		{
+			desc:       "missing field",
+			resource:   api,
+			pattern:    "$resource.api/artifacts/lint",
+			expression: `_resource.availability == "GA" && _resource.mime_type == ""`,
+			wantErr:    true,
+		},
+		{
+			desc:       "missing field checked with has",
+			resource:   api,
+			pattern:    "$resource.api/artifacts/lint",
+			expression: `_resource.availability == "GA" && !has(_resource.mime_type)`,
+			want:       scoreResult{value: true, needsUpdate: false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			formula := &rpc.ScoreFormula{
+				Artifact:        &rpc.ResourcePattern{Pattern: test.pattern},
+				ScoreExpression: test.expression,
+			}
+			got := processScoreFormula(context.Background(), client, formula, test.resource, scoreArtifact, false, patterns.ResourceUpdateThreshold)
+			if test.wantErr {
+				if got.err == nil {
+					t.Errorf("processScoreFormula() returned %v, want error", got.value)
+				}
+				return
+			}
+			opts := cmp.AllowUnexported(scoreResult{})
+			if !cmp.Equal(test.want, got, opts) {
+				t.Errorf("processScoreFormula() returned unexpected response, (-want, +got):\n%s", cmp.Diff(test.want, got, opts))
+			}
+		})
+	}
+}
+
+func TestDefinitionChanged(t *testing.T) {
+	defArtifact := &rpc.Artifact{
+		Contents:   []byte("definition"),
+		UpdateTime: timestamppb.New(time.Now().Add(-time.Minute)),
+	}
+	hash := definitionHash(defArtifact)
+	before := timestamppb.New(time.Now().Add(-time.Hour))
+	after := timestamppb.New(time.Now())
+
+	tests := []struct {
+		desc  string
+		score *rpc.Artifact
+		want  bool
+	}{
+		{
+			desc:  "same hash, saved before the definition",
+			score: &rpc.Artifact{UpdateTime: before, Annotations: map[string]string{DefinitionHashAnnotation: hash}},
+			want:  false,
+		},
+		{
+			desc:  "other hash, saved after the definition",
+			score: &rpc.Artifact{UpdateTime: after, Annotations: map[string]string{DefinitionHashAnnotation: "other"}},
+			want:  true,
+		},
+		{
+			desc:  "no hash, saved before the definition",
+			score: &rpc.Artifact{UpdateTime: before},
+			want:  true,
+		},
+		{
+			desc:  "no hash, saved after the definition",
+			score: &rpc.Artifact{UpdateTime: after},
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := definitionChanged(defArtifact, test.score, patterns.ResourceUpdateThreshold); got != test.want {
+				t.Errorf("definitionChanged() returned %t, want %t", got, test.want)
+			}
+		})
+	}
+}
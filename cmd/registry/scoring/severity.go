@@ -0,0 +1,129 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apigee/registry/rpc"
+)
+
+// severityAggregator combines the severities of the scores of a ScoreCard
+// into an overall severity.
+type severityAggregator interface {
+	// aggregate returns the overall severity of scores,
+	// where scores[i] was fetched with scorePatterns[i].
+	aggregate(scorePatterns []string, scores []*rpc.Score) rpc.Severity
+}
+
+// newSeverityAggregator returns the aggregator for the strategy of aggregation.
+// If no strategy is set, the highest severity of the scores is used.
+func newSeverityAggregator(scorePatterns []string, aggregation *rpc.SeverityAggregation) (severityAggregator, error) {
+	if errs := validateSeverityAggregation(scorePatterns, aggregation); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	switch strategy := aggregation.GetStrategy().(type) {
+	case *rpc.SeverityAggregation_Weighted:
+		return &weightedSeverityAggregator{
+			threshold: strategy.Weighted.GetThreshold(),
+			weights:   strategy.Weighted.GetWeights(),
+		}, nil
+	default:
+		return &maxSeverityAggregator{}, nil
+	}
+}
+
+// validateSeverityAggregation checks the parameters of the strategy of aggregation.
+func validateSeverityAggregation(scorePatterns []string, aggregation *rpc.SeverityAggregation) []error {
+	errs := make([]error, 0)
+	weighted := aggregation.GetWeighted()
+	if weighted == nil {
+		return errs
+	}
+
+	if threshold := weighted.GetThreshold(); threshold <= 0 || threshold > 1 {
+		errs = append(errs, fmt.Errorf("invalid severity_aggregation.weighted.threshold: %g, it should be greater than 0 and at most 1", threshold))
+	}
+
+	patterns := make(map[string]bool, len(scorePatterns))
+	for _, p := range scorePatterns {
+		patterns[p] = true
+	}
+	keys := make([]string, 0, len(weighted.GetWeights()))
+	for k := range weighted.GetWeights() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !patterns[k] {
+			errs = append(errs, fmt.Errorf("invalid severity_aggregation.weighted.weights key: %q, it should be one of the score_patterns", k))
+		}
+		if w := weighted.GetWeights()[k]; w <= 0 {
+			errs = append(errs, fmt.Errorf("invalid severity_aggregation.weighted.weights value for %q: %g, it should be greater than 0", k, w))
+		}
+	}
+
+	return errs
+}
+
+// maxSeverityAggregator uses the highest severity of any score.
+type maxSeverityAggregator struct{}
+
+func (a *maxSeverityAggregator) aggregate(scorePatterns []string, scores []*rpc.Score) rpc.Severity {
+	severity := rpc.Severity_SEVERITY_UNSPECIFIED
+	for _, s := range scores {
+		if s.GetSeverity() > severity {
+			severity = s.GetSeverity()
+		}
+	}
+	return severity
+}
+
+// weightedSeverityAggregator uses the highest severity that the scores at
+// that severity or above hold at least a threshold fraction of the weight of.
+type weightedSeverityAggregator struct {
+	threshold float32
+	weights   map[string]float32
+}
+
+func (a *weightedSeverityAggregator) aggregate(scorePatterns []string, scores []*rpc.Score) rpc.Severity {
+	// weights[s] is the total weight of the scores with severity s.
+	weights := make(map[rpc.Severity]float32)
+	var total float32
+	for i, s := range scores {
+		if s.GetSeverity() == rpc.Severity_SEVERITY_UNSPECIFIED {
+			continue
+		}
+		w, ok := a.weights[scorePatterns[i]]
+		if !ok {
+			w = 1
+		}
+		weights[s.GetSeverity()] += w
+		total += w
+	}
+	if total == 0 {
+		return rpc.Severity_SEVERITY_UNSPECIFIED
+	}
+
+	var weight float32
+	for _, severity := range []rpc.Severity{rpc.Severity_ALERT, rpc.Severity_WARNING} {
+		weight += weights[severity]
+		if weight/total >= a.threshold {
+			return severity
+		}
+	}
+	return rpc.Severity_OK
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scoring
+
+import (
+	"testing"
+
+	"github.com/apigee/registry/rpc"
+)
+
+func TestSeverityAggregation(t *testing.T) {
+	scorePatterns := []string{
+		"$resource.spec/artifacts/score-a",
+		"$resource.spec/artifacts/score-b",
+		"$resource.spec/artifacts/score-c",
+		"$resource.spec/artifacts/score-d",
+	}
+	scores := func(severities ...rpc.Severity) []*rpc.Score {
+		s := make([]*rpc.Score, len(severities))
+		for i, severity := range severities {
+			s[i] = &rpc.Score{Severity: severity}
+		}
+		return s
+	}
+	weighted := func(threshold float32, weights map[string]float32) *rpc.SeverityAggregation {
+		return &rpc.SeverityAggregation{
+			Strategy: &rpc.SeverityAggregation_Weighted{
+				Weighted: &rpc.WeightedSeverityAggregation{
+					Threshold: threshold,
+					Weights:   weights,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		desc        string
+		aggregation *rpc.SeverityAggregation
+		scores      []*rpc.Score
+		want        rpc.Severity
+	}{
+		{
+			desc:        "default is max",
+			aggregation: nil,
+			scores:      scores(rpc.Severity_OK, rpc.Severity_ALERT, rpc.Severity_OK, rpc.Severity_OK),
+			want:        rpc.Severity_ALERT,
+		},
+		{
+			desc: "max",
+			aggregation: &rpc.SeverityAggregation{
+				Strategy: &rpc.SeverityAggregation_Max{Max: &rpc.MaxSeverityAggregation{}},
+			},
+			scores: scores(rpc.Severity_OK, rpc.Severity_WARNING, rpc.Severity_OK, rpc.Severity_OK),
+			want:   rpc.Severity_WARNING,
+		},
+		{
+			desc:        "max without severities",
+			aggregation: nil,
+			scores:      scores(rpc.Severity_SEVERITY_UNSPECIFIED, rpc.Severity_SEVERITY_UNSPECIFIED),
+			want:        rpc.Severity_SEVERITY_UNSPECIFIED,
+		},
+		{
+			desc:        "weighted below threshold",
+			aggregation: weighted(0.5, nil),
+			scores:      scores(rpc.Severity_OK, rpc.Severity_ALERT, rpc.Severity_OK, rpc.Severity_OK),
+			want:        rpc.Severity_OK,
+		},
+		{
+			desc:        "weighted at threshold",
+			aggregation: weighted(0.5, nil),
+			scores:      scores(rpc.Severity_ALERT, rpc.Severity_ALERT, rpc.Severity_OK, rpc.Severity_OK),
+			want:        rpc.Severity_ALERT,
+		},
+		{
+			desc:        "weighted counts higher severities towards lower ones",
+			aggregation: weighted(0.5, nil),
+			scores:      scores(rpc.Severity_ALERT, rpc.Severity_WARNING, rpc.Severity_OK, rpc.Severity_OK),
+			want:        rpc.Severity_WARNING,
+		},
+		{
+			desc: "weighted with weights",
+			aggregation: weighted(0.5, map[string]float32{
+				"$resource.spec/artifacts/score-b": 3,
+			}),
+			scores: scores(rpc.Severity_OK, rpc.Severity_ALERT, rpc.Severity_OK, rpc.Severity_OK),
+			want:   rpc.Severity_ALERT,
+		},
+		{
+			desc:        "weighted ignores scores without severities",
+			aggregation: weighted(0.5, nil),
+			scores:      scores(rpc.Severity_ALERT, rpc.Severity_SEVERITY_UNSPECIFIED, rpc.Severity_SEVERITY_UNSPECIFIED, rpc.Severity_OK),
+			want:        rpc.Severity_ALERT,
+		},
+		{
+			desc:        "weighted without severities",
+			aggregation: weighted(1, nil),
+			scores:      scores(rpc.Severity_SEVERITY_UNSPECIFIED, rpc.Severity_SEVERITY_UNSPECIFIED),
+			want:        rpc.Severity_SEVERITY_UNSPECIFIED,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			aggregator, err := newSeverityAggregator(scorePatterns, test.aggregation)
+			if err != nil {
+				t.Fatalf("newSeverityAggregator(%v) returned error: %s", test.aggregation, err)
+			}
+			got := aggregator.aggregate(scorePatterns[:len(test.scores)], test.scores)
+			if got != test.want {
+				t.Errorf("aggregate() returned %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateSeverityAggregation(t *testing.T) {
+	scorePatterns := []string{
+		"$resource.spec/artifacts/score-a",
+		"$resource.spec/artifacts/score-b",
+	}
+	tests := []struct {
+		desc       string
+		weighted   *rpc.WeightedSeverityAggregation
+		wantNumErr int
+	}{
+		{
+			desc: "valid",
+			weighted: &rpc.WeightedSeverityAggregation{
+				Threshold: 1,
+				Weights:   map[string]float32{"$resource.spec/artifacts/score-a": 2},
+			},
+			wantNumErr: 0,
+		},
+		{
+			desc:       "missing threshold",
+			weighted:   &rpc.WeightedSeverityAggregation{},
+			wantNumErr: 1,
+		},
+		{
+			desc:       "threshold above one",
+			weighted:   &rpc.WeightedSeverityAggregation{Threshold: 1.5},
+			wantNumErr: 1,
+		},
+		{
+			desc: "unknown and nonpositive weights",
+			weighted: &rpc.WeightedSeverityAggregation{
+				Threshold: 0.5,
+				Weights: map[string]float32{
+					"$resource.spec/artifacts/score-a": 0,
+					"$resource.spec/artifacts/score-c": 1,
+				},
+			},
+			wantNumErr: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			aggregation := &rpc.SeverityAggregation{
+				Strategy: &rpc.SeverityAggregation_Weighted{Weighted: test.weighted},
+			}
+			gotErrs := validateSeverityAggregation(scorePatterns, aggregation)
+			if len(gotErrs) != test.wantNumErr {
+				t.Errorf("validateSeverityAggregation(%v) returned unexpected no. of errors: want %d, got %s", aggregation, test.wantNumErr, gotErrs)
+			}
+			if _, err := newSeverityAggregator(scorePatterns, aggregation); (err != nil) != (test.wantNumErr > 0) {
+				t.Errorf("newSeverityAggregator(%v) returned unexpected error: %v", aggregation, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is not generated; it extends the generated MigrateDatabaseOperation.
+
+package gapic
+
+import (
+	"context"
+	"errors"
+
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrMigrationCanceled is returned by ResumeMigration when the operation
+// completed because it was canceled.
+var ErrMigrationCanceled = errors.New("migration operation was canceled")
+
+// Cancel requests that the server cancel the migration.
+//
+// Cancellation is asynchronous and best-effort: the migration may still
+// complete, so call Poll or Wait to get its final state. A migration that is
+// canceled is reported by IsCanceled. Migration steps that already ran are not
+// rolled back, so a canceled migration can leave the database partially
+// migrated; migrations can be run again to complete them.
+// If the server doesn't support cancellation, status.Code(err) == codes.Unimplemented.
+// The registry server runs migrations before returning their operations,
+// so its migrations complete before they can be canceled.
+func (op *MigrateDatabaseOperation) Cancel(ctx context.Context, opts ...gax.CallOption) error {
+	return op.lro.Cancel(ctx, opts...)
+}
+
+// IsCanceled reports whether err, as returned by Poll or Wait, means that the
+// migration completed because it was canceled rather than because it failed.
+// Errors of calls that were themselves canceled, e.g. because ctx was done,
+// don't mean that the migration was canceled.
+func (op *MigrateDatabaseOperation) IsCanceled(err error) bool {
+	return op.Done() && status.Code(err) == codes.Canceled
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gapic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	rpcpb "github.com/apigee/registry/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestCancelMigration(t *testing.T) {
+	response, err := anypb.New(&rpcpb.MigrateDatabaseResponse{Message: "migrated"})
+	if err != nil {
+		t.Fatalf("Setup: failed to pack response: %s", err)
+	}
+	ops := &fakeOperations{
+		response: response,
+		polls:    map[string]int{"operations/running": 5, "operations/other": 5},
+	}
+	client := newFakeAdminClient(t, ops)
+	ctx := context.Background()
+
+	op := client.MigrateDatabaseOperation("operations/running")
+	if _, err := op.Poll(ctx); err != nil || op.Done() {
+		t.Fatalf("Poll() returned done %t and error %v, want a running operation", op.Done(), err)
+	}
+	if err := op.Cancel(ctx); err != nil {
+		t.Fatalf("Cancel() returned error: %s", err)
+	}
+	_, err = op.Poll(ctx)
+	if !op.Done() || !op.IsCanceled(err) {
+		t.Errorf("Poll() returned done %t and error %v, want a canceled operation", op.Done(), err)
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		_, err := client.ResumeMigration(ctx, "operations/running")
+		if !errors.Is(err, ErrMigrationCanceled) {
+			t.Errorf("ResumeMigration() returned error %v, want %v", err, ErrMigrationCanceled)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		err := client.MigrateDatabaseOperation("operations/unknown").Cancel(ctx)
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("Cancel() returned error %v, want %s", err, codes.NotFound)
+		}
+	})
+
+	t.Run("canceled call", func(t *testing.T) {
+		other := client.MigrateDatabaseOperation("operations/other")
+		canceledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := other.Poll(canceledCtx)
+		if err == nil || other.IsCanceled(err) {
+			t.Errorf("Poll() with a canceled context returned error %v, want a failed call", err)
+		}
+	})
+}
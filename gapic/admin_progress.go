@@ -0,0 +1,55 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is not generated; it extends the generated MigrateDatabaseOperation.
+
+package gapic
+
+import (
+	rpcpb "github.com/apigee/registry/rpc"
+)
+
+// UnknownProgress is the Percent of a MigrationProgress that the server didn't report.
+const UnknownProgress float32 = -1
+
+// MigrationProgress describes how far a database migration has progressed.
+type MigrationProgress struct {
+	// Percent is the estimated percentage complete (0-100), or UnknownProgress.
+	Percent float32
+	// Step is the name of the migration step that is currently running, if known.
+	Step string
+}
+
+// Known reports whether the server reported the progress of the migration.
+func (p MigrationProgress) Known() bool {
+	return p.Percent != UnknownProgress
+}
+
+// Progress returns the progress of the migration from the operation metadata.
+// Like Metadata, it does not contact the server; call Poll to refresh it.
+// If the server hasn't reported progress, Percent is UnknownProgress.
+func (op *MigrateDatabaseOperation) Progress() (MigrationProgress, error) {
+	meta, err := op.Metadata()
+	if err != nil {
+		return MigrationProgress{Percent: UnknownProgress}, err
+	}
+	return progressFromMetadata(meta), nil
+}
+
+func progressFromMetadata(meta *rpcpb.MigrateDatabaseMetadata) MigrationProgress {
+	if !meta.GetProgressReported() {
+		return MigrationProgress{Percent: UnknownProgress, Step: meta.GetStep()}
+	}
+	return MigrationProgress{Percent: meta.GetProgressPercent(), Step: meta.GetStep()}
+}
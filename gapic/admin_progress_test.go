@@ -0,0 +1,63 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gapic
+
+import (
+	"testing"
+
+	rpcpb "github.com/apigee/registry/rpc"
+)
+
+func TestProgressFromMetadata(t *testing.T) {
+	tests := []struct {
+		desc      string
+		meta      *rpcpb.MigrateDatabaseMetadata
+		want      MigrationProgress
+		wantKnown bool
+	}{
+		{
+			desc: "no metadata",
+			want: MigrationProgress{Percent: UnknownProgress},
+		},
+		{
+			desc: "not reported",
+			meta: &rpcpb.MigrateDatabaseMetadata{},
+			want: MigrationProgress{Percent: UnknownProgress},
+		},
+		{
+			desc:      "reported",
+			meta:      &rpcpb.MigrateDatabaseMetadata{ProgressPercent: 40, Step: "tables", ProgressReported: true},
+			want:      MigrationProgress{Percent: 40, Step: "tables"},
+			wantKnown: true,
+		},
+		{
+			desc:      "starting",
+			meta:      &rpcpb.MigrateDatabaseMetadata{ProgressReported: true},
+			want:      MigrationProgress{Percent: 0},
+			wantKnown: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := progressFromMetadata(test.meta)
+			if got != test.want {
+				t.Errorf("progressFromMetadata(%v) returned %+v, want %+v", test.meta, got, test.want)
+			}
+			if got.Known() != test.wantKnown {
+				t.Errorf("Known() returned %t, want %t", got.Known(), test.wantKnown)
+			}
+		})
+	}
+}
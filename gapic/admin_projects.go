@@ -0,0 +1,59 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is not generated; it adds filtering helpers to the generated AdminClient.
+
+package gapic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	rpcpb "github.com/apigee/registry/rpc"
+	gax "github.com/googleapis/gax-go/v2"
+)
+
+// ProjectFilter selects projects by typed conditions.
+// Conditions that are set are combined, so a project must satisfy all of them.
+// The zero ProjectFilter matches every project.
+type ProjectFilter struct {
+	// IDPrefix matches projects whose ID begins with the prefix.
+	IDPrefix string
+	// DisplayName matches projects with exactly this display name.
+	DisplayName string
+}
+
+// String returns the filter expression for the Filter field of a ListProjectsRequest.
+func (f ProjectFilter) String() string {
+	conditions := make([]string, 0)
+	if f.IDPrefix != "" {
+		conditions = append(conditions, fmt.Sprintf("project_id.startsWith(%s)", strconv.Quote(f.IDPrefix)))
+	}
+	if f.DisplayName != "" {
+		conditions = append(conditions, fmt.Sprintf("display_name == %s", strconv.Quote(f.DisplayName)))
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// ListProjectsMatching lists the projects that satisfy filter.
+func (c *AdminClient) ListProjectsMatching(ctx context.Context, filter ProjectFilter, opts ...gax.CallOption) *ProjectIterator {
+	return c.ListProjects(ctx, &rpcpb.ListProjectsRequest{Filter: filter.String()}, opts...)
+}
+
+// ListProjectsByPrefix lists the projects whose IDs begin with prefix.
+func (c *AdminClient) ListProjectsByPrefix(ctx context.Context, prefix string, opts ...gax.CallOption) *ProjectIterator {
+	return c.ListProjectsMatching(ctx, ProjectFilter{IDPrefix: prefix}, opts...)
+}
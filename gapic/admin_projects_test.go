@@ -0,0 +1,57 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gapic
+
+import "testing"
+
+func TestProjectFilterString(t *testing.T) {
+	tests := []struct {
+		desc   string
+		filter ProjectFilter
+		want   string
+	}{
+		{
+			desc: "empty",
+			want: "",
+		},
+		{
+			desc:   "prefix",
+			filter: ProjectFilter{IDPrefix: "team-"},
+			want:   `project_id.startsWith("team-")`,
+		},
+		{
+			desc:   "display name",
+			filter: ProjectFilter{DisplayName: "Team A"},
+			want:   `display_name == "Team A"`,
+		},
+		{
+			desc:   "combined",
+			filter: ProjectFilter{IDPrefix: "team-", DisplayName: "Team A"},
+			want:   `project_id.startsWith("team-") && display_name == "Team A"`,
+		},
+		{
+			desc:   "quoted",
+			filter: ProjectFilter{IDPrefix: `a"b\c`},
+			want:   `project_id.startsWith("a\"b\\c")`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if got := test.filter.String(); got != test.want {
+				t.Errorf("String() returned %q, want %q", got, test.want)
+			}
+		})
+	}
+}
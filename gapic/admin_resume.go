@@ -0,0 +1,73 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is not generated; it extends the generated MigrateDatabaseOperation.
+
+package gapic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rpcpb "github.com/apigee/registry/rpc"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrMigrationExpired is returned by ResumeMigration when the server no longer
+// knows about the operation, usually because it completed and was discarded.
+var ErrMigrationExpired = errors.New("migration operation not found, it may have expired")
+
+// MigrationStore persists the name of a MigrateDatabase operation so that a
+// later process can resume waiting for it. Implementations are provided by the caller.
+type MigrationStore interface {
+	// Save records the operation name, replacing any previously saved name.
+	Save(name string) error
+	// Load returns the most recently saved operation name.
+	Load() (string, error)
+}
+
+// SaveMigration records the name of op in store.
+func SaveMigration(store MigrationStore, op *MigrateDatabaseOperation) error {
+	if op.Name() == "" {
+		return errors.New("migration operation has no name")
+	}
+	return store.Save(op.Name())
+}
+
+// ResumeMigration rehydrates the MigrateDatabase operation with the given name,
+// possibly created by a different process, and waits for it to complete.
+// If the server no longer has the operation, the error wraps ErrMigrationExpired,
+// and if the operation was canceled, the error wraps ErrMigrationCanceled.
+func (c *AdminClient) ResumeMigration(ctx context.Context, name string, opts ...gax.CallOption) (*rpcpb.MigrateDatabaseResponse, error) {
+	op := c.MigrateDatabaseOperation(name)
+	resp, err := op.Poll(ctx, opts...)
+	if status.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("%s: %w", name, ErrMigrationExpired)
+	} else if err != nil || op.Done() {
+		return resp, canceledMigrationError(op, err)
+	}
+	resp, err = op.Wait(ctx, opts...)
+	return resp, canceledMigrationError(op, err)
+}
+
+// canceledMigrationError wraps the errors of canceled migrations with ErrMigrationCanceled.
+func canceledMigrationError(op *MigrateDatabaseOperation, err error) error {
+	if op.IsCanceled(err) {
+		return fmt.Errorf("%s: %w: %s", op.Name(), ErrMigrationCanceled, err)
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gapic
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	rpcpb "github.com/apigee/registry/rpc"
+	"google.golang.org/api/option"
+	"google.golang.org/genproto/googleapis/longrunning"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// memoryStore is a MigrationStore that keeps the name in memory.
+type memoryStore struct {
+	name string
+}
+
+func (s *memoryStore) Save(name string) error {
+	s.name = name
+	return nil
+}
+
+func (s *memoryStore) Load() (string, error) {
+	if s.name == "" {
+		return "", errors.New("no saved migration")
+	}
+	return s.name, nil
+}
+
+// fakeOperations serves operations that complete after a fixed number of polls.
+// Canceled operations complete with a CANCELLED error when they are polled next.
+type fakeOperations struct {
+	longrunning.UnimplementedOperationsServer
+	response *anypb.Any
+	polls    map[string]int
+	canceled map[string]bool
+}
+
+func (s *fakeOperations) GetOperation(ctx context.Context, req *longrunning.GetOperationRequest) (*longrunning.Operation, error) {
+	remaining, ok := s.polls[req.GetName()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "%s not found", req.GetName())
+	}
+	if s.canceled[req.GetName()] {
+		return &longrunning.Operation{
+			Name:   req.GetName(),
+			Done:   true,
+			Result: &longrunning.Operation_Error{Error: status.New(codes.Canceled, "canceled").Proto()},
+		}, nil
+	}
+	if remaining > 0 {
+		s.polls[req.GetName()] = remaining - 1
+		return &longrunning.Operation{Name: req.GetName()}, nil
+	}
+	return &longrunning.Operation{
+		Name:   req.GetName(),
+		Done:   true,
+		Result: &longrunning.Operation_Response{Response: s.response},
+	}, nil
+}
+
+func (s *fakeOperations) CancelOperation(ctx context.Context, req *longrunning.CancelOperationRequest) (*emptypb.Empty, error) {
+	if _, ok := s.polls[req.GetName()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "%s not found", req.GetName())
+	}
+	if s.canceled == nil {
+		s.canceled = make(map[string]bool)
+	}
+	s.canceled[req.GetName()] = true
+	return &emptypb.Empty{}, nil
+}
+
+func newFakeAdminClient(t *testing.T, ops *fakeOperations) *AdminClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	longrunning.RegisterOperationsServer(srv, ops)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Setup: failed to dial fake server: %s", err)
+	}
+	client, err := NewAdminClient(ctx, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("Setup: failed to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestResumeMigration(t *testing.T) {
+	response, err := anypb.New(&rpcpb.MigrateDatabaseResponse{Message: "migrated"})
+	if err != nil {
+		t.Fatalf("Setup: failed to pack response: %s", err)
+	}
+	ops := &fakeOperations{
+		response: response,
+		polls:    map[string]int{"operations/done": 0, "operations/running": 1},
+	}
+	client := newFakeAdminClient(t, ops)
+	ctx := context.Background()
+
+	for _, name := range []string{"operations/done", "operations/running"} {
+		t.Run(name, func(t *testing.T) {
+			store := &memoryStore{}
+			if err := SaveMigration(store, client.MigrateDatabaseOperation(name)); err != nil {
+				t.Fatalf("SaveMigration() returned error: %s", err)
+			}
+
+			// Simulate a restart by loading the name back from the store.
+			saved, err := store.Load()
+			if err != nil {
+				t.Fatalf("Load() returned error: %s", err)
+			}
+			resp, err := client.ResumeMigration(ctx, saved)
+			if err != nil {
+				t.Fatalf("ResumeMigration(%q) returned error: %s", saved, err)
+			}
+			if resp.GetMessage() != "migrated" {
+				t.Errorf("ResumeMigration(%q) returned message %q, want %q", saved, resp.GetMessage(), "migrated")
+			}
+		})
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := client.ResumeMigration(ctx, "operations/expired")
+		if !errors.Is(err, ErrMigrationExpired) {
+			t.Errorf("ResumeMigration() returned error %v, want %v", err, ErrMigrationExpired)
+		}
+	})
+}
+
+func TestSaveMigrationWithoutName(t *testing.T) {
+	client := newFakeAdminClient(t, &fakeOperations{})
+	if err := SaveMigration(&memoryStore{}, client.MigrateDatabaseOperation("")); err == nil {
+		t.Error("SaveMigration() succeeded for an operation without a name, expected error")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// This file is not generated; it adds a typed view of the server build to the generated AdminClient.
+
+package gapic
+
+import (
+	"context"
+	"time"
+
+	rpcpb "github.com/apigee/registry/rpc"
+	gax "github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ServerInfo describes the build of a server.
+// Servers that don't report build information, such as older servers and
+// servers built without module support, only have a Status.
+// Use HasBuildInfo to tell them apart from builds with empty fields.
+type ServerInfo struct {
+	// Status describes the status of the server.
+	Status string
+	// Version is the version of the server's main module.
+	// Servers built from a source checkout report "(devel)".
+	Version string
+	// Commit is the version control revision that the server was built from.
+	Commit string
+	// CommitTime is the time of Commit, or zero if it is unknown.
+	CommitTime time.Time
+	// Modified is true if the server was built from a source tree with uncommitted changes.
+	Modified bool
+	// GoVersion is the version of Go that built the server.
+	GoVersion string
+
+	hasBuildInfo bool
+}
+
+// HasBuildInfo reports whether the server reported build information.
+func (i *ServerInfo) HasBuildInfo() bool {
+	return i.hasBuildInfo
+}
+
+// GetServerInfo returns the build information of the server, read from GetStatus.
+func (c *AdminClient) GetServerInfo(ctx context.Context, opts ...gax.CallOption) (*ServerInfo, error) {
+	status, err := c.GetStatus(ctx, &emptypb.Empty{}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newServerInfo(status), nil
+}
+
+func newServerInfo(status *rpcpb.Status) *ServerInfo {
+	info := &ServerInfo{Status: status.GetMessage()}
+	build := status.GetBuild()
+	if build == nil {
+		return info
+	}
+	info.hasBuildInfo = true
+	info.Version = build.GetMain().GetVersion()
+	info.GoVersion = build.GetGoVersion()
+	// Settings are recorded by Go 1.18 and later when building from a repository.
+	settings := build.GetSettings()
+	info.Commit = settings["vcs.revision"]
+	if t, err := time.Parse(time.RFC3339, settings["vcs.time"]); err == nil {
+		info.CommitTime = t
+	}
+	info.Modified = settings["vcs.modified"] == "true"
+	return info
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gapic
+
+import (
+	"testing"
+	"time"
+
+	rpcpb "github.com/apigee/registry/rpc"
+)
+
+func TestNewServerInfo(t *testing.T) {
+	tests := []struct {
+		desc      string
+		status    *rpcpb.Status
+		want      ServerInfo
+		wantBuild bool
+	}{
+		{
+			desc:   "older server",
+			status: &rpcpb.Status{Message: "running"},
+			want:   ServerInfo{Status: "running"},
+		},
+		{
+			desc: "release build",
+			status: &rpcpb.Status{
+				Message: "running",
+				Build: &rpcpb.BuildInfo{
+					GoVersion: "go1.18.3",
+					Main:      &rpcpb.BuildInfo_Module{Path: "github.com/apigee/registry", Version: "v0.5.6"},
+					Settings: map[string]string{
+						"vcs.revision": "0123456789abcdef",
+						"vcs.time":     "2022-07-01T12:00:00Z",
+						"vcs.modified": "true",
+					},
+				},
+			},
+			want: ServerInfo{
+				Status:     "running",
+				Version:    "v0.5.6",
+				Commit:     "0123456789abcdef",
+				CommitTime: time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC),
+				Modified:   true,
+				GoVersion:  "go1.18.3",
+			},
+			wantBuild: true,
+		},
+		{
+			desc: "build without version control settings",
+			status: &rpcpb.Status{
+				Message: "running",
+				Build: &rpcpb.BuildInfo{
+					GoVersion: "go1.17",
+					Main:      &rpcpb.BuildInfo_Module{Path: "github.com/apigee/registry", Version: "(devel)"},
+				},
+			},
+			want: ServerInfo{
+				Status:    "running",
+				Version:   "(devel)",
+				GoVersion: "go1.17",
+			},
+			wantBuild: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			got := newServerInfo(test.status)
+			if got.HasBuildInfo() != test.wantBuild {
+				t.Errorf("HasBuildInfo() returned %t, want %t", got.HasBuildInfo(), test.wantBuild)
+			}
+			got.hasBuildInfo = false
+			if *got != test.want {
+				t.Errorf("newServerInfo() returned %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+}
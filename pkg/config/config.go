@@ -223,8 +223,19 @@ func Read(name string) (c Configuration, err error) {
 	if dir == "" {
 		name = filepath.Join(Directory, file)
 	}
+	return ReadFile(name)
+}
+
+// ReadFile loads a Configuration from the yaml file at path, bypassing
+// the named configurations in ~/.config/registry. Like Read(), it does not
+// bind to env vars or flags, resolve, or validate.
+func ReadFile(path string) (c Configuration, err error) {
+	if path == "" {
+		return c, fmt.Errorf("Configuration path cannot be empty.")
+	}
+
 	var r io.Reader
-	if r, err = os.Open(name); err != nil {
+	if r, err = os.Open(path); err != nil {
 		return
 	}
 	defer r.(*os.File).Close()
@@ -173,6 +173,48 @@ func TestSettingsDirectRead(t *testing.T) {
 	}
 }
 
+func TestReadFile(t *testing.T) {
+	t.Cleanup(test.CleanConfigDir(t))
+	t.Setenv("APG_REGISTRY_ADDRESS", "ignored:8080")
+
+	want := config.Configuration{
+		Registry: config.Registry{
+			Address:  "localhost:8080",
+			Insecure: true,
+			Project:  "project",
+		},
+	}
+	path := filepath.Join(t.TempDir(), "ci.yaml")
+	contents := []byte("registry:\n  address: localhost:8080\n  insecure: true\n  project: project\n")
+	if err := ioutil.WriteFile(path, contents, os.FileMode(0644)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := config.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected diff: (-want +got):\n%s", diff)
+	}
+
+	// the file is not added to the named configurations
+	configs, err := config.Configurations()
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	if len(configs) != 0 {
+		t.Errorf("expected no configurations, got %v", configs)
+	}
+
+	if _, err := config.ReadFile(""); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+	if _, err := config.ReadFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
 func TestSettingsFlags(t *testing.T) {
 	t.Cleanup(test.CleanConfigDir(t))
 
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/apigee/registry/gapic"
+	"github.com/apigee/registry/pkg/config"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 	"google.golang.org/grpc"
@@ -32,11 +33,16 @@ func clientOptions(config Config) ([]option.ClientOption, error) {
 	}
 	opts = append(opts, option.WithEndpoint(config.Address))
 	if config.Insecure {
-		conn, err := grpc.Dial(config.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, config.dialOptions()...)
+		conn, err := grpc.Dial(config.Address, dialOpts...)
 		if err != nil {
 			return nil, err
 		}
 		opts = append(opts, option.WithGRPCConn(conn))
+	} else {
+		for _, o := range config.dialOptions() {
+			opts = append(opts, option.WithGRPCDialOption(o))
+		}
 	}
 	if config.Token != "" {
 		opts = append(opts, option.WithTokenSource(oauth2.StaticTokenSource(
@@ -73,6 +79,15 @@ func NewRegistryClientWithSettings(ctx context.Context, config Config) (Registry
 	return client, err
 }
 
+// NewRegistryClientWithConfiguration creates a client with the specified Configuration.
+func NewRegistryClientWithConfiguration(ctx context.Context, configuration config.Configuration) (RegistryClient, error) {
+	c, err := ConfigFromConfiguration(configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewRegistryClientWithSettings(ctx, c)
+}
+
 type AdminClient = *gapic.AdminClient
 
 // NewAdminClient creates a new client using the active Config.
@@ -93,6 +108,15 @@ func NewAdminClientWithSettings(ctx context.Context, config Config) (AdminClient
 	return gapic.NewAdminClient(ctx, opts...)
 }
 
+// NewAdminClientWithConfiguration creates a client with the specified Configuration.
+func NewAdminClientWithConfiguration(ctx context.Context, configuration config.Configuration) (AdminClient, error) {
+	c, err := ConfigFromConfiguration(configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewAdminClientWithSettings(ctx, c)
+}
+
 type ProvisioningClient = *gapic.ProvisioningClient
 
 // NewAdminClient creates a new client using the active Config.
@@ -112,3 +136,12 @@ func NewProvisioningClientWithSettings(ctx context.Context, config Config) (Prov
 	}
 	return gapic.NewProvisioningClient(ctx, opts...)
 }
+
+// NewProvisioningClientWithConfiguration creates a client with the specified Configuration.
+func NewProvisioningClientWithConfiguration(ctx context.Context, configuration config.Configuration) (ProvisioningClient, error) {
+	c, err := ConfigFromConfiguration(configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewProvisioningClientWithSettings(ctx, c)
+}
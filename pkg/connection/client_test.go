@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/apigee/registry/pkg/config"
 	"github.com/apigee/registry/pkg/config/test"
 )
 
@@ -53,6 +54,17 @@ func TestClientBadConfig(t *testing.T) {
 	if err == nil {
 		t.Errorf("expected error")
 	}
+	clients, err := NewClients(context.Background())
+	if err == nil {
+		t.Errorf("expected error")
+	}
+	if clients != nil {
+		t.Errorf("expected nil clients, got %v", clients)
+	}
+	_, err = NewClientsWithSettings(context.Background(), Config{})
+	if err == nil {
+		t.Errorf("expected error")
+	}
 }
 
 func TestClientGoodConfig(t *testing.T) {
@@ -74,4 +86,50 @@ func TestClientGoodConfig(t *testing.T) {
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
+	clients, err := NewClients(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if clients.RegistryClient == nil || clients.AdminClient == nil {
+		t.Errorf("expected both clients, got %v", clients)
+	}
+	if err := clients.Close(); err != nil {
+		t.Errorf("unexpected error closing clients: %v", err)
+	}
+}
+
+func TestClientWithConfiguration(t *testing.T) {
+	t.Cleanup(test.CleanConfigDir(t))
+	t.Setenv("APG_REGISTRY_ADDRESS", "")
+	t.Setenv("APG_REGISTRY_INSECURE", "")
+
+	_, err := NewRegistryClientWithConfiguration(context.Background(), config.Configuration{})
+	if err == nil {
+		t.Errorf("expected error")
+	}
+
+	c := config.Configuration{
+		Registry: config.Registry{
+			Address:  "localhost:8080",
+			Insecure: true,
+		},
+	}
+	_, err = NewRegistryClientWithConfiguration(context.Background(), c)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = NewAdminClientWithConfiguration(context.Background(), c)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	_, err = NewProvisioningClientWithConfiguration(context.Background(), c)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	_, err = NewClientsWithConfiguration(context.Background(), c)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
 }
@@ -0,0 +1,77 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"github.com/apigee/registry/pkg/config"
+)
+
+// Clients holds a RegistryClient and an AdminClient for the same registry.
+// Methods that are unique to either client can be called on Clients directly;
+// methods that both clients have, such as Connection, must be called on
+// the embedded RegistryClient or AdminClient.
+type Clients struct {
+	RegistryClient
+	AdminClient
+}
+
+// NewClients creates clients using the active Config.
+func NewClients(ctx context.Context) (*Clients, error) {
+	c, err := ActiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewClientsWithSettings(ctx, c)
+}
+
+// NewClientsWithSettings creates clients with the specified Config.
+// If either client can't be created, no clients are left open.
+func NewClientsWithSettings(ctx context.Context, config Config) (*Clients, error) {
+	registryClient, err := NewRegistryClientWithSettings(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	adminClient, err := NewAdminClientWithSettings(ctx, config)
+	if err != nil {
+		registryClient.Close()
+		return nil, err
+	}
+	return &Clients{
+		RegistryClient: registryClient,
+		AdminClient:    adminClient,
+	}, nil
+}
+
+// NewClientsWithConfiguration creates clients with the specified Configuration.
+func NewClientsWithConfiguration(ctx context.Context, configuration config.Configuration) (*Clients, error) {
+	c, err := ConfigFromConfiguration(configuration)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientsWithSettings(ctx, c)
+}
+
+// Close closes both clients. Both are closed even if closing the first fails,
+// and the first error is returned.
+func (c *Clients) Close() error {
+	registryErr := c.RegistryClient.Close()
+	adminErr := c.AdminClient.Close()
+	if registryErr != nil {
+		return registryErr
+	}
+	return adminErr
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	"github.com/apigee/registry/pkg/config"
+	"golang.org/x/time/rate"
 )
 
 // Config configures the client.
@@ -29,6 +30,27 @@ type Config struct {
 	Location string `mapstructure:"location"` // optional
 	Project  string `mapstructure:"project"`  // optional
 	Token    string `mapstructure:"token"`    // bearer token
+
+	// Options set with WithRateLimit and WithHeaders are kept behind a pointer
+	// so that Configs remain comparable. They are copied on write.
+	options *callOptions
+}
+
+// callOptions holds optional client settings that can't be loaded from config files.
+type callOptions struct {
+	limiter *rate.Limiter // optional, see WithRateLimit
+	headers []string      // optional key/value pairs, see WithHeaders
+}
+
+// withOptions returns a copy of the Config with a copy of its options that can be modified.
+func (c Config) withOptions(modify func(*callOptions)) Config {
+	var o callOptions
+	if c.options != nil {
+		o = *c.options
+	}
+	modify(&o)
+	c.options = &o
+	return c
 }
 
 // If set, ActiveConfig() returns this configuration.
@@ -63,15 +85,30 @@ func ReadConfig(name string) (Config, error) {
 		return Config{}, err
 	}
 
-	config := Config{
+	return newConfig(c), nil
+}
+
+// ConfigFromConfiguration converts a Configuration, such as one loaded
+// with config.ReadFile(), to a Config. The Configuration is resolved
+// and validated but isn't combined with flags or env vars.
+func ConfigFromConfiguration(c config.Configuration) (Config, error) {
+	if err := c.Resolve(); err != nil {
+		return Config{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return newConfig(c), nil
+}
+
+func newConfig(c config.Configuration) Config {
+	return Config{
 		Address:  c.Registry.Address,
 		Insecure: c.Registry.Insecure,
 		Location: c.Registry.Location,
 		Project:  c.Registry.Project,
 		Token:    c.Registry.Token,
 	}
-
-	return config, err
 }
 
 // FQName ensures the project and location, if available,
@@ -0,0 +1,92 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// gRPC metadata keys are lowercase; see https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md.
+var headerNameRegexp = regexp.MustCompile(`^[0-9a-z_.-]+$`)
+
+// WithHeaders returns a copy of the Config whose clients send the specified
+// headers as metadata with each RPC, including streaming calls, e.g. to
+// identify a tenant to a proxy in front of the registry.
+// Header names are case-insensitive and are sent in lowercase.
+// The headers are added to the metadata that clients send themselves, such as
+// x-goog-api-client and x-goog-request-params. Names that are reserved by gRPC
+// or that aren't valid metadata keys are rejected, as are values with
+// characters that can't be sent in a header.
+func (c Config) WithHeaders(headers map[string]string) (Config, error) {
+	values := make(map[string]string, len(headers))
+	for name, value := range headers {
+		key := strings.ToLower(name)
+		if err := validateHeader(key, value); err != nil {
+			return Config{}, err
+		}
+		if _, ok := values[key]; ok {
+			return Config{}, fmt.Errorf("duplicate header %q", key)
+		}
+		values[key] = value
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key, values[key])
+	}
+	return c.withOptions(func(o *callOptions) { o.headers = pairs }), nil
+}
+
+func validateHeader(key, value string) error {
+	if !headerNameRegexp.MatchString(key) {
+		return fmt.Errorf("invalid header name %q: names may only contain letters, digits, '-', '_' and '.'", key)
+	}
+	if strings.HasPrefix(key, "grpc-") {
+		return fmt.Errorf("invalid header name %q: names beginning with \"grpc-\" are reserved", key)
+	}
+	if strings.HasSuffix(key, "-bin") {
+		return fmt.Errorf("invalid header name %q: binary headers are not supported", key)
+	}
+	for _, r := range value {
+		if r < ' ' || r > '~' {
+			return fmt.Errorf("invalid value for header %q: values may only contain printable ASCII characters", key)
+		}
+	}
+	return nil
+}
+
+func unaryHeaderInterceptor(pairs []string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(metadata.AppendToOutgoingContext(ctx, pairs...), method, req, reply, cc, opts...)
+	}
+}
+
+func streamHeaderInterceptor(pairs []string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(metadata.AppendToOutgoingContext(ctx, pairs...), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithHeaders(t *testing.T) {
+	tests := []struct {
+		desc    string
+		headers map[string]string
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc:    "valid",
+			headers: map[string]string{"X-Tenant-ID": "acme", "x-api-key": "secret"},
+			want:    []string{"x-api-key", "secret", "x-tenant-id", "acme"},
+		},
+		{
+			desc:    "duplicate name",
+			headers: map[string]string{"X-Tenant-ID": "acme", "x-tenant-id": "acme"},
+			wantErr: true,
+		},
+		{
+			desc:    "empty name",
+			headers: map[string]string{"": "value"},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid character in name",
+			headers: map[string]string{"x tenant": "acme"},
+			wantErr: true,
+		},
+		{
+			desc:    "pseudo-header",
+			headers: map[string]string{":authority": "example.com"},
+			wantErr: true,
+		},
+		{
+			desc:    "reserved name",
+			headers: map[string]string{"grpc-timeout": "1S"},
+			wantErr: true,
+		},
+		{
+			desc:    "binary header",
+			headers: map[string]string{"x-data-bin": "value"},
+			wantErr: true,
+		},
+		{
+			desc:    "invalid value",
+			headers: map[string]string{"x-tenant-id": "acme\n"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			c, err := Config{Address: "localhost:8080"}.WithHeaders(test.headers)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("WithHeaders(%v) returned error %v, want error: %t", test.headers, err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(test.want, c.options.headers); diff != "" {
+				t.Errorf("WithHeaders(%v) returned unexpected headers (-want +got):\n%s", test.headers, diff)
+			}
+		})
+	}
+}
+
+func TestHeaderInterceptors(t *testing.T) {
+	c, err := Config{}.WithHeaders(map[string]string{"x-tenant-id": "acme"})
+	if err != nil {
+		t.Fatalf("WithHeaders() returned error: %s", err)
+	}
+	if opts := c.dialOptions(); len(opts) != 2 {
+		t.Errorf("dialOptions() returned %d options for a Config with headers, want 2", len(opts))
+	}
+
+	// Clients set their own metadata before interceptors are called.
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-goog-api-client", "gl-go/1.18")
+	check := func(ctx context.Context) {
+		t.Helper()
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if got := md.Get("x-goog-api-client"); len(got) != 1 {
+			t.Errorf("x-goog-api-client = %v, want one value", got)
+		}
+		if got := md.Get("x-tenant-id"); len(got) != 1 || got[0] != "acme" {
+			t.Errorf("x-tenant-id = %v, want [acme]", got)
+		}
+	}
+
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		check(ctx)
+		return nil
+	}
+	if err := unaryHeaderInterceptor(c.options.headers)(ctx, "/test/Unary", nil, nil, nil, invoker); err != nil {
+		t.Errorf("unary call returned error: %s", err)
+	}
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		check(ctx)
+		return nil, nil
+	}
+	if _, err := streamHeaderInterceptor(c.options.headers)(ctx, &grpc.StreamDesc{}, nil, "/test/Stream", streamer); err != nil {
+		t.Errorf("stream call returned error: %s", err)
+	}
+
+	limited := c.WithRateLimit(10, 5)
+	if opts := limited.dialOptions(); len(opts) != 4 {
+		t.Errorf("dialOptions() returned %d options for a Config with headers and a rate limit, want 4", len(opts))
+	}
+}
+
+func TestConfigOptionsComparable(t *testing.T) {
+	base := Config{Address: "localhost:8080"}
+	c, err := base.WithHeaders(map[string]string{"x-tenant-id": "acme"})
+	if err != nil {
+		t.Fatalf("WithHeaders() returned error: %s", err)
+	}
+	// Configs can be compared and used as map keys.
+	seen := map[Config]bool{c: true}
+	copied := c
+	if !seen[copied] || copied != c {
+		t.Error("copies of a Config aren't equal")
+	}
+	if base.options != nil {
+		t.Error("WithHeaders() modified the options of the original Config")
+	}
+
+	limited := c.WithRateLimit(10, 5)
+	if limited == c {
+		t.Error("WithRateLimit() returned a Config equal to the original")
+	}
+	if c.options.limiter != nil {
+		t.Error("WithRateLimit() modified the options of the original Config")
+	}
+	if diff := cmp.Diff(c.options.headers, limited.options.headers); diff != "" {
+		t.Errorf("WithRateLimit() changed the headers (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+// WithRateLimit returns a copy of the Config whose clients wait for a token
+// from a token bucket before each RPC, including streaming calls.
+// qps is the sustained rate of calls and burst is the size of the bucket (at least 1).
+// All clients created with the returned Config or copies of it share the bucket,
+// so one limit covers both the admin and registry clients.
+// Waiting respects the context of each call. Configs have no rate limit by default.
+func (c Config) WithRateLimit(qps float64, burst int) Config {
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(qps), burst)
+	return c.withOptions(func(o *callOptions) { o.limiter = limiter })
+}
+
+// dialOptions returns the gRPC dial options that enforce the Config's rate limit
+// and add its headers.
+func (c Config) dialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if c.options == nil {
+		return opts
+	}
+	if c.options.limiter != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(unaryRateLimitInterceptor(c.options.limiter)),
+			grpc.WithChainStreamInterceptor(streamRateLimitInterceptor(c.options.limiter)),
+		)
+	}
+	if len(c.options.headers) > 0 {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(unaryHeaderInterceptor(c.options.headers)),
+			grpc.WithChainStreamInterceptor(streamHeaderInterceptor(c.options.headers)),
+		)
+	}
+	return opts
+}
+
+func unaryRateLimitInterceptor(limiter *rate.Limiter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func streamRateLimitInterceptor(limiter *rate.Limiter) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
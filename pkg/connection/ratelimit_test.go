@@ -0,0 +1,80 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestRateLimitDisabledByDefault(t *testing.T) {
+	if opts := (Config{}).dialOptions(); len(opts) != 0 {
+		t.Errorf("dialOptions() returned %d options for a Config without a rate limit, want 0", len(opts))
+	}
+	c := Config{Address: "localhost:8080"}.WithRateLimit(10, 5)
+	if opts := c.dialOptions(); len(opts) != 2 {
+		t.Errorf("dialOptions() returned %d options for a Config with a rate limit, want 2", len(opts))
+	}
+}
+
+func TestRateLimitInterceptors(t *testing.T) {
+	// One token is available immediately and the next isn't available for an hour.
+	c := Config{}.WithRateLimit(1.0/3600, 1)
+	unary := unaryRateLimitInterceptor(c.options.limiter)
+	stream := streamRateLimitInterceptor(c.options.limiter)
+
+	calls := 0
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		calls++
+		return nil
+	}
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		calls++
+		return nil, nil
+	}
+
+	if err := unary(context.Background(), "/test/Unary", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("first unary call returned error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := unary(ctx, "/test/Unary", nil, nil, nil, invoker); err == nil {
+		t.Error("unary call beyond the rate limit succeeded, expected error")
+	}
+	if _, err := stream(ctx, &grpc.StreamDesc{}, nil, "/test/Stream", streamer); err == nil {
+		t.Error("stream call beyond the rate limit succeeded, expected error")
+	}
+	if calls != 1 {
+		t.Errorf("RPCs were invoked %d times, want 1", calls)
+	}
+}
+
+func TestRateLimitSharedByClients(t *testing.T) {
+	c := Config{Address: "localhost:8080", Insecure: true}.WithRateLimit(10, 5)
+	copied := c
+	if copied.options.limiter != c.options.limiter {
+		t.Error("copies of a Config don't share a rate limiter")
+	}
+	if _, err := NewRegistryClientWithSettings(context.Background(), c); err != nil {
+		t.Errorf("NewRegistryClientWithSettings() returned error: %s", err)
+	}
+	if _, err := NewAdminClientWithSettings(context.Background(), copied); err != nil {
+		t.Errorf("NewAdminClientWithSettings() returned error: %s", err)
+	}
+}
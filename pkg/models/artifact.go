@@ -18,5 +18,14 @@ import "gopkg.in/yaml.v3"
 
 type Artifact struct {
 	Header `yaml:",inline"`
-	Data   yaml.Node `yaml:"data"`
+	Data   yaml.Node       `yaml:"data"`
+	Source *ArtifactSource `yaml:"source,omitempty"`
+}
+
+// ArtifactSource refers to artifact data that is stored outside of the YAML file.
+type ArtifactSource struct {
+	// URI of a YAML or JSON file containing the data of the artifact.
+	URI string `yaml:"uri"`
+	// Digest of the file in the form "sha256:{hex}" or "sha512:{hex}".
+	Digest string `yaml:"digest,omitempty"`
 }
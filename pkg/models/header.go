@@ -25,4 +25,14 @@ type Metadata struct {
 	Parent      string            `yaml:"parent,omitempty"`
 	Labels      map[string]string `yaml:"labels,omitempty"`
 	Annotations map[string]string `yaml:"annotations,omitempty"`
+
+	// The following fields are populated by the server. They are only
+	// exported in full-fidelity mode and are ignored when applying.
+	CreateTime         string `yaml:"createTime,omitempty"`
+	UpdateTime         string `yaml:"updateTime,omitempty"`
+	RevisionID         string `yaml:"revisionID,omitempty"`
+	RevisionCreateTime string `yaml:"revisionCreateTime,omitempty"`
+	RevisionUpdateTime string `yaml:"revisionUpdateTime,omitempty"`
+	Hash               string `yaml:"hash,omitempty"`
+	SizeBytes          int32  `yaml:"sizeBytes,omitempty"`
 }
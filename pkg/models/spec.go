@@ -20,9 +20,10 @@ type ApiSpec struct {
 }
 
 type ApiSpecData struct {
-	FileName    string      `yaml:"filename,omitempty"`
-	Description string      `yaml:"description,omitempty"`
-	MimeType    string      `yaml:"mimeType,omitempty"`
-	SourceURI   string      `yaml:"sourceURI,omitempty"`
-	Artifacts   []*Artifact `yaml:"artifacts,omitempty"`
+	FileName     string      `yaml:"filename,omitempty"`
+	Description  string      `yaml:"description,omitempty"`
+	MimeType     string      `yaml:"mimeType,omitempty"`
+	SourceURI    string      `yaml:"sourceURI,omitempty"`
+	RevisionTags []string    `yaml:"revisionTags,omitempty"`
+	Artifacts    []*Artifact `yaml:"artifacts,omitempty"`
 }
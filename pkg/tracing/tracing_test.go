@@ -0,0 +1,65 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNoProvider(t *testing.T) {
+	_, span := Start(context.Background(), "noop", attribute.String("key", "value"))
+	if span.IsRecording() {
+		t.Errorf("Start() returned a recording span without a provider")
+	}
+	span.SetAttributes(attribute.Int("count", 1))
+	span.End()
+}
+
+func TestProvider(t *testing.T) {
+	r := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(r)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, span := Start(context.Background(), "op", attribute.String("key", "value"))
+	if got := trace.SpanFromContext(ctx); got != span {
+		t.Errorf("Start() returned a context without its span")
+	}
+	span.SetAttributes(attribute.Int("count", 2), attribute.Bool("done", true))
+	span.End()
+
+	spans := r.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	if got := spans[0].InstrumentationScope().Name; got != InstrumentationName {
+		t.Errorf("span has instrumentation name %q, want %q", got, InstrumentationName)
+	}
+	if got := spans[0].Name(); got != "op" {
+		t.Errorf("span has name %q, want %q", got, "op")
+	}
+	want := []attribute.KeyValue{attribute.String("key", "value"), attribute.Int("count", 2), attribute.Bool("done", true)}
+	if diff := cmp.Diff(want, spans[0].Attributes(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Errorf("unexpected attributes (-want +got):\n%s", diff)
+	}
+}
@@ -27,6 +27,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -75,6 +77,30 @@ type ScoreDefinition struct {
 	//	*ScoreDefinition_Integer
 	//	*ScoreDefinition_Boolean
 	Type isScoreDefinition_Type `protobuf_oneof:"type"`
+	// Display attributes for severity levels of this score.
+	// Severity levels without display attributes are displayed using the name
+	// of the severity level.
+	SeverityDisplays []*SeverityDisplay `protobuf:"bytes,13,rep,name=severity_displays,json=severityDisplays,proto3" json:"severity_displays,omitempty"`
+	// Additional scores which are derived from the artifacts of score_formula.
+	// The artifacts are fetched once and shared by score_formula and all of the
+	// outputs. Each output is stored in a separate score artifact with the id
+	// score-{id}-{output.id}.
+	// Outputs can only be used with a score_formula.
+	Outputs []*ScoreOutput `protobuf:"bytes,14,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// If true, the thresholds of the score and of its outputs are required to
+	// cover every value that the score can take, so that every score is
+	// assigned a severity. Thresholds are optional by default, and scores
+	// without matching thresholds have an unspecified severity.
+	// Gaps between thresholds and at the ends of the range are always invalid
+	// when thresholds are set.
+	RequireThresholdCoverage bool `protobuf:"varint,15,opt,name=require_threshold_coverage,json=requireThresholdCoverage,proto3" json:"require_threshold_coverage,omitempty"`
+	// Overrides the staleness window of the scoring client for this score.
+	// A score is recomputed if its inputs or this definition were updated less
+	// than staleness_window before the score was saved. Longer windows suit
+	// slow-changing inputs; shorter windows recompute fewer scores after their
+	// inputs stop changing. Must not be negative.
+	// If unset, the staleness window of the client is used.
+	StalenessWindow *durationpb.Duration `protobuf:"bytes,16,opt,name=staleness_window,json=stalenessWindow,proto3" json:"staleness_window,omitempty"`
 }
 
 func (x *ScoreDefinition) Reset() {
@@ -207,6 +233,34 @@ func (x *ScoreDefinition) GetBoolean() *BooleanType {
 	return nil
 }
 
+func (x *ScoreDefinition) GetSeverityDisplays() []*SeverityDisplay {
+	if x != nil {
+		return x.SeverityDisplays
+	}
+	return nil
+}
+
+func (x *ScoreDefinition) GetOutputs() []*ScoreOutput {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *ScoreDefinition) GetRequireThresholdCoverage() bool {
+	if x != nil {
+		return x.RequireThresholdCoverage
+	}
+	return false
+}
+
+func (x *ScoreDefinition) GetStalenessWindow() *durationpb.Duration {
+	if x != nil {
+		return x.StalenessWindow
+	}
+	return nil
+}
+
 type isScoreDefinition_Formula interface {
 	isScoreDefinition_Formula()
 }
@@ -252,6 +306,213 @@ func (*ScoreDefinition_Integer) isScoreDefinition_Type() {}
 
 func (*ScoreDefinition_Boolean) isScoreDefinition_Type() {}
 
+// Represents an additional score which is derived from the same artifacts
+// as the score_formula of a ScoreDefinition.
+type ScoreOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the output. Must be unique within a ScoreDefinition and
+	// contain only lowercase letters, digits and hyphens.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// A human-friendly name for the output.
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// A more detailed description of the output.
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// A CEL expression which extracts the value of this output from the
+	// artifacts of the score_formula. The same variables are available as in
+	// score_formula.score_expression.
+	ScoreExpression string `protobuf:"bytes,4,opt,name=score_expression,json=scoreExpression,proto3" json:"score_expression,omitempty"`
+	// Represents the type and characteristics of the output.
+	//
+	// Types that are assignable to Type:
+	//	*ScoreOutput_Percent
+	//	*ScoreOutput_Integer
+	//	*ScoreOutput_Boolean
+	Type isScoreOutput_Type `protobuf_oneof:"type"`
+}
+
+func (x *ScoreOutput) Reset() {
+	*x = ScoreOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreOutput) ProtoMessage() {}
+
+func (x *ScoreOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreOutput.ProtoReflect.Descriptor instead.
+func (*ScoreOutput) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScoreOutput) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScoreOutput) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ScoreOutput) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ScoreOutput) GetScoreExpression() string {
+	if x != nil {
+		return x.ScoreExpression
+	}
+	return ""
+}
+
+func (m *ScoreOutput) GetType() isScoreOutput_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (x *ScoreOutput) GetPercent() *PercentType {
+	if x, ok := x.GetType().(*ScoreOutput_Percent); ok {
+		return x.Percent
+	}
+	return nil
+}
+
+func (x *ScoreOutput) GetInteger() *IntegerType {
+	if x, ok := x.GetType().(*ScoreOutput_Integer); ok {
+		return x.Integer
+	}
+	return nil
+}
+
+func (x *ScoreOutput) GetBoolean() *BooleanType {
+	if x, ok := x.GetType().(*ScoreOutput_Boolean); ok {
+		return x.Boolean
+	}
+	return nil
+}
+
+type isScoreOutput_Type interface {
+	isScoreOutput_Type()
+}
+
+type ScoreOutput_Percent struct {
+	// Set this if the output value is a percentage.
+	Percent *PercentType `protobuf:"bytes,5,opt,name=percent,proto3,oneof"`
+}
+
+type ScoreOutput_Integer struct {
+	// Set this if the output value is an integer.
+	Integer *IntegerType `protobuf:"bytes,6,opt,name=integer,proto3,oneof"`
+}
+
+type ScoreOutput_Boolean struct {
+	// Set this if the output value is a boolean.
+	Boolean *BooleanType `protobuf:"bytes,7,opt,name=boolean,proto3,oneof"`
+}
+
+func (*ScoreOutput_Percent) isScoreOutput_Type() {}
+
+func (*ScoreOutput_Integer) isScoreOutput_Type() {}
+
+func (*ScoreOutput_Boolean) isScoreOutput_Type() {}
+
+// Represents how a severity level should be displayed.
+type SeverityDisplay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The severity level that these display attributes apply to.
+	Severity Severity `protobuf:"varint,1,opt,name=severity,proto3,enum=google.cloud.apigeeregistry.v1.scoring.Severity" json:"severity,omitempty"`
+	// A human-friendly label for the severity level.
+	Label string `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	// A color for the severity level, e.g. "green" or "#00ff00".
+	Color string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+}
+
+func (x *SeverityDisplay) Reset() {
+	*x = SeverityDisplay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeverityDisplay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeverityDisplay) ProtoMessage() {}
+
+func (x *SeverityDisplay) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeverityDisplay.ProtoReflect.Descriptor instead.
+func (*SeverityDisplay) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SeverityDisplay) GetSeverity() Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Severity_SEVERITY_UNSPECIFIED
+}
+
+func (x *SeverityDisplay) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *SeverityDisplay) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
 // Represents a pattern to identify resources in the registry.
 type ResourcePattern struct {
 	state         protoimpl.MessageState
@@ -271,7 +532,7 @@ type ResourcePattern struct {
 func (x *ResourcePattern) Reset() {
 	*x = ResourcePattern{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -284,7 +545,7 @@ func (x *ResourcePattern) String() string {
 func (*ResourcePattern) ProtoMessage() {}
 
 func (x *ResourcePattern) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,7 +558,7 @@ func (x *ResourcePattern) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourcePattern.ProtoReflect.Descriptor instead.
 func (*ResourcePattern) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{1}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ResourcePattern) GetPattern() string {
@@ -323,17 +584,43 @@ type ScoreFormula struct {
 	// Pattern of the artifact from which the score value will be extracted.
 	// Should start with a $resource reference to make sure artifacts are pulled
 	// out from the correct resource.
+	// The fields of this artifact are top-level variables in score_expression.
+	// Either artifact or artifacts must be set.
 	Artifact *ResourcePattern `protobuf:"bytes,1,opt,name=artifact,proto3" json:"artifact,omitempty"`
 	// A CEL expression which extracts the score value from the artifact.
+	// The labels and annotations of the artifact are the _labels and
+	// _annotations maps, e.g. _labels['tier'], and those of a named artifact
+	// are <alias>._labels and <alias>._annotations. These names begin with an
+	// underscore so they don't collide with fields of the artifact contents.
+	// Missing labels or annotations are empty maps.
+	// The fields of the scored resource are the _resource map, e.g.
+	// _resource.mime_type for specs or _resource.availability for APIs, with
+	// their protobuf names. Fields that don't exist at the level of the scored
+	// resource are absent, so expressions can check them with has(). Scores
+	// whose expressions reference _resource are also recomputed when the
+	// resource is updated.
+	// Required unless schema_validation is set.
 	ScoreExpression string `protobuf:"bytes,2,opt,name=score_expression,json=scoreExpression,proto3" json:"score_expression,omitempty"`
 	// Set an ID to reference this value in the rollup formula.
 	ReferenceId string `protobuf:"bytes,3,opt,name=reference_id,json=referenceId,proto3" json:"reference_id,omitempty"`
+	// Weight of this value when it is combined with weightedAverage() in a
+	// rollup formula. Weights must be non-negative and at least one weight
+	// in the rollup must be greater than zero.
+	Weight float32 `protobuf:"fixed32,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Named artifacts from which the score value will be extracted.
+	// Each artifact is a variable in score_expression named by its alias,
+	// which allows one expression to combine values from several artifacts.
+	Artifacts []*ScoreArtifact `protobuf:"bytes,5,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// If set, the score value is derived by validating the contents of the
+	// artifact against a JSON schema instead of with a score_expression.
+	// Can't be combined with score_expression or named artifacts.
+	SchemaValidation *SchemaValidation `protobuf:"bytes,6,opt,name=schema_validation,json=schemaValidation,proto3" json:"schema_validation,omitempty"`
 }
 
 func (x *ScoreFormula) Reset() {
 	*x = ScoreFormula{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[2]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -346,7 +633,7 @@ func (x *ScoreFormula) String() string {
 func (*ScoreFormula) ProtoMessage() {}
 
 func (x *ScoreFormula) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[2]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -359,7 +646,7 @@ func (x *ScoreFormula) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoreFormula.ProtoReflect.Descriptor instead.
 func (*ScoreFormula) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{2}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ScoreFormula) GetArtifact() *ResourcePattern {
@@ -383,6 +670,149 @@ func (x *ScoreFormula) GetReferenceId() string {
 	return ""
 }
 
+func (x *ScoreFormula) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *ScoreFormula) GetArtifacts() []*ScoreArtifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *ScoreFormula) GetSchemaValidation() *SchemaValidation {
+	if x != nil {
+		return x.SchemaValidation
+	}
+	return nil
+}
+
+// Represents the validation of the contents of an artifact against a JSON schema.
+// The value is true if the contents are valid, or the number of violations if
+// count_violations is set. Violations are listed in the description of the score.
+type SchemaValidation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON schema that the contents of the artifact should conform to.
+	// Schemas use the dialect of JSON Schema that is supported by OpenAPI 3.0.
+	Schema *structpb.Struct `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	// If true, the value is the number of violations, which requires an
+	// integer score type. Otherwise it is a boolean.
+	CountViolations bool `protobuf:"varint,2,opt,name=count_violations,json=countViolations,proto3" json:"count_violations,omitempty"`
+}
+
+func (x *SchemaValidation) Reset() {
+	*x = SchemaValidation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchemaValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaValidation) ProtoMessage() {}
+
+func (x *SchemaValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaValidation.ProtoReflect.Descriptor instead.
+func (*SchemaValidation) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SchemaValidation) GetSchema() *structpb.Struct {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *SchemaValidation) GetCountViolations() bool {
+	if x != nil {
+		return x.CountViolations
+	}
+	return false
+}
+
+// Represents an artifact that is available to a score_expression by name.
+type ScoreArtifact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the variable that holds the artifact in score_expression.
+	// Aliases must be unique within a score_formula.
+	Alias string `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	// Pattern of the artifact. Should start with a $resource reference to make
+	// sure artifacts are pulled out from the correct resource.
+	Artifact *ResourcePattern `protobuf:"bytes,2,opt,name=artifact,proto3" json:"artifact,omitempty"`
+}
+
+func (x *ScoreArtifact) Reset() {
+	*x = ScoreArtifact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreArtifact) ProtoMessage() {}
+
+func (x *ScoreArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreArtifact.ProtoReflect.Descriptor instead.
+func (*ScoreArtifact) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ScoreArtifact) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *ScoreArtifact) GetArtifact() *ResourcePattern {
+	if x != nil {
+		return x.Artifact
+	}
+	return nil
+}
+
 // Represents how multiple scores will be derived from the result artifacts
 // and rolled up into a single value.
 type RollUpFormula struct {
@@ -396,13 +826,15 @@ type RollUpFormula struct {
 	ScoreFormulas []*ScoreFormula `protobuf:"bytes,1,rep,name=score_formulas,json=scoreFormulas,proto3" json:"score_formulas,omitempty"`
 	// A CEL expression which rolls up all the scores into a single value.
 	// Uses reference_ids of score_expr in the expression.
+	// weightedAverage() returns the average of all score values weighted by
+	// the weight of each score_formula.
 	RollupExpression string `protobuf:"bytes,2,opt,name=rollup_expression,json=rollupExpression,proto3" json:"rollup_expression,omitempty"`
 }
 
 func (x *RollUpFormula) Reset() {
 	*x = RollUpFormula{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[3]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -415,7 +847,7 @@ func (x *RollUpFormula) String() string {
 func (*RollUpFormula) ProtoMessage() {}
 
 func (x *RollUpFormula) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[3]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +860,7 @@ func (x *RollUpFormula) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RollUpFormula.ProtoReflect.Descriptor instead.
 func (*RollUpFormula) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{3}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *RollUpFormula) GetScoreFormulas() []*ScoreFormula {
@@ -488,7 +920,7 @@ type PercentType struct {
 func (x *PercentType) Reset() {
 	*x = PercentType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[4]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -501,7 +933,7 @@ func (x *PercentType) String() string {
 func (*PercentType) ProtoMessage() {}
 
 func (x *PercentType) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[4]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +946,7 @@ func (x *PercentType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PercentType.ProtoReflect.Descriptor instead.
 func (*PercentType) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{4}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PercentType) GetThresholds() []*NumberThreshold {
@@ -544,7 +976,7 @@ type IntegerType struct {
 func (x *IntegerType) Reset() {
 	*x = IntegerType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[5]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +989,7 @@ func (x *IntegerType) String() string {
 func (*IntegerType) ProtoMessage() {}
 
 func (x *IntegerType) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[5]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +1002,7 @@ func (x *IntegerType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntegerType.ProtoReflect.Descriptor instead.
 func (*IntegerType) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{5}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *IntegerType) GetMinValue() int32 {
@@ -634,7 +1066,7 @@ type BooleanType struct {
 func (x *BooleanType) Reset() {
 	*x = BooleanType{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[6]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -647,7 +1079,7 @@ func (x *BooleanType) String() string {
 func (*BooleanType) ProtoMessage() {}
 
 func (x *BooleanType) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[6]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -660,7 +1092,7 @@ func (x *BooleanType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BooleanType.ProtoReflect.Descriptor instead.
 func (*BooleanType) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{6}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BooleanType) GetDisplayTrue() string {
@@ -701,7 +1133,7 @@ type NumberThreshold struct {
 func (x *NumberThreshold) Reset() {
 	*x = NumberThreshold{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[7]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -714,7 +1146,7 @@ func (x *NumberThreshold) String() string {
 func (*NumberThreshold) ProtoMessage() {}
 
 func (x *NumberThreshold) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[7]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -727,7 +1159,7 @@ func (x *NumberThreshold) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NumberThreshold.ProtoReflect.Descriptor instead.
 func (*NumberThreshold) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{7}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *NumberThreshold) GetSeverity() Severity {
@@ -761,7 +1193,7 @@ type BooleanThreshold struct {
 func (x *BooleanThreshold) Reset() {
 	*x = BooleanThreshold{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[8]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -774,7 +1206,7 @@ func (x *BooleanThreshold) String() string {
 func (*BooleanThreshold) ProtoMessage() {}
 
 func (x *BooleanThreshold) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[8]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -787,7 +1219,7 @@ func (x *BooleanThreshold) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BooleanThreshold.ProtoReflect.Descriptor instead.
 func (*BooleanThreshold) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{8}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BooleanThreshold) GetSeverity() Severity {
@@ -827,12 +1259,16 @@ type ScoreCardDefinition struct {
 	// Should start with a $resource reference to make sure artifacts are
 	// pulled out from the correct resource.
 	ScorePatterns []string `protobuf:"bytes,6,rep,name=score_patterns,json=scorePatterns,proto3" json:"score_patterns,omitempty"`
+	// Represents how the severities of the scores are combined into the
+	// severity of the ScoreCard. If not set, the severity of the ScoreCard is
+	// the highest severity of its scores.
+	SeverityAggregation *SeverityAggregation `protobuf:"bytes,7,opt,name=severity_aggregation,json=severityAggregation,proto3" json:"severity_aggregation,omitempty"`
 }
 
 func (x *ScoreCardDefinition) Reset() {
 	*x = ScoreCardDefinition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[9]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -845,7 +1281,7 @@ func (x *ScoreCardDefinition) String() string {
 func (*ScoreCardDefinition) ProtoMessage() {}
 
 func (x *ScoreCardDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[9]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +1294,7 @@ func (x *ScoreCardDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoreCardDefinition.ProtoReflect.Descriptor instead.
 func (*ScoreCardDefinition) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{9}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ScoreCardDefinition) GetId() string {
@@ -903,6 +1339,201 @@ func (x *ScoreCardDefinition) GetScorePatterns() []string {
 	return nil
 }
 
+func (x *ScoreCardDefinition) GetSeverityAggregation() *SeverityAggregation {
+	if x != nil {
+		return x.SeverityAggregation
+	}
+	return nil
+}
+
+// Represents how the severities of the scores in a ScoreCard are combined
+// into an overall severity. Scores without a severity are ignored.
+type SeverityAggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Strategy:
+	//	*SeverityAggregation_Max
+	//	*SeverityAggregation_Weighted
+	Strategy isSeverityAggregation_Strategy `protobuf_oneof:"strategy"`
+}
+
+func (x *SeverityAggregation) Reset() {
+	*x = SeverityAggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeverityAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeverityAggregation) ProtoMessage() {}
+
+func (x *SeverityAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeverityAggregation.ProtoReflect.Descriptor instead.
+func (*SeverityAggregation) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{14}
+}
+
+func (m *SeverityAggregation) GetStrategy() isSeverityAggregation_Strategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return nil
+}
+
+func (x *SeverityAggregation) GetMax() *MaxSeverityAggregation {
+	if x, ok := x.GetStrategy().(*SeverityAggregation_Max); ok {
+		return x.Max
+	}
+	return nil
+}
+
+func (x *SeverityAggregation) GetWeighted() *WeightedSeverityAggregation {
+	if x, ok := x.GetStrategy().(*SeverityAggregation_Weighted); ok {
+		return x.Weighted
+	}
+	return nil
+}
+
+type isSeverityAggregation_Strategy interface {
+	isSeverityAggregation_Strategy()
+}
+
+type SeverityAggregation_Max struct {
+	// The overall severity is the highest severity of any score.
+	// This is the default strategy.
+	Max *MaxSeverityAggregation `protobuf:"bytes,1,opt,name=max,proto3,oneof"`
+}
+
+type SeverityAggregation_Weighted struct {
+	// The overall severity is the highest severity held by at least a
+	// threshold fraction of the scores.
+	Weighted *WeightedSeverityAggregation `protobuf:"bytes,2,opt,name=weighted,proto3,oneof"`
+}
+
+func (*SeverityAggregation_Max) isSeverityAggregation_Strategy() {}
+
+func (*SeverityAggregation_Weighted) isSeverityAggregation_Strategy() {}
+
+// Represents aggregation by the highest severity of any score.
+type MaxSeverityAggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MaxSeverityAggregation) Reset() {
+	*x = MaxSeverityAggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MaxSeverityAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaxSeverityAggregation) ProtoMessage() {}
+
+func (x *MaxSeverityAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaxSeverityAggregation.ProtoReflect.Descriptor instead.
+func (*MaxSeverityAggregation) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{15}
+}
+
+// Represents aggregation by a weighted count of the scores at each severity.
+// A score counts towards its own severity and every lower severity, so the
+// overall severity is escalated to a level only when the scores at that level
+// or above reach the threshold.
+type WeightedSeverityAggregation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The fraction of the total weight of the scores that must be at a severity
+	// for the overall severity to be escalated to it. Must be in (0, 1].
+	Threshold float32 `protobuf:"fixed32,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Weights of the scores, keyed by their patterns in score_patterns.
+	// Scores without a weight have a weight of 1. Weights must be positive.
+	Weights map[string]float32 `protobuf:"bytes,2,rep,name=weights,proto3" json:"weights,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+}
+
+func (x *WeightedSeverityAggregation) Reset() {
+	*x = WeightedSeverityAggregation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeightedSeverityAggregation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeightedSeverityAggregation) ProtoMessage() {}
+
+func (x *WeightedSeverityAggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeightedSeverityAggregation.ProtoReflect.Descriptor instead.
+func (*WeightedSeverityAggregation) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WeightedSeverityAggregation) GetThreshold() float32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *WeightedSeverityAggregation) GetWeights() map[string]float32 {
+	if x != nil {
+		return x.Weights
+	}
+	return nil
+}
+
 type NumberThreshold_NumberRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -915,7 +1546,7 @@ type NumberThreshold_NumberRange struct {
 func (x *NumberThreshold_NumberRange) Reset() {
 	*x = NumberThreshold_NumberRange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[10]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -928,7 +1559,7 @@ func (x *NumberThreshold_NumberRange) String() string {
 func (*NumberThreshold_NumberRange) ProtoMessage() {}
 
 func (x *NumberThreshold_NumberRange) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[10]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1572,7 @@ func (x *NumberThreshold_NumberRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NumberThreshold_NumberRange.ProtoReflect.Descriptor instead.
 func (*NumberThreshold_NumberRange) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{7, 0}
+	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP(), []int{11, 0}
 }
 
 func (x *NumberThreshold_NumberRange) GetMin() int32 {
@@ -972,158 +1603,281 @@ var file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDesc = []byt
 	0x74, 0x6f, 0x1a, 0x35, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64,
 	0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f,
 	0x76, 0x31, 0x2f, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x65, 0x76, 0x65, 0x72,
-	0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe5, 0x05, 0x0a, 0x0f, 0x53, 0x63,
-	0x6f, 0x72, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61,
-	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69,
-	0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x72, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x28, 0x0a,
-	0x10, 0x75, 0x72, 0x69, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x72, 0x69, 0x44, 0x69, 0x73, 0x70,
-	0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x65, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e,
-	0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0e,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x5b,
-	0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63,
-	0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53,
-	0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x5e, 0x0a, 0x0e, 0x72,
-	0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
-	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x6f, 0x6c,
-	0x6c, 0x55, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x6f,
-	0x6c, 0x6c, 0x75, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x4f, 0x0a, 0x07, 0x70,
-	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
-	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63,
-	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
-	0x65, 0x48, 0x01, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x4f, 0x0a, 0x07,
-	0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69,
-	0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73,
-	0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x54, 0x79,
-	0x70, 0x65, 0x48, 0x01, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x12, 0x4f, 0x0a,
-	0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70,
-	0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
-	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54,
-	0x79, 0x70, 0x65, 0x48, 0x01, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x42, 0x09,
-	0x0a, 0x07, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x22, 0x48, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x61, 0x74,
-	0x74, 0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74,
-	0x65, 0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0xbb, 0x01, 0x0a, 0x0c,
-	0x53, 0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x58, 0x0a, 0x08,
-	0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37,
+	0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9e, 0x08, 0x0a, 0x0f, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x69,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x12, 0x28, 0x0a, 0x10, 0x75,
+	0x72, 0x69, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x75, 0x72, 0x69, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x65, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37,
 	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70,
 	0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e,
 	0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x08, 0x61, 0x72,
-	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x2e, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f,
-	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x70, 0x72,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x22, 0xa3, 0x01, 0x0a, 0x0d, 0x52, 0x6f,
-	0x6c, 0x6c, 0x55, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x60, 0x0a, 0x0e, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
+	0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0e, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0d,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
 	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
 	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x63, 0x6f,
-	0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0d,
-	0x73, 0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x73, 0x12, 0x30, 0x0a,
-	0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x10, 0x72,
-	0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
-	0x66, 0x0a, 0x0b, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x57,
-	0x0a, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
-	0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x68, 0x72,
-	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x22, 0xa5, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65,
-	0x67, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x08, 0x6d, 0x61,
-	0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
-	0x6f, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f,
+	0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x5e, 0x0a, 0x0e, 0x72, 0x6f, 0x6c,
+	0x6c, 0x75, 0x70, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x35, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x55,
+	0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x48, 0x00, 0x52, 0x0d, 0x72, 0x6f, 0x6c, 0x6c,
+	0x75, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x4f, 0x0a, 0x07, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65,
 	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72,
-	0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68,
-	0x6f, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x22,
-	0xaf, 0x01, 0x0a, 0x0b, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
-	0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x74, 0x72, 0x75, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x54, 0x72,
-	0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x66, 0x61,
-	0x6c, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c,
-	0x61, 0x79, 0x46, 0x61, 0x6c, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x67, 0x6f,
+	0x69, 0x6e, 0x67, 0x2e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x48,
+	0x01, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x4f, 0x0a, 0x07, 0x69, 0x6e,
+	0x74, 0x65, 0x67, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65,
 	0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f,
-	0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54, 0x68, 0x72, 0x65,
-	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
-	0x73, 0x22, 0x81, 0x02, 0x0a, 0x0f, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65,
-	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x72, 0x69, 0x6e, 0x67, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65,
+	0x48, 0x01, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x07, 0x62,
+	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x48, 0x01, 0x52, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x12, 0x64, 0x0a, 0x11,
+	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67,
 	0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
-	0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x08,
-	0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x5e, 0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x52, 0x10, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x73, 0x12, 0x4d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x0e, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x12, 0x3c, 0x0a, 0x1a, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x54, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x12,
+	0x44, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x5f, 0x77, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x57,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x42, 0x09, 0x0a, 0x07, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61,
+	0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x92, 0x03, 0x0a, 0x0b, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x02, 0x69, 0x64, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41,
+	0x02, 0x52, 0x0f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x12, 0x4f, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x49, 0x6e,
+	0x74, 0x65, 0x67, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x74,
+	0x65, 0x67, 0x65, 0x72, 0x12, 0x4f, 0x0a, 0x07, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63,
+	0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x07, 0x62, 0x6f,
+	0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x90, 0x01,
+	0x0a, 0x0f, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x44, 0x69, 0x73, 0x70, 0x6c, 0x61,
+	0x79, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x6c, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72,
+	0x22, 0x48, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x85, 0x03, 0x0a, 0x0c, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x53, 0x0a, 0x08, 0x61,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69,
+	0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73,
+	0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x12, 0x29, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x53, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69,
+	0x6e, 0x67, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x65, 0x0a, 0x11, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x10, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x73, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x42,
+	0x03, 0xe0, 0x41, 0x02, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x29, 0x0a, 0x10,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x56, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x0d, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x12, 0x58, 0x0a, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x42,
+	0x03, 0xe0, 0x41, 0x02, 0x52, 0x08, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x22, 0xa3,
+	0x01, 0x0a, 0x0d, 0x52, 0x6f, 0x6c, 0x6c, 0x55, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61,
+	0x12, 0x60, 0x0a, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c,
+	0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e,
+	0x67, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x42, 0x03,
+	0xe0, 0x41, 0x02, 0x52, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c,
+	0x61, 0x73, 0x12, 0x30, 0x0a, 0x11, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x5f, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0,
+	0x41, 0x02, 0x52, 0x10, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x0b, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67,
 	0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
 	0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
-	0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x03, 0xe0, 0x41,
-	0x02, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x3b, 0x0a, 0x0b, 0x4e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x15,
-	0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x02,
-	0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x80, 0x01, 0x0a, 0x10, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
-	0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x65,
+	0x52, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x22, 0xa5, 0x01, 0x0a,
+	0x0b, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41,
+	0x02, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x57, 0x0a, 0x0a, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61,
+	0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x73, 0x22, 0xaf, 0x01, 0x0a, 0x0b, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x74, 0x72, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x54, 0x72, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x46, 0x61, 0x6c, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0a,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x38, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e,
+	0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76,
+	0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61,
+	0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x52, 0x0a, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x73, 0x22, 0x81, 0x02, 0x0a, 0x0f, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x65,
 	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x30, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
 	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63,
 	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x42, 0x03,
-	0xe0, 0x41, 0x02, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x19, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x42, 0x03, 0xe0, 0x41,
-	0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x96, 0x02, 0x0a, 0x13, 0x53, 0x63, 0x6f,
-	0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x13, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41,
-	0x02, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73,
-	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x65,
-	0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
-	0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x70,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x42, 0x03, 0xe0,
-	0x41, 0x02, 0x52, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
-	0x73, 0x42, 0x6a, 0x0a, 0x2a, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x42,
-	0x16, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f, 0x72, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0xe0, 0x41, 0x02, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x5e, 0x0a,
+	0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x3b, 0x0a,
+	0x0b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x03,
+	0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x03,
+	0x6d, 0x69, 0x6e, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x80, 0x01, 0x0a, 0x10, 0x42,
+	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x51, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x30, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x86, 0x03,
+	0x0a, 0x13, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x72, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x65, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x0e, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x6e, 0x0a, 0x14, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69,
+	0x74, 0x79, 0x5f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65,
+	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x13, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xd8, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x52,
+	0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65,
+	0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f,
+	0x72, 0x69, 0x6e, 0x67, 0x2e, 0x4d, 0x61, 0x78, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x03, 0x6d,
+	0x61, 0x78, 0x12, 0x61, 0x0a, 0x08, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x57, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x08, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x65, 0x64, 0x42, 0x0a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x22, 0x18, 0x0a, 0x16, 0x4d, 0x61, 0x78, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe8, 0x01, 0x0a, 0x1b,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x42, 0x03,
+	0xe0, 0x41, 0x02, 0x52, 0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x6a,
+	0x0a, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x50, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61,
+	0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65,
+	0x64, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x57, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x6a, 0x0a, 0x2a, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65,
+	0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f,
+	0x72, 0x69, 0x6e, 0x67, 0x42, 0x16, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65,
+	0x65, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72,
+	0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1138,42 +1892,67 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescGZIP()
 	return file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDescData
 }
 
-var file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_google_cloud_apigeeregistry_v1_scoring_definition_proto_goTypes = []interface{}{
 	(*ScoreDefinition)(nil),             // 0: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition
-	(*ResourcePattern)(nil),             // 1: google.cloud.apigeeregistry.v1.scoring.ResourcePattern
-	(*ScoreFormula)(nil),                // 2: google.cloud.apigeeregistry.v1.scoring.ScoreFormula
-	(*RollUpFormula)(nil),               // 3: google.cloud.apigeeregistry.v1.scoring.RollUpFormula
-	(*PercentType)(nil),                 // 4: google.cloud.apigeeregistry.v1.scoring.PercentType
-	(*IntegerType)(nil),                 // 5: google.cloud.apigeeregistry.v1.scoring.IntegerType
-	(*BooleanType)(nil),                 // 6: google.cloud.apigeeregistry.v1.scoring.BooleanType
-	(*NumberThreshold)(nil),             // 7: google.cloud.apigeeregistry.v1.scoring.NumberThreshold
-	(*BooleanThreshold)(nil),            // 8: google.cloud.apigeeregistry.v1.scoring.BooleanThreshold
-	(*ScoreCardDefinition)(nil),         // 9: google.cloud.apigeeregistry.v1.scoring.ScoreCardDefinition
-	(*NumberThreshold_NumberRange)(nil), // 10: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.NumberRange
-	(Severity)(0),                       // 11: google.cloud.apigeeregistry.v1.scoring.Severity
+	(*ScoreOutput)(nil),                 // 1: google.cloud.apigeeregistry.v1.scoring.ScoreOutput
+	(*SeverityDisplay)(nil),             // 2: google.cloud.apigeeregistry.v1.scoring.SeverityDisplay
+	(*ResourcePattern)(nil),             // 3: google.cloud.apigeeregistry.v1.scoring.ResourcePattern
+	(*ScoreFormula)(nil),                // 4: google.cloud.apigeeregistry.v1.scoring.ScoreFormula
+	(*SchemaValidation)(nil),            // 5: google.cloud.apigeeregistry.v1.scoring.SchemaValidation
+	(*ScoreArtifact)(nil),               // 6: google.cloud.apigeeregistry.v1.scoring.ScoreArtifact
+	(*RollUpFormula)(nil),               // 7: google.cloud.apigeeregistry.v1.scoring.RollUpFormula
+	(*PercentType)(nil),                 // 8: google.cloud.apigeeregistry.v1.scoring.PercentType
+	(*IntegerType)(nil),                 // 9: google.cloud.apigeeregistry.v1.scoring.IntegerType
+	(*BooleanType)(nil),                 // 10: google.cloud.apigeeregistry.v1.scoring.BooleanType
+	(*NumberThreshold)(nil),             // 11: google.cloud.apigeeregistry.v1.scoring.NumberThreshold
+	(*BooleanThreshold)(nil),            // 12: google.cloud.apigeeregistry.v1.scoring.BooleanThreshold
+	(*ScoreCardDefinition)(nil),         // 13: google.cloud.apigeeregistry.v1.scoring.ScoreCardDefinition
+	(*SeverityAggregation)(nil),         // 14: google.cloud.apigeeregistry.v1.scoring.SeverityAggregation
+	(*MaxSeverityAggregation)(nil),      // 15: google.cloud.apigeeregistry.v1.scoring.MaxSeverityAggregation
+	(*WeightedSeverityAggregation)(nil), // 16: google.cloud.apigeeregistry.v1.scoring.WeightedSeverityAggregation
+	(*NumberThreshold_NumberRange)(nil), // 17: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.NumberRange
+	nil,                                 // 18: google.cloud.apigeeregistry.v1.scoring.WeightedSeverityAggregation.WeightsEntry
+	(*durationpb.Duration)(nil),         // 19: google.protobuf.Duration
+	(Severity)(0),                       // 20: google.cloud.apigeeregistry.v1.scoring.Severity
+	(*structpb.Struct)(nil),             // 21: google.protobuf.Struct
 }
 var file_google_cloud_apigeeregistry_v1_scoring_definition_proto_depIdxs = []int32{
-	1,  // 0: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.target_resource:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
-	2,  // 1: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.score_formula:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreFormula
-	3,  // 2: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.rollup_formula:type_name -> google.cloud.apigeeregistry.v1.scoring.RollUpFormula
-	4,  // 3: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.percent:type_name -> google.cloud.apigeeregistry.v1.scoring.PercentType
-	5,  // 4: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.integer:type_name -> google.cloud.apigeeregistry.v1.scoring.IntegerType
-	6,  // 5: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.boolean:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanType
-	1,  // 6: google.cloud.apigeeregistry.v1.scoring.ScoreFormula.artifact:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
-	2,  // 7: google.cloud.apigeeregistry.v1.scoring.RollUpFormula.score_formulas:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreFormula
-	7,  // 8: google.cloud.apigeeregistry.v1.scoring.PercentType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold
-	7,  // 9: google.cloud.apigeeregistry.v1.scoring.IntegerType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold
-	8,  // 10: google.cloud.apigeeregistry.v1.scoring.BooleanType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanThreshold
-	11, // 11: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
-	10, // 12: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.range:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold.NumberRange
-	11, // 13: google.cloud.apigeeregistry.v1.scoring.BooleanThreshold.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
-	1,  // 14: google.cloud.apigeeregistry.v1.scoring.ScoreCardDefinition.target_resource:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
-	15, // [15:15] is the sub-list for method output_type
-	15, // [15:15] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	3,  // 0: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.target_resource:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
+	4,  // 1: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.score_formula:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreFormula
+	7,  // 2: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.rollup_formula:type_name -> google.cloud.apigeeregistry.v1.scoring.RollUpFormula
+	8,  // 3: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.percent:type_name -> google.cloud.apigeeregistry.v1.scoring.PercentType
+	9,  // 4: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.integer:type_name -> google.cloud.apigeeregistry.v1.scoring.IntegerType
+	10, // 5: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.boolean:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanType
+	2,  // 6: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.severity_displays:type_name -> google.cloud.apigeeregistry.v1.scoring.SeverityDisplay
+	1,  // 7: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.outputs:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreOutput
+	19, // 8: google.cloud.apigeeregistry.v1.scoring.ScoreDefinition.staleness_window:type_name -> google.protobuf.Duration
+	8,  // 9: google.cloud.apigeeregistry.v1.scoring.ScoreOutput.percent:type_name -> google.cloud.apigeeregistry.v1.scoring.PercentType
+	9,  // 10: google.cloud.apigeeregistry.v1.scoring.ScoreOutput.integer:type_name -> google.cloud.apigeeregistry.v1.scoring.IntegerType
+	10, // 11: google.cloud.apigeeregistry.v1.scoring.ScoreOutput.boolean:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanType
+	20, // 12: google.cloud.apigeeregistry.v1.scoring.SeverityDisplay.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
+	3,  // 13: google.cloud.apigeeregistry.v1.scoring.ScoreFormula.artifact:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
+	6,  // 14: google.cloud.apigeeregistry.v1.scoring.ScoreFormula.artifacts:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreArtifact
+	5,  // 15: google.cloud.apigeeregistry.v1.scoring.ScoreFormula.schema_validation:type_name -> google.cloud.apigeeregistry.v1.scoring.SchemaValidation
+	21, // 16: google.cloud.apigeeregistry.v1.scoring.SchemaValidation.schema:type_name -> google.protobuf.Struct
+	3,  // 17: google.cloud.apigeeregistry.v1.scoring.ScoreArtifact.artifact:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
+	4,  // 18: google.cloud.apigeeregistry.v1.scoring.RollUpFormula.score_formulas:type_name -> google.cloud.apigeeregistry.v1.scoring.ScoreFormula
+	11, // 19: google.cloud.apigeeregistry.v1.scoring.PercentType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold
+	11, // 20: google.cloud.apigeeregistry.v1.scoring.IntegerType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold
+	12, // 21: google.cloud.apigeeregistry.v1.scoring.BooleanType.thresholds:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanThreshold
+	20, // 22: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
+	17, // 23: google.cloud.apigeeregistry.v1.scoring.NumberThreshold.range:type_name -> google.cloud.apigeeregistry.v1.scoring.NumberThreshold.NumberRange
+	20, // 24: google.cloud.apigeeregistry.v1.scoring.BooleanThreshold.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
+	3,  // 25: google.cloud.apigeeregistry.v1.scoring.ScoreCardDefinition.target_resource:type_name -> google.cloud.apigeeregistry.v1.scoring.ResourcePattern
+	14, // 26: google.cloud.apigeeregistry.v1.scoring.ScoreCardDefinition.severity_aggregation:type_name -> google.cloud.apigeeregistry.v1.scoring.SeverityAggregation
+	15, // 27: google.cloud.apigeeregistry.v1.scoring.SeverityAggregation.max:type_name -> google.cloud.apigeeregistry.v1.scoring.MaxSeverityAggregation
+	16, // 28: google.cloud.apigeeregistry.v1.scoring.SeverityAggregation.weighted:type_name -> google.cloud.apigeeregistry.v1.scoring.WeightedSeverityAggregation
+	18, // 29: google.cloud.apigeeregistry.v1.scoring.WeightedSeverityAggregation.weights:type_name -> google.cloud.apigeeregistry.v1.scoring.WeightedSeverityAggregation.WeightsEntry
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() }
@@ -1196,7 +1975,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourcePattern); i {
+			switch v := v.(*ScoreOutput); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1208,7 +1987,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScoreFormula); i {
+			switch v := v.(*SeverityDisplay); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1220,7 +1999,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RollUpFormula); i {
+			switch v := v.(*ResourcePattern); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1232,7 +2011,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PercentType); i {
+			switch v := v.(*ScoreFormula); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1244,7 +2023,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntegerType); i {
+			switch v := v.(*SchemaValidation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1256,7 +2035,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BooleanType); i {
+			switch v := v.(*ScoreArtifact); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1268,7 +2047,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NumberThreshold); i {
+			switch v := v.(*RollUpFormula); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1280,7 +2059,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BooleanThreshold); i {
+			switch v := v.(*PercentType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1292,7 +2071,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScoreCardDefinition); i {
+			switch v := v.(*IntegerType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1304,6 +2083,90 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BooleanType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NumberThreshold); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BooleanThreshold); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoreCardDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeverityAggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MaxSeverityAggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WeightedSeverityAggregation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*NumberThreshold_NumberRange); i {
 			case 0:
 				return &v.state
@@ -1323,13 +2186,22 @@ func file_google_cloud_apigeeregistry_v1_scoring_definition_proto_init() {
 		(*ScoreDefinition_Integer)(nil),
 		(*ScoreDefinition_Boolean)(nil),
 	}
+	file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ScoreOutput_Percent)(nil),
+		(*ScoreOutput_Integer)(nil),
+		(*ScoreOutput_Boolean)(nil),
+	}
+	file_google_cloud_apigeeregistry_v1_scoring_definition_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*SeverityAggregation_Max)(nil),
+		(*SeverityAggregation_Weighted)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_google_cloud_apigeeregistry_v1_scoring_definition_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -71,6 +71,15 @@ type Score struct {
 	//	*Score_IntegerValue
 	//	*Score_BooleanValue
 	Value isScore_Value `protobuf_oneof:"value"`
+	// A human-friendly label for the severity (populated from ScoreDefinition).
+	// Defaults to the name of the severity level.
+	SeverityLabel string `protobuf:"bytes,12,opt,name=severity_label,json=severityLabel,proto3" json:"severity_label,omitempty"`
+	// A color for the severity (populated from ScoreDefinition).
+	SeverityColor string `protobuf:"bytes,13,opt,name=severity_color,json=severityColor,proto3" json:"severity_color,omitempty"`
+	// The values of the score_formulas of a rollup_formula, keyed by their
+	// reference_id. These are reused when the score is recalculated after
+	// the artifacts of only some of the score_formulas have changed.
+	ReferenceValues map[string]*ReferenceValue `protobuf:"bytes,14,rep,name=reference_values,json=referenceValues,proto3" json:"reference_values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *Score) Reset() {
@@ -189,6 +198,27 @@ func (x *Score) GetBooleanValue() *BooleanValue {
 	return nil
 }
 
+func (x *Score) GetSeverityLabel() string {
+	if x != nil {
+		return x.SeverityLabel
+	}
+	return ""
+}
+
+func (x *Score) GetSeverityColor() string {
+	if x != nil {
+		return x.SeverityColor
+	}
+	return ""
+}
+
+func (x *Score) GetReferenceValues() map[string]*ReferenceValue {
+	if x != nil {
+		return x.ReferenceValues
+	}
+	return nil
+}
+
 type isScore_Value interface {
 	isScore_Value()
 }
@@ -214,6 +244,104 @@ func (*Score_IntegerValue) isScore_Value() {}
 
 func (*Score_BooleanValue) isScore_Value() {}
 
+// The value of a score_formula that is referenced by a rollup_formula.
+type ReferenceValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*ReferenceValue_IntegerValue
+	//	*ReferenceValue_DoubleValue
+	//	*ReferenceValue_BooleanValue
+	Value isReferenceValue_Value `protobuf_oneof:"value"`
+}
+
+func (x *ReferenceValue) Reset() {
+	*x = ReferenceValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReferenceValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceValue) ProtoMessage() {}
+
+func (x *ReferenceValue) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceValue.ProtoReflect.Descriptor instead.
+func (*ReferenceValue) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *ReferenceValue) GetValue() isReferenceValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *ReferenceValue) GetIntegerValue() int64 {
+	if x, ok := x.GetValue().(*ReferenceValue_IntegerValue); ok {
+		return x.IntegerValue
+	}
+	return 0
+}
+
+func (x *ReferenceValue) GetDoubleValue() float64 {
+	if x, ok := x.GetValue().(*ReferenceValue_DoubleValue); ok {
+		return x.DoubleValue
+	}
+	return 0
+}
+
+func (x *ReferenceValue) GetBooleanValue() bool {
+	if x, ok := x.GetValue().(*ReferenceValue_BooleanValue); ok {
+		return x.BooleanValue
+	}
+	return false
+}
+
+type isReferenceValue_Value interface {
+	isReferenceValue_Value()
+}
+
+type ReferenceValue_IntegerValue struct {
+	// This is set if the score_expression evaluated to an integer.
+	IntegerValue int64 `protobuf:"varint,1,opt,name=integer_value,json=integerValue,proto3,oneof"`
+}
+
+type ReferenceValue_DoubleValue struct {
+	// This is set if the score_expression evaluated to a double.
+	DoubleValue float64 `protobuf:"fixed64,2,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type ReferenceValue_BooleanValue struct {
+	// This is set if the score_expression evaluated to a boolean.
+	BooleanValue bool `protobuf:"varint,3,opt,name=boolean_value,json=booleanValue,proto3,oneof"`
+}
+
+func (*ReferenceValue_IntegerValue) isReferenceValue_Value() {}
+
+func (*ReferenceValue_DoubleValue) isReferenceValue_Value() {}
+
+func (*ReferenceValue_BooleanValue) isReferenceValue_Value() {}
+
 // Represents the score which is a percentage.
 type PercentValue struct {
 	state         protoimpl.MessageState
@@ -227,7 +355,7 @@ type PercentValue struct {
 func (x *PercentValue) Reset() {
 	*x = PercentValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -240,7 +368,7 @@ func (x *PercentValue) String() string {
 func (*PercentValue) ProtoMessage() {}
 
 func (x *PercentValue) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -253,7 +381,7 @@ func (x *PercentValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PercentValue.ProtoReflect.Descriptor instead.
 func (*PercentValue) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{1}
+	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *PercentValue) GetValue() float32 {
@@ -281,7 +409,7 @@ type IntegerValue struct {
 func (x *IntegerValue) Reset() {
 	*x = IntegerValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[2]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -294,7 +422,7 @@ func (x *IntegerValue) String() string {
 func (*IntegerValue) ProtoMessage() {}
 
 func (x *IntegerValue) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[2]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +435,7 @@ func (x *IntegerValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntegerValue.ProtoReflect.Descriptor instead.
 func (*IntegerValue) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{2}
+	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *IntegerValue) GetValue() int32 {
@@ -348,7 +476,7 @@ type BooleanValue struct {
 func (x *BooleanValue) Reset() {
 	*x = BooleanValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[3]
+		mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -361,7 +489,7 @@ func (x *BooleanValue) String() string {
 func (*BooleanValue) ProtoMessage() {}
 
 func (x *BooleanValue) ProtoReflect() protoreflect.Message {
-	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[3]
+	mi := &file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,7 +502,7 @@ func (x *BooleanValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BooleanValue.ProtoReflect.Descriptor instead.
 func (*BooleanValue) Descriptor() ([]byte, []int) {
-	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{3}
+	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *BooleanValue) GetValue() bool {
@@ -405,7 +533,7 @@ var file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDesc = []byte{
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2f, 0x61, 0x70, 0x69, 0x67,
 	0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x63,
 	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcd, 0x04, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x13,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86, 0x07, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x13,
 	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52,
 	0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c,
@@ -441,29 +569,57 @@ var file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDesc = []byte{
 	0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73,
 	0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x42,
 	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x62,
-	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x0c, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x02, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
-	0x63, 0x0a, 0x0c, 0x49, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
-	0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03,
-	0xe0, 0x41, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69,
-	0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
-	0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x22, 0x4e, 0x0a, 0x0c, 0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
-	0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x65, 0x0a, 0x2a, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69,
-	0x6e, 0x67, 0x42, 0x11, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x63, 0x6f, 0x72, 0x65,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x63,
+	0x6f, 0x6c, 0x6f, 0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x6d, 0x0a, 0x10, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0e, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x1a, 0x7a, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x4c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x36, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8c, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x25, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0c, 0x64, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
+	0x0b, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a, 0x0d,
+	0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x0c,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x42, 0x03, 0xe0, 0x41, 0x02,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x63, 0x0a, 0x0c, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x4e, 0x0a, 0x0c,
+	0x42, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x42, 0x03, 0xe0, 0x41, 0x02,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x65, 0x0a, 0x2a,
+	0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x11, 0x53, 0x63, 0x6f, 0x72,
+	0x69, 0x6e, 0x67, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a,
+	0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67,
+	0x65, 0x65, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b,
+	0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -478,24 +634,28 @@ func file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescGZIP() []byt
 	return file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDescData
 }
 
-var file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_google_cloud_apigeeregistry_v1_scoring_score_proto_goTypes = []interface{}{
-	(*Score)(nil),        // 0: google.cloud.apigeeregistry.v1.scoring.Score
-	(*PercentValue)(nil), // 1: google.cloud.apigeeregistry.v1.scoring.PercentValue
-	(*IntegerValue)(nil), // 2: google.cloud.apigeeregistry.v1.scoring.IntegerValue
-	(*BooleanValue)(nil), // 3: google.cloud.apigeeregistry.v1.scoring.BooleanValue
-	(Severity)(0),        // 4: google.cloud.apigeeregistry.v1.scoring.Severity
+	(*Score)(nil),          // 0: google.cloud.apigeeregistry.v1.scoring.Score
+	(*ReferenceValue)(nil), // 1: google.cloud.apigeeregistry.v1.scoring.ReferenceValue
+	(*PercentValue)(nil),   // 2: google.cloud.apigeeregistry.v1.scoring.PercentValue
+	(*IntegerValue)(nil),   // 3: google.cloud.apigeeregistry.v1.scoring.IntegerValue
+	(*BooleanValue)(nil),   // 4: google.cloud.apigeeregistry.v1.scoring.BooleanValue
+	nil,                    // 5: google.cloud.apigeeregistry.v1.scoring.Score.ReferenceValuesEntry
+	(Severity)(0),          // 6: google.cloud.apigeeregistry.v1.scoring.Severity
 }
 var file_google_cloud_apigeeregistry_v1_scoring_score_proto_depIdxs = []int32{
-	4, // 0: google.cloud.apigeeregistry.v1.scoring.Score.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
-	1, // 1: google.cloud.apigeeregistry.v1.scoring.Score.percent_value:type_name -> google.cloud.apigeeregistry.v1.scoring.PercentValue
-	2, // 2: google.cloud.apigeeregistry.v1.scoring.Score.integer_value:type_name -> google.cloud.apigeeregistry.v1.scoring.IntegerValue
-	3, // 3: google.cloud.apigeeregistry.v1.scoring.Score.boolean_value:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanValue
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6, // 0: google.cloud.apigeeregistry.v1.scoring.Score.severity:type_name -> google.cloud.apigeeregistry.v1.scoring.Severity
+	2, // 1: google.cloud.apigeeregistry.v1.scoring.Score.percent_value:type_name -> google.cloud.apigeeregistry.v1.scoring.PercentValue
+	3, // 2: google.cloud.apigeeregistry.v1.scoring.Score.integer_value:type_name -> google.cloud.apigeeregistry.v1.scoring.IntegerValue
+	4, // 3: google.cloud.apigeeregistry.v1.scoring.Score.boolean_value:type_name -> google.cloud.apigeeregistry.v1.scoring.BooleanValue
+	5, // 4: google.cloud.apigeeregistry.v1.scoring.Score.reference_values:type_name -> google.cloud.apigeeregistry.v1.scoring.Score.ReferenceValuesEntry
+	1, // 5: google.cloud.apigeeregistry.v1.scoring.Score.ReferenceValuesEntry.value:type_name -> google.cloud.apigeeregistry.v1.scoring.ReferenceValue
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_google_cloud_apigeeregistry_v1_scoring_score_proto_init() }
@@ -518,7 +678,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_score_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PercentValue); i {
+			switch v := v.(*ReferenceValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -530,7 +690,7 @@ func file_google_cloud_apigeeregistry_v1_scoring_score_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IntegerValue); i {
+			switch v := v.(*PercentValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -542,6 +702,18 @@ func file_google_cloud_apigeeregistry_v1_scoring_score_proto_init() {
 			}
 		}
 		file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IntegerValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BooleanValue); i {
 			case 0:
 				return &v.state
@@ -559,13 +731,18 @@ func file_google_cloud_apigeeregistry_v1_scoring_score_proto_init() {
 		(*Score_IntegerValue)(nil),
 		(*Score_BooleanValue)(nil),
 	}
+	file_google_cloud_apigeeregistry_v1_scoring_score_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ReferenceValue_IntegerValue)(nil),
+		(*ReferenceValue_DoubleValue)(nil),
+		(*ReferenceValue_BooleanValue)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_google_cloud_apigeeregistry_v1_scoring_score_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -375,6 +375,9 @@ type Rule struct {
 	Severity Rule_Severity `protobuf:"varint,6,opt,name=severity,proto3,enum=google.cloud.apigeeregistry.v1.style.Rule_Severity" json:"severity,omitempty"`
 	// A link to additional documentation relating to this rule.
 	DocUri string `protobuf:"bytes,7,opt,name=doc_uri,json=docUri,proto3" json:"doc_uri,omitempty"`
+	// Other linters that can enforce this rule, in order of preference.
+	// The first available linter of linter and alternate_linters is used.
+	AlternateLinters []*AlternateLinter `protobuf:"bytes,8,rep,name=alternate_linters,json=alternateLinters,proto3" json:"alternate_linters,omitempty"`
 }
 
 func (x *Rule) Reset() {
@@ -458,6 +461,72 @@ func (x *Rule) GetDocUri() string {
 	return ""
 }
 
+func (x *Rule) GetAlternateLinters() []*AlternateLinter {
+	if x != nil {
+		return x.AlternateLinters
+	}
+	return nil
+}
+
+// AlternateLinter is a linter that can enforce a rule when the linter
+// that the rule prefers is not available.
+type AlternateLinter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the linter.
+	Linter string `protobuf:"bytes,1,opt,name=linter,proto3" json:"linter,omitempty"`
+	// Name of the rule on this linter that enforces the rule.
+	LinterRulename string `protobuf:"bytes,2,opt,name=linter_rulename,json=linterRulename,proto3" json:"linter_rulename,omitempty"`
+}
+
+func (x *AlternateLinter) Reset() {
+	*x = AlternateLinter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_google_cloud_apigeeregistry_v1_style_style_guide_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlternateLinter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlternateLinter) ProtoMessage() {}
+
+func (x *AlternateLinter) ProtoReflect() protoreflect.Message {
+	mi := &file_google_cloud_apigeeregistry_v1_style_style_guide_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlternateLinter.ProtoReflect.Descriptor instead.
+func (*AlternateLinter) Descriptor() ([]byte, []int) {
+	return file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AlternateLinter) GetLinter() string {
+	if x != nil {
+		return x.Linter
+	}
+	return ""
+}
+
+func (x *AlternateLinter) GetLinterRulename() string {
+	if x != nil {
+		return x.LinterRulename
+	}
+	return ""
+}
+
 var File_google_cloud_apigeeregistry_v1_style_style_guide_proto protoreflect.FileDescriptor
 
 var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDesc = []byte{
@@ -511,7 +580,7 @@ var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDesc = []byte
 	0x50, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56,
 	0x45, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x45, 0x50, 0x52, 0x45, 0x43, 0x41, 0x54, 0x45,
 	0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10,
-	0x04, 0x22, 0xe7, 0x02, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64,
+	0x04, 0x22, 0xcb, 0x03, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x13, 0x0a, 0x02, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x02, 0x69, 0x64, 0x12,
 	0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61,
@@ -528,19 +597,31 @@ var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDesc = []byte
 	0x76, 0x31, 0x2e, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x53, 0x65,
 	0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79,
 	0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x55, 0x72, 0x69, 0x22, 0x50, 0x0a, 0x08, 0x53, 0x65, 0x76,
-	0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54,
-	0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41,
-	0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10,
-	0x03, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x4e, 0x54, 0x10, 0x04, 0x42, 0x61, 0x0a, 0x28, 0x63,
-	0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x2e,
-	0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x42, 0x0f, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x47, 0x75,
-	0x69, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x09, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x55, 0x72, 0x69, 0x12, 0x62, 0x0a, 0x11, 0x61, 0x6c, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c,
+	0x6f, 0x75, 0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x2e, 0x41, 0x6c, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x10, 0x61, 0x6c, 0x74,
+	0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x22, 0x50, 0x0a,
+	0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x56,
+	0x45, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x49,
+	0x4e, 0x46, 0x4f, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x4e, 0x54, 0x10, 0x04, 0x22,
+	0x5c, 0x0a, 0x0f, 0x41, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x0f, 0x6c, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x03, 0xe0, 0x41, 0x02, 0x52, 0x0e, 0x6c,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x61, 0x0a,
+	0x28, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x6c, 0x6f, 0x75,
+	0x64, 0x2e, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x42, 0x0f, 0x53, 0x74, 0x79, 0x6c, 0x65,
+	0x47, 0x75, 0x69, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x22, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x69, 0x67, 0x65, 0x65, 0x2f,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x2f, 0x72, 0x70, 0x63, 0x3b, 0x72, 0x70, 0x63,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -556,26 +637,28 @@ func file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDescGZIP() [
 }
 
 var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_goTypes = []interface{}{
-	(Guideline_State)(0), // 0: google.cloud.apigeeregistry.v1.style.Guideline.State
-	(Rule_Severity)(0),   // 1: google.cloud.apigeeregistry.v1.style.Rule.Severity
-	(*StyleGuide)(nil),   // 2: google.cloud.apigeeregistry.v1.style.StyleGuide
-	(*Guideline)(nil),    // 3: google.cloud.apigeeregistry.v1.style.Guideline
-	(*Rule)(nil),         // 4: google.cloud.apigeeregistry.v1.style.Rule
-	(*Linter)(nil),       // 5: google.cloud.apigeeregistry.v1.style.Linter
+	(Guideline_State)(0),    // 0: google.cloud.apigeeregistry.v1.style.Guideline.State
+	(Rule_Severity)(0),      // 1: google.cloud.apigeeregistry.v1.style.Rule.Severity
+	(*StyleGuide)(nil),      // 2: google.cloud.apigeeregistry.v1.style.StyleGuide
+	(*Guideline)(nil),       // 3: google.cloud.apigeeregistry.v1.style.Guideline
+	(*Rule)(nil),            // 4: google.cloud.apigeeregistry.v1.style.Rule
+	(*AlternateLinter)(nil), // 5: google.cloud.apigeeregistry.v1.style.AlternateLinter
+	(*Linter)(nil),          // 6: google.cloud.apigeeregistry.v1.style.Linter
 }
 var file_google_cloud_apigeeregistry_v1_style_style_guide_proto_depIdxs = []int32{
 	3, // 0: google.cloud.apigeeregistry.v1.style.StyleGuide.guidelines:type_name -> google.cloud.apigeeregistry.v1.style.Guideline
-	5, // 1: google.cloud.apigeeregistry.v1.style.StyleGuide.linters:type_name -> google.cloud.apigeeregistry.v1.style.Linter
+	6, // 1: google.cloud.apigeeregistry.v1.style.StyleGuide.linters:type_name -> google.cloud.apigeeregistry.v1.style.Linter
 	4, // 2: google.cloud.apigeeregistry.v1.style.Guideline.rules:type_name -> google.cloud.apigeeregistry.v1.style.Rule
 	0, // 3: google.cloud.apigeeregistry.v1.style.Guideline.state:type_name -> google.cloud.apigeeregistry.v1.style.Guideline.State
 	1, // 4: google.cloud.apigeeregistry.v1.style.Rule.severity:type_name -> google.cloud.apigeeregistry.v1.style.Rule.Severity
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	5, // 5: google.cloud.apigeeregistry.v1.style.Rule.alternate_linters:type_name -> google.cloud.apigeeregistry.v1.style.AlternateLinter
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_google_cloud_apigeeregistry_v1_style_style_guide_proto_init() }
@@ -621,6 +704,18 @@ func file_google_cloud_apigeeregistry_v1_style_style_guide_proto_init() {
 				return nil
 			}
 		}
+		file_google_cloud_apigeeregistry_v1_style_style_guide_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AlternateLinter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -628,7 +723,7 @@ func file_google_cloud_apigeeregistry_v1_style_style_guide_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_google_cloud_apigeeregistry_v1_style_style_guide_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -314,6 +314,12 @@ func (s *RegistryServer) ReplaceArtifact(ctx context.Context, req *rpc.ReplaceAr
 		}
 		artifact.CreateTime = art.CreateTime // preserve creation time
 		artifact.RevisionID = art.RevisionID // revision is optional in request
+		// Requests without contents only replace metadata, existing contents are kept.
+		if len(req.Artifact.GetContents()) == 0 {
+			artifact.SizeInBytes = art.SizeInBytes
+			artifact.Hash = art.Hash
+			return db.SaveArtifact(ctx, artifact)
+		}
 		if err := db.SaveArtifact(ctx, artifact); err != nil {
 			return err
 		}
@@ -15,6 +15,7 @@
 package registry
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"testing"
@@ -1264,6 +1265,54 @@ func TestReplaceArtifact(t *testing.T) {
 	}
 }
 
+func TestReplaceArtifactWithoutContents(t *testing.T) {
+	ctx := context.Background()
+	server := defaultTestServer(t)
+	seed := &rpc.Artifact{
+		Name:     "projects/my-project/locations/global/artifacts/my-artifact",
+		MimeType: "application/json",
+		Contents: artifactContents,
+	}
+	if err := seeder.SeedArtifacts(ctx, server, seed); err != nil {
+		t.Fatalf("Setup/Seeding: Failed to seed registry: %s", err)
+	}
+
+	req := &rpc.ReplaceArtifactRequest{
+		Artifact: &rpc.Artifact{
+			Name:     seed.Name,
+			MimeType: seed.MimeType,
+			Labels:   map[string]string{"label-key": "label-value"},
+		},
+	}
+	updated, err := server.ReplaceArtifact(ctx, req)
+	if err != nil {
+		t.Fatalf("ReplaceArtifact(%+v) returned error: %s", req, err)
+	}
+
+	want := &rpc.Artifact{
+		Name:      seed.Name,
+		MimeType:  seed.MimeType,
+		SizeBytes: int32(len(artifactContents)),
+		Hash:      sha256hash(artifactContents),
+		Labels:    map[string]string{"label-key": "label-value"},
+	}
+	opts := cmp.Options{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(new(rpc.Artifact), "create_time", "update_time"),
+	}
+	if !cmp.Equal(want, updated, opts) {
+		t.Errorf("ReplaceArtifact(%+v) returned unexpected diff (-want +got):\n%s", req, cmp.Diff(want, updated, opts))
+	}
+
+	body, err := server.GetArtifactContents(ctx, &rpc.GetArtifactContentsRequest{Name: seed.Name})
+	if err != nil {
+		t.Fatalf("GetArtifactContents(%q) returned error: %s", seed.Name, err)
+	}
+	if !bytes.Equal(body.GetData(), artifactContents) {
+		t.Errorf("GetArtifactContents(%q) returned %q, want %q", seed.Name, body.GetData(), artifactContents)
+	}
+}
+
 func TestReplaceArtifactResponseCodes(t *testing.T) {
 	tests := []struct {
 		desc string
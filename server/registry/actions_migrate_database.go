@@ -39,7 +39,11 @@ func (s *RegistryServer) MigrateDatabase(ctx context.Context, req *rpc.MigrateDa
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{})
+	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{
+		ProgressPercent:  100,
+		Step:             "done",
+		ProgressReported: true,
+	})
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
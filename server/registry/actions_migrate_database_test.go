@@ -35,7 +35,11 @@ func TestMigrateDatabase(t *testing.T) {
 	server := defaultTestServer(t)
 
 	req := &rpc.MigrateDatabaseRequest{}
-	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{})
+	metadata, err := anypb.New(&rpc.MigrateDatabaseMetadata{
+		ProgressPercent:  100,
+		Step:             "done",
+		ProgressReported: true,
+	})
 	if err != nil {
 		t.Fatalf("MigrateDatabase(%+v) test failed to build expected response metadata: %s", req, err)
 	}
@@ -25,10 +25,18 @@ type Api struct {
 	ApiID     string
 }
 
+// NewApi returns the name of an API, or an error if any of its IDs are invalid.
+func NewApi(projectID, apiID string) (Api, error) {
+	if err := validateIDs(projectID, apiID); err != nil {
+		return Api{}, err
+	}
+	return Api{ProjectID: projectID, ApiID: apiID}, nil
+}
+
 // Validate returns an error if the resource name is invalid.
 // For backward compatibility, names should only be validated at creation time.
 func (a Api) Validate() error {
-	if err := validateID(a.ApiID); err != nil {
+	if err := ValidateID(a.ApiID); err != nil {
 		return err
 	}
 
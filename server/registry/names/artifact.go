@@ -41,6 +41,24 @@ type Artifact struct {
 	}
 }
 
+// ArtifactParent is implemented by the names of resources that artifacts can be attached to.
+type ArtifactParent interface {
+	Resource
+	Artifact(id string) Artifact
+}
+
+// NewArtifact returns the name of an artifact with the provided ID and parent,
+// or an error if the ID is invalid or the parent name is malformed.
+// Parents should be constructed with NewProject, NewApi, NewVersion, NewSpec,
+// or NewDeployment so that their IDs are validated too.
+func NewArtifact(parent ArtifactParent, id string) (Artifact, error) {
+	artifact := parent.Artifact(id)
+	if err := artifact.Validate(); err != nil {
+		return Artifact{}, err
+	}
+	return artifact, nil
+}
+
 // ProjectID returns the artifact's project ID, or empty string if it doesn't have one.
 func (a Artifact) ProjectID() string {
 	switch name := a.name.(type) {
@@ -59,6 +77,13 @@ func (a Artifact) ProjectID() string {
 	}
 }
 
+// Project returns the name of the artifact's parent project.
+func (a Artifact) Project() Project {
+	return Project{
+		ProjectID: a.ProjectID(),
+	}
+}
+
 // ApiID returns the artifact's API ID, or empty string if it doesn't have one.
 func (a Artifact) ApiID() string {
 	switch name := a.name.(type) {
@@ -143,6 +168,25 @@ func (a Artifact) Validate() error {
 	return a.name.Validate()
 }
 
+// Subject returns the name of the project, API, version, spec, or deployment
+// that the artifact is attached to. Unlike Parent, it never includes a revision ID.
+func (a Artifact) Subject() (string, error) {
+	switch name := a.name.(type) {
+	case projectArtifact:
+		return Project{ProjectID: name.ProjectID}.String(), nil
+	case apiArtifact:
+		return Api{ProjectID: name.ProjectID, ApiID: name.ApiID}.String(), nil
+	case versionArtifact:
+		return Version{ProjectID: name.ProjectID, ApiID: name.ApiID, VersionID: name.VersionID}.String(), nil
+	case specArtifact:
+		return Spec{ProjectID: name.ProjectID, ApiID: name.ApiID, VersionID: name.VersionID, SpecID: name.SpecID}.String(), nil
+	case deploymentArtifact:
+		return Deployment{ProjectID: name.ProjectID, ApiID: name.ApiID, DeploymentID: name.DeploymentID}.String(), nil
+	default:
+		return "", fmt.Errorf("artifact name is empty")
+	}
+}
+
 // Parent returns the resource name of the artifact's parent.
 func (a Artifact) Parent() string {
 	switch name := a.name.(type) {
@@ -217,7 +261,7 @@ type projectArtifact struct {
 }
 
 func (a projectArtifact) Validate() error {
-	if err := validateID(a.ArtifactID); err != nil {
+	if err := ValidateID(a.ArtifactID); err != nil {
 		return err
 	}
 
@@ -272,7 +316,7 @@ type apiArtifact struct {
 }
 
 func (a apiArtifact) Validate() error {
-	if err := validateID(a.ArtifactID); err != nil {
+	if err := ValidateID(a.ArtifactID); err != nil {
 		return err
 	}
 
@@ -333,7 +377,7 @@ type versionArtifact struct {
 }
 
 func (a versionArtifact) Validate() error {
-	if err := validateID(a.ArtifactID); err != nil {
+	if err := ValidateID(a.ArtifactID); err != nil {
 		return err
 	}
 
@@ -399,7 +443,7 @@ type specArtifact struct {
 }
 
 func (a specArtifact) Validate() error {
-	if err := validateID(a.ArtifactID); err != nil {
+	if err := ValidateID(a.ArtifactID); err != nil {
 		return err
 	}
 
@@ -475,7 +519,7 @@ type deploymentArtifact struct {
 }
 
 func (a deploymentArtifact) Validate() error {
-	if err := validateID(a.ArtifactID); err != nil {
+	if err := ValidateID(a.ArtifactID); err != nil {
 		return err
 	}
 
@@ -41,8 +41,11 @@ func GenerateID() string {
 	return uuid.New().String()[:8]
 }
 
-// validateID returns an error if the provided ID is invalid.
-func validateID(id string) error {
+// ValidateID returns an error if the provided ID is invalid.
+// IDs must be 1-80 characters long, contain only lowercase letters, digits,
+// dashes and periods, begin and end with a letter or digit,
+// and must not have the format of a UUID.
+func ValidateID(id string) error {
 	if "" == id {
 		return fmt.Errorf("invalid identifier %q: identifier must be nonempty", id)
 	} else if !customIdentifier.MatchString(id) {
@@ -60,6 +63,16 @@ func validateID(id string) error {
 	return nil
 }
 
+// validateIDs returns an error for the first of the provided IDs that is invalid.
+func validateIDs(ids ...string) error {
+	for _, id := range ids {
+		if err := ValidateID(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ValidateRevisionTag returns an error if the provided revision tag is invalid.
 func ValidateRevisionTag(tag string) error {
 	r := regexp.MustCompile("^" + revisionTag + "$")
@@ -39,10 +39,18 @@ type Deployment struct {
 	DeploymentID string
 }
 
+// NewDeployment returns the name of an API deployment, or an error if any of its IDs are invalid.
+func NewDeployment(projectID, apiID, deploymentID string) (Deployment, error) {
+	if err := validateIDs(projectID, apiID, deploymentID); err != nil {
+		return Deployment{}, err
+	}
+	return Deployment{ProjectID: projectID, ApiID: apiID, DeploymentID: deploymentID}, nil
+}
+
 // Validate returns an error if the resource name is invalid.
 // For backward compatibility, names should only be validated at creation time.
 func (d Deployment) Validate() error {
-	if err := validateID(d.DeploymentID); err != nil {
+	if err := ValidateID(d.DeploymentID); err != nil {
 		return err
 	}
 
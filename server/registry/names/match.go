@@ -0,0 +1,90 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The keys of the values bound to wildcards, by collection.
+var wildcardKeys = map[string]string{
+	"projects":    "project",
+	"locations":   "location",
+	"apis":        "api",
+	"versions":    "version",
+	"specs":       "spec",
+	"deployments": "deployment",
+	"artifacts":   "artifact",
+}
+
+// Match reports whether the resource name matches a pattern and returns the
+// values of the pattern's "-" wildcards, keyed by the singular name of their
+// collection (e.g. "api" for the ID that follows "apis/") and "revision" for
+// a wildcard revision ID.
+//
+// Patterns that don't start with "projects/" are relative to the project of
+// the name, e.g. "apis/-/versions/-/specs/-/artifacts/lint". A pattern segment
+// without a revision ID matches every revision of a resource, so "specs/-"
+// matches "specs/openapi.yaml@abc". Patterns can't contain $resource references.
+// Segments are compared case-insensitively.
+func Match(pattern, name string) (bool, map[string]string, error) {
+	if strings.Contains(pattern, "$") {
+		return false, nil, fmt.Errorf("invalid pattern %q: references must be substituted before matching", pattern)
+	}
+	if _, err := Parse(name); err != nil {
+		return false, nil, err
+	}
+
+	pattern = strings.Trim(pattern, "/")
+	if !strings.HasPrefix(pattern, "projects/") {
+		pattern = fmt.Sprintf("projects/-/locations/%s/%s", Location, pattern)
+	}
+	patternSegments := strings.Split(pattern, "/")
+	nameSegments := strings.Split(name, "/")
+	if len(patternSegments) != len(nameSegments) {
+		return false, nil, nil
+	}
+
+	values := make(map[string]string)
+	for i := range patternSegments {
+		p, n := patternSegments[i], nameSegments[i]
+		if i%2 == 0 { // collection
+			if p != n {
+				return false, nil, nil
+			}
+			continue
+		}
+		pID, pRevision, pHasRevision := strings.Cut(p, "@")
+		nID, nRevision, nHasRevision := strings.Cut(n, "@")
+		if !matchSegment(pID, nID, wildcardKeys[patternSegments[i-1]], values) {
+			return false, nil, nil
+		}
+		if pHasRevision && (!nHasRevision || !matchSegment(pRevision, nRevision, "revision", values)) {
+			return false, nil, nil
+		}
+	}
+	return true, values, nil
+}
+
+// matchSegment reports whether a pattern segment matches a name segment,
+// and binds the name segment to key if the pattern segment is a wildcard.
+func matchSegment(pattern, name, key string, values map[string]string) bool {
+	if pattern == "-" {
+		values[key] = name
+		return true
+	}
+	return strings.EqualFold(pattern, name)
+}
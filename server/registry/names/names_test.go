@@ -15,6 +15,7 @@
 package names
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -348,6 +349,116 @@ func TestRevisionTags(t *testing.T) {
 	}
 }
 
+func TestValidateID(t *testing.T) {
+	tests := []struct {
+		desc  string
+		id    string
+		valid bool
+	}{
+		{desc: "simple", id: "petstore", valid: true},
+		{desc: "digits, dashes and periods", id: "v1.0-beta", valid: true},
+		{desc: "one character", id: "a", valid: true},
+		{desc: "maximum length", id: strings.Repeat("a", 80), valid: true},
+		{desc: "empty", id: "", valid: false},
+		{desc: "too long", id: strings.Repeat("a", 81), valid: false},
+		{desc: "uppercase", id: "Petstore", valid: false},
+		{desc: "slash", id: "apis/petstore", valid: false},
+		{desc: "leading dash", id: "-petstore", valid: false},
+		{desc: "trailing period", id: "petstore.", valid: false},
+		{desc: "uuid", id: "8f7c2d34-0b6e-4a51-9c3b-2e4c5f6a7b8c", valid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := ValidateID(test.id)
+			if test.valid && err != nil {
+				t.Errorf("%s should be valid but was rejected with error %s", test.id, err)
+			} else if !test.valid && err == nil {
+				t.Errorf("%s should be invalid but was accepted", test.id)
+			}
+		})
+	}
+}
+
+func TestNewNames(t *testing.T) {
+	spec, err := NewSpec("my-project", "petstore", "v1", "openapi.yaml")
+	if err != nil {
+		t.Fatalf("NewSpec() returned error: %s", err)
+	}
+	artifact, err := NewArtifact(spec, "lint-spectral")
+	if err != nil {
+		t.Fatalf("NewArtifact() returned error: %s", err)
+	}
+	want := "projects/my-project/locations/global/apis/petstore/versions/v1/specs/openapi.yaml/artifacts/lint-spectral"
+	if artifact.String() != want {
+		t.Errorf("NewArtifact() returned %q, want %q", artifact, want)
+	}
+	if _, err := NewArtifact(spec.Revision("abc123"), "lint-spectral"); err != nil {
+		t.Errorf("NewArtifact() with a spec revision parent returned error: %s", err)
+	}
+
+	tests := []struct {
+		desc string
+		new  func() error
+	}{
+		{
+			desc: "invalid project",
+			new: func() error {
+				_, err := NewProject("My Project")
+				return err
+			},
+		},
+		{
+			desc: "invalid api",
+			new: func() error {
+				_, err := NewApi("my-project", "")
+				return err
+			},
+		},
+		{
+			desc: "invalid version",
+			new: func() error {
+				_, err := NewVersion("my-project", "petstore", "v1/specs/openapi.yaml")
+				return err
+			},
+		},
+		{
+			desc: "invalid spec parent",
+			new: func() error {
+				_, err := NewSpec("my-project", "-petstore", "v1", "openapi.yaml")
+				return err
+			},
+		},
+		{
+			desc: "invalid deployment",
+			new: func() error {
+				_, err := NewDeployment("my-project", "petstore", "prod.")
+				return err
+			},
+		},
+		{
+			desc: "invalid artifact",
+			new: func() error {
+				_, err := NewArtifact(spec, "Lint")
+				return err
+			},
+		},
+		{
+			desc: "malformed artifact parent",
+			new: func() error {
+				_, err := NewArtifact(Spec{ProjectID: "my-project", ApiID: "petstore", SpecID: "openapi.yaml"}, "lint")
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			if err := test.new(); err == nil {
+				t.Errorf("expected error, got none")
+			}
+		})
+	}
+}
+
 func TestExportableName(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -399,3 +510,428 @@ func TestExportableName(t *testing.T) {
 		})
 	}
 }
+
+func TestArtifactSubject(t *testing.T) {
+	tests := []struct {
+		artifact string
+		subject  string
+	}{
+		{
+			artifact: "projects/p/locations/global/artifacts/x",
+			subject:  "projects/p",
+		},
+		{
+			artifact: "projects/p/locations/global/apis/a/artifacts/x",
+			subject:  "projects/p/locations/global/apis/a",
+		},
+		{
+			artifact: "projects/p/locations/global/apis/a/versions/v/artifacts/x",
+			subject:  "projects/p/locations/global/apis/a/versions/v",
+		},
+		{
+			artifact: "projects/p/locations/global/apis/a/versions/v/specs/s/artifacts/x",
+			subject:  "projects/p/locations/global/apis/a/versions/v/specs/s",
+		},
+		{
+			artifact: "projects/p/locations/global/apis/a/versions/v/specs/s@123/artifacts/x",
+			subject:  "projects/p/locations/global/apis/a/versions/v/specs/s",
+		},
+		{
+			artifact: "projects/p/locations/global/apis/a/deployments/d@123/artifacts/x",
+			subject:  "projects/p/locations/global/apis/a/deployments/d",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.artifact, func(t *testing.T) {
+			artifact, err := ParseArtifact(test.artifact)
+			if err != nil {
+				t.Fatalf("ParseArtifact(%q) returned error: %s", test.artifact, err)
+			}
+			subject, err := artifact.Subject()
+			if err != nil {
+				t.Fatalf("Subject() returned error: %s", err)
+			}
+			if subject != test.subject {
+				t.Errorf("Subject() of %s returned %s, want %s", test.artifact, subject, test.subject)
+			}
+		})
+	}
+
+	if _, err := (Artifact{}).Subject(); err == nil {
+		t.Error("Subject() of an empty artifact name succeeded, expected error")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		project string
+	}{
+		{
+			name:    "projects/my-project",
+			kind:    "Project",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global",
+			kind:    "Project",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a",
+			kind:    "Api",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/versions/v",
+			kind:    "Version",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+			kind:    "Spec",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s@123",
+			kind:    "SpecRevision",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/deployments/d",
+			kind:    "Deployment",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/deployments/d@123",
+			kind:    "DeploymentRevision",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/artifacts/x",
+			kind:    "Artifact",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s@123/artifacts/x",
+			kind:    "Artifact",
+			project: "projects/my-project",
+		},
+		{
+			name:    "projects/my-project/locations/global/apis/a/deployments/d/artifacts/x",
+			kind:    "Artifact",
+			project: "projects/my-project",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n, err := Parse(test.name)
+			if err != nil {
+				t.Fatalf("Parse(%s) returned error %s", test.name, err)
+			}
+			if n.Project().String() != test.project {
+				t.Errorf("project of %s should be %s but was %s", test.name, test.project, n.Project())
+			}
+			kind, err := Kind(test.name)
+			if err != nil {
+				t.Fatalf("Kind(%s) returned error %s", test.name, err)
+			}
+			if kind != test.kind {
+				t.Errorf("kind of %s should be %s but was %s", test.name, test.kind, kind)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"-",
+		"projects",
+		"projects/my-project/locations/us-central1",
+		"projects/my-project/locations/global/apis",
+		"projects/my-project/locations/global/apis/a/versions",
+		"projects/my-project/locations/global/apis/a/specs/s",
+		"projects/my-project/locations/global/apis/a/deployments/d/artifacts",
+		"apis/a/versions/v",
+	}
+	for _, name := range tests {
+		t.Run(name, func(t *testing.T) {
+			if n, err := Parse(name); err == nil {
+				t.Errorf("Parse(%s) should have failed but returned %s", name, n)
+			}
+			if kind, err := Kind(name); err == nil {
+				t.Errorf("Kind(%s) should have failed but returned %s", name, kind)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+		values  map[string]string
+	}{
+		{
+			pattern: "projects/my-project/locations/global/apis/-/versions/-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v",
+			match:   true,
+			values:  map[string]string{"api": "a", "version": "v"},
+		},
+		{
+			pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s/artifacts/lint",
+			match:   true,
+			values:  map[string]string{"project": "my-project", "api": "a", "version": "v", "spec": "s"},
+		},
+		{
+			pattern: "/apis/a/versions/-/",
+			name:    "projects/my-project/locations/global/apis/A/versions/v",
+			match:   true,
+			values:  map[string]string{"project": "my-project", "version": "v"},
+		},
+		{
+			pattern: "apis/-/versions/-/specs/-/artifacts/lint",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s@123/artifacts/lint",
+			match:   true,
+			values:  map[string]string{"project": "my-project", "api": "a", "version": "v", "spec": "s"},
+		},
+		{
+			pattern: "apis/a/versions/v/specs/s@-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s@123",
+			match:   true,
+			values:  map[string]string{"project": "my-project", "revision": "123"},
+		},
+		{
+			pattern: "apis/a/deployments/-@456",
+			name:    "projects/my-project/locations/global/apis/a/deployments/d@456",
+			match:   true,
+			values:  map[string]string{"project": "my-project", "deployment": "d"},
+		},
+		{
+			pattern: "apis/a/versions/v/specs/s@-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+		},
+		{
+			pattern: "apis/a/versions/v/specs/s@456",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s@123",
+		},
+		{
+			pattern: "apis/-/versions/-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+		},
+		{
+			pattern: "apis/-/deployments/-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v",
+		},
+		{
+			pattern: "projects/other-project/locations/global/apis/-",
+			name:    "projects/my-project/locations/global/apis/a",
+		},
+		{
+			pattern: "artifacts/-",
+			name:    "projects/my-project/locations/global/apis/a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.name, func(t *testing.T) {
+			match, values, err := Match(test.pattern, test.name)
+			if err != nil {
+				t.Fatalf("Match(%s, %s) returned error %s", test.pattern, test.name, err)
+			}
+			if match != test.match {
+				t.Fatalf("Match(%s, %s) should be %t but was %t", test.pattern, test.name, test.match, match)
+			}
+			if len(values) != len(test.values) {
+				t.Fatalf("Match(%s, %s) returned values %v, want %v", test.pattern, test.name, values, test.values)
+			}
+			for k, v := range test.values {
+				if values[k] != v {
+					t.Errorf("Match(%s, %s) returned values %v, want %v", test.pattern, test.name, values, test.values)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+	}{
+		{
+			pattern: "$resource.api/versions/-",
+			name:    "projects/my-project/locations/global/apis/a/versions/v",
+		},
+		{
+			pattern: "apis/-/versions/-",
+			name:    "apis/a/versions/v",
+		},
+		{
+			pattern: "apis/-",
+			name:    "projects/my-project/locations/global/apis",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.pattern+" "+test.name, func(t *testing.T) {
+			if match, _, err := Match(test.pattern, test.name); err == nil {
+				t.Errorf("Match(%s, %s) should have failed but returned %t", test.pattern, test.name, match)
+			}
+		})
+	}
+}
+
+func TestRelativeAndAbsolute(t *testing.T) {
+	tests := []struct {
+		parent   string
+		name     string
+		relative string
+	}{
+		{
+			parent:   "projects/my-project/locations/global",
+			name:     "projects/my-project/locations/global/apis/a",
+			relative: "apis/a",
+		},
+		{
+			parent:   "projects/my-project",
+			name:     "projects/my-project/locations/global/artifacts/x",
+			relative: "artifacts/x",
+		},
+		{
+			parent:   "projects/my-project/locations/global/apis/a",
+			name:     "projects/my-project/locations/global/apis/a/versions/v",
+			relative: "versions/v",
+		},
+		{
+			parent:   "projects/my-project/locations/global/apis/a",
+			name:     "projects/my-project/locations/global/apis/a/deployments/d",
+			relative: "deployments/d",
+		},
+		{
+			parent:   "projects/my-project/locations/global/apis/a",
+			name:     "projects/my-project/locations/global/apis/a/versions/v/specs/s@123",
+			relative: "versions/v/specs/s@123",
+		},
+		{
+			parent:   "projects/my-project/locations/global/apis/a/versions/v/specs/s",
+			name:     "projects/my-project/locations/global/apis/a/versions/v/specs/s/artifacts/x",
+			relative: "artifacts/x",
+		},
+		{
+			parent:   "projects/my-project/locations/global/apis/a/deployments/d@456",
+			name:     "projects/my-project/locations/global/apis/a/deployments/d@456/artifacts/x",
+			relative: "artifacts/x",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			relative, err := Relative(test.parent, test.name)
+			if err != nil {
+				t.Fatalf("Relative(%s, %s) returned error %s", test.parent, test.name, err)
+			}
+			if relative != test.relative {
+				t.Errorf("Relative(%s, %s) returned %q, want %q", test.parent, test.name, relative, test.relative)
+			}
+			absolute, err := Absolute(test.parent, relative)
+			if err != nil {
+				t.Fatalf("Absolute(%s, %s) returned error %s", test.parent, relative, err)
+			}
+			if absolute != test.name {
+				t.Errorf("Absolute(%s, %s) returned %q, want %q", test.parent, relative, absolute, test.name)
+			}
+		})
+	}
+}
+
+func TestRelativeErrors(t *testing.T) {
+	tests := []struct {
+		parent string
+		name   string
+	}{
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			name:   "projects/my-project/locations/global/apis/b/versions/v",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			name:   "projects/other-project/locations/global/apis/a/versions/v",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			name:   "projects/my-project/locations/global/apis/a",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a/versions/v",
+			name:   "projects/my-project/locations/global/apis/a/versions/v2/specs/s",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			name:   "versions/v",
+		},
+		{
+			parent: "apis/a",
+			name:   "projects/my-project/locations/global/apis/a/versions/v",
+		},
+		{
+			parent: "projects/my-project/locations/global/artifacts/x",
+			name:   "projects/my-project/locations/global/artifacts/x",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.parent+" "+test.name, func(t *testing.T) {
+			if relative, err := Relative(test.parent, test.name); err == nil {
+				t.Errorf("Relative(%s, %s) should have failed but returned %q", test.parent, test.name, relative)
+			}
+		})
+	}
+}
+
+func TestAbsoluteErrors(t *testing.T) {
+	tests := []struct {
+		parent string
+		id     string
+	}{
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "versions",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "projects/my-project/locations/global/apis/a/versions/v",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "versions/-v",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "specs/s",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "widgets/w",
+		},
+		{
+			parent: "projects/my-project/locations/global/apis/a",
+			id:     "locations/global",
+		},
+		{
+			parent: "apis/a",
+			id:     "versions/v",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.parent+" "+test.id, func(t *testing.T) {
+			if absolute, err := Absolute(test.parent, test.id); err == nil {
+				t.Errorf("Absolute(%s, %s) should have failed but returned %q", test.parent, test.id, absolute)
+			}
+		})
+	}
+}
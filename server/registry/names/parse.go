@@ -0,0 +1,96 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Resource is implemented by the names of all resources that belong to a project.
+type Resource interface {
+	String() string
+	Project() Project
+}
+
+// Parse parses a resource name of any supported type and returns it as the
+// most specific type that matches: Project, Api, Version, Spec, SpecRevision,
+// Deployment, DeploymentRevision, or Artifact.
+// Spec and deployment names that include a revision ID are returned as revisions.
+func Parse(name string) (Resource, error) {
+	var (
+		n   Resource
+		err error
+	)
+	switch {
+	case strings.Contains(name, "/artifacts/"):
+		n, err = ParseArtifact(name)
+	case strings.Contains(name, "/specs/"):
+		if strings.Contains(name, "@") {
+			n, err = ParseSpecRevision(name)
+		} else {
+			n, err = ParseSpec(name)
+		}
+	case strings.Contains(name, "/versions/"):
+		n, err = ParseVersion(name)
+	case strings.Contains(name, "/deployments/"):
+		if strings.Contains(name, "@") {
+			n, err = ParseDeploymentRevision(name)
+		} else {
+			n, err = ParseDeployment(name)
+		}
+	case strings.Contains(name, "/apis/"):
+		n, err = ParseApi(name)
+	case strings.Contains(name, "/locations/"):
+		n, err = ParseProjectWithLocation(name)
+	default:
+		n, err = ParseProject(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Kind returns the kind of the resource identified by a name.
+// Kinds are named after the types returned by Parse.
+func Kind(name string) (string, error) {
+	n, err := Parse(name)
+	if err != nil {
+		return "", err
+	}
+
+	switch n.(type) {
+	case Project:
+		return "Project", nil
+	case Api:
+		return "Api", nil
+	case Version:
+		return "Version", nil
+	case Spec:
+		return "Spec", nil
+	case SpecRevision:
+		return "SpecRevision", nil
+	case Deployment:
+		return "Deployment", nil
+	case DeploymentRevision:
+		return "DeploymentRevision", nil
+	case Artifact:
+		return "Artifact", nil
+	default:
+		return "", fmt.Errorf("unsupported resource name %q", name)
+	}
+}
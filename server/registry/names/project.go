@@ -24,10 +24,18 @@ type Project struct {
 	ProjectID string
 }
 
+// NewProject returns the name of a project, or an error if its ID is invalid.
+func NewProject(projectID string) (Project, error) {
+	if err := validateIDs(projectID); err != nil {
+		return Project{}, err
+	}
+	return Project{ProjectID: projectID}, nil
+}
+
 // Validate returns an error if the resource name is invalid.
 // For backward compatibility, names should only be validated at creation time.
 func (p Project) Validate() error {
-	if err := validateID(p.ProjectID); err != nil {
+	if err := ValidateID(p.ProjectID); err != nil {
 		return err
 	}
 
@@ -39,6 +47,11 @@ func (p Project) Validate() error {
 	return nil
 }
 
+// Project returns this resource's name, so that projects can be handled like other named resources.
+func (p Project) Project() Project {
+	return p
+}
+
 // Api returns an API with the provided ID and this resource as its parent.
 func (p Project) Api(id string) Api {
 	return Api{
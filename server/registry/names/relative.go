@@ -0,0 +1,86 @@
+// Copyright 2022 Google LLC. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package names
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Relative returns the name of a resource relative to one of its ancestors,
+// e.g. "versions/v1/specs/openapi" for a spec relative to its API.
+// Both arguments must be valid resource names, and name must be a descendant
+// of parent; names of resources that belong to other parents are rejected.
+func Relative(parent, name string) (string, error) {
+	prefix, err := childPrefix(parent)
+	if err != nil {
+		return "", err
+	}
+	if _, err := Parse(name); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(normalize(name), prefix) {
+		return "", fmt.Errorf("%q is not a descendant of %q", name, parent)
+	}
+	return name[len(prefix):], nil
+}
+
+// Absolute returns the full name of a resource from the name of one of its
+// ancestors and a name relative to it, like the names returned by Relative.
+// Each ID in the relative name must be valid, and full resource names are
+// rejected because they would produce malformed names.
+func Absolute(parent, id string) (string, error) {
+	prefix, err := childPrefix(parent)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(id, "projects/") {
+		return "", fmt.Errorf("invalid relative name %q: must not be a full resource name", id)
+	}
+	segments := strings.Split(id, "/")
+	if len(segments)%2 != 0 {
+		return "", fmt.Errorf("invalid relative name %q: must be a sequence of collections and IDs", id)
+	}
+	for i := 0; i < len(segments); i += 2 {
+		if key, ok := wildcardKeys[segments[i]]; !ok || key == "project" || key == "location" {
+			return "", fmt.Errorf("invalid relative name %q: unknown collection %q", id, segments[i])
+		}
+		resourceID, _, _ := strings.Cut(segments[i+1], "@")
+		if err := ValidateID(resourceID); err != nil {
+			return "", fmt.Errorf("invalid relative name %q: %s", id, err)
+		}
+	}
+	name := prefix + id
+	if _, err := Parse(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// childPrefix returns the prefix shared by the names of all descendants of parent.
+func childPrefix(parent string) (string, error) {
+	p, err := Parse(parent)
+	if err != nil {
+		return "", err
+	}
+	switch p.(type) {
+	case Project:
+		return fmt.Sprintf("%s/locations/%s/", p, Location), nil
+	case Artifact:
+		return "", fmt.Errorf("invalid parent %q: artifacts have no children", parent)
+	default:
+		return p.String() + "/", nil
+	}
+}
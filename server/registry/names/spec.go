@@ -32,10 +32,18 @@ type Spec struct {
 	SpecID    string
 }
 
+// NewSpec returns the name of an API spec, or an error if any of its IDs are invalid.
+func NewSpec(projectID, apiID, versionID, specID string) (Spec, error) {
+	if err := validateIDs(projectID, apiID, versionID, specID); err != nil {
+		return Spec{}, err
+	}
+	return Spec{ProjectID: projectID, ApiID: apiID, VersionID: versionID, SpecID: specID}, nil
+}
+
 // Validate returns an error if the resource name is invalid.
 // For backward compatibility, names should only be validated at creation time.
 func (s Spec) Validate() error {
-	if err := validateID(s.SpecID); err != nil {
+	if err := ValidateID(s.SpecID); err != nil {
 		return err
 	}
 
@@ -26,10 +26,18 @@ type Version struct {
 	VersionID string
 }
 
+// NewVersion returns the name of an API version, or an error if any of its IDs are invalid.
+func NewVersion(projectID, apiID, versionID string) (Version, error) {
+	if err := validateIDs(projectID, apiID, versionID); err != nil {
+		return Version{}, err
+	}
+	return Version{ProjectID: projectID, ApiID: apiID, VersionID: versionID}, nil
+}
+
 // Validate returns an error if the resource name is invalid.
 // For backward compatibility, names should only be validated at creation time.
 func (v Version) Validate() error {
-	if err := validateID(v.VersionID); err != nil {
+	if err := ValidateID(v.VersionID); err != nil {
 		return err
 	}
 
@@ -185,3 +185,37 @@ func TestSeedRegistry(t *testing.T) {
 		})
 	}
 }
+
+func TestSeedSynthetic(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		server = new(fakeServer)
+		spec   = SyntheticSpec{
+			ProjectID:        "p",
+			APIs:             2,
+			VersionsPerAPI:   2,
+			SpecsPerVersion:  1,
+			ArtifactsPerSpec: 1,
+		}
+	)
+
+	if err := SeedSynthetic(ctx, server, spec); err != nil {
+		t.Fatalf("SeedSynthetic(%+v) returned error: %s", spec, err)
+	}
+
+	want := []string{"projects/p"}
+	for _, api := range []string{"api-0", "api-1"} {
+		want = append(want, "projects/p/locations/global/apis/"+api)
+		for _, version := range []string{"v0", "v1"} {
+			v := fmt.Sprintf("projects/p/locations/global/apis/%s/versions/%s", api, version)
+			want = append(want, v, v+"/specs/spec-0.yaml", v+"/specs/spec-0.yaml/artifacts/artifact-0")
+		}
+	}
+	if diff := cmp.Diff(want, server.Resources); diff != "" {
+		t.Errorf("SeedSynthetic(%+v) performed unexpected resource creation sequence (-want +got):\n%s", spec, diff)
+	}
+
+	if err := SeedSynthetic(ctx, server, SyntheticSpec{APIs: 1}); err == nil {
+		t.Errorf("SeedSynthetic() without a project ID succeeded but should have failed")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seeder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apigee/registry/rpc"
+)
+
+// SyntheticSpec describes a generated hierarchy of resources in a project.
+type SyntheticSpec struct {
+	// ProjectID is the project that contains the generated resources.
+	ProjectID string
+	// APIs is the number of APIs in the project.
+	APIs int
+	// VersionsPerAPI is the number of versions of each API.
+	VersionsPerAPI int
+	// SpecsPerVersion is the number of specs in each version.
+	SpecsPerVersion int
+	// ArtifactsPerSpec is the number of artifacts attached to each spec.
+	ArtifactsPerSpec int
+
+	// SpecMimeType and SpecContents are used for every generated spec.
+	SpecMimeType string
+	SpecContents []byte
+	// ArtifactMimeType and ArtifactContents are used for every generated artifact.
+	ArtifactMimeType string
+	ArtifactContents []byte
+}
+
+// SyntheticResources returns the resources described by spec.
+// Names are deterministic, e.g. "apis/api-0/versions/v0/specs/spec-0.yaml/artifacts/artifact-0".
+func SyntheticResources(spec SyntheticSpec) []RegistryResource {
+	resources := []RegistryResource{
+		&rpc.Project{Name: fmt.Sprintf("projects/%s", spec.ProjectID)},
+	}
+	for a := 0; a < spec.APIs; a++ {
+		api := fmt.Sprintf("projects/%s/locations/global/apis/api-%d", spec.ProjectID, a)
+		resources = append(resources, &rpc.Api{Name: api})
+		for v := 0; v < spec.VersionsPerAPI; v++ {
+			version := fmt.Sprintf("%s/versions/v%d", api, v)
+			resources = append(resources, &rpc.ApiVersion{Name: version})
+			for s := 0; s < spec.SpecsPerVersion; s++ {
+				name := fmt.Sprintf("%s/specs/spec-%d.yaml", version, s)
+				resources = append(resources, &rpc.ApiSpec{
+					Name:     name,
+					MimeType: spec.SpecMimeType,
+					Contents: spec.SpecContents,
+				})
+				for i := 0; i < spec.ArtifactsPerSpec; i++ {
+					resources = append(resources, &rpc.Artifact{
+						Name:     fmt.Sprintf("%s/artifacts/artifact-%d", name, i),
+						MimeType: spec.ArtifactMimeType,
+						Contents: spec.ArtifactContents,
+					})
+				}
+			}
+		}
+	}
+	return resources
+}
+
+// SeedSynthetic initializes registry with the resources described by spec.
+// The project must not already exist.
+func SeedSynthetic(ctx context.Context, s Registry, spec SyntheticSpec) error {
+	if spec.ProjectID == "" {
+		return fmt.Errorf("synthetic spec requires a project ID")
+	}
+	return SeedRegistry(ctx, s, SyntheticResources(spec)...)
+}